@@ -0,0 +1,154 @@
+package discordgo
+
+import (
+	"strings"
+	"time"
+)
+
+// Prefixes of the asset IDs of activities whose images are not hosted by
+// Discord, and the URLs the IDs are turned into.
+var activityAssetURLs = map[string]func(id string) string{
+	"mp":      func(id string) string { return "https://media.discordapp.net/" + id },
+	"spotify": func(id string) string { return "https://i.scdn.co/image/" + id },
+	"youtube": func(id string) string { return "https://i.ytimg.com/vi/" + id + "/hqdefault_live.jpg" },
+	"twitch": func(id string) string {
+		return "https://static-cdn.jtvnw.net/previews-ttv/live_user_" + id + "-1280x720.jpg"
+	},
+}
+
+// AssetURL returns the URL of an image asset of the activity, such as
+// Assets.LargeImageID. Assets are either uploaded to the application of
+// the activity, or prefixed with the service hosting them, eg. "spotify:".
+// It returns an empty string if the asset is empty or unknown.
+func (g *Game) AssetURL(asset string) string {
+	if asset == "" {
+		return ""
+	}
+
+	if i := strings.IndexByte(asset, ':'); i > 0 {
+		if url, ok := activityAssetURLs[asset[:i]]; ok {
+			return url(asset[i+1:])
+		}
+		return ""
+	}
+
+	if g.ApplicationID == "" {
+		return ""
+	}
+	return EndpointApplicationAsset(g.ApplicationID, asset)
+}
+
+// LargeImageURL returns the URL of the large image of the activity, see AssetURL.
+func (g *Game) LargeImageURL() string {
+	return g.AssetURL(g.Assets.LargeImageID)
+}
+
+// SmallImageURL returns the URL of the small image of the activity, see AssetURL.
+func (g *Game) SmallImageURL() string {
+	return g.AssetURL(g.Assets.SmallImageID)
+}
+
+// activityTime converts an activity timestamp in milliseconds.
+func activityTime(ms int64) time.Time {
+	if ms == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, ms*int64(time.Millisecond))
+}
+
+// SpotifyActivity is the track a user listens to on Spotify.
+type SpotifyActivity struct {
+	TrackID     string
+	Title       string
+	Artists     []string
+	Album       string
+	AlbumArtURL string
+
+	// The time the track started and will end at.
+	Start time.Time
+	End   time.Time
+}
+
+// URL returns the URL of the track on Spotify.
+func (a *SpotifyActivity) URL() string {
+	return "https://open.spotify.com/track/" + a.TrackID
+}
+
+// Duration returns the length of the track.
+func (a *SpotifyActivity) Duration() time.Duration {
+	return a.End.Sub(a.Start)
+}
+
+// Spotify returns the track of a Spotify listening activity, or false if
+// the activity is not one.
+func (g *Game) Spotify() (*SpotifyActivity, bool) {
+	if g.Type != GameTypeListening || g.Name != "Spotify" || g.SyncID == "" {
+		return nil, false
+	}
+
+	a := &SpotifyActivity{
+		TrackID:     g.SyncID,
+		Title:       g.Details,
+		Album:       g.Assets.LargeText,
+		AlbumArtURL: g.LargeImageURL(),
+		Start:       activityTime(g.TimeStamps.StartTimestamp),
+		End:         activityTime(g.TimeStamps.EndTimestamp),
+	}
+	if g.State != "" {
+		// Multiple artists are separated by semicolons.
+		a.Artists = strings.Split(g.State, "; ")
+	}
+	return a, true
+}
+
+// StreamActivity is the stream of a user on Twitch or YouTube.
+type StreamActivity struct {
+	URL string
+
+	// The platform of the stream, eg. "Twitch" or "YouTube".
+	Platform string
+
+	Title string
+
+	// The game being streamed, if any.
+	Game string
+
+	// The preview image of the stream.
+	PreviewURL string
+}
+
+// Stream returns the stream of a streaming activity, or false if the
+// activity is not one.
+func (g *Game) Stream() (*StreamActivity, bool) {
+	if g.Type != GameTypeStreaming || g.URL == "" {
+		return nil, false
+	}
+
+	return &StreamActivity{
+		URL:        g.URL,
+		Platform:   g.Name,
+		Title:      g.Details,
+		Game:       g.State,
+		PreviewURL: g.LargeImageURL(),
+	}, true
+}
+
+// Spotify returns the Spotify track the user of the presence listens to, if any.
+func (p *Presence) Spotify() (*SpotifyActivity, bool) {
+	for _, g := range p.Activities {
+		if a, ok := g.Spotify(); ok {
+			return a, true
+		}
+	}
+	return nil, false
+}
+
+// Stream returns the stream of the user of the presence, if any.
+func (p *Presence) Stream() (*StreamActivity, bool) {
+	for _, g := range p.Activities {
+		if a, ok := g.Stream(); ok {
+			return a, true
+		}
+	}
+	return nil, false
+}
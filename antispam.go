@@ -0,0 +1,334 @@
+package discordgo
+
+import (
+	"sync"
+	"time"
+)
+
+// SpamDetectionType is the type of a SpamDetection.
+type SpamDetectionType int
+
+// Valid SpamDetectionType values
+const (
+	// A user sent too many messages in a short time.
+	SpamDetectionMessageRate SpamDetectionType = iota + 1
+
+	// A user sent the same message too many times.
+	SpamDetectionDuplicate
+
+	// A user mentioned too many users or roles.
+	SpamDetectionMentions
+
+	// Too many members joined a guild in a short time.
+	SpamDetectionJoinBurst
+)
+
+// String returns a name for the SpamDetectionType.
+func (t SpamDetectionType) String() string {
+	switch t {
+	case SpamDetectionMessageRate:
+		return "message rate"
+	case SpamDetectionDuplicate:
+		return "duplicate messages"
+	case SpamDetectionMentions:
+		return "mentions"
+	case SpamDetectionJoinBurst:
+		return "join burst"
+	}
+	return "unknown"
+}
+
+// A SpamDetection is emitted by a SpamDetector when a threshold is reached.
+type SpamDetection struct {
+	Type    SpamDetectionType
+	GuildID string
+
+	// The user and the channel of the last message, empty for join bursts.
+	UserID    string
+	ChannelID string
+
+	// The IDs of the messages which reached the threshold, for join
+	// bursts the IDs of the users which joined.
+	IDs []string
+
+	// The count which reached the threshold, eg. the number of messages.
+	Count int
+}
+
+// SpamThresholds configures when a SpamDetector emits detections. Each
+// check counts the events within its window and emits a detection when the
+// count reaches the threshold, a threshold of zero disables the check.
+type SpamThresholds struct {
+	// Messages of a user in a guild.
+	MessageCount  int
+	MessageWindow time.Duration
+
+	// Messages of a user in a guild with the same content.
+	DuplicateCount  int
+	DuplicateWindow time.Duration
+
+	// Users and roles mentioned by a user in a guild, @everyone counts
+	// as a single mention.
+	MentionCount  int
+	MentionWindow time.Duration
+
+	// Members joining a guild.
+	JoinCount  int
+	JoinWindow time.Duration
+}
+
+// DefaultSpamThresholds are reasonable thresholds for most guilds.
+var DefaultSpamThresholds = SpamThresholds{
+	MessageCount:    8,
+	MessageWindow:   5 * time.Second,
+	DuplicateCount:  4,
+	DuplicateWindow: 30 * time.Second,
+	MentionCount:    10,
+	MentionWindow:   30 * time.Second,
+	JoinCount:       10,
+	JoinWindow:      10 * time.Second,
+}
+
+// maxWindow returns the longest window of the thresholds.
+func (t *SpamThresholds) maxWindow() time.Duration {
+	max := t.MessageWindow
+	for _, w := range []time.Duration{t.DuplicateWindow, t.MentionWindow, t.JoinWindow} {
+		if w > max {
+			max = w
+		}
+	}
+	return max
+}
+
+// spamRecord is a message or join tracked by a SpamDetector.
+type spamRecord struct {
+	time     time.Time
+	id       string
+	content  string
+	mentions int
+}
+
+// spamTracker holds the records of a user or of the joins of a guild.
+type spamTracker struct {
+	records []spamRecord
+
+	// Records up to the last detection of a type are not counted again
+	// for that type, so one burst only results in a single detection.
+	reset map[SpamDetectionType]time.Time
+}
+
+// count returns the IDs of the records within the window since the last
+// detection of the type which match, and the sum of their counts.
+func (t *spamTracker) count(typ SpamDetectionType, now time.Time, window time.Duration, match func(*spamRecord) int) (ids []string, count int) {
+	for i := range t.records {
+		r := &t.records[i]
+		if now.Sub(r.time) > window || !r.time.After(t.reset[typ]) {
+			continue
+		}
+		if n := match(r); n > 0 {
+			ids = append(ids, r.id)
+			count += n
+		}
+	}
+	return
+}
+
+// prune removes the records older than the window.
+func (t *spamTracker) prune(now time.Time, window time.Duration) {
+	i := 0
+	for i < len(t.records) && now.Sub(t.records[i].time) > window {
+		i++
+	}
+	t.records = t.records[i:]
+}
+
+// A SpamDetector tracks the messages and joins of guilds and emits a
+// SpamDetected event when one of its thresholds is reached. It only keeps
+// the records within the windows of the thresholds, older ones decay.
+// Messages of bots are ignored.
+type SpamDetector struct {
+	sync.Mutex
+
+	// The thresholds can be changed at any time while holding the lock.
+	Thresholds SpamThresholds
+
+	session  *Session
+	handlers []HandlerID
+
+	users     map[guildUserKey]*spamTracker
+	joins     map[string]*spamTracker
+	lastPrune time.Time
+
+	joinHooks []*spamJoinHook
+}
+
+// spamJoinHook is called with the detections of a join before they are
+// emitted, eg. so an AccountAgeGate is armed before it gates the member.
+type spamJoinHook struct {
+	fn func(s *Session, m *GuildMemberAdd, detections []*SpamDetection)
+}
+
+// NewSpamDetector returns a new SpamDetector which tracks the events of s.
+func NewSpamDetector(s *Session, thresholds SpamThresholds) *SpamDetector {
+	d := &SpamDetector{
+		Thresholds: thresholds,
+		session:    s,
+		users:      make(map[guildUserKey]*spamTracker),
+		joins:      make(map[string]*spamTracker),
+	}
+
+	d.handlers = []HandlerID{
+		s.AddHandlerComplex(func(s *Session, m *MessageCreate) {
+			d.emit(d.message(m.Message, time.Now()))
+		}, HandlerOptions{}),
+		s.AddHandlerComplex(func(s *Session, m *GuildMemberAdd) {
+			detections := d.join(m.Member, time.Now())
+
+			d.Lock()
+			hooks := d.joinHooks
+			d.Unlock()
+			for _, h := range hooks {
+				h.fn(s, m, detections)
+			}
+			d.emit(detections)
+		}, HandlerOptions{}),
+	}
+	return d
+}
+
+// Close removes the event handlers of the detector.
+func (d *SpamDetector) Close() {
+	for _, id := range d.handlers {
+		d.session.RemoveHandler(id)
+	}
+}
+
+// addJoinHook adds a function called with the detections of every join
+// before they are emitted, and returns a function removing it.
+func (d *SpamDetector) addJoinHook(fn func(s *Session, m *GuildMemberAdd, detections []*SpamDetection)) func() {
+	d.Lock()
+	defer d.Unlock()
+
+	h := &spamJoinHook{fn}
+	d.joinHooks = append(d.joinHooks, h)
+
+	return func() {
+		d.Lock()
+		defer d.Unlock()
+
+		// The hooks are copied, so a join being handled keeps its slice.
+		hooks := make([]*spamJoinHook, 0, len(d.joinHooks))
+		for _, jh := range d.joinHooks {
+			if jh != h {
+				hooks = append(hooks, jh)
+			}
+		}
+		d.joinHooks = hooks
+	}
+}
+
+// emit dispatches the detections in a goroutine, as it is called by the
+// handlers of the session while they dispatch an event.
+func (d *SpamDetector) emit(detections []*SpamDetection) {
+	for _, sd := range detections {
+		go d.session.handleEvent(spamDetectedEventType, &SpamDetected{sd})
+	}
+}
+
+// detect adds a detection of the type if count reached the threshold.
+func detect(detections []*SpamDetection, t *spamTracker, sd *SpamDetection, threshold int, now time.Time) []*SpamDetection {
+	if threshold <= 0 || sd.Count < threshold {
+		return detections
+	}
+
+	t.reset[sd.Type] = now
+	return append(detections, sd)
+}
+
+// message records a message and returns the resulting detections.
+func (d *SpamDetector) message(m *Message, now time.Time) (detections []*SpamDetection) {
+	if m == nil || m.GuildID == "" || m.Author == nil || m.Author.Bot {
+		return
+	}
+
+	d.Lock()
+	defer d.Unlock()
+	d.pruneIdle(now)
+
+	key := guildUserKey{m.GuildID, m.Author.ID}
+	t, ok := d.users[key]
+	if !ok {
+		t = &spamTracker{reset: map[SpamDetectionType]time.Time{}}
+		d.users[key] = t
+	}
+
+	mentions := len(m.Mentions) + len(m.MentionRoles)
+	if m.MentionEveryone {
+		mentions++
+	}
+
+	t.prune(now, d.Thresholds.maxWindow())
+	t.records = append(t.records, spamRecord{time: now, id: m.ID, content: m.Content, mentions: mentions})
+
+	th := &d.Thresholds
+	newDetection := func(typ SpamDetectionType, ids []string, count int) *SpamDetection {
+		return &SpamDetection{Type: typ, GuildID: m.GuildID, UserID: m.Author.ID, ChannelID: m.ChannelID, IDs: ids, Count: count}
+	}
+
+	ids, count := t.count(SpamDetectionMessageRate, now, th.MessageWindow, func(*spamRecord) int { return 1 })
+	detections = detect(detections, t, newDetection(SpamDetectionMessageRate, ids, count), th.MessageCount, now)
+
+	if m.Content != "" {
+		ids, count = t.count(SpamDetectionDuplicate, now, th.DuplicateWindow, func(r *spamRecord) int {
+			if r.content == m.Content {
+				return 1
+			}
+			return 0
+		})
+		detections = detect(detections, t, newDetection(SpamDetectionDuplicate, ids, count), th.DuplicateCount, now)
+	}
+
+	if mentions > 0 {
+		ids, count = t.count(SpamDetectionMentions, now, th.MentionWindow, func(r *spamRecord) int { return r.mentions })
+		detections = detect(detections, t, newDetection(SpamDetectionMentions, ids, count), th.MentionCount, now)
+	}
+	return
+}
+
+// join records a member joining a guild and returns the resulting detections.
+func (d *SpamDetector) join(m *Member, now time.Time) (detections []*SpamDetection) {
+	if m == nil || m.User == nil {
+		return
+	}
+
+	d.Lock()
+	defer d.Unlock()
+
+	t, ok := d.joins[m.GuildID]
+	if !ok {
+		t = &spamTracker{reset: map[SpamDetectionType]time.Time{}}
+		d.joins[m.GuildID] = t
+	}
+
+	t.prune(now, d.Thresholds.JoinWindow)
+	t.records = append(t.records, spamRecord{time: now, id: m.User.ID})
+
+	ids, count := t.count(SpamDetectionJoinBurst, now, d.Thresholds.JoinWindow, func(*spamRecord) int { return 1 })
+	return detect(nil, t, &SpamDetection{Type: SpamDetectionJoinBurst, GuildID: m.GuildID, IDs: ids, Count: count}, d.Thresholds.JoinCount, now)
+}
+
+// pruneIdle removes the trackers of users without recent messages, at
+// most once per window.
+func (d *SpamDetector) pruneIdle(now time.Time) {
+	window := d.Thresholds.maxWindow()
+	if now.Sub(d.lastPrune) < window {
+		return
+	}
+	d.lastPrune = now
+
+	for key, t := range d.users {
+		if t.prune(now, window); len(t.records) == 0 {
+			delete(d.users, key)
+		}
+	}
+}
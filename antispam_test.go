@@ -0,0 +1,64 @@
+package discordgo
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSpamDetector(t *testing.T) {
+	d := NewSpamDetector(&Session{}, SpamThresholds{
+		MessageCount:    3,
+		MessageWindow:   time.Second,
+		DuplicateCount:  2,
+		DuplicateWindow: time.Minute,
+	})
+	defer d.Close()
+
+	now := time.Now()
+	msg := func(id, content string) *Message {
+		return &Message{ID: id, GuildID: "g", ChannelID: "c", Content: content, Author: &User{ID: "u"}}
+	}
+
+	if ds := d.message(msg("1", "a"), now); len(ds) != 0 {
+		t.Fatalf("expected no detections, got %d", len(ds))
+	}
+
+	ds := d.message(msg("2", "a"), now.Add(100*time.Millisecond))
+	if len(ds) != 1 || ds[0].Type != SpamDetectionDuplicate || ds[0].Count != 2 {
+		t.Fatalf("expected a duplicate detection, got %v", ds)
+	}
+
+	ds = d.message(msg("3", "b"), now.Add(200*time.Millisecond))
+	if len(ds) != 1 || ds[0].Type != SpamDetectionMessageRate || len(ds[0].IDs) != 3 {
+		t.Fatalf("expected a message rate detection, got %v", ds)
+	}
+
+	// Records counted by a detection don't trigger it again, and old ones decay.
+	if ds = d.message(msg("4", "a"), now.Add(2*time.Second)); len(ds) != 0 {
+		t.Fatalf("expected no detections, got %d", len(ds))
+	}
+}
+
+func TestSpamDetectorEvent(t *testing.T) {
+	s := &Session{SyncEvents: true}
+	d := NewSpamDetector(s, SpamThresholds{DuplicateCount: 2, DuplicateWindow: time.Minute})
+	defer d.Close()
+
+	detected := make(chan *SpamDetected, 1)
+	s.AddHandler(func(s *Session, sd *SpamDetected) {
+		detected <- sd
+	})
+
+	for _, id := range []string{"1", "2"} {
+		s.handleEvent(messageCreateEventType, &MessageCreate{&Message{ID: id, GuildID: "g", ChannelID: "c", Content: "a", Author: &User{ID: "u"}}})
+	}
+
+	select {
+	case sd := <-detected:
+		if sd.Type != SpamDetectionDuplicate {
+			t.Errorf("expected a duplicate detection, got %v", sd.Type)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected a SpamDetected event")
+	}
+}
@@ -0,0 +1,101 @@
+package discordgo
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestApplicationInstallParams(t *testing.T) {
+	data := `{
+		"id": "1",
+		"team": {"id": "2", "owner_user_id": "3", "members": [
+			{"user": {"id": "3"}, "team_id": "2", "membership_state": 2, "role": "admin"},
+			{"user": {"id": "4"}, "team_id": "2", "membership_state": 1, "role": "read_only"}
+		]},
+		"install_params": {"scopes": ["bot", "applications.commands"], "permissions": "8"},
+		"integration_types_config": {"0": {"oauth2_install_params": {"scopes": ["bot"], "permissions": "2048"}}, "1": {}}
+	}`
+
+	var a Application
+	if err := json.Unmarshal([]byte(data), &a); err != nil {
+		t.Fatal(err)
+	}
+
+	if o := a.Team.Owner(); o == nil || o.User.ID != "3" {
+		t.Errorf("expected the owner to be user 3, got %+v", o)
+	}
+	if m := a.Team.Member("4"); m == nil || m.Role != TeamMemberRoleReadOnly || m.MembershipState != MembershipStateInvited {
+		t.Errorf("unexpected member %+v", m)
+	}
+	if a.InstallParams == nil || a.InstallParams.Permissions != 8 || len(a.InstallParams.Scopes) != 2 {
+		t.Errorf("unexpected install params %+v", a.InstallParams)
+	}
+
+	guild := a.IntegrationTypesConfig[ApplicationIntegrationGuildInstall]
+	if guild == nil || guild.OAuth2InstallParams == nil || guild.OAuth2InstallParams.Permissions != PermissionSendMessages {
+		t.Errorf("unexpected guild install config %+v", guild)
+	}
+	if user := a.IntegrationTypesConfig[ApplicationIntegrationUserInstall]; user == nil || user.OAuth2InstallParams != nil {
+		t.Errorf("unexpected user install config %+v", user)
+	}
+}
+
+func TestBotInviteURL(t *testing.T) {
+	tests := []struct {
+		permissions        int64
+		scopes             []string
+		guildID            string
+		disableGuildSelect bool
+		want               string
+	}{
+		{0, nil, "", false, "client_id=1&scope=bot"},
+		{8, []string{OAuth2ScopeBot, OAuth2ScopeApplicationsCommands}, "", true, "client_id=1&permissions=8&scope=bot+applications.commands"},
+		{2048, nil, "2", true, "client_id=1&disable_guild_select=true&guild_id=2&permissions=2048&scope=bot"},
+	}
+
+	for i, test := range tests {
+		got := BotInviteURL("1", test.permissions, test.scopes, test.guildID, test.disableGuildSelect)
+		if want := EndpointOauth2Authorize + "?" + test.want; got != want {
+			t.Errorf("%d: expected %s, got %s", i, want, got)
+		}
+	}
+
+	p := &InstallParams{Scopes: []string{OAuth2ScopeBot}, Permissions: 8}
+	if got, want := p.InviteURL("1"), EndpointOauth2Authorize+"?client_id=1&permissions=8&scope=bot"; got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
+
+func TestApplicationMe(t *testing.T) {
+	tr := &routeTransport{routes: map[string]string{
+		"GET " + EndpointApplicationMe: `{"id": "1", "flags": 69632, "approximate_guild_count": 99}`,
+	}}
+	s := newTestSession(tr)
+
+	a, err := s.ApplicationMe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.ApproximateGuildCount != 99 || a.Flags != ApplicationFlagGatewayPresence|ApplicationFlagVerificationPendingGuildLimit {
+		t.Errorf("unexpected application %+v", a)
+	}
+}
+
+func TestApplicationActivityInstance(t *testing.T) {
+	tr := &routeTransport{routes: map[string]string{
+		"GET " + EndpointApplicationActivityInstance("1", "i-2"): `{
+			"application_id": "1", "instance_id": "i-2", "launch_id": "3",
+			"location": {"id": "gc-4-5", "kind": "gc", "channel_id": "5", "guild_id": "4"},
+			"users": ["6", "7"]
+		}`,
+	}}
+	s := newTestSession(tr)
+
+	a, err := s.ApplicationActivityInstance("1", "i-2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.InstanceID != "i-2" || a.Location == nil || a.Location.Kind != ActivityLocationKindGuildChannel || a.Location.ChannelID != "5" || len(a.Users) != 2 {
+		t.Errorf("unexpected activity instance %+v", a)
+	}
+}
@@ -0,0 +1,442 @@
+package discordgo
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// ApplicationCommandType is the type of an ApplicationCommand.
+// https://discord.com/developers/docs/interactions/application-commands#application-command-object-application-command-types
+type ApplicationCommandType int
+
+// Block contains the valid known ApplicationCommandType values
+const (
+	// Slash commands.
+	ChatApplicationCommand ApplicationCommandType = iota + 1
+
+	// Commands in the context menu of users and messages.
+	UserApplicationCommand
+	MessageApplicationCommand
+)
+
+// ApplicationIntegrationType is where an application is installed.
+type ApplicationIntegrationType int
+
+// Block contains the valid known ApplicationIntegrationType values
+const (
+	// The application is installed to a guild.
+	ApplicationIntegrationGuildInstall ApplicationIntegrationType = iota
+
+	// The application is installed to a user, its commands can be used
+	// everywhere by the user.
+	ApplicationIntegrationUserInstall
+)
+
+// InteractionContextType is where an interaction can be used.
+type InteractionContextType int
+
+// Block contains the valid known InteractionContextType values
+const (
+	InteractionContextGuild InteractionContextType = iota
+
+	// The DM of the bot of the application.
+	InteractionContextBotDM
+
+	// DMs and group DMs other than the DM of the bot, for user installed
+	// applications only.
+	InteractionContextPrivateChannel
+)
+
+// An ApplicationCommand is a slash or context menu command of an application.
+type ApplicationCommand struct {
+	ID            string                 `json:"id,omitempty"`
+	ApplicationID string                 `json:"application_id,omitempty"`
+	GuildID       string                 `json:"guild_id,omitempty"`
+	Version       string                 `json:"version,omitempty"`
+	Type          ApplicationCommandType `json:"type,omitempty"`
+
+	Name              string            `json:"name"`
+	NameLocalizations map[string]string `json:"name_localizations,omitempty"`
+
+	// Slash commands only.
+	Description              string                      `json:"description,omitempty"`
+	DescriptionLocalizations map[string]string           `json:"description_localizations,omitempty"`
+	Options                  []*ApplicationCommandOption `json:"options,omitempty"`
+
+	// The permissions members need to use the command by default, nil
+	// for everyone and 0 for administrators only.
+	DefaultMemberPermissions *int64 `json:"default_member_permissions,string,omitempty"`
+	NSFW                     bool   `json:"nsfw,omitempty"`
+
+	// Whether the global command can be used in DMs with the bot, nil for
+	// true. Deprecated: use Contexts instead.
+	DMPermission *bool `json:"dm_permission,omitempty"`
+
+	// Where the command is available, global commands only. Nil uses the
+	// integration types of the application and all contexts.
+	IntegrationTypes []ApplicationIntegrationType `json:"integration_types,omitempty"`
+	Contexts         []InteractionContextType     `json:"contexts,omitempty"`
+}
+
+// SetDefaultMemberPermissions sets the permissions members need to use the
+// command by default, eg. PermissionBanMembers. Without permissions only
+// administrators can use the command. Guild admins can override it in the
+// integration settings of the guild.
+func (c *ApplicationCommand) SetDefaultMemberPermissions(perms ...int) *ApplicationCommand {
+	var p int64
+	for _, perm := range perms {
+		p |= int64(perm)
+	}
+	c.DefaultMemberPermissions = &p
+	return c
+}
+
+// SetDMPermission sets whether the global command can be used in DMs with the bot.
+func (c *ApplicationCommand) SetDMPermission(allowed bool) *ApplicationCommand {
+	c.DMPermission = &allowed
+	return c
+}
+
+// ApplicationCommandPermissionType is the type of the target of an
+// ApplicationCommandPermission.
+type ApplicationCommandPermissionType int
+
+// Block contains the valid known ApplicationCommandPermissionType values
+const (
+	ApplicationCommandPermissionRole ApplicationCommandPermissionType = iota + 1
+	ApplicationCommandPermissionUser
+	ApplicationCommandPermissionChannel
+)
+
+// An ApplicationCommandPermission allows or denies a role, a user or a
+// channel the use of a command. The guild ID is the ID of the everyone
+// role, and the guild ID minus 1 the ID of all channels, see
+// CommandPermissionAllChannels.
+type ApplicationCommandPermission struct {
+	ID         string                           `json:"id"`
+	Type       ApplicationCommandPermissionType `json:"type"`
+	Permission bool                             `json:"permission"`
+}
+
+// GuildApplicationCommandPermissions holds the permissions of a command in a
+// guild. The ID is the ID of the command, or of the application for the
+// defaults of all its commands.
+type GuildApplicationCommandPermissions struct {
+	ID            string                          `json:"id"`
+	ApplicationID string                          `json:"application_id"`
+	GuildID       string                          `json:"guild_id"`
+	Permissions   []*ApplicationCommandPermission `json:"permissions"`
+}
+
+// ApplicationCommandPermissionsList holds the permissions an
+// ApplicationCommandPermissionsEdit replaces the permissions of a command with.
+type ApplicationCommandPermissionsList struct {
+	Permissions []*ApplicationCommandPermission `json:"permissions"`
+}
+
+// ApplicationCommandOptionType is the type of an ApplicationCommandOption.
+type ApplicationCommandOptionType int
+
+// Block contains the valid known ApplicationCommandOptionType values
+const (
+	ApplicationCommandOptionSubCommand ApplicationCommandOptionType = iota + 1
+	ApplicationCommandOptionSubCommandGroup
+	ApplicationCommandOptionString
+	ApplicationCommandOptionInteger
+	ApplicationCommandOptionBoolean
+	ApplicationCommandOptionUser
+	ApplicationCommandOptionChannel
+	ApplicationCommandOptionRole
+	ApplicationCommandOptionMentionable
+	ApplicationCommandOptionNumber
+	ApplicationCommandOptionAttachment
+)
+
+// An ApplicationCommandOption is an option or a subcommand of a slash command.
+type ApplicationCommandOption struct {
+	Type ApplicationCommandOptionType `json:"type"`
+
+	Name                     string            `json:"name"`
+	NameLocalizations        map[string]string `json:"name_localizations,omitempty"`
+	Description              string            `json:"description"`
+	DescriptionLocalizations map[string]string `json:"description_localizations,omitempty"`
+
+	Required bool `json:"required,omitempty"`
+
+	// The values users pick from, string, integer and number options only.
+	Choices []*ApplicationCommandOptionChoice `json:"choices,omitempty"`
+
+	// The options of a subcommand, or the subcommands of a group.
+	Options []*ApplicationCommandOption `json:"options,omitempty"`
+
+	// The channel types users can pick, channel options only.
+	ChannelTypes []ChannelType `json:"channel_types,omitempty"`
+
+	// The range of integer and number options, and the length of string options.
+	MinValue  *float64 `json:"min_value,omitempty"`
+	MaxValue  *float64 `json:"max_value,omitempty"`
+	MinLength *int     `json:"min_length,omitempty"`
+	MaxLength *int     `json:"max_length,omitempty"`
+
+	// Whether the choices are sent with autocomplete interactions, instead
+	// of the Choices field.
+	Autocomplete bool `json:"autocomplete,omitempty"`
+}
+
+// Limits of application command definitions.
+const (
+	ApplicationCommandDescriptionLimit = 100
+	ApplicationCommandOptionsLimit     = 25
+	ApplicationCommandChoicesLimit     = 25
+	ApplicationCommandStringLimit      = 6000
+)
+
+// applicationCommandNameRegexp matches valid names of slash commands and options.
+var applicationCommandNameRegexp = regexp.MustCompile(`^[-_\p{L}\p{N}]{1,32}$`)
+
+// An ApplicationCommandError is returned when validating an invalid command definition.
+type ApplicationCommandError struct {
+	// The path of the invalid command or option, eg. "ban/user".
+	Path   string
+	Reason string
+}
+
+// Error returns the path and the reason.
+func (e *ApplicationCommandError) Error() string {
+	return e.Path + ": " + e.Reason
+}
+
+// Validate checks the command definition against the rules of Discord for
+// names, descriptions and options, an *ApplicationCommandError is returned
+// for the first violation.
+func (c *ApplicationCommand) Validate() error {
+	fail := func(format string, a ...interface{}) error {
+		return &ApplicationCommandError{c.Name, fmt.Sprintf(format, a...)}
+	}
+
+	if c.Type != ChatApplicationCommand && c.Type != 0 {
+		// Context menu commands have free form names and no options.
+		if n := utf8.RuneCountInString(c.Name); n < 1 || n > 32 {
+			return fail("name must be 1 to 32 characters long")
+		}
+		if c.Description != "" || len(c.Options) > 0 {
+			return fail("context menu commands can't have a description or options")
+		}
+		return nil
+	}
+
+	if !applicationCommandNameRegexp.MatchString(c.Name) || strings.ToLower(c.Name) != c.Name {
+		return fail("name must be 1 to 32 lowercase letters, numbers, dashes or underscores")
+	}
+	if n := utf8.RuneCountInString(c.Description); n < 1 || n > ApplicationCommandDescriptionLimit {
+		return fail("description must be 1 to %d characters long", ApplicationCommandDescriptionLimit)
+	}
+	return validateApplicationCommandOptions(c.Name, c.Options, 0)
+}
+
+// validateApplicationCommandOptions validates the options of a command or
+// subcommand, depth is the number of subcommand levels above the options.
+func validateApplicationCommandOptions(path string, options []*ApplicationCommandOption, depth int) error {
+	if len(options) > ApplicationCommandOptionsLimit {
+		return &ApplicationCommandError{path, fmt.Sprintf("more than %d options", ApplicationCommandOptionsLimit)}
+	}
+
+	optional := false
+	for _, o := range options {
+		if err := o.validate(path+"/"+o.Name, depth); err != nil {
+			return err
+		}
+
+		if o.Required && optional {
+			return &ApplicationCommandError{path + "/" + o.Name, "required options must be listed before optional options"}
+		}
+		optional = optional || !o.Required
+	}
+	return nil
+}
+
+func (o *ApplicationCommandOption) validate(path string, depth int) error {
+	fail := func(format string, a ...interface{}) error {
+		return &ApplicationCommandError{path, fmt.Sprintf(format, a...)}
+	}
+
+	if !applicationCommandNameRegexp.MatchString(o.Name) || strings.ToLower(o.Name) != o.Name {
+		return fail("name must be 1 to 32 lowercase letters, numbers, dashes or underscores")
+	}
+	if n := utf8.RuneCountInString(o.Description); n < 1 || n > ApplicationCommandDescriptionLimit {
+		return fail("description must be 1 to %d characters long", ApplicationCommandDescriptionLimit)
+	}
+
+	numeric := o.Type == ApplicationCommandOptionInteger || o.Type == ApplicationCommandOptionNumber
+	switch {
+	case (o.MinValue != nil || o.MaxValue != nil) && !numeric:
+		return fail("min and max values are only allowed for integer and number options")
+	case o.MinValue != nil && o.MaxValue != nil && *o.MinValue > *o.MaxValue:
+		return fail("min value %v is greater than max value %v", *o.MinValue, *o.MaxValue)
+	case (o.MinLength != nil || o.MaxLength != nil) && o.Type != ApplicationCommandOptionString:
+		return fail("min and max lengths are only allowed for string options")
+	case o.MinLength != nil && (*o.MinLength < 0 || *o.MinLength > ApplicationCommandStringLimit):
+		return fail("min length must be between 0 and %d", ApplicationCommandStringLimit)
+	case o.MaxLength != nil && (*o.MaxLength < 1 || *o.MaxLength > ApplicationCommandStringLimit):
+		return fail("max length must be between 1 and %d", ApplicationCommandStringLimit)
+	case o.MinLength != nil && o.MaxLength != nil && *o.MinLength > *o.MaxLength:
+		return fail("min length %d is greater than max length %d", *o.MinLength, *o.MaxLength)
+	case len(o.ChannelTypes) > 0 && o.Type != ApplicationCommandOptionChannel:
+		return fail("channel types are only allowed for channel options")
+	case len(o.Choices) > 0 && !numeric && o.Type != ApplicationCommandOptionString:
+		return fail("choices are only allowed for string, integer and number options")
+	case len(o.Choices) > ApplicationCommandChoicesLimit:
+		return fail("more than %d choices", ApplicationCommandChoicesLimit)
+	case len(o.Choices) > 0 && o.Autocomplete:
+		return fail("options with autocomplete can't have choices")
+	}
+
+	switch o.Type {
+	case ApplicationCommandOptionSubCommandGroup:
+		if depth > 0 {
+			return fail("subcommand groups can't be nested")
+		}
+		for _, sub := range o.Options {
+			if sub.Type != ApplicationCommandOptionSubCommand {
+				return &ApplicationCommandError{path + "/" + sub.Name, "subcommand groups can only contain subcommands"}
+			}
+		}
+		return validateApplicationCommandOptions(path, o.Options, depth+1)
+	case ApplicationCommandOptionSubCommand:
+		if o.Required {
+			return fail("subcommands can't be required")
+		}
+		for _, sub := range o.Options {
+			if sub.Type == ApplicationCommandOptionSubCommand || sub.Type == ApplicationCommandOptionSubCommandGroup {
+				return &ApplicationCommandError{path + "/" + sub.Name, "subcommands can't contain subcommands"}
+			}
+		}
+		return validateApplicationCommandOptions(path, o.Options, depth+1)
+	}
+
+	if len(o.Options) > 0 {
+		return fail("only subcommands and groups can have options")
+	}
+	return nil
+}
+
+// An ApplicationCommandOptionChoice is a value users pick from for an option.
+type ApplicationCommandOptionChoice struct {
+	Name              string            `json:"name"`
+	NameLocalizations map[string]string `json:"name_localizations,omitempty"`
+
+	// A string, integer or float64 depending on the type of the option.
+	Value interface{} `json:"value"`
+}
+
+// ApplicationCommandInteractionData is the data of a command interaction.
+type ApplicationCommandInteractionData struct {
+	ID          string                 `json:"id"`
+	Name        string                 `json:"name"`
+	CommandType ApplicationCommandType `json:"type"`
+	GuildID     string                 `json:"guild_id,omitempty"`
+
+	Options []*ApplicationCommandInteractionDataOption `json:"options"`
+
+	// The users, members, roles, channels and attachments of the options,
+	// and the target of context menu commands.
+	Resolved *ApplicationCommandInteractionDataResolved `json:"resolved"`
+
+	// The ID of the user or message of a context menu command.
+	TargetID string `json:"target_id,omitempty"`
+}
+
+// ApplicationCommandInteractionDataResolved holds the objects referenced by
+// the options of a command interaction, by ID.
+type ApplicationCommandInteractionDataResolved struct {
+	Users       map[string]*User              `json:"users"`
+	Members     map[string]*Member            `json:"members"`
+	Roles       map[string]*Role              `json:"roles"`
+	Channels    map[string]*Channel           `json:"channels"`
+	Messages    map[string]*Message           `json:"messages"`
+	Attachments map[string]*MessageAttachment `json:"attachments"`
+}
+
+// ApplicationCommandInteractionDataOption is the value of an option of a
+// command interaction, or a subcommand with its options.
+type ApplicationCommandInteractionDataOption struct {
+	Name string                       `json:"name"`
+	Type ApplicationCommandOptionType `json:"type"`
+
+	// A string, float64 or bool depending on the type of the option. The
+	// value of user, channel, role and attachment options is the ID.
+	Value interface{} `json:"value,omitempty"`
+
+	// The options of a subcommand, or the subcommand of a group.
+	Options []*ApplicationCommandInteractionDataOption `json:"options,omitempty"`
+
+	// Whether the user is typing this option, autocomplete interactions only.
+	Focused bool `json:"focused,omitempty"`
+}
+
+// StringValue returns the value of a string option, or the ID of a user,
+// channel, role, mentionable or attachment option.
+func (o *ApplicationCommandInteractionDataOption) StringValue() string {
+	v, _ := o.Value.(string)
+	return v
+}
+
+// IntValue returns the value of an integer option.
+func (o *ApplicationCommandInteractionDataOption) IntValue() int64 {
+	v, _ := o.Value.(float64)
+	return int64(v)
+}
+
+// FloatValue returns the value of a number option.
+func (o *ApplicationCommandInteractionDataOption) FloatValue() float64 {
+	v, _ := o.Value.(float64)
+	return v
+}
+
+// BoolValue returns the value of a boolean option.
+func (o *ApplicationCommandInteractionDataOption) BoolValue() bool {
+	v, _ := o.Value.(bool)
+	return v
+}
+
+// UserValue returns the user of a user or mentionable option, or nil if
+// the option is not a user.
+func (o *ApplicationCommandInteractionDataOption) UserValue(data *ApplicationCommandInteractionData) *User {
+	if data.Resolved == nil {
+		return nil
+	}
+	return data.Resolved.Users[o.StringValue()]
+}
+
+// RoleValue returns the role of a role or mentionable option, or nil if
+// the option is not a role.
+func (o *ApplicationCommandInteractionDataOption) RoleValue(data *ApplicationCommandInteractionData) *Role {
+	if data.Resolved == nil {
+		return nil
+	}
+	return data.Resolved.Roles[o.StringValue()]
+}
+
+// ChannelValue returns the partial channel of a channel option.
+func (o *ApplicationCommandInteractionDataOption) ChannelValue(data *ApplicationCommandInteractionData) *Channel {
+	if data.Resolved == nil {
+		return nil
+	}
+	return data.Resolved.Channels[o.StringValue()]
+}
+
+// AttachmentValue returns the file uploaded for an attachment option.
+func (o *ApplicationCommandInteractionDataOption) AttachmentValue(data *ApplicationCommandInteractionData) *MessageAttachment {
+	if data.Resolved == nil {
+		return nil
+	}
+	return data.Resolved.Attachments[o.StringValue()]
+}
+
+// ApplicationCommandData decodes the data of a command interaction.
+func (i *Interaction) ApplicationCommandData() (data *ApplicationCommandInteractionData, err error) {
+	err = unmarshal(i.Data, &data)
+	return
+}
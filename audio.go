@@ -0,0 +1,39 @@
+package discordgo
+
+import "io"
+
+// An OpusSource is a source of 20ms opus packets, eg. an OggOpusReader or
+// an FFmpegSource. ReadFrame returns io.EOF at the end of the source.
+type OpusSource interface {
+	ReadFrame() ([]byte, error)
+}
+
+// PlayOpus sends the opus packets of the source to the connection until the
+// end of the source or until stop is closed, and stops speaking afterwards.
+// It returns nil at the end of the source.
+func (v *VoiceConnection) PlayOpus(src OpusSource, stop <-chan struct{}) error {
+	v.RLock()
+	send := v.OpusSend
+	v.RUnlock()
+	if send == nil {
+		return ErrVoiceNotReady
+	}
+
+	defer v.Speaking(false)
+
+	for {
+		frame, err := src.ReadFrame()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		select {
+		case send <- frame:
+		case <-stop:
+			return nil
+		}
+	}
+}
@@ -0,0 +1,323 @@
+package discordgo
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// A SettingChange describes the change of a single setting of a guild,
+// channel or role. Before and After are formatted for display in a message,
+// eg. channels as mentions, and are empty for unset values.
+type SettingChange struct {
+	Setting string
+	Before  string
+	After   string
+}
+
+// String returns the change as `Setting: Before → After`.
+func (c *SettingChange) String() string {
+	before, after := c.Before, c.After
+	if before == "" {
+		before = "none"
+	}
+	if after == "" {
+		after = "none"
+	}
+	return c.Setting + ": " + before + " → " + after
+}
+
+// FormatSettingChanges returns the changes as lines of a message, eg. to
+// post to a log channel.
+func FormatSettingChanges(changes []*SettingChange) string {
+	lines := make([]string, len(changes))
+	for i, c := range changes {
+		lines[i] = c.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// EventSettingChanges returns the setting changes of a GuildUpdate,
+// ChannelUpdate or GuildRoleUpdate event. Nil is returned for other events,
+// and for updates without BeforeUpdate, which requires state tracking.
+func EventSettingChanges(i interface{}) []*SettingChange {
+	switch t := i.(type) {
+	case *GuildUpdate:
+		if t.Guild != nil && t.BeforeUpdate != nil {
+			return GuildSettingChanges(t.BeforeUpdate, t.Guild)
+		}
+	case *ChannelUpdate:
+		if t.Channel != nil && t.BeforeUpdate != nil {
+			return ChannelSettingChanges(t.BeforeUpdate, t.Channel)
+		}
+	case *GuildRoleUpdate:
+		if t.GuildRole != nil && t.Role != nil && t.BeforeUpdate != nil {
+			return RoleSettingChanges(t.BeforeUpdate, t.Role)
+		}
+	}
+	return nil
+}
+
+// settingDiffer collects the changes of compared settings.
+type settingDiffer []*SettingChange
+
+func (d *settingDiffer) str(setting, before, after string) {
+	if before != after {
+		*d = append(*d, &SettingChange{setting, before, after})
+	}
+}
+
+func (d *settingDiffer) int(setting string, before, after int) {
+	d.str(setting, strconv.Itoa(before), strconv.Itoa(after))
+}
+
+func (d *settingDiffer) bool(setting string, before, after bool) {
+	d.str(setting, yesNo(before), yesNo(after))
+}
+
+func (d *settingDiffer) channel(setting, before, after string) {
+	d.str(setting, channelMention(before), channelMention(after))
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
+
+func channelMention(id string) string {
+	if id == "" {
+		return ""
+	}
+	return "<#" + id + ">"
+}
+
+// GuildSettingChanges returns the changed settings of a guild.
+func GuildSettingChanges(before, after *Guild) []*SettingChange {
+	d := settingDiffer{}
+	d.str("Name", before.Name, after.Name)
+	d.str("Description", before.Description, after.Description)
+	if before.Icon != after.Icon {
+		d.str("Icon", guildIconURL(before), guildIconURL(after))
+	}
+	d.str("Banner", before.Banner, after.Banner)
+	d.str("Splash", before.Splash, after.Splash)
+	d.str("Region", before.Region, after.Region)
+	d.str("Preferred locale", before.PreferredLocale, after.PreferredLocale)
+	if before.OwnerID != after.OwnerID {
+		d.str("Owner", "<@"+before.OwnerID+">", "<@"+after.OwnerID+">")
+	}
+	d.channel("AFK channel", before.AfkChannelID, after.AfkChannelID)
+	d.int("AFK timeout", before.AfkTimeout, after.AfkTimeout)
+	d.channel("System channel", before.SystemChannelID, after.SystemChannelID)
+	d.channel("Rules channel", before.RulesChannelID, after.RulesChannelID)
+	d.channel("Public updates channel", before.PublicUpdatesChannelID, after.PublicUpdatesChannelID)
+	d.bool("Widget enabled", before.WidgetEnabled, after.WidgetEnabled)
+	d.channel("Widget channel", before.WidgetChannelID, after.WidgetChannelID)
+	d.str("Vanity URL code", before.VanityURLCode, after.VanityURLCode)
+	d.str("Verification level", verificationLevelName(before.VerificationLevel), verificationLevelName(after.VerificationLevel))
+	d.str("Explicit content filter", explicitContentFilterName(before.ExplicitContentFilter), explicitContentFilterName(after.ExplicitContentFilter))
+	d.str("Default notifications", messageNotificationsName(before.DefaultMessageNotifications), messageNotificationsName(after.DefaultMessageNotifications))
+	d.bool("2FA required for moderation", before.MfaLevel == MfaLevelElevated, after.MfaLevel == MfaLevelElevated)
+	d.int("Boost level", int(before.PremiumTier), int(after.PremiumTier))
+	return d
+}
+
+func guildIconURL(g *Guild) string {
+	if g.Icon == "" {
+		return ""
+	}
+	return g.IconURL()
+}
+
+func verificationLevelName(l VerificationLevel) string {
+	switch l {
+	case VerificationLevelNone:
+		return "none"
+	case VerificationLevelLow:
+		return "low"
+	case VerificationLevelMedium:
+		return "medium"
+	case VerificationLevelHigh:
+		return "high"
+	case VerificationLevelVeryHigh:
+		return "very high"
+	}
+	return strconv.Itoa(int(l))
+}
+
+func explicitContentFilterName(l ExplicitContentFilterLevel) string {
+	switch l {
+	case ExplicitContentFilterDisabled:
+		return "disabled"
+	case ExplicitContentFilterMembersWithoutRoles:
+		return "members without roles"
+	case ExplicitContentFilterAllMembers:
+		return "all members"
+	}
+	return strconv.Itoa(int(l))
+}
+
+func messageNotificationsName(n MessageNotifications) string {
+	switch n {
+	case MessageNotificationsAllMessages:
+		return "all messages"
+	case MessageNotificationsOnlyMentions:
+		return "only mentions"
+	}
+	return strconv.Itoa(int(n))
+}
+
+// ChannelSettingChanges returns the changed settings of a channel,
+// including the changed permissions of its permission overwrites.
+func ChannelSettingChanges(before, after *Channel) []*SettingChange {
+	d := settingDiffer{}
+	d.str("Name", before.Name, after.Name)
+	d.str("Topic", before.Topic, after.Topic)
+	d.bool("NSFW", before.NSFW, after.NSFW)
+	d.channel("Category", before.ParentID, after.ParentID)
+	d.int("Position", before.Position, after.Position)
+	d.int("Slowmode (seconds)", before.RateLimitPerUser, after.RateLimitPerUser)
+	d.int("Bitrate", before.Bitrate, after.Bitrate)
+	d.int("User limit", before.UserLimit, after.UserLimit)
+
+	overwrites := func(c *Channel) map[string]*PermissionOverwrite {
+		m := make(map[string]*PermissionOverwrite, len(c.PermissionOverwrites))
+		for _, o := range c.PermissionOverwrites {
+			m[o.ID] = o
+		}
+		return m
+	}
+	beforeOverwrites, afterOverwrites := overwrites(before), overwrites(after)
+
+	// Walk the overwrites in order, first those of before then the added ones.
+	var ordered []*PermissionOverwrite
+	ordered = append(ordered, before.PermissionOverwrites...)
+	for _, o := range after.PermissionOverwrites {
+		if beforeOverwrites[o.ID] == nil {
+			ordered = append(ordered, o)
+		}
+	}
+
+	for _, o := range ordered {
+		b, a := beforeOverwrites[o.ID], afterOverwrites[o.ID]
+		target := "<@&" + o.ID + ">"
+		if o.Type == "member" {
+			target = "<@" + o.ID + ">"
+		}
+
+		if b == nil {
+			b = &PermissionOverwrite{}
+			d.str("Overwrite for "+target, "", "added")
+		}
+		if a == nil {
+			a = &PermissionOverwrite{}
+			d.str("Overwrite for "+target, "", "removed")
+		}
+
+		for _, p := range permissionBits {
+			d.str("Overwrite for "+target+" "+permissionNames[p], overwriteState(b, p), overwriteState(a, p))
+		}
+	}
+	return d
+}
+
+func overwriteState(o *PermissionOverwrite, p int) string {
+	switch {
+	case o.Allow&p != 0:
+		return "allow"
+	case o.Deny&p != 0:
+		return "deny"
+	}
+	return "inherit"
+}
+
+// RoleSettingChanges returns the changed settings of a role, including
+// its changed permissions.
+func RoleSettingChanges(before, after *Role) []*SettingChange {
+	d := settingDiffer{}
+	d.str("Name", before.Name, after.Name)
+	d.str("Color", roleColor(before.Color), roleColor(after.Color))
+	d.bool("Hoisted", before.Hoist, after.Hoist)
+	d.bool("Mentionable", before.Mentionable, after.Mentionable)
+	d.int("Position", before.Position, after.Position)
+
+	for _, p := range permissionBits {
+		d.bool("Permission "+permissionNames[p], before.Permissions&p != 0, after.Permissions&p != 0)
+	}
+	return d
+}
+
+func roleColor(color int) string {
+	if color == 0 {
+		return ""
+	}
+	return fmt.Sprintf("#%06x", color)
+}
+
+// permissionBits holds the permissions of permissionNames in display order.
+var permissionBits = []int{
+	PermissionAdministrator,
+	PermissionViewAuditLogs,
+	PermissionManageServer,
+	PermissionManageRoles,
+	PermissionManageChannels,
+	PermissionKickMembers,
+	PermissionBanMembers,
+	PermissionCreateInstantInvite,
+	PermissionChangeNickname,
+	PermissionManageNicknames,
+	PermissionManageEmojis,
+	PermissionManageWebhooks,
+	PermissionViewChannel,
+	PermissionSendMessages,
+	PermissionSendTTSMessages,
+	PermissionManageMessages,
+	PermissionEmbedLinks,
+	PermissionAttachFiles,
+	PermissionReadMessageHistory,
+	PermissionMentionEveryone,
+	PermissionUseExternalEmojis,
+	PermissionAddReactions,
+	PermissionVoiceConnect,
+	PermissionVoiceSpeak,
+	PermissionVoiceMuteMembers,
+	PermissionVoiceDeafenMembers,
+	PermissionVoiceMoveMembers,
+	PermissionVoiceUseVAD,
+	PermissionVoicePrioritySpeaker,
+}
+
+// permissionNames holds the names of permissions as shown in the Discord client.
+var permissionNames = map[int]string{
+	PermissionAdministrator:        "Administrator",
+	PermissionViewAuditLogs:        "View Audit Log",
+	PermissionManageServer:         "Manage Server",
+	PermissionManageRoles:          "Manage Roles",
+	PermissionManageChannels:       "Manage Channels",
+	PermissionKickMembers:          "Kick Members",
+	PermissionBanMembers:           "Ban Members",
+	PermissionCreateInstantInvite:  "Create Invite",
+	PermissionChangeNickname:       "Change Nickname",
+	PermissionManageNicknames:      "Manage Nicknames",
+	PermissionManageEmojis:         "Manage Emojis",
+	PermissionManageWebhooks:       "Manage Webhooks",
+	PermissionViewChannel:          "View Channel",
+	PermissionSendMessages:         "Send Messages",
+	PermissionSendTTSMessages:      "Send TTS Messages",
+	PermissionManageMessages:       "Manage Messages",
+	PermissionEmbedLinks:           "Embed Links",
+	PermissionAttachFiles:          "Attach Files",
+	PermissionReadMessageHistory:   "Read Message History",
+	PermissionMentionEveryone:      "Mention Everyone",
+	PermissionUseExternalEmojis:    "Use External Emojis",
+	PermissionAddReactions:         "Add Reactions",
+	PermissionVoiceConnect:         "Connect",
+	PermissionVoiceSpeak:           "Speak",
+	PermissionVoiceMuteMembers:     "Mute Members",
+	PermissionVoiceDeafenMembers:   "Deafen Members",
+	PermissionVoiceMoveMembers:     "Move Members",
+	PermissionVoiceUseVAD:          "Use Voice Activity",
+	PermissionVoicePrioritySpeaker: "Priority Speaker",
+}
@@ -0,0 +1,75 @@
+package discordgo
+
+import "testing"
+
+func TestGuildSettingChanges(t *testing.T) {
+	before := &Guild{ID: "1", Name: "guild", OwnerID: "2", VerificationLevel: VerificationLevelLow, SystemChannelID: "3"}
+	after := &Guild{ID: "1", Name: "guild", OwnerID: "4", VerificationLevel: VerificationLevelVeryHigh, MfaLevel: MfaLevelElevated}
+
+	got := FormatSettingChanges(GuildSettingChanges(before, after))
+	want := "Owner: <@2> → <@4>\n" +
+		"System channel: <#3> → none\n" +
+		"Verification level: low → very high\n" +
+		"2FA required for moderation: no → yes"
+	if got != want {
+		t.Errorf("expected\n%s\ngot\n%s", want, got)
+	}
+
+	if changes := GuildSettingChanges(before, before); len(changes) != 0 {
+		t.Errorf("expected no changes of the same guild, got %v", changes)
+	}
+}
+
+func TestChannelSettingChanges(t *testing.T) {
+	before := &Channel{ID: "1", Name: "general", Topic: "hi", PermissionOverwrites: []*PermissionOverwrite{
+		{ID: "2", Type: "role", Deny: PermissionSendMessages},
+		{ID: "3", Type: "member", Allow: PermissionAddReactions},
+	}}
+	after := &Channel{ID: "1", Name: "general", RateLimitPerUser: 10, PermissionOverwrites: []*PermissionOverwrite{
+		{ID: "2", Type: "role", Allow: PermissionSendMessages},
+		{ID: "4", Type: "member", Deny: PermissionViewChannel},
+	}}
+
+	got := FormatSettingChanges(ChannelSettingChanges(before, after))
+	want := "Topic: hi → none\n" +
+		"Slowmode (seconds): 0 → 10\n" +
+		"Overwrite for <@&2> Send Messages: deny → allow\n" +
+		"Overwrite for <@3>: none → removed\n" +
+		"Overwrite for <@3> Add Reactions: allow → inherit\n" +
+		"Overwrite for <@4>: none → added\n" +
+		"Overwrite for <@4> View Channel: inherit → deny"
+	if got != want {
+		t.Errorf("expected\n%s\ngot\n%s", want, got)
+	}
+}
+
+func TestRoleSettingChanges(t *testing.T) {
+	before := &Role{ID: "1", Name: "mod", Permissions: PermissionKickMembers}
+	after := &Role{ID: "1", Name: "moderator", Color: 0xff0000, Hoist: true, Permissions: PermissionBanMembers}
+
+	got := FormatSettingChanges(RoleSettingChanges(before, after))
+	want := "Name: mod → moderator\n" +
+		"Color: none → #ff0000\n" +
+		"Hoisted: no → yes\n" +
+		"Permission Kick Members: yes → no\n" +
+		"Permission Ban Members: no → yes"
+	if got != want {
+		t.Errorf("expected\n%s\ngot\n%s", want, got)
+	}
+}
+
+func TestEventSettingChanges(t *testing.T) {
+	role := &GuildRoleUpdate{GuildRole: &GuildRole{GuildID: "1", Role: &Role{ID: "2", Name: "after"}}}
+	if changes := EventSettingChanges(role); changes != nil {
+		t.Errorf("expected no changes without the role before the update, got %v", changes)
+	}
+
+	role.BeforeUpdate = &Role{ID: "2", Name: "before"}
+	if changes := EventSettingChanges(role); len(changes) != 1 || changes[0].String() != "Name: before → after" {
+		t.Errorf("expected the name change, got %v", changes)
+	}
+
+	if changes := EventSettingChanges(&MessageCreate{}); changes != nil {
+		t.Errorf("expected no changes of other events, got %v", changes)
+	}
+}
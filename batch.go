@@ -0,0 +1,309 @@
+package discordgo
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrBatchJobNotFound is returned when cancelling a job which isn't queued,
+// eg. because it already completed.
+var ErrBatchJobNotFound = errors.New("batch job not found")
+
+// DefaultBatchFraction is the default Fraction of a BatchScheduler.
+const DefaultBatchFraction = 0.5
+
+// A BatchOperation is a REST request of a BatchJob.
+type BatchOperation struct {
+	Method   string          `json:"method"`
+	URL      string          `json:"url"`
+	BucketID string          `json:"bucket_id"`
+	Data     json.RawMessage `json:"data,omitempty"`
+}
+
+// NewBatchOperation returns a BatchOperation, data is JSON encoded and sent
+// as the body of the request if it isn't nil.
+// method   : The HTTP method.
+// urlStr   : The URL of the request, eg. an Endpoint.
+// bucketID : The rate limit bucket of the request, as for RequestWithBucketID.
+// data     : The body of the request.
+func NewBatchOperation(method, urlStr, bucketID string, data interface{}) (op *BatchOperation, err error) {
+	op = &BatchOperation{Method: method, URL: urlStr, BucketID: bucketID}
+	if data != nil {
+		op.Data, err = json.Marshal(data)
+	}
+	return
+}
+
+// BatchGuildMemberRoleAdd returns the BatchOperation of a GuildMemberRoleAdd.
+func BatchGuildMemberRoleAdd(guildID, userID, roleID string) *BatchOperation {
+	return &BatchOperation{Method: "PUT", URL: EndpointGuildMemberRole(guildID, userID, roleID), BucketID: EndpointGuildMemberRole(guildID, "", "")}
+}
+
+// BatchGuildMemberRoleRemove returns the BatchOperation of a GuildMemberRoleRemove.
+func BatchGuildMemberRoleRemove(guildID, userID, roleID string) *BatchOperation {
+	return &BatchOperation{Method: "DELETE", URL: EndpointGuildMemberRole(guildID, userID, roleID), BucketID: EndpointGuildMemberRole(guildID, "", "")}
+}
+
+// A BatchJob is a batch of REST requests run by a BatchScheduler.
+type BatchJob struct {
+	ID         string            `json:"id"`
+	Operations []*BatchOperation `json:"operations"`
+
+	// The number of operations which ran, the job continues at this
+	// operation after a restart.
+	Done int `json:"done"`
+
+	// The number of operations which failed.
+	Failed int `json:"failed"`
+}
+
+// A BatchStore persists the jobs of a BatchScheduler and their progress,
+// so they continue after a restart of the bot.
+type BatchStore interface {
+	// BatchSave saves a new job, or the progress of a job.
+	BatchSave(j *BatchJob) error
+
+	// BatchDelete deletes a job which completed or was cancelled.
+	BatchDelete(id string) error
+
+	// BatchLoad returns all saved jobs.
+	BatchLoad() ([]*BatchJob, error)
+}
+
+// A BatchScheduler runs large batches of REST requests in the background,
+// eg. removing a role from thousands of members. The requests are sent with
+// RequestPriorityLow, one at a time, and only while they use less than
+// Fraction of the limit of their rate limit bucket, so the interactive
+// requests of the bot aren't rate limited by them.
+//
+// The jobs run one after the other in the order they were submitted. When a
+// job completes a BatchJobComplete event is sent.
+type BatchScheduler struct {
+	sync.Mutex
+
+	// The fraction of the rate limit of a bucket the requests may use.
+	Fraction float64
+
+	// Store persists the jobs, it is optional.
+	Store BatchStore
+
+	session *Session
+	queue   []*BatchJob
+	working bool
+	closed  bool
+	lastID  int64
+}
+
+// NewBatchScheduler returns a new BatchScheduler which runs jobs for s.
+// Call Load after creating it to continue the jobs saved in a Store.
+func NewBatchScheduler(s *Session) *BatchScheduler {
+	return &BatchScheduler{
+		Fraction: DefaultBatchFraction,
+		session:  s,
+	}
+}
+
+// Submit queues a job of the operations, and returns its ID.
+func (b *BatchScheduler) Submit(ops []*BatchOperation) (id string, err error) {
+	b.Lock()
+	// IDs only need to be unique, the time keeps them unique across restarts.
+	b.lastID++
+	id = strconv.FormatInt(time.Now().UnixNano(), 36) + "-" + strconv.FormatInt(b.lastID, 36)
+	b.Unlock()
+
+	j := &BatchJob{ID: id, Operations: ops}
+	if b.Store != nil {
+		if err = b.Store.BatchSave(j); err != nil {
+			return "", err
+		}
+	}
+
+	b.add(j)
+	return
+}
+
+// Load queues the jobs saved in the Store.
+func (b *BatchScheduler) Load() error {
+	if b.Store == nil {
+		return nil
+	}
+
+	jobs, err := b.Store.BatchLoad()
+	if err != nil {
+		return err
+	}
+
+	for _, j := range jobs {
+		b.Lock()
+		queued := b.index(j.ID) >= 0
+		b.Unlock()
+
+		if !queued {
+			b.add(j)
+		}
+	}
+	return nil
+}
+
+// add queues a job and starts the worker if it isn't running.
+func (b *BatchScheduler) add(j *BatchJob) {
+	b.Lock()
+	if b.closed {
+		b.Unlock()
+		return
+	}
+	b.queue = append(b.queue, j)
+
+	start := !b.working
+	b.working = true
+	b.Unlock()
+
+	if start {
+		go b.work()
+	}
+}
+
+// index returns the index of a job in the queue, or -1 if it isn't
+// queued. The lock must be held.
+func (b *BatchScheduler) index(id string) int {
+	for i, j := range b.queue {
+		if j.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// Progress returns the number of operations of a queued job which ran, and
+// the number of operations of the job.
+func (b *BatchScheduler) Progress(id string) (done, total int, err error) {
+	b.Lock()
+	defer b.Unlock()
+
+	i := b.index(id)
+	if i < 0 {
+		return 0, 0, ErrBatchJobNotFound
+	}
+	return b.queue[i].Done, len(b.queue[i].Operations), nil
+}
+
+// Jobs returns copies of the queued jobs, the first is running.
+func (b *BatchScheduler) Jobs() []*BatchJob {
+	b.Lock()
+	defer b.Unlock()
+
+	jobs := make([]*BatchJob, 0, len(b.queue))
+	for _, j := range b.queue {
+		jobs = append(jobs, j.copy())
+	}
+	return jobs
+}
+
+func (j *BatchJob) copy() *BatchJob {
+	c := *j
+	c.Operations = append([]*BatchOperation(nil), j.Operations...)
+	return &c
+}
+
+// Cancel cancels the job with the given ID, the operations which ran
+// aren't undone.
+func (b *BatchScheduler) Cancel(id string) error {
+	b.Lock()
+	i := b.index(id)
+	if i >= 0 {
+		b.queue = append(b.queue[:i], b.queue[i+1:]...)
+	}
+	b.Unlock()
+
+	if i < 0 {
+		return ErrBatchJobNotFound
+	}
+
+	if b.Store != nil {
+		return b.Store.BatchDelete(id)
+	}
+	return nil
+}
+
+// Close stops running the jobs after the current operation, without
+// deleting them from the Store, eg. before shutting down.
+func (b *BatchScheduler) Close() {
+	b.Lock()
+	defer b.Unlock()
+
+	b.closed = true
+	b.queue = nil
+}
+
+// work runs the queued jobs until the queue is empty.
+func (b *BatchScheduler) work() {
+	for {
+		b.Lock()
+		if len(b.queue) == 0 || b.closed {
+			b.working = false
+			b.Unlock()
+			return
+		}
+		j := b.queue[0]
+		if j.Done >= len(j.Operations) {
+			b.queue = b.queue[1:]
+			b.Unlock()
+			b.complete(j)
+			continue
+		}
+		op := j.Operations[j.Done]
+		fraction := b.Fraction
+		b.Unlock()
+
+		if fraction <= 0 || fraction > 1 {
+			fraction = DefaultBatchFraction
+		}
+		bucketID := op.BucketID
+		if bucketID == "" {
+			bucketID = op.URL
+		}
+		if wait := b.session.Ratelimiter.budgetWait(bucketID, fraction); wait > 0 {
+			time.Sleep(wait)
+		}
+
+		var data interface{}
+		if len(op.Data) > 0 {
+			data = op.Data
+		}
+		_, err := b.session.RequestWithPriority(op.Method, op.URL, data, op.BucketID, RequestPriorityLow)
+
+		b.Lock()
+		if b.index(j.ID) < 0 && !b.closed {
+			// Cancelled while the operation ran.
+			b.Unlock()
+			continue
+		}
+		j.Done++
+		if err != nil {
+			j.Failed++
+		}
+		b.Unlock()
+
+		if err != nil {
+			b.session.log(LogError, "error running operation %d of batch job %s, %s", j.Done-1, j.ID, err)
+		}
+		if b.Store != nil {
+			if err = b.Store.BatchSave(j); err != nil {
+				b.session.log(LogError, "error saving batch job %s, %s", j.ID, err)
+			}
+		}
+	}
+}
+
+// complete deletes a completed job and sends its BatchJobComplete event.
+func (b *BatchScheduler) complete(j *BatchJob) {
+	if b.Store != nil {
+		if err := b.Store.BatchDelete(j.ID); err != nil {
+			b.session.log(LogError, "error deleting batch job %s, %s", j.ID, err)
+		}
+	}
+	b.session.handleEvent(batchJobCompleteEventType, &BatchJobComplete{j})
+}
@@ -0,0 +1,149 @@
+package discordgo
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// memoryBatchStore is a BatchStore keeping the jobs in memory.
+type memoryBatchStore struct {
+	sync.Mutex
+	jobs  map[string]BatchJob
+	saves int
+}
+
+func (m *memoryBatchStore) BatchSave(j *BatchJob) error {
+	m.Lock()
+	defer m.Unlock()
+	m.jobs[j.ID] = *j
+	m.saves++
+	return nil
+}
+
+func (m *memoryBatchStore) BatchDelete(id string) error {
+	m.Lock()
+	defer m.Unlock()
+	delete(m.jobs, id)
+	return nil
+}
+
+func (m *memoryBatchStore) BatchLoad() (jobs []*BatchJob, err error) {
+	m.Lock()
+	defer m.Unlock()
+	for _, j := range m.jobs {
+		j := j
+		jobs = append(jobs, &j)
+	}
+	return
+}
+
+func TestBatchScheduler(t *testing.T) {
+	tr := &routeTransport{routes: map[string]string{
+		"DELETE " + EndpointGuildMemberRole("1", "2", "3"): ``,
+		"DELETE " + EndpointGuildMemberRole("1", "4", "3"): ``,
+	}}
+	s := newTestSession(tr)
+
+	complete := make(chan *BatchJobComplete, 2)
+	s.AddHandler(func(s *Session, e *BatchJobComplete) {
+		complete <- e
+	})
+
+	store := &memoryBatchStore{jobs: map[string]BatchJob{}}
+	b := NewBatchScheduler(s)
+	b.Store = store
+
+	// The job saved by a previous run continues at its first operation left.
+	store.jobs["saved"] = BatchJob{ID: "saved", Operations: []*BatchOperation{
+		BatchGuildMemberRoleRemove("1", "9", "3"),
+		BatchGuildMemberRoleRemove("1", "2", "3"),
+	}, Done: 1}
+	if err := b.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	id, err := b.Submit([]*BatchOperation{
+		BatchGuildMemberRoleRemove("1", "4", "3"),
+		BatchGuildMemberRoleRemove("1", "5", "3"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The handlers of the events may run in any order.
+	want := map[string][2]int{"saved": {2, 0}, id: {2, 1}}
+	for range want {
+		select {
+		case e := <-complete:
+			if w, ok := want[e.ID]; !ok || e.Done != w[0] || e.Failed != w[1] {
+				t.Errorf("unexpected completed job %s, %d done, %d failed", e.ID, e.Done, e.Failed)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("expected the job to complete")
+		}
+	}
+
+	if len(tr.requests) != 3 {
+		t.Errorf("expected 3 requests, got %v", tr.requests)
+	}
+	if _, _, err = b.Progress(id); err != ErrBatchJobNotFound {
+		t.Errorf("expected the completed job to be removed, got %v", err)
+	}
+	store.Lock()
+	if len(store.jobs) != 0 || store.saves != 4 {
+		t.Errorf("expected the jobs to be deleted after 4 saves, got %d jobs and %d saves", len(store.jobs), store.saves)
+	}
+	store.Unlock()
+}
+
+func TestBatchSchedulerJobs(t *testing.T) {
+	tr := &routeTransport{handle: answer(``), release: make(chan struct{})}
+	s := newTestSession(tr)
+
+	complete := make(chan *BatchJobComplete, 1)
+	s.AddHandler(func(s *Session, e *BatchJobComplete) {
+		complete <- e
+	})
+
+	b := NewBatchScheduler(s)
+	id, err := b.Submit([]*BatchOperation{BatchGuildMemberRoleRemove("1", "2", "3")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The jobs are copies, which the running job doesn't change.
+	jobs := b.Jobs()
+	if len(jobs) != 1 || jobs[0].ID != id {
+		t.Fatalf("expected the submitted job, got %+v", jobs)
+	}
+	jobs[0].Done = 1
+	jobs[0].Operations[0] = nil
+	close(tr.release)
+
+	select {
+	case e := <-complete:
+		if e.Done != 1 || e.Failed != 0 || e.Operations[0] == nil {
+			t.Errorf("unexpected completed job %+v", e.BatchJob)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the job to complete")
+	}
+	if tr.count() != 1 {
+		t.Errorf("expected the operation to run once, got %v", tr.requests)
+	}
+}
+
+func TestRatelimitBudgetWait(t *testing.T) {
+	rl := NewRatelimiter()
+	b := rl.GetBucket("/guilds/1/members")
+	b.limit, b.Remaining, b.reset = 10, 6, time.Now().Add(time.Second)
+
+	if wait := rl.budgetWait("/guilds/1/members", 0.5); wait != 0 {
+		t.Errorf("expected no wait with 4 of 10 requests used, got %s", wait)
+	}
+	b.Remaining = 5
+	if wait := rl.budgetWait("/guilds/1/members", 0.5); wait <= 0 {
+		t.Error("expected a wait with half of the requests used")
+	}
+}
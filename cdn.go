@@ -0,0 +1,186 @@
+package discordgo
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ImageFormat is the format of an image of Discord's CDN.
+type ImageFormat string
+
+// Block contains the valid known ImageFormat values
+const (
+	// GIF for animated images, PNG otherwise.
+	ImageFormatAuto ImageFormat = ""
+
+	ImageFormatPNG  ImageFormat = "png"
+	ImageFormatJPEG ImageFormat = "jpg"
+	ImageFormatWebP ImageFormat = "webp"
+	ImageFormatGIF  ImageFormat = "gif"
+)
+
+// Sizes of the images of Discord's CDN.
+const (
+	ImageSizeMin = 16
+	ImageSizeMax = 4096
+)
+
+// IsAnimated returns whether the image of an asset hash is animated, which
+// Discord marks with an a_ prefix.
+func IsAnimated(hash string) bool {
+	return strings.HasPrefix(hash, "a_")
+}
+
+// imageURL returns the URL of an image of the CDN.
+// base   : The URL of the image without the extension.
+// hash   : The asset hash of the image.
+// format : The format of the image.
+// size   : The size of the image, rounded up to a power of two, 0 for the original size.
+func imageURL(base, hash string, format ImageFormat, size int) string {
+	if format == ImageFormatAuto {
+		format = ImageFormatPNG
+		if IsAnimated(hash) {
+			format = ImageFormatGIF
+		}
+	}
+
+	URL := base + "." + string(format)
+	if size > 0 {
+		s := ImageSizeMin
+		for s < size && s < ImageSizeMax {
+			s *= 2
+		}
+		URL += "?size=" + strconv.Itoa(s)
+	}
+	return URL
+}
+
+// IconURLComplex returns a URL to the guild's icon, or an empty string if
+// it has none.
+// format : The format of the image, ImageFormatAuto for a GIF if it is animated.
+// size   : The size of the image, 0 for the original size.
+func (g *Guild) IconURLComplex(format ImageFormat, size int) string {
+	if g.Icon == "" {
+		return ""
+	}
+	return imageURL(EndpointCDNIcons+g.ID+"/"+g.Icon, g.Icon, format, size)
+}
+
+// BannerURL returns a URL to the guild's banner, or an empty string if it
+// has none.
+// format : The format of the image, ImageFormatAuto for a GIF if it is animated.
+// size   : The size of the image, 0 for the original size.
+func (g *Guild) BannerURL(format ImageFormat, size int) string {
+	if g.Banner == "" {
+		return ""
+	}
+	return imageURL(EndpointCDNBanners+g.ID+"/"+g.Banner, g.Banner, format, size)
+}
+
+// SplashURL returns a URL to the guild's invite splash, or an empty string
+// if it has none.
+// format : The format of the image.
+// size   : The size of the image, 0 for the original size.
+func (g *Guild) SplashURL(format ImageFormat, size int) string {
+	if g.Splash == "" {
+		return ""
+	}
+	return imageURL(EndpointCDNSplashes+g.ID+"/"+g.Splash, g.Splash, format, size)
+}
+
+// DiscoverySplashURL returns a URL to the guild's discovery splash, or an
+// empty string if it has none.
+// format : The format of the image.
+// size   : The size of the image, 0 for the original size.
+func (g *Guild) DiscoverySplashURL(format ImageFormat, size int) string {
+	if g.DiscoverySplash == "" {
+		return ""
+	}
+	return imageURL(EndpointCDNDiscoverySplashes+g.ID+"/"+g.DiscoverySplash, g.DiscoverySplash, format, size)
+}
+
+// AvatarURLComplex returns a URL to the user's avatar, or to the default
+// avatar if the user has none, which is always a PNG.
+// format : The format of the image, ImageFormatAuto for a GIF if it is animated.
+// size   : The size of the image, 0 for the original size.
+func (u *User) AvatarURLComplex(format ImageFormat, size int) string {
+	if u.Avatar == "" {
+		return imageURL(strings.TrimSuffix(EndpointDefaultUserAvatar(u.Discriminator), ".png"), "", ImageFormatPNG, size)
+	}
+	return imageURL(EndpointCDNAvatars+u.ID+"/"+u.Avatar, u.Avatar, format, size)
+}
+
+// WidgetImageStyle is the style of the image of a guild widget.
+type WidgetImageStyle string
+
+// Block contains the valid known WidgetImageStyle values
+const (
+	WidgetImageStyleShield  WidgetImageStyle = "shield"
+	WidgetImageStyleBanner1 WidgetImageStyle = "banner1"
+	WidgetImageStyleBanner2 WidgetImageStyle = "banner2"
+	WidgetImageStyleBanner3 WidgetImageStyle = "banner3"
+	WidgetImageStyleBanner4 WidgetImageStyle = "banner4"
+)
+
+// GuildWidgetImage downloads the PNG image of the widget of a guild, which
+// must have its widget enabled. The caller must close the image.
+// guildID : The ID of a Guild.
+// style   : The style of the image, "" for WidgetImageStyleShield.
+func (s *Session) GuildWidgetImage(guildID string, style WidgetImageStyle) (io.ReadCloser, error) {
+	URL := EndpointGuildWidgetImage(guildID)
+	if style != "" {
+		URL += "?style=" + string(style)
+	}
+	return s.downloadImage(URL)
+}
+
+// GuildSplashImage downloads the invite splash of a guild, or returns
+// ErrGuildNoSplash if it has none. The caller must close the image.
+// guild  : The guild, eg. from the state.
+// format : The format of the image.
+// size   : The size of the image, 0 for the original size.
+func (s *Session) GuildSplashImage(guild *Guild, format ImageFormat, size int) (io.ReadCloser, error) {
+	URL := guild.SplashURL(format, size)
+	if URL == "" {
+		return nil, ErrGuildNoSplash
+	}
+	return s.downloadImage(URL)
+}
+
+// GuildBannerImage downloads the banner of a guild, or returns
+// ErrGuildNoBanner if it has none. The caller must close the image.
+// guild  : The guild, eg. from the state.
+// format : The format of the image, ImageFormatAuto for a GIF if it is animated.
+// size   : The size of the image, 0 for the original size.
+func (s *Session) GuildBannerImage(guild *Guild, format ImageFormat, size int) (io.ReadCloser, error) {
+	URL := guild.BannerURL(format, size)
+	if URL == "" {
+		return nil, ErrGuildNoBanner
+	}
+	return s.downloadImage(URL)
+}
+
+// downloadImage requests an image and returns its body without reading
+// it. The images are public, so the request is sent without the token.
+func (s *Session) downloadImage(URL string) (io.ReadCloser, error) {
+	req, err := http.NewRequest("GET", URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", s.UserAgent)
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1<<16))
+		return nil, newRestError(req, resp, body)
+	}
+	return resp.Body, nil
+}
@@ -0,0 +1,49 @@
+package discordgo
+
+import "testing"
+
+func TestImageURLs(t *testing.T) {
+	g := &Guild{ID: "1", Icon: "a_icon", Banner: "banner", DiscoverySplash: "splash"}
+	tests := []struct {
+		got, want string
+	}{
+		{g.IconURLComplex(ImageFormatAuto, 0), EndpointCDNIcons + "1/a_icon.gif"},
+		{g.IconURLComplex(ImageFormatWebP, 100), EndpointCDNIcons + "1/a_icon.webp?size=128"},
+		{g.BannerURL(ImageFormatAuto, 8000), EndpointCDNBanners + "1/banner.png?size=4096"},
+		{g.DiscoverySplashURL(ImageFormatJPEG, 1), EndpointCDNDiscoverySplashes + "1/splash.jpg?size=16"},
+		{g.SplashURL(ImageFormatAuto, 0), ""},
+		{(&User{ID: "2", Avatar: "a_avatar"}).AvatarURLComplex(ImageFormatAuto, 64), EndpointCDNAvatars + "2/a_avatar.gif?size=64"},
+		{(&User{ID: "2", Discriminator: "0003"}).AvatarURLComplex(ImageFormatGIF, 0), EndpointCDN + "embed/avatars/3.png"},
+	}
+
+	for i, test := range tests {
+		if test.got != test.want {
+			t.Errorf("%d: expected %q, got %q", i, test.want, test.got)
+		}
+	}
+}
+
+func TestGuildImages(t *testing.T) {
+	tr := &routeTransport{handle: answer(`png`)}
+	s := newTestSession(tr)
+	img, err := s.GuildWidgetImage("1", WidgetImageStyleBanner2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	img.Close()
+	if r := tr.last(); r != "GET "+EndpointGuildWidgetImage("1")+"?style=banner2" {
+		t.Errorf("unexpected request %s", r)
+	}
+
+	if _, err = s.GuildBannerImage(&Guild{ID: "1"}, ImageFormatAuto, 0); err != ErrGuildNoBanner {
+		t.Errorf("expected ErrGuildNoBanner, got %v", err)
+	}
+	img, err = s.GuildSplashImage(&Guild{ID: "1", Splash: "abc"}, ImageFormatWebP, 600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	img.Close()
+	if r := tr.last(); r != "GET "+EndpointCDNSplashes+"1/abc.webp?size=1024" {
+		t.Errorf("unexpected request %s", r)
+	}
+}
@@ -0,0 +1,125 @@
+package discordgo
+
+// A StringChange is the old and new value of a changed string field.
+type StringChange struct {
+	Old, New string
+}
+
+// An IntChange is the old and new value of a changed int field.
+type IntChange struct {
+	Old, New int
+}
+
+// A BoolChange is the old and new value of a changed bool field.
+type BoolChange struct {
+	Old, New bool
+}
+
+// A PermissionOverwriteUpdate is an overwrite whose permissions changed.
+type PermissionOverwriteUpdate struct {
+	Old, New *PermissionOverwrite
+}
+
+// A PermissionOverwritesChange is a change of the permission overwrites
+// of a channel. The overwrites are matched by ID and type.
+type PermissionOverwritesChange struct {
+	Added   []*PermissionOverwrite
+	Removed []*PermissionOverwrite
+	Updated []*PermissionOverwriteUpdate
+}
+
+// ChannelChanges are the changed fields of a channel, nil for the fields
+// which didn't change.
+type ChannelChanges struct {
+	Name                       *StringChange
+	Topic                      *StringChange
+	NSFW                       *BoolChange
+	Position                   *IntChange
+	Bitrate                    *IntChange
+	UserLimit                  *IntChange
+	ParentID                   *StringChange
+	RateLimitPerUser           *IntChange
+	RTCRegion                  *StringChange
+	DefaultAutoArchiveDuration *IntChange
+	PermissionOverwrites       *PermissionOverwritesChange
+}
+
+// Empty returns whether none of the fields changed.
+func (c *ChannelChanges) Empty() bool {
+	return *c == ChannelChanges{}
+}
+
+// DiffChannels returns the changes from one version of a channel to
+// another, eg. the BeforeUpdate and Channel of a ChannelUpdate.
+// before : The old version of the channel.
+// after  : The new version of the channel.
+func DiffChannels(before, after *Channel) *ChannelChanges {
+	return &ChannelChanges{
+		Name:                       diffString(before.Name, after.Name),
+		Topic:                      diffString(before.Topic, after.Topic),
+		NSFW:                       diffBool(before.NSFW, after.NSFW),
+		Position:                   diffInt(before.Position, after.Position),
+		Bitrate:                    diffInt(before.Bitrate, after.Bitrate),
+		UserLimit:                  diffInt(before.UserLimit, after.UserLimit),
+		ParentID:                   diffString(before.ParentID, after.ParentID),
+		RateLimitPerUser:           diffInt(before.RateLimitPerUser, after.RateLimitPerUser),
+		RTCRegion:                  diffString(before.RTCRegion, after.RTCRegion),
+		DefaultAutoArchiveDuration: diffInt(before.DefaultAutoArchiveDuration, after.DefaultAutoArchiveDuration),
+		PermissionOverwrites:       diffPermissionOverwrites(before.PermissionOverwrites, after.PermissionOverwrites),
+	}
+}
+
+func diffString(old, new string) *StringChange {
+	if old == new {
+		return nil
+	}
+	return &StringChange{Old: old, New: new}
+}
+
+func diffInt(old, new int) *IntChange {
+	if old == new {
+		return nil
+	}
+	return &IntChange{Old: old, New: new}
+}
+
+func diffBool(old, new bool) *BoolChange {
+	if old == new {
+		return nil
+	}
+	return &BoolChange{Old: old, New: new}
+}
+
+func diffPermissionOverwrites(old, new []*PermissionOverwrite) *PermissionOverwritesChange {
+	key := func(o *PermissionOverwrite) string {
+		return o.Type + ":" + o.ID
+	}
+
+	before := make(map[string]*PermissionOverwrite, len(old))
+	for _, o := range old {
+		before[key(o)] = o
+	}
+
+	change := &PermissionOverwritesChange{}
+	for _, o := range new {
+		k := key(o)
+		b, ok := before[k]
+		delete(before, k)
+		switch {
+		case !ok:
+			change.Added = append(change.Added, o)
+		case b.Allow != o.Allow || b.Deny != o.Deny:
+			change.Updated = append(change.Updated, &PermissionOverwriteUpdate{Old: b, New: o})
+		}
+	}
+	for _, o := range old {
+		if _, ok := before[key(o)]; ok {
+			change.Removed = append(change.Removed, o)
+		}
+	}
+
+	if change.Added == nil && change.Removed == nil && change.Updated == nil {
+		return nil
+	}
+	return change
+}
@@ -0,0 +1,50 @@
+package discordgo
+
+import "testing"
+
+func TestChannelUpdateChanges(t *testing.T) {
+	se := &Session{StateEnabled: true, State: NewState()}
+	se.State.OnInterface(se, &GuildCreate{&Guild{ID: "1"}})
+	se.State.OnInterface(se, &ChannelCreate{&Channel{ID: "2", GuildID: "1", Name: "general", Topic: "hi", PermissionOverwrites: []*PermissionOverwrite{
+		{ID: "3", Type: "role", Deny: 1},
+		{ID: "4", Type: "member", Allow: 2},
+	}}})
+
+	update := &ChannelUpdate{Channel: &Channel{ID: "2", GuildID: "1", Name: "general", Topic: "hello", RateLimitPerUser: 10, PermissionOverwrites: []*PermissionOverwrite{
+		{ID: "3", Type: "role", Deny: 3},
+		{ID: "5", Type: "member", Allow: 2},
+	}}}
+	se.State.OnInterface(se, update)
+
+	c := update.Changes
+	if c == nil || c.Empty() {
+		t.Fatal("expected changes")
+	}
+	if c.Name != nil {
+		t.Errorf("expected the name to be unchanged, got %+v", c.Name)
+	}
+	if c.Topic == nil || c.Topic.Old != "hi" || c.Topic.New != "hello" {
+		t.Errorf("unexpected topic change %+v", c.Topic)
+	}
+	if c.RateLimitPerUser == nil || c.RateLimitPerUser.Old != 0 || c.RateLimitPerUser.New != 10 {
+		t.Errorf("unexpected slowmode change %+v", c.RateLimitPerUser)
+	}
+
+	o := c.PermissionOverwrites
+	if o == nil || len(o.Added) != 1 || o.Added[0].ID != "5" || len(o.Removed) != 1 || o.Removed[0].ID != "4" || len(o.Updated) != 1 || o.Updated[0].New.Deny != 3 {
+		t.Errorf("unexpected overwrite changes %+v", o)
+	}
+
+	if !DiffChannels(update.Channel, update.Channel).Empty() {
+		t.Error("expected no changes of the same channel")
+	}
+	// An update without overwrites keeps the cached ones.
+	update = &ChannelUpdate{Channel: &Channel{ID: "2", GuildID: "1", Name: "general", Topic: "bye", RateLimitPerUser: 10}}
+	se.State.OnInterface(se, update)
+	if c = update.Changes; c.PermissionOverwrites != nil || c.Topic == nil {
+		t.Errorf("expected only the topic to change, got %+v", c)
+	}
+	if cached, _ := se.State.Channel("2"); len(cached.PermissionOverwrites) != 2 {
+		t.Errorf("expected the overwrites to be kept, got %+v", cached.PermissionOverwrites)
+	}
+}
@@ -0,0 +1,152 @@
+package discordgo
+
+import (
+	"strconv"
+
+	"github.com/gorilla/websocket"
+)
+
+// GatewayCloseCode is a close code of the gateway websocket.
+// https://discord.com/developers/docs/topics/opcodes-and-status-codes#gateway-gateway-close-event-codes
+type GatewayCloseCode int
+
+// Block contains the valid known GatewayCloseCode values
+const (
+	GatewayCloseUnknownError         GatewayCloseCode = 4000
+	GatewayCloseUnknownOpcode        GatewayCloseCode = 4001
+	GatewayCloseDecodeError          GatewayCloseCode = 4002
+	GatewayCloseNotAuthenticated     GatewayCloseCode = 4003
+	GatewayCloseAuthenticationFailed GatewayCloseCode = 4004
+	GatewayCloseAlreadyAuthenticated GatewayCloseCode = 4005
+	GatewayCloseInvalidSequence      GatewayCloseCode = 4007
+	GatewayCloseRateLimited          GatewayCloseCode = 4008
+	GatewayCloseSessionTimedOut      GatewayCloseCode = 4009
+	GatewayCloseInvalidShard         GatewayCloseCode = 4010
+	GatewayCloseShardingRequired     GatewayCloseCode = 4011
+	GatewayCloseInvalidAPIVersion    GatewayCloseCode = 4012
+	GatewayCloseInvalidIntents       GatewayCloseCode = 4013
+	GatewayCloseDisallowedIntents    GatewayCloseCode = 4014
+)
+
+var gatewayCloseCauses = map[GatewayCloseCode]string{
+	GatewayCloseUnknownError:         "unknown error",
+	GatewayCloseUnknownOpcode:        "unknown opcode sent",
+	GatewayCloseDecodeError:          "invalid payload sent",
+	GatewayCloseNotAuthenticated:     "payload sent before identifying",
+	GatewayCloseAuthenticationFailed: "invalid token",
+	GatewayCloseAlreadyAuthenticated: "identified more than once",
+	GatewayCloseInvalidSequence:      "invalid sequence to resume",
+	GatewayCloseRateLimited:          "payloads sent too quickly",
+	GatewayCloseSessionTimedOut:      "session timed out",
+	GatewayCloseInvalidShard:         "invalid shard",
+	GatewayCloseShardingRequired:     "sharding required, too many guilds",
+	GatewayCloseInvalidAPIVersion:    "invalid API version",
+	GatewayCloseInvalidIntents:       "invalid intents",
+	GatewayCloseDisallowedIntents:    "privileged intents not enabled or approved",
+}
+
+// String returns the cause of the close code.
+func (c GatewayCloseCode) String() string {
+	if cause, ok := gatewayCloseCauses[c]; ok {
+		return cause
+	}
+	return "close code " + strconv.Itoa(int(c))
+}
+
+// IsFatal returns whether reconnecting can't succeed without changing the
+// token, shards, API version or intents.
+func (c GatewayCloseCode) IsFatal() bool {
+	switch c {
+	case GatewayCloseAuthenticationFailed, GatewayCloseInvalidShard, GatewayCloseShardingRequired,
+		GatewayCloseInvalidAPIVersion, GatewayCloseInvalidIntents, GatewayCloseDisallowedIntents:
+		return true
+	}
+	return false
+}
+
+// VoiceCloseCode is a close code of the voice websocket.
+// https://discord.com/developers/docs/topics/opcodes-and-status-codes#voice-voice-close-event-codes
+type VoiceCloseCode int
+
+// Block contains the valid known VoiceCloseCode values
+const (
+	VoiceCloseUnknownOpcode         VoiceCloseCode = 4001
+	VoiceCloseDecodeError           VoiceCloseCode = 4002
+	VoiceCloseNotAuthenticated      VoiceCloseCode = 4003
+	VoiceCloseAuthenticationFailed  VoiceCloseCode = 4004
+	VoiceCloseAlreadyAuthenticated  VoiceCloseCode = 4005
+	VoiceCloseSessionInvalid        VoiceCloseCode = 4006
+	VoiceCloseSessionTimeout        VoiceCloseCode = 4009
+	VoiceCloseServerNotFound        VoiceCloseCode = 4011
+	VoiceCloseUnknownProtocol       VoiceCloseCode = 4012
+	VoiceCloseDisconnected          VoiceCloseCode = 4014
+	VoiceCloseServerCrashed         VoiceCloseCode = 4015
+	VoiceCloseUnknownEncryptionMode VoiceCloseCode = 4016
+)
+
+var voiceCloseCauses = map[VoiceCloseCode]string{
+	VoiceCloseUnknownOpcode:         "unknown opcode sent",
+	VoiceCloseDecodeError:           "invalid payload sent",
+	VoiceCloseNotAuthenticated:      "payload sent before identifying",
+	VoiceCloseAuthenticationFailed:  "invalid token",
+	VoiceCloseAlreadyAuthenticated:  "identified more than once",
+	VoiceCloseSessionInvalid:        "session no longer valid",
+	VoiceCloseSessionTimeout:        "session timed out",
+	VoiceCloseServerNotFound:        "voice server not found",
+	VoiceCloseUnknownProtocol:       "unknown protocol",
+	VoiceCloseDisconnected:          "disconnected from the channel",
+	VoiceCloseServerCrashed:         "voice server crashed",
+	VoiceCloseUnknownEncryptionMode: "unknown encryption mode",
+}
+
+// String returns the cause of the close code.
+func (c VoiceCloseCode) String() string {
+	if cause, ok := voiceCloseCauses[c]; ok {
+		return cause
+	}
+	return "close code " + strconv.Itoa(int(c))
+}
+
+// IsFatal returns whether reconnecting the voice websocket can't succeed,
+// eg. because the bot was disconnected or moved out of the channel. The
+// channel must be joined again instead.
+func (c VoiceCloseCode) IsFatal() bool {
+	switch c {
+	case VoiceCloseAuthenticationFailed, VoiceCloseSessionInvalid, VoiceCloseUnknownProtocol,
+		VoiceCloseDisconnected, VoiceCloseUnknownEncryptionMode:
+		return true
+	}
+	return false
+}
+
+// A GatewayCloseError is returned by Open and given to Disconnect events
+// when Discord closed the gateway websocket.
+type GatewayCloseError struct {
+	Code GatewayCloseCode
+
+	// The reason sent by Discord, if any.
+	Reason string
+}
+
+// Error returns the code, cause and reason of the close.
+func (e *GatewayCloseError) Error() string {
+	msg := "gateway closed with " + strconv.Itoa(int(e.Code)) + " (" + e.Code.String() + ")"
+	if e.Reason != "" {
+		msg += ": " + e.Reason
+	}
+	return msg
+}
+
+// IsFatal returns whether reconnecting can't succeed, see GatewayCloseCode.IsFatal.
+func (e *GatewayCloseError) IsFatal() bool {
+	return e.Code.IsFatal()
+}
+
+// gatewayCloseError returns a *GatewayCloseError for a websocket close
+// error, or nil for other errors.
+func gatewayCloseError(err error) *GatewayCloseError {
+	if ce, ok := err.(*websocket.CloseError); ok {
+		return &GatewayCloseError{Code: GatewayCloseCode(ce.Code), Reason: ce.Text}
+	}
+	return nil
+}
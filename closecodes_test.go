@@ -0,0 +1,34 @@
+package discordgo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestGatewayCloseError(t *testing.T) {
+	cerr := gatewayCloseError(&websocket.CloseError{Code: 4004, Text: "Authentication failed."})
+	if cerr == nil || cerr.Code != GatewayCloseAuthenticationFailed || !cerr.IsFatal() {
+		t.Fatalf("unexpected close error %#v", cerr)
+	}
+	if msg := cerr.Error(); msg != "gateway closed with 4004 (invalid token): Authentication failed." {
+		t.Errorf("unexpected message %q", msg)
+	}
+
+	if cerr = gatewayCloseError(&websocket.CloseError{Code: int(GatewayCloseSessionTimedOut)}); cerr == nil || cerr.IsFatal() {
+		t.Errorf("expected a session timeout not to be fatal, got %#v", cerr)
+	}
+	if cerr = gatewayCloseError(errors.New("EOF")); cerr != nil {
+		t.Errorf("expected no close error, got %#v", cerr)
+	}
+}
+
+func TestVoiceCloseCode(t *testing.T) {
+	if !VoiceCloseDisconnected.IsFatal() || VoiceCloseServerCrashed.IsFatal() {
+		t.Error("unexpected fatal voice close codes")
+	}
+	if s := VoiceCloseCode(4999).String(); s != "close code 4999" {
+		t.Errorf("unexpected cause %q", s)
+	}
+}
@@ -0,0 +1,51 @@
+package discordgo
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidColor is returned by ParseColor when the string isn't a hex color.
+var ErrInvalidColor = errors.New("invalid hex color, expected #rrggbb or #rgb")
+
+// Colors of Discord's palette, for MessageEmbed.Color and role colors.
+const (
+	ColorBlurple = 0x5865f2
+	ColorGreen   = 0x57f287
+	ColorYellow  = 0xfee75c
+	ColorFuchsia = 0xeb459e
+	ColorRed     = 0xed4245
+	ColorWhite   = 0xffffff
+	ColorBlack   = 0x23272a
+	ColorGrey    = 0x99aab5
+)
+
+// ColorRGB returns the color of the red, green and blue components, as
+// used by MessageEmbed.Color and role colors.
+func ColorRGB(r, g, b uint8) int {
+	return int(r)<<16 | int(g)<<8 | int(b)
+}
+
+// ParseColor parses a hex color in the #rrggbb or #rgb format, the # and
+// a 0x prefix are optional.
+func ParseColor(s string) (int, error) {
+	s = strings.TrimSpace(s)
+	s = strings.TrimPrefix(s, "#")
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		s = s[2:]
+	}
+
+	if len(s) == 3 {
+		s = string([]byte{s[0], s[0], s[1], s[1], s[2], s[2]})
+	}
+	if len(s) != 6 {
+		return 0, ErrInvalidColor
+	}
+
+	color, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return 0, ErrInvalidColor
+	}
+	return int(color), nil
+}
@@ -0,0 +1,28 @@
+package discordgo
+
+import "testing"
+
+func TestParseColor(t *testing.T) {
+	tests := []struct {
+		s    string
+		want int
+		err  error
+	}{
+		{"#5865f2", ColorBlurple, nil},
+		{"5865F2", ColorBlurple, nil},
+		{"0x5865f2", ColorBlurple, nil},
+		{"#fff", ColorWhite, nil},
+		{"#12345", 0, ErrInvalidColor},
+		{"#zzzzzz", 0, ErrInvalidColor},
+	}
+
+	for _, test := range tests {
+		if got, err := ParseColor(test.s); got != test.want || err != test.err {
+			t.Errorf("%s: expected %x %v, got %x %v", test.s, test.want, test.err, got, err)
+		}
+	}
+
+	if c := ColorRGB(0x58, 0x65, 0xf2); c != ColorBlurple {
+		t.Errorf("unexpected color %x", c)
+	}
+}
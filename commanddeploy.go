@@ -0,0 +1,87 @@
+package discordgo
+
+import (
+	"os"
+	"strings"
+	"time"
+)
+
+// CommandDevGuildsEnv is the environment variable read by
+// CommandDeploymentFromEnv, a comma separated list of guild IDs.
+const CommandDevGuildsEnv = "DISCORDGO_DEV_GUILDS"
+
+// A CommandDeployment registers the commands of an application, either
+// globally for production or to development guilds. Guild commands are
+// updated instantly, which makes them better suited for iterating on commands.
+// The commands of modules are returned by Session.ModuleCommands.
+type CommandDeployment struct {
+	ApplicationID string
+	Commands      []*ApplicationCommand
+
+	// The development guilds. In production the commands are removed from
+	// them, so stale development commands don't show up next to the global
+	// commands.
+	DevGuildIDs []string
+
+	// Whether the commands are registered to the development guilds
+	// instead of globally.
+	Dev bool
+}
+
+// CommandDeploymentFromEnv returns a CommandDeployment which is in
+// development mode if CommandDevGuildsEnv is set, with the guilds of the
+// variable as development guilds.
+func CommandDeploymentFromEnv(appID string, commands ...*ApplicationCommand) *CommandDeployment {
+	d := &CommandDeployment{ApplicationID: appID, Commands: commands}
+
+	for _, id := range strings.Split(os.Getenv(CommandDevGuildsEnv), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			d.DevGuildIDs = append(d.DevGuildIDs, id)
+		}
+	}
+	d.Dev = len(d.DevGuildIDs) > 0
+	return d
+}
+
+// Deploy validates the commands and replaces the registered commands with
+// them. In development mode they replace the commands of every development
+// guild and the global commands are left as they are. In production they
+// replace the global commands and the commands of the development guilds
+// are deleted. If any guild fails an IDErrors is returned.
+func (d *CommandDeployment) Deploy(s *Session) error {
+	for _, cmd := range d.Commands {
+		if err := cmd.Validate(); err != nil {
+			return err
+		}
+	}
+
+	// A nil slice would be sent as null instead of an empty list.
+	commands := d.Commands
+	if commands == nil {
+		commands = []*ApplicationCommand{}
+	}
+
+	if !d.Dev {
+		if err := d.overwrite(s, "", commands); err != nil {
+			return err
+		}
+		commands = []*ApplicationCommand{}
+	}
+
+	errs := IDErrors{}
+	for _, guildID := range d.DevGuildIDs {
+		if err := d.overwrite(s, guildID, commands); err != nil {
+			errs[guildID] = err
+		}
+	}
+	return errs.errorOrNil()
+}
+
+// overwrite replaces the global commands, or the commands of a guild, and
+// passes the CommandEventDeploy event to the CommandHooks of the session.
+func (d *CommandDeployment) overwrite(s *Session, guildID string, commands []*ApplicationCommand) error {
+	start := time.Now()
+	_, err := s.ApplicationCommandBulkOverwrite(d.ApplicationID, guildID, commands)
+	s.emitCommandEvent(&CommandEvent{Type: CommandEventDeploy, GuildID: guildID, Latency: time.Since(start), Err: err})
+	return err
+}
@@ -0,0 +1,69 @@
+package discordgo
+
+import (
+	"net/http"
+	"os"
+	"testing"
+)
+
+func TestCommandDeploymentFromEnv(t *testing.T) {
+	defer os.Setenv(CommandDevGuildsEnv, os.Getenv(CommandDevGuildsEnv))
+
+	os.Setenv(CommandDevGuildsEnv, "")
+	if d := CommandDeploymentFromEnv("1"); d.Dev || len(d.DevGuildIDs) != 0 {
+		t.Errorf("expected a production deployment, got %+v", d)
+	}
+
+	os.Setenv(CommandDevGuildsEnv, " 2, ,3 ")
+	d := CommandDeploymentFromEnv("1", &ApplicationCommand{Name: "ping", Description: "Pong"})
+	if !d.Dev || len(d.DevGuildIDs) != 2 || d.DevGuildIDs[0] != "2" || d.DevGuildIDs[1] != "3" || len(d.Commands) != 1 {
+		t.Errorf("expected a development deployment to guilds 2 and 3, got %+v", d)
+	}
+}
+
+func TestCommandDeploymentDeploy(t *testing.T) {
+	global := "PUT " + EndpointApplicationGlobalCommands("1")
+	guild := func(id string) string { return "PUT " + EndpointApplicationGuildCommands("1", id) }
+	tr := &routeTransport{
+		routes: map[string]string{
+			global:     `[]`,
+			guild("2"): `[]`,
+			guild("3"): `{"code": 50001, "message": "Missing Access"}`,
+		},
+		statuses: map[string]int{guild("3"): http.StatusForbidden},
+	}
+	s := newTestSession(tr)
+
+	commands := []*ApplicationCommand{{Name: "ping", Description: "Pong"}}
+	d := &CommandDeployment{ApplicationID: "1", Commands: commands, DevGuildIDs: []string{"2"}}
+
+	// In production the commands are registered globally and removed from
+	// the development guilds.
+	if err := d.Deploy(s); err != nil {
+		t.Fatal(err)
+	}
+	if len(tr.requests) != 2 || tr.requests[0] != global || tr.requests[1] != guild("2") {
+		t.Fatalf("unexpected requests %v", tr.requests)
+	}
+	if tr.bodies[0] != `[{"name":"ping","description":"Pong"}]` || tr.bodies[1] != `[]` {
+		t.Errorf("unexpected commands %s and %s", tr.bodies[0], tr.bodies[1])
+	}
+
+	// In development they are registered to every development guild only.
+	tr.requests, tr.bodies = nil, nil
+	d.Dev, d.DevGuildIDs = true, []string{"2", "3"}
+	err := d.Deploy(s)
+	if errs, ok := err.(IDErrors); !ok || len(errs) != 1 || restErrorCode(errs["3"]) != ErrCodeMissingAccess {
+		t.Errorf("expected the error of guild 3, got %v", err)
+	}
+	if len(tr.requests) != 2 || tr.requests[0] != guild("2") || tr.bodies[0] != `[{"name":"ping","description":"Pong"}]` {
+		t.Errorf("unexpected requests %v %v", tr.requests, tr.bodies)
+	}
+
+	// Invalid commands aren't deployed.
+	tr.requests = nil
+	d.Commands = append(commands, &ApplicationCommand{Name: "Invalid"})
+	if _, ok := d.Deploy(s).(*ApplicationCommandError); !ok || len(tr.requests) != 0 {
+		t.Errorf("expected a validation error and no requests, got %v", tr.requests)
+	}
+}
@@ -0,0 +1,91 @@
+package discordgo
+
+import (
+	"sort"
+	"sync"
+)
+
+// A CommandDirectoryEntry is a command in a CommandDirectory.
+type CommandDirectoryEntry struct {
+	*ApplicationCommand
+
+	// Whether the command is a global command, else it is a command of
+	// the guild. The IntegrationTypes and Contexts of the command are only
+	// set for global commands.
+	Global bool
+
+	// The permissions of the command in the guild, nil if the command uses
+	// the Defaults of the directory.
+	Permissions []*ApplicationCommandPermission
+}
+
+// A CommandDirectory is the global commands of an application and its
+// commands in a guild, with their permissions in the guild, eg. for the
+// command list of a dashboard.
+type CommandDirectory struct {
+	ApplicationID string
+	GuildID       string
+
+	// The permissions of the commands without permissions of their own.
+	Defaults []*ApplicationCommandPermission
+
+	// The commands, sorted by name and with global commands first.
+	Commands []*CommandDirectoryEntry
+}
+
+// GuildCommandDirectory fetches the global and guild commands of an
+// application and their permissions in the guild. The three requests are
+// sent concurrently, the first error is returned.
+// appID   : The ID of an Application
+// guildID : The ID of a Guild
+func (s *Session) GuildCommandDirectory(appID, guildID string) (*CommandDirectory, error) {
+	var (
+		wg                           sync.WaitGroup
+		global, guild                []*ApplicationCommand
+		permissions                  []*GuildApplicationCommandPermissions
+		globalErr, guildErr, permErr error
+	)
+
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		global, globalErr = s.ApplicationCommands(appID, "")
+	}()
+	go func() {
+		defer wg.Done()
+		guild, guildErr = s.ApplicationCommands(appID, guildID)
+	}()
+	go func() {
+		defer wg.Done()
+		permissions, permErr = s.GuildApplicationCommandsPermissions(appID, guildID)
+	}()
+	wg.Wait()
+
+	for _, err := range []error{globalErr, guildErr, permErr} {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	byID := make(map[string][]*ApplicationCommandPermission, len(permissions))
+	for _, p := range permissions {
+		byID[p.ID] = p.Permissions
+	}
+
+	d := &CommandDirectory{ApplicationID: appID, GuildID: guildID, Defaults: byID[appID]}
+	for _, cmd := range global {
+		d.Commands = append(d.Commands, &CommandDirectoryEntry{ApplicationCommand: cmd, Global: true, Permissions: byID[cmd.ID]})
+	}
+	for _, cmd := range guild {
+		d.Commands = append(d.Commands, &CommandDirectoryEntry{ApplicationCommand: cmd, Permissions: byID[cmd.ID]})
+	}
+
+	sort.SliceStable(d.Commands, func(i, j int) bool {
+		a, b := d.Commands[i], d.Commands[j]
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Global && !b.Global
+	})
+	return d, nil
+}
@@ -0,0 +1,41 @@
+package discordgo
+
+import (
+	"testing"
+)
+
+func TestGuildCommandDirectory(t *testing.T) {
+	tr := &routeTransport{routes: map[string]string{
+		"GET " + EndpointApplicationGlobalCommands("1"):                `[{"id": "10", "name": "help", "contexts": [0, 1]}, {"id": "11", "name": "ban"}]`,
+		"GET " + EndpointApplicationGuildCommands("1", "2"):            `[{"id": "12", "name": "ban", "guild_id": "2"}]`,
+		"GET " + EndpointApplicationGuildCommandsPermissions("1", "2"): `[{"id": "1", "permissions": [{"id": "2", "type": 1, "permission": false}]}, {"id": "12", "permissions": [{"id": "5", "type": 1, "permission": true}]}]`,
+	}}
+	s := newTestSession(tr)
+
+	d, err := s.GuildCommandDirectory("1", "2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(d.Defaults) != 1 || d.Defaults[0].ID != "2" {
+		t.Errorf("unexpected default permissions %+v", d.Defaults)
+	}
+
+	expected := []struct {
+		id     string
+		global bool
+		perms  int
+	}{{"11", true, 0}, {"12", false, 1}, {"10", true, 0}}
+	if len(d.Commands) != len(expected) {
+		t.Fatalf("expected %d commands, got %d", len(expected), len(d.Commands))
+	}
+	for i, e := range expected {
+		c := d.Commands[i]
+		if c.ID != e.id || c.Global != e.global || len(c.Permissions) != e.perms {
+			t.Errorf("unexpected command %d: %s, global %v, %d permissions", i, c.ID, c.Global, len(c.Permissions))
+		}
+	}
+	if len(d.Commands[2].Contexts) != 2 {
+		t.Errorf("expected the contexts of the global command, got %v", d.Commands[2].Contexts)
+	}
+}
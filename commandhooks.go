@@ -0,0 +1,119 @@
+package discordgo
+
+import "time"
+
+// CommandEventType is the type of a CommandEvent.
+type CommandEventType int
+
+// Valid CommandEventType values
+const (
+	// A handler of a command added with AddModuleCommand returned. Latency
+	// is the time the handler ran and Err its error.
+	CommandEventRun CommandEventType = iota + 1
+
+	// An InteractionResponder responded to an interaction for the first
+	// time, or deferred it. Latency is the time since the interaction was
+	// created and Err the error of the response.
+	CommandEventResponse
+
+	// A CommandDeployment replaced the global commands of the application,
+	// or the commands of a guild if GuildID is set. Latency is the time
+	// of the request and Err its error.
+	CommandEventDeploy
+)
+
+// A CommandEvent is the use of a command, or the deployment of commands,
+// passed to the CommandHooks of a Session.
+type CommandEvent struct {
+	Type CommandEventType
+
+	// The name of the command, or the custom ID of the component for the
+	// responses to component interactions. Empty for deployments.
+	Name string
+
+	// The module of the command, run events only.
+	Module string
+
+	// The guild and the user of the interaction, the guild is empty in DMs.
+	GuildID string
+	UserID  string
+
+	Latency time.Duration
+	Err     error
+}
+
+// A CommandHook receives the command events of a Session, eg. to export
+// usage metrics and error rates without wrapping every handler. Hooks are
+// called synchronously and must not block.
+type CommandHook interface {
+	OnCommandEvent(s *Session, e *CommandEvent)
+}
+
+// CommandHookFunc is a function which implements CommandHook.
+type CommandHookFunc func(s *Session, e *CommandEvent)
+
+// OnCommandEvent calls f.
+func (f CommandHookFunc) OnCommandEvent(s *Session, e *CommandEvent) {
+	f(s, e)
+}
+
+// commandHookEntry holds a hook added to a Session, so it can be removed
+// by identity.
+type commandHookEntry struct {
+	hook CommandHook
+}
+
+// AddCommandHook adds a hook receiving the command events of the session.
+// The returned function removes the hook.
+// hook : The hook.
+func (s *Session) AddCommandHook(hook CommandHook) func() {
+	entry := &commandHookEntry{hook}
+
+	s.commandHooksMu.Lock()
+	s.commandHooks = append(s.commandHooks, entry)
+	s.commandHooksMu.Unlock()
+
+	return func() {
+		s.commandHooksMu.Lock()
+		defer s.commandHooksMu.Unlock()
+
+		for i, e := range s.commandHooks {
+			if e == entry {
+				s.commandHooks = append(s.commandHooks[:i:i], s.commandHooks[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// emitCommandEvent passes the event to the hooks of the session.
+func (s *Session) emitCommandEvent(e *CommandEvent) {
+	s.commandHooksMu.RLock()
+	hooks := s.commandHooks
+	s.commandHooksMu.RUnlock()
+
+	for _, entry := range hooks {
+		entry.hook.OnCommandEvent(s, e)
+	}
+}
+
+// interactionCommandEvent returns the event of an interaction, with the
+// name of its command or component.
+func interactionCommandEvent(typ CommandEventType, i *Interaction) *CommandEvent {
+	e := &CommandEvent{Type: typ, GuildID: i.GuildID}
+	if u := i.Author(); u != nil {
+		e.UserID = u.ID
+	}
+
+	switch i.Type {
+	case InteractionApplicationCommand:
+		if data, err := i.ApplicationCommandData(); err == nil {
+			e.Name = data.Name
+		}
+	case InteractionMessageComponent:
+		if data, err := i.MessageComponentData(); err == nil {
+			e.Name = data.CustomID
+		}
+	}
+	return e
+}
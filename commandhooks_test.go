@@ -0,0 +1,82 @@
+package discordgo
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestCommandHooks(t *testing.T) {
+	tr := &routeTransport{
+		routes: map[string]string{
+			"PUT " + EndpointApplicationGlobalCommands("1"):     `[]`,
+			"PUT " + EndpointApplicationGuildCommands("1", "2"): `[]`,
+			"PUT " + EndpointApplicationGuildCommands("1", "4"): `{"code": 50001, "message": "Missing Access"}`,
+		},
+		statuses: map[string]int{"PUT " + EndpointApplicationGuildCommands("1", "4"): http.StatusForbidden},
+		handle:   answer(``),
+	}
+	s := newTestSession(tr)
+	s.SyncEvents = true
+
+	var events []*CommandEvent
+	remove := s.AddCommandHook(CommandHookFunc(func(s *Session, e *CommandEvent) {
+		events = append(events, e)
+	}))
+
+	failed := errors.New("failed")
+	m := &commandsModule{name: "ping", commands: []*ApplicationCommand{{Name: "ping"}}}
+	s.ModuleAdd(m)
+	s.AddModuleCommand("ping", &ApplicationCommand{Name: "fail"}, func(*Session, *InteractionCreate) error {
+		return failed
+	})
+
+	use := func(name string) {
+		s.handleEvent(interactionCreateEventType, &InteractionCreate{&Interaction{
+			ID:      snowflakeAt(time.Now().Add(-time.Second)),
+			Type:    InteractionApplicationCommand,
+			GuildID: "2",
+			Member:  &Member{User: &User{ID: "3"}},
+			Data:    []byte(`{"name": "` + name + `"}`),
+		}})
+	}
+	use("ping")
+	use("fail")
+	if len(events) != 2 {
+		t.Fatalf("expected 2 run events, got %d", len(events))
+	}
+	if e := events[0]; e.Type != CommandEventRun || e.Name != "ping" || e.Module != "ping" || e.GuildID != "2" || e.UserID != "3" || e.Err != nil {
+		t.Errorf("unexpected event %+v", e)
+	}
+	if events[1].Name != "fail" || events[1].Err != failed {
+		t.Errorf("expected the error of the handler, got %+v", events[1])
+	}
+
+	AutoDefer(time.Hour, false, func(s *Session, i *InteractionCreate, r *InteractionResponder) {
+		r.Respond(&InteractionResponse{Type: InteractionResponseChannelMessageWithSource, Data: &InteractionResponseData{Content: "pong"}})
+	})(s, &InteractionCreate{&Interaction{
+		ID:   snowflakeAt(time.Now().Add(-time.Second)),
+		Type: InteractionApplicationCommand,
+		User: &User{ID: "3"},
+		Data: []byte(`{"name": "ping"}`),
+	}})
+	if e := events[2]; e.Type != CommandEventResponse || e.Name != "ping" || e.UserID != "3" || e.Latency < time.Second || e.Err != nil {
+		t.Errorf("unexpected response event %+v", e)
+	}
+
+	d := &CommandDeployment{ApplicationID: "1", DevGuildIDs: []string{"2", "4"}}
+	if err := d.Deploy(s); err == nil {
+		t.Error("expected the error of guild 4")
+	}
+	if len(events) != 6 || events[3].Type != CommandEventDeploy || events[3].GuildID != "" || events[4].GuildID != "2" ||
+		events[5].GuildID != "4" || events[5].Err == nil {
+		t.Errorf("unexpected deploy events %+v", events[3:])
+	}
+
+	remove()
+	use("ping")
+	if len(events) != 6 {
+		t.Errorf("expected no events after removing the hook, got %d", len(events))
+	}
+}
@@ -0,0 +1,171 @@
+package discordgo
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+)
+
+// ErrCommandNotFound is returned by a CommandPermissionSync for the
+// permissions of a command which isn't registered.
+var ErrCommandNotFound = errors.New("command not found")
+
+// CommandPermissionAllChannels returns the ID of the permission target of
+// all channels of a guild, the guild ID minus 1.
+func CommandPermissionAllChannels(guildID string) string {
+	id, err := strconv.ParseUint(guildID, 10, 64)
+	if err != nil || id == 0 {
+		return ""
+	}
+	return strconv.FormatUint(id-1, 10)
+}
+
+// A CommandPermissionChange is a change of the permissions of a command in a guild.
+type CommandPermissionChange struct {
+	GuildID     string
+	CommandID   string
+	CommandName string
+
+	Added   []*ApplicationCommandPermission
+	Removed []*ApplicationCommandPermission
+}
+
+// A CommandPermissionSync declares the permissions of the commands of an
+// application in guilds, and reconciles the permissions in the guilds
+// with them.
+//
+// Reading the permissions works with a bot token, but Discord only allows
+// editing them with the Bearer token of a user who can manage the guild,
+// so Diff can be called with the session of the bot and Sync needs an
+// OAuth2 session.
+type CommandPermissionSync struct {
+	ApplicationID string
+
+	// The permissions by guild ID and command name, global commands and
+	// the commands of the guild. The permissions of a command are replaced
+	// with them, an empty list removes them. The commands left out keep
+	// their permissions.
+	Permissions map[string]map[string][]*ApplicationCommandPermission
+}
+
+// Diff returns the changes Sync would make, without making them. If any
+// guild fails an IDErrors is returned with the changes of the others.
+func (c *CommandPermissionSync) Diff(s *Session) (changes []*CommandPermissionChange, err error) {
+	return c.reconcile(s, false)
+}
+
+// Sync replaces the permissions of the commands which differ from the
+// declared permissions, and returns the changes made. If any guild fails an
+// IDErrors is returned with the changes of the others.
+func (c *CommandPermissionSync) Sync(s *Session) (changes []*CommandPermissionChange, err error) {
+	return c.reconcile(s, true)
+}
+
+func (c *CommandPermissionSync) reconcile(s *Session, apply bool) (changes []*CommandPermissionChange, err error) {
+	global, err := s.ApplicationCommands(c.ApplicationID, "")
+	if err != nil {
+		return
+	}
+
+	errs := IDErrors{}
+	for _, guildID := range sortedGuildIDs(c.Permissions) {
+		guildChanges, err := c.reconcileGuild(s, guildID, global, apply)
+		changes = append(changes, guildChanges...)
+		if err != nil {
+			errs[guildID] = err
+		}
+	}
+	return changes, errs.errorOrNil()
+}
+
+func (c *CommandPermissionSync) reconcileGuild(s *Session, guildID string, global []*ApplicationCommand, apply bool) (changes []*CommandPermissionChange, err error) {
+	commands, err := s.ApplicationCommands(c.ApplicationID, guildID)
+	if err != nil {
+		return
+	}
+
+	// The guild commands take precedence over global commands of the same name.
+	ids := map[string]string{}
+	for _, cmd := range append(global, commands...) {
+		ids[cmd.Name] = cmd.ID
+	}
+
+	current, err := s.GuildApplicationCommandsPermissions(c.ApplicationID, guildID)
+	if err != nil {
+		return
+	}
+	currentByID := map[string][]*ApplicationCommandPermission{}
+	for _, p := range current {
+		currentByID[p.ID] = p.Permissions
+	}
+
+	desired := c.Permissions[guildID]
+	names := make([]string, 0, len(desired))
+	for name := range desired {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		id, ok := ids[name]
+		if !ok {
+			return changes, ErrCommandNotFound
+		}
+
+		change := diffCommandPermissions(currentByID[id], desired[name])
+		if len(change.Added) == 0 && len(change.Removed) == 0 {
+			continue
+		}
+		change.GuildID, change.CommandID, change.CommandName = guildID, id, name
+
+		if apply {
+			permissions := desired[name]
+			if permissions == nil {
+				permissions = []*ApplicationCommandPermission{}
+			}
+			_, err = s.ApplicationCommandPermissionsEdit(c.ApplicationID, guildID, id, &ApplicationCommandPermissionsList{permissions})
+			if err != nil {
+				return
+			}
+		}
+		changes = append(changes, change)
+	}
+	return
+}
+
+// diffCommandPermissions returns the permissions to add and remove to go
+// from the current to the desired permissions. A changed permission of a
+// target is both removed and added.
+func diffCommandPermissions(current, desired []*ApplicationCommandPermission) *CommandPermissionChange {
+	key := func(p *ApplicationCommandPermission) string {
+		return strconv.Itoa(int(p.Type)) + ":" + p.ID
+	}
+
+	change := &CommandPermissionChange{}
+	have := map[string]*ApplicationCommandPermission{}
+	for _, p := range current {
+		have[key(p)] = p
+	}
+	want := map[string]*ApplicationCommandPermission{}
+	for _, p := range desired {
+		want[key(p)] = p
+		if h, ok := have[key(p)]; !ok || h.Permission != p.Permission {
+			change.Added = append(change.Added, p)
+		}
+	}
+	for _, p := range current {
+		if w, ok := want[key(p)]; !ok || w.Permission != p.Permission {
+			change.Removed = append(change.Removed, p)
+		}
+	}
+	return change
+}
+
+func sortedGuildIDs(m map[string]map[string][]*ApplicationCommandPermission) []string {
+	ids := make([]string, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
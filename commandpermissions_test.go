@@ -0,0 +1,71 @@
+package discordgo
+
+import (
+	"testing"
+)
+
+func TestCommandPermissionAllChannels(t *testing.T) {
+	if id := CommandPermissionAllChannels("1000"); id != "999" {
+		t.Errorf("expected 999, got %s", id)
+	}
+	if id := CommandPermissionAllChannels("invalid"); id != "" {
+		t.Errorf("expected no ID, got %s", id)
+	}
+}
+
+func TestCommandPermissionSync(t *testing.T) {
+	tr := &routeTransport{routes: map[string]string{
+		"GET " + EndpointApplicationGlobalCommands("1"):                `[{"id": "10", "name": "ban"}, {"id": "11", "name": "kick"}]`,
+		"GET " + EndpointApplicationGuildCommands("1", "2"):            `[{"id": "12", "name": "config"}]`,
+		"GET " + EndpointApplicationGuildCommandsPermissions("1", "2"): `[{"id": "10", "permissions": [{"id": "5", "type": 1, "permission": true}]}, {"id": "11", "permissions": [{"id": "6", "type": 1, "permission": true}]}]`,
+		"PUT " + EndpointApplicationCommandPermissions("1", "2", "10"): `{}`,
+		"PUT " + EndpointApplicationCommandPermissions("1", "2", "12"): `{}`,
+	}}
+	s := newTestSession(tr)
+
+	sync := &CommandPermissionSync{
+		ApplicationID: "1",
+		Permissions: map[string]map[string][]*ApplicationCommandPermission{
+			"2": {
+				"ban":    {{ID: "5", Type: ApplicationCommandPermissionRole, Permission: false}},
+				"kick":   {{ID: "6", Type: ApplicationCommandPermissionRole, Permission: true}},
+				"config": {{ID: "7", Type: ApplicationCommandPermissionUser, Permission: true}},
+			},
+		},
+	}
+
+	changes, err := sync.Diff(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 2 || changes[0].CommandName != "ban" || len(changes[0].Added) != 1 || len(changes[0].Removed) != 1 ||
+		changes[1].CommandName != "config" || len(changes[1].Added) != 1 || len(changes[1].Removed) != 0 {
+		t.Fatalf("unexpected changes %+v", changes)
+	}
+
+	if _, err = sync.Sync(s); err == nil {
+		t.Error("expected an error syncing with a bot token")
+	} else if errs, ok := err.(IDErrors); !ok || errs["2"] != ErrBearerTokenRequired {
+		t.Errorf("expected ErrBearerTokenRequired for the guild, got %v", err)
+	}
+
+	s.Token = "Bearer token"
+	tr.requests = nil
+	if changes, err = sync.Sync(s); err != nil || len(changes) != 2 {
+		t.Fatalf("unexpected sync result %v, %+v", err, changes)
+	}
+	puts := 0
+	for _, r := range tr.requests {
+		if r[:3] == "PUT" {
+			puts++
+		}
+	}
+	if puts != 2 {
+		t.Errorf("expected 2 edits, got requests %v", tr.requests)
+	}
+
+	sync.Permissions["2"]["unknown"] = nil
+	if _, err = sync.Diff(s); err == nil || err.(IDErrors)["2"] != ErrCommandNotFound {
+		t.Errorf("expected ErrCommandNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,88 @@
+package discordgo
+
+// ComponentType is the type of a MessageComponent.
+// https://discord.com/developers/docs/interactions/message-components#component-object-component-types
+type ComponentType int
+
+// Block contains the valid known ComponentType values
+const (
+	ComponentTypeActionsRow ComponentType = iota + 1
+	ComponentTypeButton
+	ComponentTypeSelectMenu
+)
+
+// ButtonStyle is the style of a button component.
+type ButtonStyle int
+
+// Block contains the valid known ButtonStyle values
+const (
+	ButtonStylePrimary ButtonStyle = iota + 1
+	ButtonStyleSecondary
+	ButtonStyleSuccess
+	ButtonStyleDanger
+
+	// Link buttons open their URL and don't send an interaction.
+	ButtonStyleLink
+)
+
+// ComponentEmoji is the emoji of a button or a select menu option, either
+// the ID of a custom emoji or the unicode character of a standard emoji.
+type ComponentEmoji struct {
+	ID       string `json:"id,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Animated bool   `json:"animated,omitempty"`
+}
+
+// A SelectMenuOption is an option of a select menu component.
+type SelectMenuOption struct {
+	Label       string          `json:"label"`
+	Value       string          `json:"value"`
+	Description string          `json:"description,omitempty"`
+	Emoji       *ComponentEmoji `json:"emoji,omitempty"`
+	Default     bool            `json:"default,omitempty"`
+}
+
+// A MessageComponent is an interactive component of a message. Messages
+// hold up to 5 action rows, which hold up to 5 buttons or a single select
+// menu. Which fields are used depends on the type of the component.
+type MessageComponent struct {
+	Type ComponentType `json:"type"`
+
+	// The ID sent with the interactions of the component, up to 100
+	// characters. Not used for action rows and link buttons.
+	CustomID string `json:"custom_id,omitempty"`
+
+	Disabled bool `json:"disabled,omitempty"`
+
+	// Buttons only.
+	Style ButtonStyle     `json:"style,omitempty"`
+	Label string          `json:"label,omitempty"`
+	Emoji *ComponentEmoji `json:"emoji,omitempty"`
+	URL   string          `json:"url,omitempty"`
+
+	// Select menus only.
+	Options     []*SelectMenuOption `json:"options,omitempty"`
+	Placeholder string              `json:"placeholder,omitempty"`
+	MinValues   *int                `json:"min_values,omitempty"`
+	MaxValues   int                 `json:"max_values,omitempty"`
+
+	// The components of an action row.
+	Components []*MessageComponent `json:"components,omitempty"`
+}
+
+// DisabledComponents returns a copy of the components, and the components of
+// action rows, with all buttons and select menus disabled. Link buttons
+// are left as they are, as they don't send interactions.
+func DisabledComponents(components []*MessageComponent) []*MessageComponent {
+	disabled := make([]*MessageComponent, len(components))
+	for i, c := range components {
+		d := *c
+		if d.Type == ComponentTypeActionsRow {
+			d.Components = DisabledComponents(c.Components)
+		} else if d.Style != ButtonStyleLink {
+			d.Disabled = true
+		}
+		disabled[i] = &d
+	}
+	return disabled
+}
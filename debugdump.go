@@ -0,0 +1,194 @@
+package discordgo
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// redacted replaces the secrets and personal data in a DebugDump.
+const redacted = "[REDACTED]"
+
+// debugDump is the data written by DebugDump.
+type debugDump struct {
+	Time    time.Time `json:"time"`
+	Version string    `json:"version"`
+
+	Config      debugDumpConfig      `json:"config"`
+	Gateway     debugDumpGateway     `json:"gateway"`
+	RateLimiter debugDumpRateLimiter `json:"rate_limiter"`
+	State       *debugDumpState      `json:"state"`
+}
+
+type debugDumpConfig struct {
+	Token                   string           `json:"token"`
+	TokenProvider           bool             `json:"token_provider"`
+	LogLevel                int              `json:"log_level"`
+	StrictDecode            StrictDecodeMode `json:"strict_decode"`
+	ShouldReconnectOnError  bool             `json:"should_reconnect_on_error"`
+	Compress                bool             `json:"compress"`
+	ShardID                 int              `json:"shard_id"`
+	ShardCount              int              `json:"shard_count"`
+	StateEnabled            bool             `json:"state_enabled"`
+	SyncEvents              bool             `json:"sync_events"`
+	MaxRestRetries          int              `json:"max_rest_retries"`
+	MaxResponseSize         int64            `json:"max_response_size"`
+	InvalidRequestThreshold int              `json:"invalid_request_threshold"`
+	UserAgent               string           `json:"user_agent"`
+	Intents                 *Intent          `json:"intents"`
+	LargeThreshold          int              `json:"large_threshold"`
+}
+
+type debugDumpGateway struct {
+	Connected         bool          `json:"connected"`
+	DataReady         bool          `json:"data_ready"`
+	Gateway           string        `json:"gateway"`
+	SessionID         string        `json:"session_id"`
+	Sequence          int64         `json:"sequence"`
+	LastHeartbeatSent time.Time     `json:"last_heartbeat_sent"`
+	LastHeartbeatAck  time.Time     `json:"last_heartbeat_ack"`
+	HeartbeatLatency  time.Duration `json:"heartbeat_latency"`
+	VoiceConnections  int           `json:"voice_connections"`
+}
+
+type debugDumpRateLimiter struct {
+	Buckets         int       `json:"buckets"`
+	BusyBuckets     int       `json:"busy_buckets"`
+	LimitedBuckets  int       `json:"limited_buckets"`
+	GlobalReset     time.Time `json:"global_reset,omitempty"`
+	InvalidRequests int       `json:"invalid_requests"`
+}
+
+type debugDumpState struct {
+	UserID          string `json:"user_id"`
+	Guilds          int    `json:"guilds"`
+	Members         int    `json:"members"`
+	Presences       int    `json:"presences"`
+	Channels        int    `json:"channels"`
+	PrivateChannels int    `json:"private_channels"`
+	Outage          bool   `json:"outage"`
+}
+
+// DebugDump writes the configuration of the session, the status of its
+// gateway connection, a snapshot of its rate limiter and the size of its
+// state as JSON, to attach to bug reports. The token and session ID are
+// redacted, and no data of users is written besides the ID of the bot.
+func (s *Session) DebugDump(w io.Writer) error {
+	s.RLock()
+	d := &debugDump{
+		Time:    time.Now().UTC(),
+		Version: VERSION,
+		Config: debugDumpConfig{
+			Token:                   redactToken(s.token()),
+			TokenProvider:           s.TokenProvider != nil,
+			LogLevel:                s.LogLevel,
+			StrictDecode:            s.StrictDecode,
+			ShouldReconnectOnError:  s.ShouldReconnectOnError,
+			Compress:                s.Compress,
+			ShardID:                 s.ShardID,
+			ShardCount:              s.ShardCount,
+			StateEnabled:            s.StateEnabled,
+			SyncEvents:              s.SyncEvents,
+			MaxRestRetries:          s.MaxRestRetries,
+			MaxResponseSize:         s.MaxResponseSize,
+			InvalidRequestThreshold: s.invalidRequestThreshold(),
+			UserAgent:               s.UserAgent,
+			Intents:                 s.Identify.Intents,
+			LargeThreshold:          s.Identify.LargeThreshold,
+		},
+		Gateway: debugDumpGateway{
+			Connected:         s.wsConn != nil,
+			DataReady:         s.DataReady,
+			Gateway:           s.gateway,
+			LastHeartbeatSent: s.LastHeartbeatSent,
+			LastHeartbeatAck:  s.LastHeartbeatAck,
+			HeartbeatLatency:  s.HeartbeatLatency(),
+			VoiceConnections:  len(s.VoiceConnections),
+		},
+	}
+	s.readyMu.RLock()
+	if s.sessionID != "" {
+		d.Gateway.SessionID = redacted
+	}
+	s.readyMu.RUnlock()
+	if s.sequence != nil {
+		d.Gateway.Sequence = atomic.LoadInt64(s.sequence)
+	}
+	s.RUnlock()
+
+	if s.Ratelimiter != nil {
+		d.RateLimiter = s.Ratelimiter.debugDump()
+	}
+	d.RateLimiter.InvalidRequests = s.InvalidRequests()
+
+	if s.State != nil {
+		d.State = s.State.debugDump()
+	}
+
+	e := json.NewEncoder(w)
+	e.SetIndent("", "  ")
+	return e.Encode(d)
+}
+
+// redactToken returns the type of a token, with the token redacted.
+func redactToken(token string) string {
+	if token == "" {
+		return ""
+	}
+	for _, prefix := range []string{"Bot ", "Bearer "} {
+		if strings.HasPrefix(token, prefix) {
+			return prefix + redacted
+		}
+	}
+	return redacted
+}
+
+// debugDump returns the snapshot of the rate limiter for a DebugDump. The
+// limits of buckets which are held by requests aren't read, so the
+// snapshot doesn't wait for them.
+func (r *RateLimiter) debugDump() (d debugDumpRateLimiter) {
+	r.Lock()
+	defer r.Unlock()
+
+	now := time.Now()
+	d.Buckets = len(r.buckets)
+	for _, b := range r.buckets {
+		if atomic.LoadInt32(&b.users) > 0 {
+			d.BusyBuckets++
+			continue
+		}
+		b.Lock()
+		if b.Remaining < 1 && b.reset.After(now) {
+			d.LimitedBuckets++
+		}
+		b.Unlock()
+	}
+
+	if global := time.Unix(0, atomic.LoadInt64(r.global)); global.After(now) {
+		d.GlobalReset = global
+	}
+	return
+}
+
+// debugDump returns the size of the state for a DebugDump.
+func (s *State) debugDump() *debugDumpState {
+	s.RLock()
+	defer s.RUnlock()
+
+	d := &debugDumpState{
+		Guilds:          len(s.Guilds),
+		PrivateChannels: len(s.PrivateChannels),
+		Outage:          s.outage,
+	}
+	if s.User != nil {
+		d.UserID = s.User.ID
+	}
+	for _, g := range s.Guilds {
+		d.Members += len(g.Members)
+		d.Presences += len(g.Presences)
+		d.Channels += len(g.Channels)
+	}
+	return d
+}
@@ -0,0 +1,30 @@
+package discordgo
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestDebugDump(t *testing.T) {
+	s, _ := New("Bot secret")
+	s.State.User = &User{ID: "1", Email: "bot@example.com"}
+	s.State.GuildAdd(&Guild{ID: "2", Members: []*Member{{User: &User{ID: "3", Username: "user"}}}})
+
+	var b bytes.Buffer
+	if err := s.DebugDump(&b); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(b.String(), "secret") || strings.Contains(b.String(), "bot@example.com") || strings.Contains(b.String(), `"user"`) {
+		t.Errorf("dump contains secrets or personal data\n%s", b.String())
+	}
+
+	var d debugDump
+	if err := json.Unmarshal(b.Bytes(), &d); err != nil {
+		t.Fatal(err)
+	}
+	if d.Config.Token != "Bot "+redacted || d.State == nil || d.State.UserID != "1" || d.State.Guilds != 1 || d.State.Members != 1 {
+		t.Errorf("unexpected dump %+v", d)
+	}
+}
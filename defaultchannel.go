@@ -0,0 +1,100 @@
+package discordgo
+
+import (
+	"errors"
+	"sort"
+)
+
+// ErrNoDefaultChannel is returned when the bot can't send messages to any
+// text channel of a guild.
+var ErrNoDefaultChannel = errors.New("no channel the bot can send messages to")
+
+// DefaultChannelKind is the kind of content a default channel of a guild
+// is resolved for.
+type DefaultChannelKind int
+
+// Block contains the valid DefaultChannelKind values, with the channels of
+// the guild they use in order of preference.
+const (
+	// The system channel.
+	DefaultChannelWelcome DefaultChannelKind = iota
+
+	// The rules channel, then the system channel.
+	DefaultChannelRules
+
+	// The public updates channel, then the safety alerts and system channels.
+	DefaultChannelUpdates
+
+	// The safety alerts channel, then the public updates and system channels.
+	DefaultChannelSafetyAlerts
+)
+
+// defaultChannelIDs returns the IDs of the configured channels of the guild
+// for the kind, in order of preference.
+func defaultChannelIDs(g *Guild, kind DefaultChannelKind) []string {
+	switch kind {
+	case DefaultChannelRules:
+		return []string{g.RulesChannelID, g.SystemChannelID}
+	case DefaultChannelUpdates:
+		return []string{g.PublicUpdatesChannelID, g.SafetyAlertsChannelID, g.SystemChannelID}
+	case DefaultChannelSafetyAlerts:
+		return []string{g.SafetyAlertsChannelID, g.PublicUpdatesChannelID, g.SystemChannelID}
+	}
+	return []string{g.SystemChannelID}
+}
+
+// GuildDefaultChannel returns the channel of a guild the bot should post the
+// given kind of content to, eg. welcome messages. The channels configured in
+// the guild settings for the kind are tried first, and then the text
+// channels of the guild by position. Only channels the bot can view and
+// send messages to are returned, according to the state.
+// guildID : The ID of a Guild.
+// kind    : The kind of content.
+func (s *State) GuildDefaultChannel(guildID string, kind DefaultChannelKind) (*Channel, error) {
+	if s == nil {
+		return nil, ErrNilState
+	}
+	if s.User == nil {
+		return nil, ErrStateNotFound
+	}
+
+	g, err := s.Guild(guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	canSend := func(c *Channel) bool {
+		if c.Type != ChannelTypeGuildText && c.Type != ChannelTypeGuildNews {
+			return false
+		}
+		perms, err := s.UserChannelPermissions(s.User.ID, c.ID)
+		return err == nil && perms&(PermissionViewChannel|PermissionSendMessages) == PermissionViewChannel|PermissionSendMessages
+	}
+
+	for _, id := range defaultChannelIDs(g, kind) {
+		if id == "" {
+			continue
+		}
+		if c, err := s.Channel(id); err == nil && canSend(c) {
+			return c, nil
+		}
+	}
+
+	s.RLock()
+	channels := append([]*Channel(nil), g.Channels...)
+	s.RUnlock()
+
+	sort.Slice(channels, func(i, j int) bool {
+		if channels[i].Position != channels[j].Position {
+			return channels[i].Position < channels[j].Position
+		}
+		return channels[i].ID < channels[j].ID
+	})
+
+	for _, c := range channels {
+		if canSend(c) {
+			return c, nil
+		}
+	}
+	return nil, ErrNoDefaultChannel
+}
@@ -0,0 +1,53 @@
+package discordgo
+
+import "testing"
+
+func TestGuildDefaultChannel(t *testing.T) {
+	s := NewState()
+	if _, err := s.GuildDefaultChannel("1", DefaultChannelWelcome); err != ErrStateNotFound {
+		t.Errorf("expected ErrStateNotFound without a user, got %v", err)
+	}
+	s.User = &User{ID: "10"}
+
+	send := PermissionViewChannel | PermissionSendMessages
+	err := s.GuildAdd(&Guild{
+		ID:              "1",
+		SystemChannelID: "2",
+		RulesChannelID:  "3",
+		Roles:           []*Role{{ID: "1", Permissions: send}},
+		Channels: []*Channel{
+			{ID: "2", GuildID: "1", Type: ChannelTypeGuildText, Position: 3},
+			{ID: "3", GuildID: "1", Type: ChannelTypeGuildText, Position: 2, PermissionOverwrites: []*PermissionOverwrite{
+				{ID: "1", Type: "role", Deny: PermissionSendMessages},
+			}},
+			{ID: "4", GuildID: "1", Type: ChannelTypeGuildVoice, Position: 0},
+			{ID: "5", GuildID: "1", Type: ChannelTypeGuildText, Position: 1},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = s.MemberAdd(&Member{GuildID: "1", User: &User{ID: "10"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The bot can't send messages to the rules channel, so the system
+	// channel is used instead.
+	for kind, want := range map[DefaultChannelKind]string{DefaultChannelWelcome: "2", DefaultChannelRules: "2"} {
+		if c, err := s.GuildDefaultChannel("1", kind); err != nil || c.ID != want {
+			t.Errorf("expected channel %s for kind %d, got %+v, %v", want, kind, c, err)
+		}
+	}
+
+	// Without configured channels, the first text channel by position is used.
+	g, _ := s.Guild("1")
+	g.SystemChannelID = ""
+	if c, err := s.GuildDefaultChannel("1", DefaultChannelUpdates); err != nil || c.ID != "5" {
+		t.Errorf("expected channel 5, got %+v, %v", c, err)
+	}
+
+	g.Roles[0].Permissions = PermissionViewChannel
+	if _, err := s.GuildDefaultChannel("1", DefaultChannelWelcome); err != ErrNoDefaultChannel {
+		t.Errorf("expected ErrNoDefaultChannel, got %v", err)
+	}
+}
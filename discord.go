@@ -76,6 +76,8 @@ func New(args ...interface{}) (s *Session, err error) {
 	s.Identify.Properties.Browser = "DiscordGo v" + VERSION
 	s.Identify.Intents = MakeIntent(IntentsAllWithoutPrivileged)
 
+	s.Scheduler = NewScheduler(s)
+
 	// If no arguments are passed return the empty Session interface.
 	if args == nil {
 		return
@@ -1,6 +1,7 @@
 package discordgo
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"runtime"
@@ -201,3 +202,69 @@ func TestRemoveHandler(t *testing.T) {
 		t.Fatalf("testHandler was not called once.")
 	}
 }
+
+func TestAddHandlerContext(t *testing.T) {
+
+	shardID := make(chan int, 1)
+	testHandler := func(ctx context.Context, s *Session, m *MessageCreate) {
+		id, ok := ShardIDFromContext(ctx)
+		if !ok {
+			id = -1
+		}
+		shardID <- id
+	}
+
+	d := Session{ShardID: 3, ShardCount: 4}
+	d.AddHandler(testHandler)
+
+	d.handleEvent(messageCreateEventType, &MessageCreate{})
+
+	select {
+	case id := <-shardID:
+		if id != 3 {
+			t.Fatalf("context carried shard ID %d, expected 3", id)
+		}
+	case <-time.After(500 * time.Millisecond):
+		t.Fatalf("testHandler was not called.")
+	}
+}
+
+func TestHandlerGroups(t *testing.T) {
+
+	var order []string
+	handler := func(name string) func(*Session, *MessageCreate) {
+		return func(s *Session, m *MessageCreate) {
+			order = append(order, name)
+		}
+	}
+
+	d := Session{SyncEvents: true}
+	d.AddHandlerComplex(handler("low"), HandlerOptions{Priority: -1})
+	d.AddHandlerComplex(handler("default"), HandlerOptions{})
+	d.AddHandlerComplex(handler("high"), HandlerOptions{Priority: 10, Group: "plugin"})
+	id := d.AddHandlerComplex(handler("removed"), HandlerOptions{})
+
+	d.RemoveHandler(id)
+	d.handleEvent(messageCreateEventType, &MessageCreate{})
+
+	if fmt.Sprint(order) != "[high default low]" {
+		t.Fatalf("handlers were called in the wrong order: %v", order)
+	}
+
+	order = nil
+	d.DisableHandlerGroup("plugin")
+	d.handleEvent(messageCreateEventType, &MessageCreate{})
+
+	if fmt.Sprint(order) != "[default low]" {
+		t.Fatalf("disabled handler group was called: %v", order)
+	}
+
+	order = nil
+	d.EnableHandlerGroup("plugin")
+	d.RemoveHandlerGroup("plugin")
+	d.handleEvent(messageCreateEventType, &MessageCreate{})
+
+	if fmt.Sprint(order) != "[default low]" {
+		t.Fatalf("removed handler group was called: %v", order)
+	}
+}
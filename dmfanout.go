@@ -0,0 +1,155 @@
+package discordgo
+
+import "time"
+
+// DefaultDMFanoutInterval is the default Interval of a DMFanout.
+const DefaultDMFanoutInterval = time.Second
+
+// DMFanoutStatus is the outcome of a DM of a DMFanout.
+type DMFanoutStatus int
+
+// Valid DMFanoutStatus values
+const (
+	DMFanoutSent DMFanoutStatus = iota
+	// The user closed their DMs or blocked the bot.
+	DMFanoutClosed
+	DMFanoutFailed
+)
+
+// A DMFanoutResult is the outcome of the DM of a user.
+type DMFanoutResult struct {
+	UserID string
+	Status DMFanoutStatus
+
+	// The error of a failed DM.
+	Err error
+}
+
+// A DMFanout DMs the same message to many users, eg. an announcement or a
+// vote reminder. Opening many DMs quickly is a common reason for bots to
+// be flagged by Discord, so the DMs are sent one at a time, Interval apart,
+// and the fan-out backs off exponentially when Discord rejects a DM with
+// ErrCodeOpeningDMsTooFast.
+//
+// Done is the checkpoint of the fan-out: save the DMFanout, eg. as JSON in
+// OnResult, and call Run again after a restart to continue at the first
+// user who wasn't DMed.
+type DMFanout struct {
+	UserIDs []string     `json:"user_ids"`
+	Message *MessageSend `json:"message"`
+
+	// The number of users who were DMed, or failed to be.
+	Done int `json:"done"`
+
+	// The time between two DMs, 0 for DefaultDMFanoutInterval.
+	Interval time.Duration `json:"-"`
+
+	// The delay after the first ErrCodeOpeningDMsTooFast, doubled after
+	// every further one in a row up to MaxBackoff. Zero for 1 minute and
+	// 30 minutes.
+	MinBackoff time.Duration `json:"-"`
+	MaxBackoff time.Duration `json:"-"`
+
+	// OnResult is called after every user, with Done already advanced past
+	// them, optional.
+	OnResult func(r *DMFanoutResult) `json:"-"`
+}
+
+// Run sends the DMs from Done on, and returns the results of the users it
+// DMed. It returns early when stop is closed, Done is the user to continue
+// at. The message must not contain files.
+// s    : The session to send the DMs with.
+// stop : Closed to stop the fan-out, optional.
+func (f *DMFanout) Run(s *Session, stop <-chan struct{}) (results []*DMFanoutResult) {
+	interval := f.Interval
+	if interval == 0 {
+		interval = DefaultDMFanoutInterval
+	}
+
+	backoffs := 0
+	for f.Done < len(f.UserIDs) {
+		if len(results) > 0 && !fanoutSleep(interval, stop) {
+			return
+		}
+
+		userID := f.UserIDs[f.Done]
+		err := f.send(s, userID)
+		if restErrorCode(err) == ErrCodeOpeningDMsTooFast {
+			backoffs++
+			d := f.backoff(backoffs)
+			s.log(LogWarning, "opening DMs too fast, retrying %s in %s", userID, d)
+			if !fanoutSleep(d, stop) {
+				return
+			}
+			continue
+		}
+		backoffs = 0
+
+		r := &DMFanoutResult{UserID: userID}
+		switch {
+		case err == nil:
+			r.Status = DMFanoutSent
+		case restErrorCode(err) == ErrCodeCannotSendMessagesToThisUser:
+			r.Status = DMFanoutClosed
+		default:
+			r.Status, r.Err = DMFanoutFailed, err
+		}
+		results = append(results, r)
+
+		f.Done++
+		if f.OnResult != nil {
+			f.OnResult(r)
+		}
+	}
+	return
+}
+
+func (f *DMFanout) send(s *Session, userID string) error {
+	c, err := s.UserChannelCreate(userID)
+	if err != nil {
+		return err
+	}
+	_, err = s.ChannelMessageSendComplex(c.ID, f.Message)
+	return err
+}
+
+// backoff returns the delay after the given number of
+// ErrCodeOpeningDMsTooFast errors in a row.
+func (f *DMFanout) backoff(n int) time.Duration {
+	d, max := f.MinBackoff, f.MaxBackoff
+	if d == 0 {
+		d = time.Minute
+	}
+	if max == 0 {
+		max = 30 * time.Minute
+	}
+	for i := 1; i < n && d < max; i++ {
+		d *= 2
+	}
+	if d > max {
+		d = max
+	}
+	return d
+}
+
+// restErrorCode returns the Discord error code of a REST error, 0 for
+// other errors.
+func restErrorCode(err error) int {
+	if restErr, ok := err.(*RESTError); ok && restErr.Message != nil {
+		return restErr.Message.Code
+	}
+	return 0
+}
+
+// fanoutSleep waits for d, and returns false if stop was closed first.
+func fanoutSleep(d time.Duration, stop <-chan struct{}) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return true
+	case <-stop:
+		return false
+	}
+}
@@ -0,0 +1,70 @@
+package discordgo
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// dmTransport returns a transport which opens a DM channel with the ID of
+// the recipient, and answers the messages to it with the next error of the
+// user if they have one. The URLs of the messages are added to sent.
+func dmTransport(errors map[string][]string, sent *[]string) *routeTransport {
+	return &routeTransport{handle: func(req *http.Request, body []byte) (int, string) {
+		if req.URL.String() != EndpointUserChannels("@me") {
+			*sent = append(*sent, req.URL.String())
+			return http.StatusOK, `{"id": "1"}`
+		}
+
+		var data struct {
+			RecipientID string `json:"recipient_id"`
+		}
+		json.Unmarshal(body, &data)
+
+		if errs := errors[data.RecipientID]; len(errs) > 0 {
+			errors[data.RecipientID] = errs[1:]
+			return http.StatusBadRequest, errs[0]
+		}
+		return http.StatusOK, `{"id": "` + data.RecipientID + `"}`
+	}}
+}
+
+func TestDMFanout(t *testing.T) {
+	var sent []string
+	s := newTestSession(dmTransport(map[string][]string{
+		"2": {`{"code": 40003, "message": "You are opening direct messages too fast"}`},
+		"3": {`{"code": 50007, "message": "Cannot send messages to this user"}`},
+	}, &sent))
+
+	var checkpoints []int
+	f := &DMFanout{
+		UserIDs:    []string{"1", "2", "3", "4"},
+		Message:    &MessageSend{Content: "vote"},
+		Interval:   time.Millisecond,
+		MinBackoff: time.Millisecond,
+	}
+	f.OnResult = func(r *DMFanoutResult) {
+		checkpoints = append(checkpoints, f.Done)
+	}
+
+	results := f.Run(s, nil)
+	if len(results) != 4 || f.Done != 4 || len(checkpoints) != 4 || checkpoints[3] != 4 {
+		t.Fatalf("expected 4 results, got %d, done %d, checkpoints %v", len(results), f.Done, checkpoints)
+	}
+	for i, status := range []DMFanoutStatus{DMFanoutSent, DMFanoutSent, DMFanoutClosed, DMFanoutSent} {
+		if results[i].Status != status {
+			t.Errorf("expected status %d for %s, got %d (%v)", status, results[i].UserID, results[i].Status, results[i].Err)
+		}
+	}
+	if len(sent) != 3 {
+		t.Errorf("expected 3 messages, got %v", sent)
+	}
+
+	stop := make(chan struct{})
+	close(stop)
+	f = &DMFanout{UserIDs: []string{"5", "6", "7"}, Message: &MessageSend{Content: "vote"}, Done: 1}
+	if results := f.Run(s, stop); len(results) != 1 || results[0].UserID != "6" || f.Done != 2 {
+		t.Errorf("expected to resume at user 6 and stop after it, got %+v, done %d", results, f.Done)
+	}
+}
@@ -0,0 +1,262 @@
+package discordgo
+
+import (
+	"context"
+	"math"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultEmojiUsageHalfLife is the half-life of the usage counts of an
+// EmojiUsageCollector created without one.
+const DefaultEmojiUsageHalfLife = 30 * 24 * time.Hour
+
+// minEmojiUsage is the count below which the usage of an emoji is
+// forgotten, after about 7 half-lives for an emoji used once.
+const minEmojiUsage = 0.01
+
+// emojiPattern matches the custom emojis in message content.
+var emojiPattern = regexp.MustCompile(`<(a?):(\w+):(\d+)>`)
+
+// EmojiUsage is the usage of a custom emoji in a guild. The counts decay
+// by the half-life of the collector, so recent uses weigh more.
+type EmojiUsage struct {
+	EmojiID  string
+	Name     string
+	Animated bool
+
+	// The number of messages the emoji was used in, and the number of
+	// reactions with it.
+	Messages  float64
+	Reactions float64
+
+	// The last time the emoji was used, zero if it wasn't.
+	LastUsed time.Time
+}
+
+// Count returns the number of uses of the emoji in messages and reactions.
+func (u *EmojiUsage) Count() float64 {
+	return u.Messages + u.Reactions
+}
+
+// An EmojiUsageCollector tallies the uses of custom emojis in the messages
+// and reactions of guilds, eg. to find the emojis to remove when a guild
+// runs out of slots. Messages of bots and reactions of the session's user
+// are ignored, an emoji counts once per message.
+type EmojiUsageCollector struct {
+	sync.Mutex
+
+	// The time after which the counts are halved, it can be changed at
+	// any time while holding the lock.
+	HalfLife time.Duration
+
+	session  *Session
+	handlers []HandlerID
+	guilds   map[string]map[string]*EmojiUsage
+	decayed  map[string]time.Time
+}
+
+// NewEmojiUsageCollector returns a new EmojiUsageCollector which tallies
+// the emojis used in the events of s.
+// s        : The session.
+// halfLife : The half-life of the counts, DefaultEmojiUsageHalfLife if zero.
+func NewEmojiUsageCollector(s *Session, halfLife time.Duration) *EmojiUsageCollector {
+	if halfLife == 0 {
+		halfLife = DefaultEmojiUsageHalfLife
+	}
+
+	c := &EmojiUsageCollector{
+		HalfLife: halfLife,
+		session:  s,
+		guilds:   make(map[string]map[string]*EmojiUsage),
+		decayed:  make(map[string]time.Time),
+	}
+
+	c.handlers = []HandlerID{
+		s.AddHandlerComplex(func(s *Session, m *MessageCreate) {
+			c.message(m.Message, time.Now())
+		}, HandlerOptions{}),
+		s.AddHandlerComplex(func(s *Session, r *MessageReactionAdd) {
+			c.reaction(s, r.MessageReaction, time.Now())
+		}, HandlerOptions{}),
+	}
+	return c
+}
+
+// Close removes the event handlers of the collector.
+func (c *EmojiUsageCollector) Close() {
+	for _, id := range c.handlers {
+		c.session.RemoveHandler(id)
+	}
+}
+
+func (c *EmojiUsageCollector) message(m *Message, now time.Time) {
+	if m.GuildID == "" || m.Author == nil || m.Author.Bot {
+		return
+	}
+
+	seen := make(map[string]bool)
+	for _, match := range emojiPattern.FindAllStringSubmatch(m.Content, -1) {
+		id := match[3]
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		c.Lock()
+		u := c.usage(m.GuildID, id, match[2], match[1] == "a", now)
+		u.Messages++
+		c.Unlock()
+	}
+}
+
+func (c *EmojiUsageCollector) reaction(s *Session, r *MessageReaction, now time.Time) {
+	if r.GuildID == "" || r.Emoji.ID == "" {
+		return
+	}
+	if s.State != nil && s.State.User != nil && r.UserID == s.State.User.ID {
+		return
+	}
+
+	c.Lock()
+	u := c.usage(r.GuildID, r.Emoji.ID, r.Emoji.Name, r.Emoji.Animated, now)
+	u.Reactions++
+	c.Unlock()
+}
+
+// usage returns the decayed usage of an emoji, marked as used now.
+// The lock must be held.
+func (c *EmojiUsageCollector) usage(guildID, emojiID, name string, animated bool, now time.Time) *EmojiUsage {
+	c.decay(guildID, now)
+
+	emojis := c.guilds[guildID]
+	if emojis == nil {
+		emojis = make(map[string]*EmojiUsage)
+		c.guilds[guildID] = emojis
+	}
+
+	u := emojis[emojiID]
+	if u == nil {
+		u = &EmojiUsage{EmojiID: emojiID}
+		emojis[emojiID] = u
+	}
+	u.Name, u.Animated, u.LastUsed = name, animated, now
+	return u
+}
+
+// decay decays the counts of a guild up to now, forgetting the emojis
+// which were barely used. The lock must be held.
+func (c *EmojiUsageCollector) decay(guildID string, now time.Time) {
+	last, ok := c.decayed[guildID]
+	c.decayed[guildID] = now
+	if !ok || c.HalfLife <= 0 || !now.After(last) {
+		return
+	}
+
+	emojis := c.guilds[guildID]
+	factor := math.Exp2(-float64(now.Sub(last)) / float64(c.HalfLife))
+	for id, u := range emojis {
+		u.Messages *= factor
+		u.Reactions *= factor
+		if u.Count() < minEmojiUsage {
+			delete(emojis, id)
+		}
+	}
+	if len(emojis) == 0 {
+		delete(c.guilds, guildID)
+	}
+}
+
+// Usage returns the usage of the emojis used in a guild, including the
+// emojis of other guilds, sorted by their count from most to least used.
+// guildID : The ID of a Guild.
+func (c *EmojiUsageCollector) Usage(guildID string) []*EmojiUsage {
+	c.Lock()
+	defer c.Unlock()
+
+	c.decay(guildID, time.Now())
+
+	usage := make([]*EmojiUsage, 0, len(c.guilds[guildID]))
+	for _, u := range c.guilds[guildID] {
+		copied := *u
+		usage = append(usage, &copied)
+	}
+	sort.Slice(usage, func(i, j int) bool {
+		return emojiUsageLess(usage[j], usage[i])
+	})
+	return usage
+}
+
+// LeastUsed returns the usage of the emojis of a guild from least to most
+// used, including the unused ones. The emojis of integrations are left out
+// as they can't be deleted. The emojis are taken from the state if the
+// guild is cached.
+// guildID : The ID of a Guild.
+// limit   : The maximum number of emojis to return, 0 for all.
+func (c *EmojiUsageCollector) LeastUsed(guildID string, limit int) ([]*EmojiUsage, error) {
+	g, err := c.session.resolveGuild(context.Background(), guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The emojis of a cached guild are replaced by the state.
+	emojis := g.Emojis
+	if st := c.session.State; st != nil {
+		st.RLock()
+		emojis = make([]*Emoji, 0, len(g.Emojis))
+		for _, e := range g.Emojis {
+			copied := *e
+			emojis = append(emojis, &copied)
+		}
+		st.RUnlock()
+	}
+
+	c.Lock()
+	c.decay(guildID, time.Now())
+
+	usage := make([]*EmojiUsage, 0, len(emojis))
+	for _, e := range emojis {
+		if e.Managed {
+			continue
+		}
+
+		u := EmojiUsage{EmojiID: e.ID}
+		if used := c.guilds[guildID][e.ID]; used != nil {
+			u = *used
+		}
+		u.Name, u.Animated = e.Name, e.Animated
+		usage = append(usage, &u)
+	}
+	c.Unlock()
+
+	sort.Slice(usage, func(i, j int) bool {
+		return emojiUsageLess(usage[i], usage[j])
+	})
+	if limit > 0 && len(usage) > limit {
+		usage = usage[:limit]
+	}
+	return usage, nil
+}
+
+// Reset forgets the usage of the emojis in a guild.
+// guildID : The ID of a Guild.
+func (c *EmojiUsageCollector) Reset(guildID string) {
+	c.Lock()
+	delete(c.guilds, guildID)
+	delete(c.decayed, guildID)
+	c.Unlock()
+}
+
+// emojiUsageLess returns whether a was used less than b, or less recently
+// if they were used as often.
+func emojiUsageLess(a, b *EmojiUsage) bool {
+	if a.Count() != b.Count() {
+		return a.Count() < b.Count()
+	}
+	if !a.LastUsed.Equal(b.LastUsed) {
+		return a.LastUsed.Before(b.LastUsed)
+	}
+	return a.Name < b.Name
+}
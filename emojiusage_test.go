@@ -0,0 +1,68 @@
+package discordgo
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestEmojiUsageCollector(t *testing.T) {
+	s, _ := New("Bot token")
+	s.State.User = &User{ID: "9"}
+	s.State.GuildAdd(&Guild{ID: "1", Emojis: []*Emoji{
+		{ID: "10", Name: "wave"},
+		{ID: "11", Name: "party", Animated: true},
+		{ID: "12", Name: "unused"},
+		{ID: "13", Name: "twitch", Managed: true},
+	}})
+
+	c := NewEmojiUsageCollector(s, time.Hour)
+	defer c.Close()
+
+	start := time.Now().Add(-time.Hour)
+	author := &User{ID: "2"}
+	c.message(&Message{GuildID: "1", Author: author, Content: "<:wave:10> hi <:wave:10> <a:party:11>"}, start)
+	c.message(&Message{GuildID: "1", Author: &User{ID: "3", Bot: true}, Content: "<:wave:10>"}, start)
+	c.message(&Message{GuildID: "1", Author: author, Content: "<:other:20>"}, start)
+	c.reaction(s, &MessageReaction{GuildID: "1", UserID: "2", Emoji: Emoji{ID: "11", Name: "party", Animated: true}}, start)
+	c.reaction(s, &MessageReaction{GuildID: "1", UserID: "9", Emoji: Emoji{ID: "10", Name: "wave"}}, start)
+	c.reaction(s, &MessageReaction{GuildID: "1", UserID: "2", Emoji: Emoji{Name: "👍"}}, start)
+
+	usage := c.Usage("1")
+	if len(usage) != 3 {
+		t.Fatalf("expected 3 emojis, got %d", len(usage))
+	}
+	if usage[0].EmojiID != "11" || math.Abs(usage[0].Messages-0.5) > 0.01 || math.Abs(usage[0].Reactions-0.5) > 0.01 {
+		t.Errorf("expected party to be used in half a message and reaction after a half-life, got %+v", usage[0])
+	}
+	if !usage[0].Animated {
+		t.Error("expected party to be animated")
+	}
+
+	least, err := c.LeastUsed("1", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(least) != 2 || least[0].EmojiID != "12" || least[0].Count() != 0 || least[1].EmojiID != "10" {
+		t.Errorf("expected unused and wave, got %+v", least)
+	}
+
+	// Emojis are forgotten once their usage decayed.
+	c.Lock()
+	c.decayed["1"] = time.Now().Add(-7 * time.Hour)
+	c.Unlock()
+	if usage := c.Usage("1"); len(usage) != 0 {
+		t.Errorf("expected the decayed emojis to be forgotten, got %+v", usage)
+	}
+	c.Lock()
+	if _, ok := c.guilds["1"]; ok {
+		t.Error("expected the guild to be forgotten")
+	}
+	c.Unlock()
+
+	c.message(&Message{GuildID: "1", Author: author, Content: "<:wave:10>"}, time.Now())
+	c.Reset("1")
+	if usage := c.Usage("1"); len(usage) != 0 {
+		t.Errorf("expected no usage after a reset, got %d emojis", len(usage))
+	}
+}
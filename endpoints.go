@@ -23,22 +23,25 @@ var (
 	EndpointSmActive   = EndpointSm + "active.json"
 	EndpointSmUpcoming = EndpointSm + "upcoming.json"
 
-	EndpointDiscord    = "https://discord.com/"
-	EndpointAPI        = EndpointDiscord + "api/v" + APIVersion + "/"
-	EndpointGuilds     = EndpointAPI + "guilds/"
-	EndpointChannels   = EndpointAPI + "channels/"
-	EndpointUsers      = EndpointAPI + "users/"
-	EndpointGateway    = EndpointAPI + "gateway"
-	EndpointGatewayBot = EndpointGateway + "/bot"
-	EndpointWebhooks   = EndpointAPI + "webhooks/"
-
-	EndpointCDN             = "https://cdn.discordapp.com/"
-	EndpointCDNAttachments  = EndpointCDN + "attachments/"
-	EndpointCDNAvatars      = EndpointCDN + "avatars/"
-	EndpointCDNIcons        = EndpointCDN + "icons/"
-	EndpointCDNSplashes     = EndpointCDN + "splashes/"
-	EndpointCDNChannelIcons = EndpointCDN + "channel-icons/"
-	EndpointCDNBanners      = EndpointCDN + "banners/"
+	EndpointDiscord      = "https://discord.com/"
+	EndpointAPI          = EndpointDiscord + "api/v" + APIVersion + "/"
+	EndpointGuilds       = EndpointAPI + "guilds/"
+	EndpointChannels     = EndpointAPI + "channels/"
+	EndpointUsers        = EndpointAPI + "users/"
+	EndpointGateway      = EndpointAPI + "gateway"
+	EndpointGatewayBot   = EndpointGateway + "/bot"
+	EndpointWebhooks     = EndpointAPI + "webhooks/"
+	EndpointInteractions = EndpointAPI + "interactions/"
+
+	EndpointCDN                  = "https://cdn.discordapp.com/"
+	EndpointCDNAttachments       = EndpointCDN + "attachments/"
+	EndpointCDNAvatars           = EndpointCDN + "avatars/"
+	EndpointCDNIcons             = EndpointCDN + "icons/"
+	EndpointCDNSplashes          = EndpointCDN + "splashes/"
+	EndpointCDNChannelIcons      = EndpointCDN + "channel-icons/"
+	EndpointCDNBanners           = EndpointCDN + "banners/"
+	EndpointCDNDiscoverySplashes = EndpointCDN + "discovery-splashes/"
+	EndpointCDNAppAssets         = EndpointCDN + "app-assets/"
 
 	EndpointAuth           = EndpointAPI + "auth/"
 	EndpointLogin          = EndpointAuth + "login"
@@ -72,6 +75,7 @@ var (
 	EndpointUserGuilds        = func(uID string) string { return EndpointUsers + uID + "/guilds" }
 	EndpointUserGuild         = func(uID, gID string) string { return EndpointUsers + uID + "/guilds/" + gID }
 	EndpointUserGuildSettings = func(uID, gID string) string { return EndpointUsers + uID + "/guilds/" + gID + "/settings" }
+	EndpointUserGuildMember   = func(uID, gID string) string { return EndpointUsers + uID + "/guilds/" + gID + "/member" }
 	EndpointUserChannels      = func(uID string) string { return EndpointUsers + uID + "/channels" }
 	EndpointUserDevices       = func(uID string) string { return EndpointUsers + uID + "/devices" }
 	EndpointUserConnections   = func(uID string) string { return EndpointUsers + uID + "/connections" }
@@ -92,14 +96,22 @@ var (
 	EndpointGuildInvites         = func(gID string) string { return EndpointGuilds + gID + "/invites" }
 	EndpointGuildEmbed           = func(gID string) string { return EndpointGuilds + gID + "/embed" }
 	EndpointGuildPrune           = func(gID string) string { return EndpointGuilds + gID + "/prune" }
+	EndpointGuildScreening       = func(gID string) string { return EndpointGuilds + gID + "/member-verification" }
+	EndpointGuildPreview         = func(gID string) string { return EndpointGuilds + gID + "/preview" }
+	EndpointGuildVanityURL       = func(gID string) string { return EndpointGuilds + gID + "/vanity-url" }
+	EndpointGuildWelcomeScreen   = func(gID string) string { return EndpointGuilds + gID + "/welcome-screen" }
+	EndpointGuildOnboarding      = func(gID string) string { return EndpointGuilds + gID + "/onboarding" }
+	EndpointGuildWidgetImage     = func(gID string) string { return EndpointGuilds + gID + "/widget.png" }
 	EndpointGuildIcon            = func(gID, hash string) string { return EndpointCDNIcons + gID + "/" + hash + ".png" }
 	EndpointGuildIconAnimated    = func(gID, hash string) string { return EndpointCDNIcons + gID + "/" + hash + ".gif" }
 	EndpointGuildSplash          = func(gID, hash string) string { return EndpointCDNSplashes + gID + "/" + hash + ".png" }
+	EndpointGuildDiscoverySplash = func(gID, hash string) string { return EndpointCDNDiscoverySplashes + gID + "/" + hash + ".png" }
 	EndpointGuildWebhooks        = func(gID string) string { return EndpointGuilds + gID + "/webhooks" }
 	EndpointGuildAuditLogs       = func(gID string) string { return EndpointGuilds + gID + "/audit-logs" }
 	EndpointGuildEmojis          = func(gID string) string { return EndpointGuilds + gID + "/emojis" }
 	EndpointGuildEmoji           = func(gID, eID string) string { return EndpointGuilds + gID + "/emojis/" + eID }
 	EndpointGuildBanner          = func(gID, hash string) string { return EndpointCDNBanners + gID + "/" + hash + ".png" }
+	EndpointGuildBannerAnimated  = func(gID, hash string) string { return EndpointCDNBanners + gID + "/" + hash + ".gif" }
 
 	EndpointChannel                   = func(cID string) string { return EndpointChannels + cID }
 	EndpointChannelPermissions        = func(cID string) string { return EndpointChannels + cID + "/permissions" }
@@ -114,12 +126,24 @@ var (
 	EndpointChannelMessagePin         = func(cID, mID string) string { return EndpointChannel(cID) + "/pins/" + mID }
 	EndpointChannelMessageCrosspost   = func(cID, mID string) string { return EndpointChannel(cID) + "/messages/" + mID + "/crosspost" }
 	EndpointChannelFollow             = func(cID string) string { return EndpointChannel(cID) + "/followers" }
+	EndpointChannelThreads            = func(cID string) string { return EndpointChannel(cID) + "/threads" }
+	EndpointThreadMember              = func(cID, uID string) string { return EndpointChannel(cID) + "/thread-members/" + uID }
 
 	EndpointGroupIcon = func(cID, hash string) string { return EndpointCDNChannelIcons + cID + "/" + hash + ".png" }
 
 	EndpointChannelWebhooks = func(cID string) string { return EndpointChannel(cID) + "/webhooks" }
 	EndpointWebhook         = func(wID string) string { return EndpointWebhooks + wID }
 	EndpointWebhookToken    = func(wID, token string) string { return EndpointWebhooks + wID + "/" + token }
+	EndpointWebhookMessage  = func(wID, token, messageID string) string {
+		return EndpointWebhookToken(wID, token) + "/messages/" + messageID
+	}
+
+	EndpointInteractionResponse = func(iID, iToken string) string {
+		return EndpointInteractions + iID + "/" + iToken + "/callback"
+	}
+	EndpointInteractionResponseActions = func(aID, iToken string) string {
+		return EndpointWebhookMessage(aID, iToken, "@original")
+	}
 
 	EndpointMessageReactionsAll = func(cID, mID string) string {
 		return EndpointChannelMessage(cID, mID) + "/reactions"
@@ -145,8 +169,30 @@ var (
 	EndpointEmojiAnimated = func(eID string) string { return EndpointCDN + "emojis/" + eID + ".gif" }
 
 	EndpointOauth2            = EndpointAPI + "oauth2/"
+	EndpointOauth2Authorize   = EndpointDiscord + "oauth2/authorize"
 	EndpointApplications      = EndpointOauth2 + "applications"
 	EndpointApplication       = func(aID string) string { return EndpointApplications + "/" + aID }
 	EndpointApplicationsBot   = func(aID string) string { return EndpointApplications + "/" + aID + "/bot" }
 	EndpointApplicationAssets = func(aID string) string { return EndpointApplications + "/" + aID + "/assets" }
+	EndpointApplicationMe     = EndpointAPI + "applications/@me"
+	EndpointApplicationAsset  = func(aID, assetID string) string { return EndpointCDNAppAssets + aID + "/" + assetID + ".png" }
+
+	EndpointApplicationGlobalCommands = func(aID string) string { return EndpointAPI + "applications/" + aID + "/commands" }
+	EndpointApplicationGlobalCommand  = func(aID, cID string) string { return EndpointApplicationGlobalCommands(aID) + "/" + cID }
+	EndpointApplicationGuildCommands  = func(aID, gID string) string {
+		return EndpointAPI + "applications/" + aID + "/guilds/" + gID + "/commands"
+	}
+	EndpointApplicationGuildCommand = func(aID, gID, cID string) string {
+		return EndpointApplicationGuildCommands(aID, gID) + "/" + cID
+	}
+	EndpointApplicationGuildCommandsPermissions = func(aID, gID string) string {
+		return EndpointApplicationGuildCommands(aID, gID) + "/permissions"
+	}
+	EndpointApplicationCommandPermissions = func(aID, gID, cID string) string {
+		return EndpointApplicationGuildCommand(aID, gID, cID) + "/permissions"
+	}
+
+	EndpointApplicationActivityInstance = func(aID, iID string) string {
+		return EndpointAPI + "applications/" + aID + "/activity-instances/" + iID
+	}
 )
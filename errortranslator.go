@@ -0,0 +1,164 @@
+package discordgo
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Keys of the messages of an ErrorTranslator. The messages can use the
+// variables given in the comments as {{variable}} placeholders.
+const (
+	ErrorMessageGeneric = "generic"
+
+	// {{permission}} is the name of the missing permission.
+	ErrorMessageMissingPermission = "missing_permission"
+
+	// Discord doesn't tell which permission is missing.
+	ErrorMessageMissingPermissions = "missing_permissions"
+
+	ErrorMessageMissingAccess = "missing_access"
+	ErrorMessageNotFound      = "not_found"
+	ErrorMessageCannotDM      = "cannot_dm"
+	ErrorMessageRateLimited   = "rate_limited"
+	ErrorMessageUnavailable   = "unavailable"
+
+	// {{field}} and {{limit}} are the field which is too long and its limit.
+	ErrorMessageTooLong = "too_long"
+)
+
+// DefaultErrorMessages are the English messages of an ErrorTranslator.
+var DefaultErrorMessages = map[string]string{
+	ErrorMessageGeneric:            "Something went wrong, please try again later.",
+	ErrorMessageMissingPermission:  "I need the {{permission}} permission to do that.",
+	ErrorMessageMissingPermissions: "I don't have the permissions to do that.",
+	ErrorMessageMissingAccess:      "I don't have access to that channel.",
+	ErrorMessageNotFound:           "That doesn't exist anymore.",
+	ErrorMessageCannotDM:           "I can't send you direct messages, please check your privacy settings.",
+	ErrorMessageRateLimited:        "Slow down, please try again in a moment.",
+	ErrorMessageUnavailable:        "Discord is having issues, please try again later.",
+	ErrorMessageTooLong:            "The {{field}} is too long, the limit is {{limit}} characters.",
+}
+
+// An ErrorTranslator maps errors, such as REST errors and missing
+// permissions, to messages which can be shown to users, eg. as the reply to
+// an interaction.
+type ErrorTranslator struct {
+	// Messages by locale and key. Messages missing for a locale are looked
+	// up in the language of the locale, eg. "es" for "es-ES", and then in
+	// the DefaultLocale.
+	Messages map[string]map[string]string
+
+	// The locale of DefaultErrorMessages in NewErrorTranslator, "en-US".
+	DefaultLocale string
+
+	// Translate maps errors of the application to a key and its variables,
+	// it is called before the errors of discordgo are mapped. Optional.
+	Translate func(err error) (key string, vars TemplateVars, ok bool)
+}
+
+// NewErrorTranslator returns a new ErrorTranslator with the
+// DefaultErrorMessages, more locales can be added to its Messages.
+func NewErrorTranslator() *ErrorTranslator {
+	messages := make(map[string]string, len(DefaultErrorMessages))
+	for key, msg := range DefaultErrorMessages {
+		messages[key] = msg
+	}
+
+	return &ErrorTranslator{
+		Messages:      map[string]map[string]string{"en-US": messages},
+		DefaultLocale: "en-US",
+	}
+}
+
+// errorKey returns the message key and variables of an error.
+func (t *ErrorTranslator) errorKey(err error) (string, TemplateVars) {
+	if t.Translate != nil {
+		if key, vars, ok := t.Translate(err); ok {
+			return key, vars
+		}
+	}
+
+	switch e := err.(type) {
+	case *ErrMissingPermission:
+		name, ok := permissionNames[e.Perm]
+		if !ok {
+			return ErrorMessageMissingPermissions, nil
+		}
+		return ErrorMessageMissingPermission, TemplateVars{"permission": name}
+	case *MessageLimitError:
+		return ErrorMessageTooLong, TemplateVars{"field": e.Field, "limit": strconv.Itoa(e.Limit)}
+	case *RESTError:
+		if e.Message != nil {
+			switch code := e.Message.Code; {
+			case code >= ErrCodeUnknownAccount && code <= ErrCodeUnknownWebhook:
+				return ErrorMessageNotFound, nil
+			case code == ErrCodeMissingPermissions:
+				return ErrorMessageMissingPermissions, nil
+			case code == ErrCodeMissingAccess:
+				return ErrorMessageMissingAccess, nil
+			case code == ErrCodeCannotSendMessagesToThisUser:
+				return ErrorMessageCannotDM, nil
+			}
+		}
+
+		if e.Response != nil {
+			switch code := e.Response.StatusCode; {
+			case code == http.StatusForbidden:
+				return ErrorMessageMissingPermissions, nil
+			case code == http.StatusNotFound:
+				return ErrorMessageNotFound, nil
+			case code == http.StatusTooManyRequests:
+				return ErrorMessageRateLimited, nil
+			case code >= 500:
+				return ErrorMessageUnavailable, nil
+			}
+		}
+	}
+
+	if err == ErrStateNotFound {
+		return ErrorMessageNotFound, nil
+	}
+	return ErrorMessageGeneric, nil
+}
+
+// message returns the message of a key in the locale.
+func (t *ErrorTranslator) message(key, locale string) (string, bool) {
+	locales := []string{locale}
+	if i := strings.IndexByte(locale, '-'); i > 0 {
+		locales = append(locales, locale[:i])
+	}
+	locales = append(locales, t.DefaultLocale)
+
+	for _, l := range locales {
+		if msg, ok := t.Messages[l][key]; ok {
+			return msg, true
+		}
+	}
+	return "", false
+}
+
+// Message returns the message shown to users for the error, in the given
+// locale, eg. the Locale of an Interaction.
+func (t *ErrorTranslator) Message(err error, locale string) string {
+	key, vars := t.errorKey(err)
+
+	msg, ok := t.message(key, locale)
+	if !ok {
+		msg, _ = t.message(ErrorMessageGeneric, locale)
+	}
+	return vars.Replace(msg)
+}
+
+// InteractionResponse returns an ephemeral response to the interaction with
+// the message of the error in the locale of the user.
+func (t *ErrorTranslator) InteractionResponse(err error, i *Interaction) *InteractionResponse {
+	return &InteractionResponse{
+		Type: InteractionResponseChannelMessageWithSource,
+		Data: &InteractionResponseData{
+			Content:         t.Message(err, i.Locale),
+			Flags:           MessageFlagsEphemeral,
+			AllowedMentions: &MessageAllowedMentions{},
+		},
+	}
+}
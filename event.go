@@ -1,5 +1,12 @@
 package discordgo
 
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
 // EventHandler is an interface for Discord events.
 type EventHandler interface {
 	// Type returns the type of event this handler belongs to.
@@ -11,6 +18,17 @@ type EventHandler interface {
 	Handle(*Session, interface{})
 }
 
+// ContextEventHandler is an EventHandler that is also able to receive the
+// context of the event it is handling.
+type ContextEventHandler interface {
+	EventHandler
+
+	// HandleContext is called instead of Handle whenever an event of Type()
+	// happens. The context is canceled when the gateway connection the event
+	// was received on is closed.
+	HandleContext(context.Context, *Session, interface{})
+}
+
 // EventInterfaceProvider is an interface for providing empty interfaces for
 // Discord events.
 type EventInterfaceProvider interface {
@@ -39,6 +57,66 @@ func (eh interfaceEventHandler) Handle(s *Session, i interface{}) {
 	eh(s, i)
 }
 
+// interfaceContextEventHandler is a context aware event handler for interface{} events.
+type interfaceContextEventHandler func(context.Context, *Session, interface{})
+
+// Type returns the event type for interface{} events.
+func (eh interfaceContextEventHandler) Type() string {
+	return interfaceEventType
+}
+
+// Handle is the handler for an interface{} event.
+func (eh interfaceContextEventHandler) Handle(s *Session, i interface{}) {
+	eh(context.Background(), s, i)
+}
+
+// HandleContext is the context aware handler for an interface{} event.
+func (eh interfaceContextEventHandler) HandleContext(ctx context.Context, s *Session, i interface{}) {
+	eh(ctx, s, i)
+}
+
+// EventMetadata describes the event a handler context was created for.
+type EventMetadata struct {
+	// The type of the event, eg. "MESSAGE_CREATE".
+	Type string
+
+	// The gateway sequence number at the time the event was dispatched.
+	Sequence int64
+
+	// The shard the event was received on.
+	ShardID    int
+	ShardCount int
+
+	// The time at which the event was dispatched to the handlers.
+	Received time.Time
+}
+
+// EventTracer can be set on a Session to start a trace span for every
+// dispatched event. The returned context is passed to context aware
+// handlers and the returned function is called once all handlers for
+// the event have returned.
+type EventTracer interface {
+	StartEventSpan(ctx context.Context, meta *EventMetadata) (context.Context, func())
+}
+
+type eventMetadataKey struct{}
+
+// EventMetadataFromContext returns the EventMetadata stored in a context
+// passed to a context aware event handler.
+func EventMetadataFromContext(ctx context.Context) (*EventMetadata, bool) {
+	meta, ok := ctx.Value(eventMetadataKey{}).(*EventMetadata)
+	return meta, ok
+}
+
+// ShardIDFromContext returns the ID of the shard an event was received on.
+func ShardIDFromContext(ctx context.Context) (int, bool) {
+	meta, ok := EventMetadataFromContext(ctx)
+	if !ok {
+		return 0, false
+	}
+	return meta.ShardID, true
+}
+
 var registeredInterfaceProviders = map[string]EventInterfaceProvider{}
 
 // registerInterfaceProvider registers a provider so that DiscordGo can
@@ -54,24 +132,84 @@ func registerInterfaceProvider(eh EventInterfaceProvider) {
 	return
 }
 
+// HandlerID identifies an event handler added with AddHandlerComplex.
+type HandlerID uint64
+
+// HandlerOptions is provided to AddHandlerComplex
+type HandlerOptions struct {
+	// The name of the handler group the handler belongs to.
+	// Handler groups can be enabled, disabled and removed in bulk.
+	Group string
+
+	// Priority controls the order in which the handlers of an event are
+	// called, handlers with a higher priority are called first. Handlers
+	// with the same priority are called in the order they were added.
+	// When SyncEvents is false this only controls the order in which the
+	// handler goroutines are started.
+	Priority int
+
+	// Whether the handler should be removed after it has been fired once.
+	Once bool
+}
+
 // eventHandlerInstance is a wrapper around an event handler, as functions
 // cannot be compared directly.
 type eventHandlerInstance struct {
 	eventHandler EventHandler
+	id           HandlerID
+	group        string
+	priority     int
 }
 
-// addEventHandler adds an event handler that will be fired anytime
-// the Discord WSAPI matching eventHandler.Type() fires.
-func (s *Session) addEventHandler(eventHandler EventHandler) func() {
+// insertEventHandlerInstance adds an event handler instance to the given
+// handler map, keeping the handlers of an event sorted by priority.
+// s.handlersMu must be held when calling this.
+func insertEventHandlerInstance(handlers map[string][]*eventHandlerInstance, t string, ehi *eventHandlerInstance) {
+	list := handlers[t]
+	i := len(list)
+	for i > 0 && list[i-1].priority < ehi.priority {
+		i--
+	}
+
+	list = append(list, nil)
+	copy(list[i+1:], list[i:])
+	list[i] = ehi
+	handlers[t] = list
+}
+
+// addEventHandlerComplex adds an event handler with the given options and
+// returns its instance.
+func (s *Session) addEventHandlerComplex(eventHandler EventHandler, opts HandlerOptions) *eventHandlerInstance {
 	s.handlersMu.Lock()
 	defer s.handlersMu.Unlock()
 
-	if s.handlers == nil {
-		s.handlers = map[string][]*eventHandlerInstance{}
+	s.lastHandlerID++
+	ehi := &eventHandlerInstance{
+		eventHandler: eventHandler,
+		id:           s.lastHandlerID,
+		group:        opts.Group,
+		priority:     opts.Priority,
 	}
 
-	ehi := &eventHandlerInstance{eventHandler}
-	s.handlers[eventHandler.Type()] = append(s.handlers[eventHandler.Type()], ehi)
+	if opts.Once {
+		if s.onceHandlers == nil {
+			s.onceHandlers = map[string][]*eventHandlerInstance{}
+		}
+		insertEventHandlerInstance(s.onceHandlers, eventHandler.Type(), ehi)
+	} else {
+		if s.handlers == nil {
+			s.handlers = map[string][]*eventHandlerInstance{}
+		}
+		insertEventHandlerInstance(s.handlers, eventHandler.Type(), ehi)
+	}
+
+	return ehi
+}
+
+// addEventHandler adds an event handler that will be fired anytime
+// the Discord WSAPI matching eventHandler.Type() fires.
+func (s *Session) addEventHandler(eventHandler EventHandler) func() {
+	ehi := s.addEventHandlerComplex(eventHandler, HandlerOptions{})
 
 	return func() {
 		s.removeEventHandlerInstance(eventHandler.Type(), ehi)
@@ -81,15 +219,7 @@ func (s *Session) addEventHandler(eventHandler EventHandler) func() {
 // addEventHandler adds an event handler that will be fired the next time
 // the Discord WSAPI matching eventHandler.Type() fires.
 func (s *Session) addEventHandlerOnce(eventHandler EventHandler) func() {
-	s.handlersMu.Lock()
-	defer s.handlersMu.Unlock()
-
-	if s.onceHandlers == nil {
-		s.onceHandlers = map[string][]*eventHandlerInstance{}
-	}
-
-	ehi := &eventHandlerInstance{eventHandler}
-	s.onceHandlers[eventHandler.Type()] = append(s.onceHandlers[eventHandler.Type()], ehi)
+	ehi := s.addEventHandlerComplex(eventHandler, HandlerOptions{Once: true})
 
 	return func() {
 		s.removeEventHandlerInstance(eventHandler.Type(), ehi)
@@ -109,6 +239,12 @@ func (s *Session) addEventHandlerOnce(eventHandler EventHandler) func() {
 //     Session.AddHandler(func(s *discordgo.Session, m *discordgo.PresenceUpdate) {
 //     })
 //
+// Handlers may also take a context.Context as their first parameter, the
+// context carries the EventMetadata of the event and is canceled once the
+// gateway connection the event was received on is closed:
+//     Session.AddHandler(func(ctx context.Context, s *discordgo.Session, m *discordgo.MessageCreate) {
+//     })
+//
 // List of events can be found at this page, with corresponding names in the
 // library for each event: https://discord.com/developers/docs/topics/gateway#event-names
 // There are also synthetic events fired by the library internally which are
@@ -142,6 +278,86 @@ func (s *Session) AddHandlerOnce(handler interface{}) func() {
 	return s.addEventHandlerOnce(eh)
 }
 
+// AddHandlerComplex adds an event handler in the same way as AddHandler,
+// but allows setting the group, priority and lifetime of the handler.
+// The returned HandlerID can be used to remove the handler with RemoveHandler,
+// 0 is returned if the handler type is invalid.
+func (s *Session) AddHandlerComplex(handler interface{}, opts HandlerOptions) HandlerID {
+	eh := handlerForInterface(handler)
+
+	if eh == nil {
+		s.log(LogError, "Invalid handler type, handler will never be called")
+		return 0
+	}
+
+	return s.addEventHandlerComplex(eh, opts).id
+}
+
+// RemoveHandler removes the event handler with the given ID.
+func (s *Session) RemoveHandler(id HandlerID) {
+	s.removeEventHandlers(func(ehi *eventHandlerInstance) bool {
+		return ehi.id == id
+	})
+}
+
+// RemoveHandlerGroup removes all the event handlers of a handler group.
+func (s *Session) RemoveHandlerGroup(group string) {
+	s.removeEventHandlers(func(ehi *eventHandlerInstance) bool {
+		return ehi.group == group
+	})
+
+	s.handlersMu.Lock()
+	delete(s.disabledHandlerGroups, group)
+	s.handlersMu.Unlock()
+}
+
+// DisableHandlerGroup stops the event handlers of a handler group from being
+// fired until the group is enabled again.
+func (s *Session) DisableHandlerGroup(group string) {
+	s.handlersMu.Lock()
+	defer s.handlersMu.Unlock()
+
+	if s.disabledHandlerGroups == nil {
+		s.disabledHandlerGroups = map[string]bool{}
+	}
+	s.disabledHandlerGroups[group] = true
+}
+
+// EnableHandlerGroup enables a handler group disabled by DisableHandlerGroup.
+func (s *Session) EnableHandlerGroup(group string) {
+	s.handlersMu.Lock()
+	defer s.handlersMu.Unlock()
+
+	delete(s.disabledHandlerGroups, group)
+}
+
+// HandlerGroupEnabled returns whether the handlers of a handler group are fired.
+func (s *Session) HandlerGroupEnabled(group string) bool {
+	s.handlersMu.RLock()
+	defer s.handlersMu.RUnlock()
+
+	return !s.disabledHandlerGroups[group]
+}
+
+// removeEventHandlers removes all event handler instances matching the
+// given function.
+func (s *Session) removeEventHandlers(match func(*eventHandlerInstance) bool) {
+	s.handlersMu.Lock()
+	defer s.handlersMu.Unlock()
+
+	for _, handlers := range []map[string][]*eventHandlerInstance{s.handlers, s.onceHandlers} {
+		for t, list := range handlers {
+			kept := list[:0]
+			for _, ehi := range list {
+				if !match(ehi) {
+					kept = append(kept, ehi)
+				}
+			}
+			handlers[t] = kept
+		}
+	}
+}
+
 // removeEventHandler instance removes an event handler instance.
 func (s *Session) removeEventHandlerInstance(t string, ehi *eventHandlerInstance) {
 	s.handlersMu.Lock()
@@ -157,33 +373,85 @@ func (s *Session) removeEventHandlerInstance(t string, ehi *eventHandlerInstance
 	onceHandlers := s.onceHandlers[t]
 	for i := range onceHandlers {
 		if onceHandlers[i] == ehi {
-			s.onceHandlers[t] = append(onceHandlers[:i], handlers[i+1:]...)
+			s.onceHandlers[t] = append(onceHandlers[:i], onceHandlers[i+1:]...)
 		}
 	}
 }
 
+// call calls the event handler, passing the context along if the
+// handler is context aware.
+func (ehi *eventHandlerInstance) call(ctx context.Context, s *Session, i interface{}) {
+	if ceh, ok := ehi.eventHandler.(ContextEventHandler); ok {
+		ceh.HandleContext(ctx, s, i)
+		return
+	}
+	ehi.eventHandler.Handle(s, i)
+}
+
 // Handles calling permanent and once handlers for an event type.
-func (s *Session) handle(t string, i interface{}) {
-	for _, eh := range s.handlers[t] {
+func (s *Session) handle(ctx context.Context, wg *sync.WaitGroup, t string, i interface{}) {
+	run := func(eh *eventHandlerInstance) {
 		if s.SyncEvents {
-			eh.eventHandler.Handle(s, i)
-		} else {
-			go eh.eventHandler.Handle(s, i)
+			eh.call(ctx, s, i)
+			return
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			eh.call(ctx, s, i)
+		}()
+	}
+
+	for _, eh := range s.handlers[t] {
+		if !s.disabledHandlerGroups[eh.group] {
+			run(eh)
 		}
 	}
 
 	if len(s.onceHandlers[t]) > 0 {
+		// Handlers of disabled groups have not been fired yet, so keep them.
+		var kept []*eventHandlerInstance
 		for _, eh := range s.onceHandlers[t] {
-			if s.SyncEvents {
-				eh.eventHandler.Handle(s, i)
-			} else {
-				go eh.eventHandler.Handle(s, i)
+			if s.disabledHandlerGroups[eh.group] {
+				kept = append(kept, eh)
+				continue
 			}
+			run(eh)
 		}
-		s.onceHandlers[t] = nil
+		s.onceHandlers[t] = kept
 	}
 }
 
+// eventContext creates the context passed to context aware handlers of an
+// event, and a function to be called once all handlers have returned.
+// The returned function is nil when no EventTracer is set.
+func (s *Session) eventContext(t string) (context.Context, func()) {
+	s.ctxMu.RLock()
+	ctx := s.ctx
+	s.ctxMu.RUnlock()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	meta := &EventMetadata{
+		Type:       t,
+		ShardID:    s.ShardID,
+		ShardCount: s.ShardCount,
+		Received:   time.Now(),
+	}
+	if s.sequence != nil {
+		meta.Sequence = atomic.LoadInt64(s.sequence)
+	}
+	ctx = context.WithValue(ctx, eventMetadataKey{}, meta)
+
+	if s.Tracer == nil {
+		return ctx, nil
+	}
+	return s.Tracer.StartEventSpan(ctx, meta)
+}
+
 // Handles an event type by calling internal methods, firing handlers and firing the
 // interface{} event.
 func (s *Session) handleEvent(t string, i interface{}) {
@@ -193,11 +461,21 @@ func (s *Session) handleEvent(t string, i interface{}) {
 	// All events are dispatched internally first.
 	s.onInterface(i)
 
+	ctx, done := s.eventContext(t)
+	wg := &sync.WaitGroup{}
+
 	// Then they are dispatched to anyone handling interface{} events.
-	s.handle(interfaceEventType, i)
+	s.handle(ctx, wg, interfaceEventType, i)
 
 	// Finally they are dispatched to any typed handlers.
-	s.handle(t, i)
+	s.handle(ctx, wg, t, i)
+
+	if done != nil {
+		go func() {
+			wg.Wait()
+			done()
+		}()
+	}
 }
 
 // setGuildIds will set the GuildID on all the members of a guild.
@@ -237,11 +515,37 @@ func (s *Session) onInterface(i interface{}) {
 	if err != nil {
 		s.log(LogDebug, "error dispatching internal event, %s", err)
 	}
+
+	if t, ok := i.(*VoiceStateUpdate); ok && s.StateEnabled && s.State != nil && s.State.TrackVoice {
+		s.voiceOccupancyEvents(t)
+	}
+
+	switch i.(type) {
+	case *GuildCreate, *GuildDelete:
+		if s.StateEnabled && s.State != nil {
+			s.guildOutageEvents()
+		}
+	}
 }
 
 // onReady handles the ready event.
 func (s *Session) onReady(r *Ready) {
 
+	s.readyMu.Lock()
+	defer s.readyMu.Unlock()
+
 	// Store the SessionID within the Session struct.
 	s.sessionID = r.SessionID
+	s.resumeGatewayURL = r.ResumeGatewayURL
+	s.application = r.Application
+}
+
+// CurrentApplication returns the application of the bot from the Ready
+// event, with only its ID and flags, or nil before the session is ready.
+// Use Application to get the whole application.
+func (s *Session) CurrentApplication() *Application {
+	s.readyMu.RLock()
+	defer s.readyMu.RUnlock()
+
+	return s.application
 }
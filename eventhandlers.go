@@ -3,56 +3,107 @@
 
 package discordgo
 
+import "context"
+
 // Following are all the event types.
 // Event type values are used to match the events returned by Discord.
 // EventTypes surrounded by __ are synthetic and are internal to DiscordGo.
 const (
-	channelCreateEventType            = "CHANNEL_CREATE"
-	channelDeleteEventType            = "CHANNEL_DELETE"
-	channelPinsUpdateEventType        = "CHANNEL_PINS_UPDATE"
-	channelUpdateEventType            = "CHANNEL_UPDATE"
-	connectEventType                  = "__CONNECT__"
-	disconnectEventType               = "__DISCONNECT__"
-	eventEventType                    = "__EVENT__"
-	guildBanAddEventType              = "GUILD_BAN_ADD"
-	guildBanRemoveEventType           = "GUILD_BAN_REMOVE"
-	guildCreateEventType              = "GUILD_CREATE"
-	guildDeleteEventType              = "GUILD_DELETE"
-	guildEmojisUpdateEventType        = "GUILD_EMOJIS_UPDATE"
-	guildIntegrationsUpdateEventType  = "GUILD_INTEGRATIONS_UPDATE"
-	guildMemberAddEventType           = "GUILD_MEMBER_ADD"
-	guildMemberRemoveEventType        = "GUILD_MEMBER_REMOVE"
-	guildMemberUpdateEventType        = "GUILD_MEMBER_UPDATE"
-	guildMembersChunkEventType        = "GUILD_MEMBERS_CHUNK"
-	guildRoleCreateEventType          = "GUILD_ROLE_CREATE"
-	guildRoleDeleteEventType          = "GUILD_ROLE_DELETE"
-	guildRoleUpdateEventType          = "GUILD_ROLE_UPDATE"
-	guildUpdateEventType              = "GUILD_UPDATE"
-	messageAckEventType               = "MESSAGE_ACK"
-	messageCreateEventType            = "MESSAGE_CREATE"
-	messageDeleteEventType            = "MESSAGE_DELETE"
-	messageDeleteBulkEventType        = "MESSAGE_DELETE_BULK"
-	messageReactionAddEventType       = "MESSAGE_REACTION_ADD"
-	messageReactionRemoveEventType    = "MESSAGE_REACTION_REMOVE"
-	messageReactionRemoveAllEventType = "MESSAGE_REACTION_REMOVE_ALL"
-	messageUpdateEventType            = "MESSAGE_UPDATE"
-	presenceUpdateEventType           = "PRESENCE_UPDATE"
-	presencesReplaceEventType         = "PRESENCES_REPLACE"
-	rateLimitEventType                = "__RATE_LIMIT__"
-	readyEventType                    = "READY"
-	relationshipAddEventType          = "RELATIONSHIP_ADD"
-	relationshipRemoveEventType       = "RELATIONSHIP_REMOVE"
-	resumedEventType                  = "RESUMED"
-	typingStartEventType              = "TYPING_START"
-	userGuildSettingsUpdateEventType  = "USER_GUILD_SETTINGS_UPDATE"
-	userNoteUpdateEventType           = "USER_NOTE_UPDATE"
-	userSettingsUpdateEventType       = "USER_SETTINGS_UPDATE"
-	userUpdateEventType               = "USER_UPDATE"
-	voiceServerUpdateEventType        = "VOICE_SERVER_UPDATE"
-	voiceStateUpdateEventType         = "VOICE_STATE_UPDATE"
-	webhooksUpdateEventType           = "WEBHOOKS_UPDATE"
+	batchJobCompleteEventType           = "__BATCH_JOB_COMPLETE__"
+	channelCreateEventType              = "CHANNEL_CREATE"
+	channelDeleteEventType              = "CHANNEL_DELETE"
+	channelPinsUpdateEventType          = "CHANNEL_PINS_UPDATE"
+	channelUpdateEventType              = "CHANNEL_UPDATE"
+	connectEventType                    = "__CONNECT__"
+	disconnectEventType                 = "__DISCONNECT__"
+	eventEventType                      = "__EVENT__"
+	guildBanAddEventType                = "GUILD_BAN_ADD"
+	guildBanRemoveEventType             = "GUILD_BAN_REMOVE"
+	guildCreateEventType                = "GUILD_CREATE"
+	guildDeleteEventType                = "GUILD_DELETE"
+	guildEmojisUpdateEventType          = "GUILD_EMOJIS_UPDATE"
+	guildIntegrationsUpdateEventType    = "GUILD_INTEGRATIONS_UPDATE"
+	guildMemberAddEventType             = "GUILD_MEMBER_ADD"
+	guildMemberRemoveEventType          = "GUILD_MEMBER_REMOVE"
+	guildMemberUpdateEventType          = "GUILD_MEMBER_UPDATE"
+	guildMembersChunkEventType          = "GUILD_MEMBERS_CHUNK"
+	guildOutageEventType                = "__GUILD_OUTAGE__"
+	guildRoleCreateEventType            = "GUILD_ROLE_CREATE"
+	guildRoleDeleteEventType            = "GUILD_ROLE_DELETE"
+	guildRoleUpdateEventType            = "GUILD_ROLE_UPDATE"
+	guildUpdateEventType                = "GUILD_UPDATE"
+	integrationCreateEventType          = "INTEGRATION_CREATE"
+	integrationDeleteEventType          = "INTEGRATION_DELETE"
+	integrationUpdateEventType          = "INTEGRATION_UPDATE"
+	interactionCreateEventType          = "INTERACTION_CREATE"
+	invalidRequestLimitEventType        = "__INVALID_REQUEST_LIMIT__"
+	memberGatedEventType                = "MEMBER_GATED"
+	messageAckEventType                 = "MESSAGE_ACK"
+	messageCreateEventType              = "MESSAGE_CREATE"
+	messageDeleteEventType              = "MESSAGE_DELETE"
+	messageDeleteBulkEventType          = "MESSAGE_DELETE_BULK"
+	messageReactionAddEventType         = "MESSAGE_REACTION_ADD"
+	messageReactionRemoveEventType      = "MESSAGE_REACTION_REMOVE"
+	messageReactionRemoveAllEventType   = "MESSAGE_REACTION_REMOVE_ALL"
+	messageReactionRemoveEmojiEventType = "MESSAGE_REACTION_REMOVE_EMOJI"
+	messageUpdateEventType              = "MESSAGE_UPDATE"
+	presenceUpdateEventType             = "PRESENCE_UPDATE"
+	presencesReplaceEventType           = "PRESENCES_REPLACE"
+	rateLimitEventType                  = "__RATE_LIMIT__"
+	readyEventType                      = "READY"
+	relationshipAddEventType            = "RELATIONSHIP_ADD"
+	relationshipRemoveEventType         = "RELATIONSHIP_REMOVE"
+	resumedEventType                    = "RESUMED"
+	spamDetectedEventType               = "__SPAM_DETECTED__"
+	threadCreateEventType               = "THREAD_CREATE"
+	typingStartEventType                = "TYPING_START"
+	userGuildSettingsUpdateEventType    = "USER_GUILD_SETTINGS_UPDATE"
+	userNoteUpdateEventType             = "USER_NOTE_UPDATE"
+	userSettingsUpdateEventType         = "USER_SETTINGS_UPDATE"
+	userUpdateEventType                 = "USER_UPDATE"
+	voiceChannelEmptyEventType          = "__VOICE_CHANNEL_EMPTY__"
+	voiceChannelFullEventType           = "__VOICE_CHANNEL_FULL__"
+	voiceServerUpdateEventType          = "VOICE_SERVER_UPDATE"
+	voiceStateUpdateEventType           = "VOICE_STATE_UPDATE"
+	webhooksUpdateEventType             = "WEBHOOKS_UPDATE"
 )
 
+// batchJobCompleteEventHandler is an event handler for BatchJobComplete events.
+type batchJobCompleteEventHandler func(*Session, *BatchJobComplete)
+
+// Type returns the event type for BatchJobComplete events.
+func (eh batchJobCompleteEventHandler) Type() string {
+	return batchJobCompleteEventType
+}
+
+// Handle is the handler for BatchJobComplete events.
+func (eh batchJobCompleteEventHandler) Handle(s *Session, i interface{}) {
+	if t, ok := i.(*BatchJobComplete); ok {
+		eh(s, t)
+	}
+}
+
+// batchJobCompleteContextEventHandler is an event handler for BatchJobComplete events
+// that receives the context of the event.
+type batchJobCompleteContextEventHandler func(context.Context, *Session, *BatchJobComplete)
+
+// Type returns the event type for BatchJobComplete events.
+func (eh batchJobCompleteContextEventHandler) Type() string {
+	return batchJobCompleteEventType
+}
+
+// Handle is the handler for BatchJobComplete events.
+func (eh batchJobCompleteContextEventHandler) Handle(s *Session, i interface{}) {
+	eh.HandleContext(context.Background(), s, i)
+}
+
+// HandleContext is the context aware handler for BatchJobComplete events.
+func (eh batchJobCompleteContextEventHandler) HandleContext(ctx context.Context, s *Session, i interface{}) {
+	if t, ok := i.(*BatchJobComplete); ok {
+		eh(ctx, s, t)
+	}
+}
+
 // channelCreateEventHandler is an event handler for ChannelCreate events.
 type channelCreateEventHandler func(*Session, *ChannelCreate)
 
@@ -73,6 +124,27 @@ func (eh channelCreateEventHandler) Handle(s *Session, i interface{}) {
 	}
 }
 
+// channelCreateContextEventHandler is an event handler for ChannelCreate events
+// that receives the context of the event.
+type channelCreateContextEventHandler func(context.Context, *Session, *ChannelCreate)
+
+// Type returns the event type for ChannelCreate events.
+func (eh channelCreateContextEventHandler) Type() string {
+	return channelCreateEventType
+}
+
+// Handle is the handler for ChannelCreate events.
+func (eh channelCreateContextEventHandler) Handle(s *Session, i interface{}) {
+	eh.HandleContext(context.Background(), s, i)
+}
+
+// HandleContext is the context aware handler for ChannelCreate events.
+func (eh channelCreateContextEventHandler) HandleContext(ctx context.Context, s *Session, i interface{}) {
+	if t, ok := i.(*ChannelCreate); ok {
+		eh(ctx, s, t)
+	}
+}
+
 // channelDeleteEventHandler is an event handler for ChannelDelete events.
 type channelDeleteEventHandler func(*Session, *ChannelDelete)
 
@@ -93,6 +165,27 @@ func (eh channelDeleteEventHandler) Handle(s *Session, i interface{}) {
 	}
 }
 
+// channelDeleteContextEventHandler is an event handler for ChannelDelete events
+// that receives the context of the event.
+type channelDeleteContextEventHandler func(context.Context, *Session, *ChannelDelete)
+
+// Type returns the event type for ChannelDelete events.
+func (eh channelDeleteContextEventHandler) Type() string {
+	return channelDeleteEventType
+}
+
+// Handle is the handler for ChannelDelete events.
+func (eh channelDeleteContextEventHandler) Handle(s *Session, i interface{}) {
+	eh.HandleContext(context.Background(), s, i)
+}
+
+// HandleContext is the context aware handler for ChannelDelete events.
+func (eh channelDeleteContextEventHandler) HandleContext(ctx context.Context, s *Session, i interface{}) {
+	if t, ok := i.(*ChannelDelete); ok {
+		eh(ctx, s, t)
+	}
+}
+
 // channelPinsUpdateEventHandler is an event handler for ChannelPinsUpdate events.
 type channelPinsUpdateEventHandler func(*Session, *ChannelPinsUpdate)
 
@@ -113,6 +206,27 @@ func (eh channelPinsUpdateEventHandler) Handle(s *Session, i interface{}) {
 	}
 }
 
+// channelPinsUpdateContextEventHandler is an event handler for ChannelPinsUpdate events
+// that receives the context of the event.
+type channelPinsUpdateContextEventHandler func(context.Context, *Session, *ChannelPinsUpdate)
+
+// Type returns the event type for ChannelPinsUpdate events.
+func (eh channelPinsUpdateContextEventHandler) Type() string {
+	return channelPinsUpdateEventType
+}
+
+// Handle is the handler for ChannelPinsUpdate events.
+func (eh channelPinsUpdateContextEventHandler) Handle(s *Session, i interface{}) {
+	eh.HandleContext(context.Background(), s, i)
+}
+
+// HandleContext is the context aware handler for ChannelPinsUpdate events.
+func (eh channelPinsUpdateContextEventHandler) HandleContext(ctx context.Context, s *Session, i interface{}) {
+	if t, ok := i.(*ChannelPinsUpdate); ok {
+		eh(ctx, s, t)
+	}
+}
+
 // channelUpdateEventHandler is an event handler for ChannelUpdate events.
 type channelUpdateEventHandler func(*Session, *ChannelUpdate)
 
@@ -133,6 +247,27 @@ func (eh channelUpdateEventHandler) Handle(s *Session, i interface{}) {
 	}
 }
 
+// channelUpdateContextEventHandler is an event handler for ChannelUpdate events
+// that receives the context of the event.
+type channelUpdateContextEventHandler func(context.Context, *Session, *ChannelUpdate)
+
+// Type returns the event type for ChannelUpdate events.
+func (eh channelUpdateContextEventHandler) Type() string {
+	return channelUpdateEventType
+}
+
+// Handle is the handler for ChannelUpdate events.
+func (eh channelUpdateContextEventHandler) Handle(s *Session, i interface{}) {
+	eh.HandleContext(context.Background(), s, i)
+}
+
+// HandleContext is the context aware handler for ChannelUpdate events.
+func (eh channelUpdateContextEventHandler) HandleContext(ctx context.Context, s *Session, i interface{}) {
+	if t, ok := i.(*ChannelUpdate); ok {
+		eh(ctx, s, t)
+	}
+}
+
 // connectEventHandler is an event handler for Connect events.
 type connectEventHandler func(*Session, *Connect)
 
@@ -148,6 +283,27 @@ func (eh connectEventHandler) Handle(s *Session, i interface{}) {
 	}
 }
 
+// connectContextEventHandler is an event handler for Connect events
+// that receives the context of the event.
+type connectContextEventHandler func(context.Context, *Session, *Connect)
+
+// Type returns the event type for Connect events.
+func (eh connectContextEventHandler) Type() string {
+	return connectEventType
+}
+
+// Handle is the handler for Connect events.
+func (eh connectContextEventHandler) Handle(s *Session, i interface{}) {
+	eh.HandleContext(context.Background(), s, i)
+}
+
+// HandleContext is the context aware handler for Connect events.
+func (eh connectContextEventHandler) HandleContext(ctx context.Context, s *Session, i interface{}) {
+	if t, ok := i.(*Connect); ok {
+		eh(ctx, s, t)
+	}
+}
+
 // disconnectEventHandler is an event handler for Disconnect events.
 type disconnectEventHandler func(*Session, *Disconnect)
 
@@ -163,6 +319,27 @@ func (eh disconnectEventHandler) Handle(s *Session, i interface{}) {
 	}
 }
 
+// disconnectContextEventHandler is an event handler for Disconnect events
+// that receives the context of the event.
+type disconnectContextEventHandler func(context.Context, *Session, *Disconnect)
+
+// Type returns the event type for Disconnect events.
+func (eh disconnectContextEventHandler) Type() string {
+	return disconnectEventType
+}
+
+// Handle is the handler for Disconnect events.
+func (eh disconnectContextEventHandler) Handle(s *Session, i interface{}) {
+	eh.HandleContext(context.Background(), s, i)
+}
+
+// HandleContext is the context aware handler for Disconnect events.
+func (eh disconnectContextEventHandler) HandleContext(ctx context.Context, s *Session, i interface{}) {
+	if t, ok := i.(*Disconnect); ok {
+		eh(ctx, s, t)
+	}
+}
+
 // eventEventHandler is an event handler for Event events.
 type eventEventHandler func(*Session, *Event)
 
@@ -178,6 +355,27 @@ func (eh eventEventHandler) Handle(s *Session, i interface{}) {
 	}
 }
 
+// eventContextEventHandler is an event handler for Event events
+// that receives the context of the event.
+type eventContextEventHandler func(context.Context, *Session, *Event)
+
+// Type returns the event type for Event events.
+func (eh eventContextEventHandler) Type() string {
+	return eventEventType
+}
+
+// Handle is the handler for Event events.
+func (eh eventContextEventHandler) Handle(s *Session, i interface{}) {
+	eh.HandleContext(context.Background(), s, i)
+}
+
+// HandleContext is the context aware handler for Event events.
+func (eh eventContextEventHandler) HandleContext(ctx context.Context, s *Session, i interface{}) {
+	if t, ok := i.(*Event); ok {
+		eh(ctx, s, t)
+	}
+}
+
 // guildBanAddEventHandler is an event handler for GuildBanAdd events.
 type guildBanAddEventHandler func(*Session, *GuildBanAdd)
 
@@ -198,6 +396,27 @@ func (eh guildBanAddEventHandler) Handle(s *Session, i interface{}) {
 	}
 }
 
+// guildBanAddContextEventHandler is an event handler for GuildBanAdd events
+// that receives the context of the event.
+type guildBanAddContextEventHandler func(context.Context, *Session, *GuildBanAdd)
+
+// Type returns the event type for GuildBanAdd events.
+func (eh guildBanAddContextEventHandler) Type() string {
+	return guildBanAddEventType
+}
+
+// Handle is the handler for GuildBanAdd events.
+func (eh guildBanAddContextEventHandler) Handle(s *Session, i interface{}) {
+	eh.HandleContext(context.Background(), s, i)
+}
+
+// HandleContext is the context aware handler for GuildBanAdd events.
+func (eh guildBanAddContextEventHandler) HandleContext(ctx context.Context, s *Session, i interface{}) {
+	if t, ok := i.(*GuildBanAdd); ok {
+		eh(ctx, s, t)
+	}
+}
+
 // guildBanRemoveEventHandler is an event handler for GuildBanRemove events.
 type guildBanRemoveEventHandler func(*Session, *GuildBanRemove)
 
@@ -218,6 +437,27 @@ func (eh guildBanRemoveEventHandler) Handle(s *Session, i interface{}) {
 	}
 }
 
+// guildBanRemoveContextEventHandler is an event handler for GuildBanRemove events
+// that receives the context of the event.
+type guildBanRemoveContextEventHandler func(context.Context, *Session, *GuildBanRemove)
+
+// Type returns the event type for GuildBanRemove events.
+func (eh guildBanRemoveContextEventHandler) Type() string {
+	return guildBanRemoveEventType
+}
+
+// Handle is the handler for GuildBanRemove events.
+func (eh guildBanRemoveContextEventHandler) Handle(s *Session, i interface{}) {
+	eh.HandleContext(context.Background(), s, i)
+}
+
+// HandleContext is the context aware handler for GuildBanRemove events.
+func (eh guildBanRemoveContextEventHandler) HandleContext(ctx context.Context, s *Session, i interface{}) {
+	if t, ok := i.(*GuildBanRemove); ok {
+		eh(ctx, s, t)
+	}
+}
+
 // guildCreateEventHandler is an event handler for GuildCreate events.
 type guildCreateEventHandler func(*Session, *GuildCreate)
 
@@ -238,6 +478,27 @@ func (eh guildCreateEventHandler) Handle(s *Session, i interface{}) {
 	}
 }
 
+// guildCreateContextEventHandler is an event handler for GuildCreate events
+// that receives the context of the event.
+type guildCreateContextEventHandler func(context.Context, *Session, *GuildCreate)
+
+// Type returns the event type for GuildCreate events.
+func (eh guildCreateContextEventHandler) Type() string {
+	return guildCreateEventType
+}
+
+// Handle is the handler for GuildCreate events.
+func (eh guildCreateContextEventHandler) Handle(s *Session, i interface{}) {
+	eh.HandleContext(context.Background(), s, i)
+}
+
+// HandleContext is the context aware handler for GuildCreate events.
+func (eh guildCreateContextEventHandler) HandleContext(ctx context.Context, s *Session, i interface{}) {
+	if t, ok := i.(*GuildCreate); ok {
+		eh(ctx, s, t)
+	}
+}
+
 // guildDeleteEventHandler is an event handler for GuildDelete events.
 type guildDeleteEventHandler func(*Session, *GuildDelete)
 
@@ -258,6 +519,27 @@ func (eh guildDeleteEventHandler) Handle(s *Session, i interface{}) {
 	}
 }
 
+// guildDeleteContextEventHandler is an event handler for GuildDelete events
+// that receives the context of the event.
+type guildDeleteContextEventHandler func(context.Context, *Session, *GuildDelete)
+
+// Type returns the event type for GuildDelete events.
+func (eh guildDeleteContextEventHandler) Type() string {
+	return guildDeleteEventType
+}
+
+// Handle is the handler for GuildDelete events.
+func (eh guildDeleteContextEventHandler) Handle(s *Session, i interface{}) {
+	eh.HandleContext(context.Background(), s, i)
+}
+
+// HandleContext is the context aware handler for GuildDelete events.
+func (eh guildDeleteContextEventHandler) HandleContext(ctx context.Context, s *Session, i interface{}) {
+	if t, ok := i.(*GuildDelete); ok {
+		eh(ctx, s, t)
+	}
+}
+
 // guildEmojisUpdateEventHandler is an event handler for GuildEmojisUpdate events.
 type guildEmojisUpdateEventHandler func(*Session, *GuildEmojisUpdate)
 
@@ -278,6 +560,27 @@ func (eh guildEmojisUpdateEventHandler) Handle(s *Session, i interface{}) {
 	}
 }
 
+// guildEmojisUpdateContextEventHandler is an event handler for GuildEmojisUpdate events
+// that receives the context of the event.
+type guildEmojisUpdateContextEventHandler func(context.Context, *Session, *GuildEmojisUpdate)
+
+// Type returns the event type for GuildEmojisUpdate events.
+func (eh guildEmojisUpdateContextEventHandler) Type() string {
+	return guildEmojisUpdateEventType
+}
+
+// Handle is the handler for GuildEmojisUpdate events.
+func (eh guildEmojisUpdateContextEventHandler) Handle(s *Session, i interface{}) {
+	eh.HandleContext(context.Background(), s, i)
+}
+
+// HandleContext is the context aware handler for GuildEmojisUpdate events.
+func (eh guildEmojisUpdateContextEventHandler) HandleContext(ctx context.Context, s *Session, i interface{}) {
+	if t, ok := i.(*GuildEmojisUpdate); ok {
+		eh(ctx, s, t)
+	}
+}
+
 // guildIntegrationsUpdateEventHandler is an event handler for GuildIntegrationsUpdate events.
 type guildIntegrationsUpdateEventHandler func(*Session, *GuildIntegrationsUpdate)
 
@@ -298,6 +601,27 @@ func (eh guildIntegrationsUpdateEventHandler) Handle(s *Session, i interface{})
 	}
 }
 
+// guildIntegrationsUpdateContextEventHandler is an event handler for GuildIntegrationsUpdate events
+// that receives the context of the event.
+type guildIntegrationsUpdateContextEventHandler func(context.Context, *Session, *GuildIntegrationsUpdate)
+
+// Type returns the event type for GuildIntegrationsUpdate events.
+func (eh guildIntegrationsUpdateContextEventHandler) Type() string {
+	return guildIntegrationsUpdateEventType
+}
+
+// Handle is the handler for GuildIntegrationsUpdate events.
+func (eh guildIntegrationsUpdateContextEventHandler) Handle(s *Session, i interface{}) {
+	eh.HandleContext(context.Background(), s, i)
+}
+
+// HandleContext is the context aware handler for GuildIntegrationsUpdate events.
+func (eh guildIntegrationsUpdateContextEventHandler) HandleContext(ctx context.Context, s *Session, i interface{}) {
+	if t, ok := i.(*GuildIntegrationsUpdate); ok {
+		eh(ctx, s, t)
+	}
+}
+
 // guildMemberAddEventHandler is an event handler for GuildMemberAdd events.
 type guildMemberAddEventHandler func(*Session, *GuildMemberAdd)
 
@@ -318,6 +642,27 @@ func (eh guildMemberAddEventHandler) Handle(s *Session, i interface{}) {
 	}
 }
 
+// guildMemberAddContextEventHandler is an event handler for GuildMemberAdd events
+// that receives the context of the event.
+type guildMemberAddContextEventHandler func(context.Context, *Session, *GuildMemberAdd)
+
+// Type returns the event type for GuildMemberAdd events.
+func (eh guildMemberAddContextEventHandler) Type() string {
+	return guildMemberAddEventType
+}
+
+// Handle is the handler for GuildMemberAdd events.
+func (eh guildMemberAddContextEventHandler) Handle(s *Session, i interface{}) {
+	eh.HandleContext(context.Background(), s, i)
+}
+
+// HandleContext is the context aware handler for GuildMemberAdd events.
+func (eh guildMemberAddContextEventHandler) HandleContext(ctx context.Context, s *Session, i interface{}) {
+	if t, ok := i.(*GuildMemberAdd); ok {
+		eh(ctx, s, t)
+	}
+}
+
 // guildMemberRemoveEventHandler is an event handler for GuildMemberRemove events.
 type guildMemberRemoveEventHandler func(*Session, *GuildMemberRemove)
 
@@ -338,6 +683,27 @@ func (eh guildMemberRemoveEventHandler) Handle(s *Session, i interface{}) {
 	}
 }
 
+// guildMemberRemoveContextEventHandler is an event handler for GuildMemberRemove events
+// that receives the context of the event.
+type guildMemberRemoveContextEventHandler func(context.Context, *Session, *GuildMemberRemove)
+
+// Type returns the event type for GuildMemberRemove events.
+func (eh guildMemberRemoveContextEventHandler) Type() string {
+	return guildMemberRemoveEventType
+}
+
+// Handle is the handler for GuildMemberRemove events.
+func (eh guildMemberRemoveContextEventHandler) Handle(s *Session, i interface{}) {
+	eh.HandleContext(context.Background(), s, i)
+}
+
+// HandleContext is the context aware handler for GuildMemberRemove events.
+func (eh guildMemberRemoveContextEventHandler) HandleContext(ctx context.Context, s *Session, i interface{}) {
+	if t, ok := i.(*GuildMemberRemove); ok {
+		eh(ctx, s, t)
+	}
+}
+
 // guildMemberUpdateEventHandler is an event handler for GuildMemberUpdate events.
 type guildMemberUpdateEventHandler func(*Session, *GuildMemberUpdate)
 
@@ -358,6 +724,27 @@ func (eh guildMemberUpdateEventHandler) Handle(s *Session, i interface{}) {
 	}
 }
 
+// guildMemberUpdateContextEventHandler is an event handler for GuildMemberUpdate events
+// that receives the context of the event.
+type guildMemberUpdateContextEventHandler func(context.Context, *Session, *GuildMemberUpdate)
+
+// Type returns the event type for GuildMemberUpdate events.
+func (eh guildMemberUpdateContextEventHandler) Type() string {
+	return guildMemberUpdateEventType
+}
+
+// Handle is the handler for GuildMemberUpdate events.
+func (eh guildMemberUpdateContextEventHandler) Handle(s *Session, i interface{}) {
+	eh.HandleContext(context.Background(), s, i)
+}
+
+// HandleContext is the context aware handler for GuildMemberUpdate events.
+func (eh guildMemberUpdateContextEventHandler) HandleContext(ctx context.Context, s *Session, i interface{}) {
+	if t, ok := i.(*GuildMemberUpdate); ok {
+		eh(ctx, s, t)
+	}
+}
+
 // guildMembersChunkEventHandler is an event handler for GuildMembersChunk events.
 type guildMembersChunkEventHandler func(*Session, *GuildMembersChunk)
 
@@ -378,6 +765,63 @@ func (eh guildMembersChunkEventHandler) Handle(s *Session, i interface{}) {
 	}
 }
 
+// guildMembersChunkContextEventHandler is an event handler for GuildMembersChunk events
+// that receives the context of the event.
+type guildMembersChunkContextEventHandler func(context.Context, *Session, *GuildMembersChunk)
+
+// Type returns the event type for GuildMembersChunk events.
+func (eh guildMembersChunkContextEventHandler) Type() string {
+	return guildMembersChunkEventType
+}
+
+// Handle is the handler for GuildMembersChunk events.
+func (eh guildMembersChunkContextEventHandler) Handle(s *Session, i interface{}) {
+	eh.HandleContext(context.Background(), s, i)
+}
+
+// HandleContext is the context aware handler for GuildMembersChunk events.
+func (eh guildMembersChunkContextEventHandler) HandleContext(ctx context.Context, s *Session, i interface{}) {
+	if t, ok := i.(*GuildMembersChunk); ok {
+		eh(ctx, s, t)
+	}
+}
+
+// guildOutageEventHandler is an event handler for GuildOutage events.
+type guildOutageEventHandler func(*Session, *GuildOutage)
+
+// Type returns the event type for GuildOutage events.
+func (eh guildOutageEventHandler) Type() string {
+	return guildOutageEventType
+}
+
+// Handle is the handler for GuildOutage events.
+func (eh guildOutageEventHandler) Handle(s *Session, i interface{}) {
+	if t, ok := i.(*GuildOutage); ok {
+		eh(s, t)
+	}
+}
+
+// guildOutageContextEventHandler is an event handler for GuildOutage events
+// that receives the context of the event.
+type guildOutageContextEventHandler func(context.Context, *Session, *GuildOutage)
+
+// Type returns the event type for GuildOutage events.
+func (eh guildOutageContextEventHandler) Type() string {
+	return guildOutageEventType
+}
+
+// Handle is the handler for GuildOutage events.
+func (eh guildOutageContextEventHandler) Handle(s *Session, i interface{}) {
+	eh.HandleContext(context.Background(), s, i)
+}
+
+// HandleContext is the context aware handler for GuildOutage events.
+func (eh guildOutageContextEventHandler) HandleContext(ctx context.Context, s *Session, i interface{}) {
+	if t, ok := i.(*GuildOutage); ok {
+		eh(ctx, s, t)
+	}
+}
+
 // guildRoleCreateEventHandler is an event handler for GuildRoleCreate events.
 type guildRoleCreateEventHandler func(*Session, *GuildRoleCreate)
 
@@ -398,6 +842,27 @@ func (eh guildRoleCreateEventHandler) Handle(s *Session, i interface{}) {
 	}
 }
 
+// guildRoleCreateContextEventHandler is an event handler for GuildRoleCreate events
+// that receives the context of the event.
+type guildRoleCreateContextEventHandler func(context.Context, *Session, *GuildRoleCreate)
+
+// Type returns the event type for GuildRoleCreate events.
+func (eh guildRoleCreateContextEventHandler) Type() string {
+	return guildRoleCreateEventType
+}
+
+// Handle is the handler for GuildRoleCreate events.
+func (eh guildRoleCreateContextEventHandler) Handle(s *Session, i interface{}) {
+	eh.HandleContext(context.Background(), s, i)
+}
+
+// HandleContext is the context aware handler for GuildRoleCreate events.
+func (eh guildRoleCreateContextEventHandler) HandleContext(ctx context.Context, s *Session, i interface{}) {
+	if t, ok := i.(*GuildRoleCreate); ok {
+		eh(ctx, s, t)
+	}
+}
+
 // guildRoleDeleteEventHandler is an event handler for GuildRoleDelete events.
 type guildRoleDeleteEventHandler func(*Session, *GuildRoleDelete)
 
@@ -418,16 +883,37 @@ func (eh guildRoleDeleteEventHandler) Handle(s *Session, i interface{}) {
 	}
 }
 
-// guildRoleUpdateEventHandler is an event handler for GuildRoleUpdate events.
-type guildRoleUpdateEventHandler func(*Session, *GuildRoleUpdate)
+// guildRoleDeleteContextEventHandler is an event handler for GuildRoleDelete events
+// that receives the context of the event.
+type guildRoleDeleteContextEventHandler func(context.Context, *Session, *GuildRoleDelete)
 
-// Type returns the event type for GuildRoleUpdate events.
-func (eh guildRoleUpdateEventHandler) Type() string {
-	return guildRoleUpdateEventType
+// Type returns the event type for GuildRoleDelete events.
+func (eh guildRoleDeleteContextEventHandler) Type() string {
+	return guildRoleDeleteEventType
 }
 
-// New returns a new instance of GuildRoleUpdate.
-func (eh guildRoleUpdateEventHandler) New() interface{} {
+// Handle is the handler for GuildRoleDelete events.
+func (eh guildRoleDeleteContextEventHandler) Handle(s *Session, i interface{}) {
+	eh.HandleContext(context.Background(), s, i)
+}
+
+// HandleContext is the context aware handler for GuildRoleDelete events.
+func (eh guildRoleDeleteContextEventHandler) HandleContext(ctx context.Context, s *Session, i interface{}) {
+	if t, ok := i.(*GuildRoleDelete); ok {
+		eh(ctx, s, t)
+	}
+}
+
+// guildRoleUpdateEventHandler is an event handler for GuildRoleUpdate events.
+type guildRoleUpdateEventHandler func(*Session, *GuildRoleUpdate)
+
+// Type returns the event type for GuildRoleUpdate events.
+func (eh guildRoleUpdateEventHandler) Type() string {
+	return guildRoleUpdateEventType
+}
+
+// New returns a new instance of GuildRoleUpdate.
+func (eh guildRoleUpdateEventHandler) New() interface{} {
 	return &GuildRoleUpdate{}
 }
 
@@ -438,6 +924,27 @@ func (eh guildRoleUpdateEventHandler) Handle(s *Session, i interface{}) {
 	}
 }
 
+// guildRoleUpdateContextEventHandler is an event handler for GuildRoleUpdate events
+// that receives the context of the event.
+type guildRoleUpdateContextEventHandler func(context.Context, *Session, *GuildRoleUpdate)
+
+// Type returns the event type for GuildRoleUpdate events.
+func (eh guildRoleUpdateContextEventHandler) Type() string {
+	return guildRoleUpdateEventType
+}
+
+// Handle is the handler for GuildRoleUpdate events.
+func (eh guildRoleUpdateContextEventHandler) Handle(s *Session, i interface{}) {
+	eh.HandleContext(context.Background(), s, i)
+}
+
+// HandleContext is the context aware handler for GuildRoleUpdate events.
+func (eh guildRoleUpdateContextEventHandler) HandleContext(ctx context.Context, s *Session, i interface{}) {
+	if t, ok := i.(*GuildRoleUpdate); ok {
+		eh(ctx, s, t)
+	}
+}
+
 // guildUpdateEventHandler is an event handler for GuildUpdate events.
 type guildUpdateEventHandler func(*Session, *GuildUpdate)
 
@@ -458,6 +965,268 @@ func (eh guildUpdateEventHandler) Handle(s *Session, i interface{}) {
 	}
 }
 
+// guildUpdateContextEventHandler is an event handler for GuildUpdate events
+// that receives the context of the event.
+type guildUpdateContextEventHandler func(context.Context, *Session, *GuildUpdate)
+
+// Type returns the event type for GuildUpdate events.
+func (eh guildUpdateContextEventHandler) Type() string {
+	return guildUpdateEventType
+}
+
+// Handle is the handler for GuildUpdate events.
+func (eh guildUpdateContextEventHandler) Handle(s *Session, i interface{}) {
+	eh.HandleContext(context.Background(), s, i)
+}
+
+// HandleContext is the context aware handler for GuildUpdate events.
+func (eh guildUpdateContextEventHandler) HandleContext(ctx context.Context, s *Session, i interface{}) {
+	if t, ok := i.(*GuildUpdate); ok {
+		eh(ctx, s, t)
+	}
+}
+
+// integrationCreateEventHandler is an event handler for IntegrationCreate events.
+type integrationCreateEventHandler func(*Session, *IntegrationCreate)
+
+// Type returns the event type for IntegrationCreate events.
+func (eh integrationCreateEventHandler) Type() string {
+	return integrationCreateEventType
+}
+
+// New returns a new instance of IntegrationCreate.
+func (eh integrationCreateEventHandler) New() interface{} {
+	return &IntegrationCreate{}
+}
+
+// Handle is the handler for IntegrationCreate events.
+func (eh integrationCreateEventHandler) Handle(s *Session, i interface{}) {
+	if t, ok := i.(*IntegrationCreate); ok {
+		eh(s, t)
+	}
+}
+
+// integrationCreateContextEventHandler is an event handler for IntegrationCreate events
+// that receives the context of the event.
+type integrationCreateContextEventHandler func(context.Context, *Session, *IntegrationCreate)
+
+// Type returns the event type for IntegrationCreate events.
+func (eh integrationCreateContextEventHandler) Type() string {
+	return integrationCreateEventType
+}
+
+// Handle is the handler for IntegrationCreate events.
+func (eh integrationCreateContextEventHandler) Handle(s *Session, i interface{}) {
+	eh.HandleContext(context.Background(), s, i)
+}
+
+// HandleContext is the context aware handler for IntegrationCreate events.
+func (eh integrationCreateContextEventHandler) HandleContext(ctx context.Context, s *Session, i interface{}) {
+	if t, ok := i.(*IntegrationCreate); ok {
+		eh(ctx, s, t)
+	}
+}
+
+// integrationDeleteEventHandler is an event handler for IntegrationDelete events.
+type integrationDeleteEventHandler func(*Session, *IntegrationDelete)
+
+// Type returns the event type for IntegrationDelete events.
+func (eh integrationDeleteEventHandler) Type() string {
+	return integrationDeleteEventType
+}
+
+// New returns a new instance of IntegrationDelete.
+func (eh integrationDeleteEventHandler) New() interface{} {
+	return &IntegrationDelete{}
+}
+
+// Handle is the handler for IntegrationDelete events.
+func (eh integrationDeleteEventHandler) Handle(s *Session, i interface{}) {
+	if t, ok := i.(*IntegrationDelete); ok {
+		eh(s, t)
+	}
+}
+
+// integrationDeleteContextEventHandler is an event handler for IntegrationDelete events
+// that receives the context of the event.
+type integrationDeleteContextEventHandler func(context.Context, *Session, *IntegrationDelete)
+
+// Type returns the event type for IntegrationDelete events.
+func (eh integrationDeleteContextEventHandler) Type() string {
+	return integrationDeleteEventType
+}
+
+// Handle is the handler for IntegrationDelete events.
+func (eh integrationDeleteContextEventHandler) Handle(s *Session, i interface{}) {
+	eh.HandleContext(context.Background(), s, i)
+}
+
+// HandleContext is the context aware handler for IntegrationDelete events.
+func (eh integrationDeleteContextEventHandler) HandleContext(ctx context.Context, s *Session, i interface{}) {
+	if t, ok := i.(*IntegrationDelete); ok {
+		eh(ctx, s, t)
+	}
+}
+
+// integrationUpdateEventHandler is an event handler for IntegrationUpdate events.
+type integrationUpdateEventHandler func(*Session, *IntegrationUpdate)
+
+// Type returns the event type for IntegrationUpdate events.
+func (eh integrationUpdateEventHandler) Type() string {
+	return integrationUpdateEventType
+}
+
+// New returns a new instance of IntegrationUpdate.
+func (eh integrationUpdateEventHandler) New() interface{} {
+	return &IntegrationUpdate{}
+}
+
+// Handle is the handler for IntegrationUpdate events.
+func (eh integrationUpdateEventHandler) Handle(s *Session, i interface{}) {
+	if t, ok := i.(*IntegrationUpdate); ok {
+		eh(s, t)
+	}
+}
+
+// integrationUpdateContextEventHandler is an event handler for IntegrationUpdate events
+// that receives the context of the event.
+type integrationUpdateContextEventHandler func(context.Context, *Session, *IntegrationUpdate)
+
+// Type returns the event type for IntegrationUpdate events.
+func (eh integrationUpdateContextEventHandler) Type() string {
+	return integrationUpdateEventType
+}
+
+// Handle is the handler for IntegrationUpdate events.
+func (eh integrationUpdateContextEventHandler) Handle(s *Session, i interface{}) {
+	eh.HandleContext(context.Background(), s, i)
+}
+
+// HandleContext is the context aware handler for IntegrationUpdate events.
+func (eh integrationUpdateContextEventHandler) HandleContext(ctx context.Context, s *Session, i interface{}) {
+	if t, ok := i.(*IntegrationUpdate); ok {
+		eh(ctx, s, t)
+	}
+}
+
+// interactionCreateEventHandler is an event handler for InteractionCreate events.
+type interactionCreateEventHandler func(*Session, *InteractionCreate)
+
+// Type returns the event type for InteractionCreate events.
+func (eh interactionCreateEventHandler) Type() string {
+	return interactionCreateEventType
+}
+
+// New returns a new instance of InteractionCreate.
+func (eh interactionCreateEventHandler) New() interface{} {
+	return &InteractionCreate{}
+}
+
+// Handle is the handler for InteractionCreate events.
+func (eh interactionCreateEventHandler) Handle(s *Session, i interface{}) {
+	if t, ok := i.(*InteractionCreate); ok {
+		eh(s, t)
+	}
+}
+
+// interactionCreateContextEventHandler is an event handler for InteractionCreate events
+// that receives the context of the event.
+type interactionCreateContextEventHandler func(context.Context, *Session, *InteractionCreate)
+
+// Type returns the event type for InteractionCreate events.
+func (eh interactionCreateContextEventHandler) Type() string {
+	return interactionCreateEventType
+}
+
+// Handle is the handler for InteractionCreate events.
+func (eh interactionCreateContextEventHandler) Handle(s *Session, i interface{}) {
+	eh.HandleContext(context.Background(), s, i)
+}
+
+// HandleContext is the context aware handler for InteractionCreate events.
+func (eh interactionCreateContextEventHandler) HandleContext(ctx context.Context, s *Session, i interface{}) {
+	if t, ok := i.(*InteractionCreate); ok {
+		eh(ctx, s, t)
+	}
+}
+
+// invalidRequestLimitEventHandler is an event handler for InvalidRequestLimit events.
+type invalidRequestLimitEventHandler func(*Session, *InvalidRequestLimit)
+
+// Type returns the event type for InvalidRequestLimit events.
+func (eh invalidRequestLimitEventHandler) Type() string {
+	return invalidRequestLimitEventType
+}
+
+// Handle is the handler for InvalidRequestLimit events.
+func (eh invalidRequestLimitEventHandler) Handle(s *Session, i interface{}) {
+	if t, ok := i.(*InvalidRequestLimit); ok {
+		eh(s, t)
+	}
+}
+
+// invalidRequestLimitContextEventHandler is an event handler for InvalidRequestLimit events
+// that receives the context of the event.
+type invalidRequestLimitContextEventHandler func(context.Context, *Session, *InvalidRequestLimit)
+
+// Type returns the event type for InvalidRequestLimit events.
+func (eh invalidRequestLimitContextEventHandler) Type() string {
+	return invalidRequestLimitEventType
+}
+
+// Handle is the handler for InvalidRequestLimit events.
+func (eh invalidRequestLimitContextEventHandler) Handle(s *Session, i interface{}) {
+	eh.HandleContext(context.Background(), s, i)
+}
+
+// HandleContext is the context aware handler for InvalidRequestLimit events.
+func (eh invalidRequestLimitContextEventHandler) HandleContext(ctx context.Context, s *Session, i interface{}) {
+	if t, ok := i.(*InvalidRequestLimit); ok {
+		eh(ctx, s, t)
+	}
+}
+
+// memberGatedEventHandler is an event handler for MemberGated events.
+type memberGatedEventHandler func(*Session, *MemberGated)
+
+// Type returns the event type for MemberGated events.
+func (eh memberGatedEventHandler) Type() string {
+	return memberGatedEventType
+}
+
+// New returns a new instance of MemberGated.
+func (eh memberGatedEventHandler) New() interface{} {
+	return &MemberGated{}
+}
+
+// Handle is the handler for MemberGated events.
+func (eh memberGatedEventHandler) Handle(s *Session, i interface{}) {
+	if t, ok := i.(*MemberGated); ok {
+		eh(s, t)
+	}
+}
+
+// memberGatedContextEventHandler is an event handler for MemberGated events
+// that receives the context of the event.
+type memberGatedContextEventHandler func(context.Context, *Session, *MemberGated)
+
+// Type returns the event type for MemberGated events.
+func (eh memberGatedContextEventHandler) Type() string {
+	return memberGatedEventType
+}
+
+// Handle is the handler for MemberGated events.
+func (eh memberGatedContextEventHandler) Handle(s *Session, i interface{}) {
+	eh.HandleContext(context.Background(), s, i)
+}
+
+// HandleContext is the context aware handler for MemberGated events.
+func (eh memberGatedContextEventHandler) HandleContext(ctx context.Context, s *Session, i interface{}) {
+	if t, ok := i.(*MemberGated); ok {
+		eh(ctx, s, t)
+	}
+}
+
 // messageAckEventHandler is an event handler for MessageAck events.
 type messageAckEventHandler func(*Session, *MessageAck)
 
@@ -478,6 +1247,27 @@ func (eh messageAckEventHandler) Handle(s *Session, i interface{}) {
 	}
 }
 
+// messageAckContextEventHandler is an event handler for MessageAck events
+// that receives the context of the event.
+type messageAckContextEventHandler func(context.Context, *Session, *MessageAck)
+
+// Type returns the event type for MessageAck events.
+func (eh messageAckContextEventHandler) Type() string {
+	return messageAckEventType
+}
+
+// Handle is the handler for MessageAck events.
+func (eh messageAckContextEventHandler) Handle(s *Session, i interface{}) {
+	eh.HandleContext(context.Background(), s, i)
+}
+
+// HandleContext is the context aware handler for MessageAck events.
+func (eh messageAckContextEventHandler) HandleContext(ctx context.Context, s *Session, i interface{}) {
+	if t, ok := i.(*MessageAck); ok {
+		eh(ctx, s, t)
+	}
+}
+
 // messageCreateEventHandler is an event handler for MessageCreate events.
 type messageCreateEventHandler func(*Session, *MessageCreate)
 
@@ -498,6 +1288,27 @@ func (eh messageCreateEventHandler) Handle(s *Session, i interface{}) {
 	}
 }
 
+// messageCreateContextEventHandler is an event handler for MessageCreate events
+// that receives the context of the event.
+type messageCreateContextEventHandler func(context.Context, *Session, *MessageCreate)
+
+// Type returns the event type for MessageCreate events.
+func (eh messageCreateContextEventHandler) Type() string {
+	return messageCreateEventType
+}
+
+// Handle is the handler for MessageCreate events.
+func (eh messageCreateContextEventHandler) Handle(s *Session, i interface{}) {
+	eh.HandleContext(context.Background(), s, i)
+}
+
+// HandleContext is the context aware handler for MessageCreate events.
+func (eh messageCreateContextEventHandler) HandleContext(ctx context.Context, s *Session, i interface{}) {
+	if t, ok := i.(*MessageCreate); ok {
+		eh(ctx, s, t)
+	}
+}
+
 // messageDeleteEventHandler is an event handler for MessageDelete events.
 type messageDeleteEventHandler func(*Session, *MessageDelete)
 
@@ -518,6 +1329,27 @@ func (eh messageDeleteEventHandler) Handle(s *Session, i interface{}) {
 	}
 }
 
+// messageDeleteContextEventHandler is an event handler for MessageDelete events
+// that receives the context of the event.
+type messageDeleteContextEventHandler func(context.Context, *Session, *MessageDelete)
+
+// Type returns the event type for MessageDelete events.
+func (eh messageDeleteContextEventHandler) Type() string {
+	return messageDeleteEventType
+}
+
+// Handle is the handler for MessageDelete events.
+func (eh messageDeleteContextEventHandler) Handle(s *Session, i interface{}) {
+	eh.HandleContext(context.Background(), s, i)
+}
+
+// HandleContext is the context aware handler for MessageDelete events.
+func (eh messageDeleteContextEventHandler) HandleContext(ctx context.Context, s *Session, i interface{}) {
+	if t, ok := i.(*MessageDelete); ok {
+		eh(ctx, s, t)
+	}
+}
+
 // messageDeleteBulkEventHandler is an event handler for MessageDeleteBulk events.
 type messageDeleteBulkEventHandler func(*Session, *MessageDeleteBulk)
 
@@ -538,6 +1370,27 @@ func (eh messageDeleteBulkEventHandler) Handle(s *Session, i interface{}) {
 	}
 }
 
+// messageDeleteBulkContextEventHandler is an event handler for MessageDeleteBulk events
+// that receives the context of the event.
+type messageDeleteBulkContextEventHandler func(context.Context, *Session, *MessageDeleteBulk)
+
+// Type returns the event type for MessageDeleteBulk events.
+func (eh messageDeleteBulkContextEventHandler) Type() string {
+	return messageDeleteBulkEventType
+}
+
+// Handle is the handler for MessageDeleteBulk events.
+func (eh messageDeleteBulkContextEventHandler) Handle(s *Session, i interface{}) {
+	eh.HandleContext(context.Background(), s, i)
+}
+
+// HandleContext is the context aware handler for MessageDeleteBulk events.
+func (eh messageDeleteBulkContextEventHandler) HandleContext(ctx context.Context, s *Session, i interface{}) {
+	if t, ok := i.(*MessageDeleteBulk); ok {
+		eh(ctx, s, t)
+	}
+}
+
 // messageReactionAddEventHandler is an event handler for MessageReactionAdd events.
 type messageReactionAddEventHandler func(*Session, *MessageReactionAdd)
 
@@ -558,6 +1411,27 @@ func (eh messageReactionAddEventHandler) Handle(s *Session, i interface{}) {
 	}
 }
 
+// messageReactionAddContextEventHandler is an event handler for MessageReactionAdd events
+// that receives the context of the event.
+type messageReactionAddContextEventHandler func(context.Context, *Session, *MessageReactionAdd)
+
+// Type returns the event type for MessageReactionAdd events.
+func (eh messageReactionAddContextEventHandler) Type() string {
+	return messageReactionAddEventType
+}
+
+// Handle is the handler for MessageReactionAdd events.
+func (eh messageReactionAddContextEventHandler) Handle(s *Session, i interface{}) {
+	eh.HandleContext(context.Background(), s, i)
+}
+
+// HandleContext is the context aware handler for MessageReactionAdd events.
+func (eh messageReactionAddContextEventHandler) HandleContext(ctx context.Context, s *Session, i interface{}) {
+	if t, ok := i.(*MessageReactionAdd); ok {
+		eh(ctx, s, t)
+	}
+}
+
 // messageReactionRemoveEventHandler is an event handler for MessageReactionRemove events.
 type messageReactionRemoveEventHandler func(*Session, *MessageReactionRemove)
 
@@ -578,6 +1452,27 @@ func (eh messageReactionRemoveEventHandler) Handle(s *Session, i interface{}) {
 	}
 }
 
+// messageReactionRemoveContextEventHandler is an event handler for MessageReactionRemove events
+// that receives the context of the event.
+type messageReactionRemoveContextEventHandler func(context.Context, *Session, *MessageReactionRemove)
+
+// Type returns the event type for MessageReactionRemove events.
+func (eh messageReactionRemoveContextEventHandler) Type() string {
+	return messageReactionRemoveEventType
+}
+
+// Handle is the handler for MessageReactionRemove events.
+func (eh messageReactionRemoveContextEventHandler) Handle(s *Session, i interface{}) {
+	eh.HandleContext(context.Background(), s, i)
+}
+
+// HandleContext is the context aware handler for MessageReactionRemove events.
+func (eh messageReactionRemoveContextEventHandler) HandleContext(ctx context.Context, s *Session, i interface{}) {
+	if t, ok := i.(*MessageReactionRemove); ok {
+		eh(ctx, s, t)
+	}
+}
+
 // messageReactionRemoveAllEventHandler is an event handler for MessageReactionRemoveAll events.
 type messageReactionRemoveAllEventHandler func(*Session, *MessageReactionRemoveAll)
 
@@ -598,6 +1493,68 @@ func (eh messageReactionRemoveAllEventHandler) Handle(s *Session, i interface{})
 	}
 }
 
+// messageReactionRemoveAllContextEventHandler is an event handler for MessageReactionRemoveAll events
+// that receives the context of the event.
+type messageReactionRemoveAllContextEventHandler func(context.Context, *Session, *MessageReactionRemoveAll)
+
+// Type returns the event type for MessageReactionRemoveAll events.
+func (eh messageReactionRemoveAllContextEventHandler) Type() string {
+	return messageReactionRemoveAllEventType
+}
+
+// Handle is the handler for MessageReactionRemoveAll events.
+func (eh messageReactionRemoveAllContextEventHandler) Handle(s *Session, i interface{}) {
+	eh.HandleContext(context.Background(), s, i)
+}
+
+// HandleContext is the context aware handler for MessageReactionRemoveAll events.
+func (eh messageReactionRemoveAllContextEventHandler) HandleContext(ctx context.Context, s *Session, i interface{}) {
+	if t, ok := i.(*MessageReactionRemoveAll); ok {
+		eh(ctx, s, t)
+	}
+}
+
+// messageReactionRemoveEmojiEventHandler is an event handler for MessageReactionRemoveEmoji events.
+type messageReactionRemoveEmojiEventHandler func(*Session, *MessageReactionRemoveEmoji)
+
+// Type returns the event type for MessageReactionRemoveEmoji events.
+func (eh messageReactionRemoveEmojiEventHandler) Type() string {
+	return messageReactionRemoveEmojiEventType
+}
+
+// New returns a new instance of MessageReactionRemoveEmoji.
+func (eh messageReactionRemoveEmojiEventHandler) New() interface{} {
+	return &MessageReactionRemoveEmoji{}
+}
+
+// Handle is the handler for MessageReactionRemoveEmoji events.
+func (eh messageReactionRemoveEmojiEventHandler) Handle(s *Session, i interface{}) {
+	if t, ok := i.(*MessageReactionRemoveEmoji); ok {
+		eh(s, t)
+	}
+}
+
+// messageReactionRemoveEmojiContextEventHandler is an event handler for MessageReactionRemoveEmoji events
+// that receives the context of the event.
+type messageReactionRemoveEmojiContextEventHandler func(context.Context, *Session, *MessageReactionRemoveEmoji)
+
+// Type returns the event type for MessageReactionRemoveEmoji events.
+func (eh messageReactionRemoveEmojiContextEventHandler) Type() string {
+	return messageReactionRemoveEmojiEventType
+}
+
+// Handle is the handler for MessageReactionRemoveEmoji events.
+func (eh messageReactionRemoveEmojiContextEventHandler) Handle(s *Session, i interface{}) {
+	eh.HandleContext(context.Background(), s, i)
+}
+
+// HandleContext is the context aware handler for MessageReactionRemoveEmoji events.
+func (eh messageReactionRemoveEmojiContextEventHandler) HandleContext(ctx context.Context, s *Session, i interface{}) {
+	if t, ok := i.(*MessageReactionRemoveEmoji); ok {
+		eh(ctx, s, t)
+	}
+}
+
 // messageUpdateEventHandler is an event handler for MessageUpdate events.
 type messageUpdateEventHandler func(*Session, *MessageUpdate)
 
@@ -618,6 +1575,27 @@ func (eh messageUpdateEventHandler) Handle(s *Session, i interface{}) {
 	}
 }
 
+// messageUpdateContextEventHandler is an event handler for MessageUpdate events
+// that receives the context of the event.
+type messageUpdateContextEventHandler func(context.Context, *Session, *MessageUpdate)
+
+// Type returns the event type for MessageUpdate events.
+func (eh messageUpdateContextEventHandler) Type() string {
+	return messageUpdateEventType
+}
+
+// Handle is the handler for MessageUpdate events.
+func (eh messageUpdateContextEventHandler) Handle(s *Session, i interface{}) {
+	eh.HandleContext(context.Background(), s, i)
+}
+
+// HandleContext is the context aware handler for MessageUpdate events.
+func (eh messageUpdateContextEventHandler) HandleContext(ctx context.Context, s *Session, i interface{}) {
+	if t, ok := i.(*MessageUpdate); ok {
+		eh(ctx, s, t)
+	}
+}
+
 // presenceUpdateEventHandler is an event handler for PresenceUpdate events.
 type presenceUpdateEventHandler func(*Session, *PresenceUpdate)
 
@@ -638,6 +1616,27 @@ func (eh presenceUpdateEventHandler) Handle(s *Session, i interface{}) {
 	}
 }
 
+// presenceUpdateContextEventHandler is an event handler for PresenceUpdate events
+// that receives the context of the event.
+type presenceUpdateContextEventHandler func(context.Context, *Session, *PresenceUpdate)
+
+// Type returns the event type for PresenceUpdate events.
+func (eh presenceUpdateContextEventHandler) Type() string {
+	return presenceUpdateEventType
+}
+
+// Handle is the handler for PresenceUpdate events.
+func (eh presenceUpdateContextEventHandler) Handle(s *Session, i interface{}) {
+	eh.HandleContext(context.Background(), s, i)
+}
+
+// HandleContext is the context aware handler for PresenceUpdate events.
+func (eh presenceUpdateContextEventHandler) HandleContext(ctx context.Context, s *Session, i interface{}) {
+	if t, ok := i.(*PresenceUpdate); ok {
+		eh(ctx, s, t)
+	}
+}
+
 // presencesReplaceEventHandler is an event handler for PresencesReplace events.
 type presencesReplaceEventHandler func(*Session, *PresencesReplace)
 
@@ -658,6 +1657,27 @@ func (eh presencesReplaceEventHandler) Handle(s *Session, i interface{}) {
 	}
 }
 
+// presencesReplaceContextEventHandler is an event handler for PresencesReplace events
+// that receives the context of the event.
+type presencesReplaceContextEventHandler func(context.Context, *Session, *PresencesReplace)
+
+// Type returns the event type for PresencesReplace events.
+func (eh presencesReplaceContextEventHandler) Type() string {
+	return presencesReplaceEventType
+}
+
+// Handle is the handler for PresencesReplace events.
+func (eh presencesReplaceContextEventHandler) Handle(s *Session, i interface{}) {
+	eh.HandleContext(context.Background(), s, i)
+}
+
+// HandleContext is the context aware handler for PresencesReplace events.
+func (eh presencesReplaceContextEventHandler) HandleContext(ctx context.Context, s *Session, i interface{}) {
+	if t, ok := i.(*PresencesReplace); ok {
+		eh(ctx, s, t)
+	}
+}
+
 // rateLimitEventHandler is an event handler for RateLimit events.
 type rateLimitEventHandler func(*Session, *RateLimit)
 
@@ -673,6 +1693,27 @@ func (eh rateLimitEventHandler) Handle(s *Session, i interface{}) {
 	}
 }
 
+// rateLimitContextEventHandler is an event handler for RateLimit events
+// that receives the context of the event.
+type rateLimitContextEventHandler func(context.Context, *Session, *RateLimit)
+
+// Type returns the event type for RateLimit events.
+func (eh rateLimitContextEventHandler) Type() string {
+	return rateLimitEventType
+}
+
+// Handle is the handler for RateLimit events.
+func (eh rateLimitContextEventHandler) Handle(s *Session, i interface{}) {
+	eh.HandleContext(context.Background(), s, i)
+}
+
+// HandleContext is the context aware handler for RateLimit events.
+func (eh rateLimitContextEventHandler) HandleContext(ctx context.Context, s *Session, i interface{}) {
+	if t, ok := i.(*RateLimit); ok {
+		eh(ctx, s, t)
+	}
+}
+
 // readyEventHandler is an event handler for Ready events.
 type readyEventHandler func(*Session, *Ready)
 
@@ -693,6 +1734,27 @@ func (eh readyEventHandler) Handle(s *Session, i interface{}) {
 	}
 }
 
+// readyContextEventHandler is an event handler for Ready events
+// that receives the context of the event.
+type readyContextEventHandler func(context.Context, *Session, *Ready)
+
+// Type returns the event type for Ready events.
+func (eh readyContextEventHandler) Type() string {
+	return readyEventType
+}
+
+// Handle is the handler for Ready events.
+func (eh readyContextEventHandler) Handle(s *Session, i interface{}) {
+	eh.HandleContext(context.Background(), s, i)
+}
+
+// HandleContext is the context aware handler for Ready events.
+func (eh readyContextEventHandler) HandleContext(ctx context.Context, s *Session, i interface{}) {
+	if t, ok := i.(*Ready); ok {
+		eh(ctx, s, t)
+	}
+}
+
 // relationshipAddEventHandler is an event handler for RelationshipAdd events.
 type relationshipAddEventHandler func(*Session, *RelationshipAdd)
 
@@ -713,6 +1775,27 @@ func (eh relationshipAddEventHandler) Handle(s *Session, i interface{}) {
 	}
 }
 
+// relationshipAddContextEventHandler is an event handler for RelationshipAdd events
+// that receives the context of the event.
+type relationshipAddContextEventHandler func(context.Context, *Session, *RelationshipAdd)
+
+// Type returns the event type for RelationshipAdd events.
+func (eh relationshipAddContextEventHandler) Type() string {
+	return relationshipAddEventType
+}
+
+// Handle is the handler for RelationshipAdd events.
+func (eh relationshipAddContextEventHandler) Handle(s *Session, i interface{}) {
+	eh.HandleContext(context.Background(), s, i)
+}
+
+// HandleContext is the context aware handler for RelationshipAdd events.
+func (eh relationshipAddContextEventHandler) HandleContext(ctx context.Context, s *Session, i interface{}) {
+	if t, ok := i.(*RelationshipAdd); ok {
+		eh(ctx, s, t)
+	}
+}
+
 // relationshipRemoveEventHandler is an event handler for RelationshipRemove events.
 type relationshipRemoveEventHandler func(*Session, *RelationshipRemove)
 
@@ -733,6 +1816,27 @@ func (eh relationshipRemoveEventHandler) Handle(s *Session, i interface{}) {
 	}
 }
 
+// relationshipRemoveContextEventHandler is an event handler for RelationshipRemove events
+// that receives the context of the event.
+type relationshipRemoveContextEventHandler func(context.Context, *Session, *RelationshipRemove)
+
+// Type returns the event type for RelationshipRemove events.
+func (eh relationshipRemoveContextEventHandler) Type() string {
+	return relationshipRemoveEventType
+}
+
+// Handle is the handler for RelationshipRemove events.
+func (eh relationshipRemoveContextEventHandler) Handle(s *Session, i interface{}) {
+	eh.HandleContext(context.Background(), s, i)
+}
+
+// HandleContext is the context aware handler for RelationshipRemove events.
+func (eh relationshipRemoveContextEventHandler) HandleContext(ctx context.Context, s *Session, i interface{}) {
+	if t, ok := i.(*RelationshipRemove); ok {
+		eh(ctx, s, t)
+	}
+}
+
 // resumedEventHandler is an event handler for Resumed events.
 type resumedEventHandler func(*Session, *Resumed)
 
@@ -753,6 +1857,104 @@ func (eh resumedEventHandler) Handle(s *Session, i interface{}) {
 	}
 }
 
+// resumedContextEventHandler is an event handler for Resumed events
+// that receives the context of the event.
+type resumedContextEventHandler func(context.Context, *Session, *Resumed)
+
+// Type returns the event type for Resumed events.
+func (eh resumedContextEventHandler) Type() string {
+	return resumedEventType
+}
+
+// Handle is the handler for Resumed events.
+func (eh resumedContextEventHandler) Handle(s *Session, i interface{}) {
+	eh.HandleContext(context.Background(), s, i)
+}
+
+// HandleContext is the context aware handler for Resumed events.
+func (eh resumedContextEventHandler) HandleContext(ctx context.Context, s *Session, i interface{}) {
+	if t, ok := i.(*Resumed); ok {
+		eh(ctx, s, t)
+	}
+}
+
+// spamDetectedEventHandler is an event handler for SpamDetected events.
+type spamDetectedEventHandler func(*Session, *SpamDetected)
+
+// Type returns the event type for SpamDetected events.
+func (eh spamDetectedEventHandler) Type() string {
+	return spamDetectedEventType
+}
+
+// Handle is the handler for SpamDetected events.
+func (eh spamDetectedEventHandler) Handle(s *Session, i interface{}) {
+	if t, ok := i.(*SpamDetected); ok {
+		eh(s, t)
+	}
+}
+
+// spamDetectedContextEventHandler is an event handler for SpamDetected events
+// that receives the context of the event.
+type spamDetectedContextEventHandler func(context.Context, *Session, *SpamDetected)
+
+// Type returns the event type for SpamDetected events.
+func (eh spamDetectedContextEventHandler) Type() string {
+	return spamDetectedEventType
+}
+
+// Handle is the handler for SpamDetected events.
+func (eh spamDetectedContextEventHandler) Handle(s *Session, i interface{}) {
+	eh.HandleContext(context.Background(), s, i)
+}
+
+// HandleContext is the context aware handler for SpamDetected events.
+func (eh spamDetectedContextEventHandler) HandleContext(ctx context.Context, s *Session, i interface{}) {
+	if t, ok := i.(*SpamDetected); ok {
+		eh(ctx, s, t)
+	}
+}
+
+// threadCreateEventHandler is an event handler for ThreadCreate events.
+type threadCreateEventHandler func(*Session, *ThreadCreate)
+
+// Type returns the event type for ThreadCreate events.
+func (eh threadCreateEventHandler) Type() string {
+	return threadCreateEventType
+}
+
+// New returns a new instance of ThreadCreate.
+func (eh threadCreateEventHandler) New() interface{} {
+	return &ThreadCreate{}
+}
+
+// Handle is the handler for ThreadCreate events.
+func (eh threadCreateEventHandler) Handle(s *Session, i interface{}) {
+	if t, ok := i.(*ThreadCreate); ok {
+		eh(s, t)
+	}
+}
+
+// threadCreateContextEventHandler is an event handler for ThreadCreate events
+// that receives the context of the event.
+type threadCreateContextEventHandler func(context.Context, *Session, *ThreadCreate)
+
+// Type returns the event type for ThreadCreate events.
+func (eh threadCreateContextEventHandler) Type() string {
+	return threadCreateEventType
+}
+
+// Handle is the handler for ThreadCreate events.
+func (eh threadCreateContextEventHandler) Handle(s *Session, i interface{}) {
+	eh.HandleContext(context.Background(), s, i)
+}
+
+// HandleContext is the context aware handler for ThreadCreate events.
+func (eh threadCreateContextEventHandler) HandleContext(ctx context.Context, s *Session, i interface{}) {
+	if t, ok := i.(*ThreadCreate); ok {
+		eh(ctx, s, t)
+	}
+}
+
 // typingStartEventHandler is an event handler for TypingStart events.
 type typingStartEventHandler func(*Session, *TypingStart)
 
@@ -773,6 +1975,27 @@ func (eh typingStartEventHandler) Handle(s *Session, i interface{}) {
 	}
 }
 
+// typingStartContextEventHandler is an event handler for TypingStart events
+// that receives the context of the event.
+type typingStartContextEventHandler func(context.Context, *Session, *TypingStart)
+
+// Type returns the event type for TypingStart events.
+func (eh typingStartContextEventHandler) Type() string {
+	return typingStartEventType
+}
+
+// Handle is the handler for TypingStart events.
+func (eh typingStartContextEventHandler) Handle(s *Session, i interface{}) {
+	eh.HandleContext(context.Background(), s, i)
+}
+
+// HandleContext is the context aware handler for TypingStart events.
+func (eh typingStartContextEventHandler) HandleContext(ctx context.Context, s *Session, i interface{}) {
+	if t, ok := i.(*TypingStart); ok {
+		eh(ctx, s, t)
+	}
+}
+
 // userGuildSettingsUpdateEventHandler is an event handler for UserGuildSettingsUpdate events.
 type userGuildSettingsUpdateEventHandler func(*Session, *UserGuildSettingsUpdate)
 
@@ -793,6 +2016,27 @@ func (eh userGuildSettingsUpdateEventHandler) Handle(s *Session, i interface{})
 	}
 }
 
+// userGuildSettingsUpdateContextEventHandler is an event handler for UserGuildSettingsUpdate events
+// that receives the context of the event.
+type userGuildSettingsUpdateContextEventHandler func(context.Context, *Session, *UserGuildSettingsUpdate)
+
+// Type returns the event type for UserGuildSettingsUpdate events.
+func (eh userGuildSettingsUpdateContextEventHandler) Type() string {
+	return userGuildSettingsUpdateEventType
+}
+
+// Handle is the handler for UserGuildSettingsUpdate events.
+func (eh userGuildSettingsUpdateContextEventHandler) Handle(s *Session, i interface{}) {
+	eh.HandleContext(context.Background(), s, i)
+}
+
+// HandleContext is the context aware handler for UserGuildSettingsUpdate events.
+func (eh userGuildSettingsUpdateContextEventHandler) HandleContext(ctx context.Context, s *Session, i interface{}) {
+	if t, ok := i.(*UserGuildSettingsUpdate); ok {
+		eh(ctx, s, t)
+	}
+}
+
 // userNoteUpdateEventHandler is an event handler for UserNoteUpdate events.
 type userNoteUpdateEventHandler func(*Session, *UserNoteUpdate)
 
@@ -813,6 +2057,27 @@ func (eh userNoteUpdateEventHandler) Handle(s *Session, i interface{}) {
 	}
 }
 
+// userNoteUpdateContextEventHandler is an event handler for UserNoteUpdate events
+// that receives the context of the event.
+type userNoteUpdateContextEventHandler func(context.Context, *Session, *UserNoteUpdate)
+
+// Type returns the event type for UserNoteUpdate events.
+func (eh userNoteUpdateContextEventHandler) Type() string {
+	return userNoteUpdateEventType
+}
+
+// Handle is the handler for UserNoteUpdate events.
+func (eh userNoteUpdateContextEventHandler) Handle(s *Session, i interface{}) {
+	eh.HandleContext(context.Background(), s, i)
+}
+
+// HandleContext is the context aware handler for UserNoteUpdate events.
+func (eh userNoteUpdateContextEventHandler) HandleContext(ctx context.Context, s *Session, i interface{}) {
+	if t, ok := i.(*UserNoteUpdate); ok {
+		eh(ctx, s, t)
+	}
+}
+
 // userSettingsUpdateEventHandler is an event handler for UserSettingsUpdate events.
 type userSettingsUpdateEventHandler func(*Session, *UserSettingsUpdate)
 
@@ -833,6 +2098,27 @@ func (eh userSettingsUpdateEventHandler) Handle(s *Session, i interface{}) {
 	}
 }
 
+// userSettingsUpdateContextEventHandler is an event handler for UserSettingsUpdate events
+// that receives the context of the event.
+type userSettingsUpdateContextEventHandler func(context.Context, *Session, *UserSettingsUpdate)
+
+// Type returns the event type for UserSettingsUpdate events.
+func (eh userSettingsUpdateContextEventHandler) Type() string {
+	return userSettingsUpdateEventType
+}
+
+// Handle is the handler for UserSettingsUpdate events.
+func (eh userSettingsUpdateContextEventHandler) Handle(s *Session, i interface{}) {
+	eh.HandleContext(context.Background(), s, i)
+}
+
+// HandleContext is the context aware handler for UserSettingsUpdate events.
+func (eh userSettingsUpdateContextEventHandler) HandleContext(ctx context.Context, s *Session, i interface{}) {
+	if t, ok := i.(*UserSettingsUpdate); ok {
+		eh(ctx, s, t)
+	}
+}
+
 // userUpdateEventHandler is an event handler for UserUpdate events.
 type userUpdateEventHandler func(*Session, *UserUpdate)
 
@@ -853,6 +2139,99 @@ func (eh userUpdateEventHandler) Handle(s *Session, i interface{}) {
 	}
 }
 
+// userUpdateContextEventHandler is an event handler for UserUpdate events
+// that receives the context of the event.
+type userUpdateContextEventHandler func(context.Context, *Session, *UserUpdate)
+
+// Type returns the event type for UserUpdate events.
+func (eh userUpdateContextEventHandler) Type() string {
+	return userUpdateEventType
+}
+
+// Handle is the handler for UserUpdate events.
+func (eh userUpdateContextEventHandler) Handle(s *Session, i interface{}) {
+	eh.HandleContext(context.Background(), s, i)
+}
+
+// HandleContext is the context aware handler for UserUpdate events.
+func (eh userUpdateContextEventHandler) HandleContext(ctx context.Context, s *Session, i interface{}) {
+	if t, ok := i.(*UserUpdate); ok {
+		eh(ctx, s, t)
+	}
+}
+
+// voiceChannelEmptyEventHandler is an event handler for VoiceChannelEmpty events.
+type voiceChannelEmptyEventHandler func(*Session, *VoiceChannelEmpty)
+
+// Type returns the event type for VoiceChannelEmpty events.
+func (eh voiceChannelEmptyEventHandler) Type() string {
+	return voiceChannelEmptyEventType
+}
+
+// Handle is the handler for VoiceChannelEmpty events.
+func (eh voiceChannelEmptyEventHandler) Handle(s *Session, i interface{}) {
+	if t, ok := i.(*VoiceChannelEmpty); ok {
+		eh(s, t)
+	}
+}
+
+// voiceChannelEmptyContextEventHandler is an event handler for VoiceChannelEmpty events
+// that receives the context of the event.
+type voiceChannelEmptyContextEventHandler func(context.Context, *Session, *VoiceChannelEmpty)
+
+// Type returns the event type for VoiceChannelEmpty events.
+func (eh voiceChannelEmptyContextEventHandler) Type() string {
+	return voiceChannelEmptyEventType
+}
+
+// Handle is the handler for VoiceChannelEmpty events.
+func (eh voiceChannelEmptyContextEventHandler) Handle(s *Session, i interface{}) {
+	eh.HandleContext(context.Background(), s, i)
+}
+
+// HandleContext is the context aware handler for VoiceChannelEmpty events.
+func (eh voiceChannelEmptyContextEventHandler) HandleContext(ctx context.Context, s *Session, i interface{}) {
+	if t, ok := i.(*VoiceChannelEmpty); ok {
+		eh(ctx, s, t)
+	}
+}
+
+// voiceChannelFullEventHandler is an event handler for VoiceChannelFull events.
+type voiceChannelFullEventHandler func(*Session, *VoiceChannelFull)
+
+// Type returns the event type for VoiceChannelFull events.
+func (eh voiceChannelFullEventHandler) Type() string {
+	return voiceChannelFullEventType
+}
+
+// Handle is the handler for VoiceChannelFull events.
+func (eh voiceChannelFullEventHandler) Handle(s *Session, i interface{}) {
+	if t, ok := i.(*VoiceChannelFull); ok {
+		eh(s, t)
+	}
+}
+
+// voiceChannelFullContextEventHandler is an event handler for VoiceChannelFull events
+// that receives the context of the event.
+type voiceChannelFullContextEventHandler func(context.Context, *Session, *VoiceChannelFull)
+
+// Type returns the event type for VoiceChannelFull events.
+func (eh voiceChannelFullContextEventHandler) Type() string {
+	return voiceChannelFullEventType
+}
+
+// Handle is the handler for VoiceChannelFull events.
+func (eh voiceChannelFullContextEventHandler) Handle(s *Session, i interface{}) {
+	eh.HandleContext(context.Background(), s, i)
+}
+
+// HandleContext is the context aware handler for VoiceChannelFull events.
+func (eh voiceChannelFullContextEventHandler) HandleContext(ctx context.Context, s *Session, i interface{}) {
+	if t, ok := i.(*VoiceChannelFull); ok {
+		eh(ctx, s, t)
+	}
+}
+
 // voiceServerUpdateEventHandler is an event handler for VoiceServerUpdate events.
 type voiceServerUpdateEventHandler func(*Session, *VoiceServerUpdate)
 
@@ -873,6 +2252,27 @@ func (eh voiceServerUpdateEventHandler) Handle(s *Session, i interface{}) {
 	}
 }
 
+// voiceServerUpdateContextEventHandler is an event handler for VoiceServerUpdate events
+// that receives the context of the event.
+type voiceServerUpdateContextEventHandler func(context.Context, *Session, *VoiceServerUpdate)
+
+// Type returns the event type for VoiceServerUpdate events.
+func (eh voiceServerUpdateContextEventHandler) Type() string {
+	return voiceServerUpdateEventType
+}
+
+// Handle is the handler for VoiceServerUpdate events.
+func (eh voiceServerUpdateContextEventHandler) Handle(s *Session, i interface{}) {
+	eh.HandleContext(context.Background(), s, i)
+}
+
+// HandleContext is the context aware handler for VoiceServerUpdate events.
+func (eh voiceServerUpdateContextEventHandler) HandleContext(ctx context.Context, s *Session, i interface{}) {
+	if t, ok := i.(*VoiceServerUpdate); ok {
+		eh(ctx, s, t)
+	}
+}
+
 // voiceStateUpdateEventHandler is an event handler for VoiceStateUpdate events.
 type voiceStateUpdateEventHandler func(*Session, *VoiceStateUpdate)
 
@@ -893,6 +2293,27 @@ func (eh voiceStateUpdateEventHandler) Handle(s *Session, i interface{}) {
 	}
 }
 
+// voiceStateUpdateContextEventHandler is an event handler for VoiceStateUpdate events
+// that receives the context of the event.
+type voiceStateUpdateContextEventHandler func(context.Context, *Session, *VoiceStateUpdate)
+
+// Type returns the event type for VoiceStateUpdate events.
+func (eh voiceStateUpdateContextEventHandler) Type() string {
+	return voiceStateUpdateEventType
+}
+
+// Handle is the handler for VoiceStateUpdate events.
+func (eh voiceStateUpdateContextEventHandler) Handle(s *Session, i interface{}) {
+	eh.HandleContext(context.Background(), s, i)
+}
+
+// HandleContext is the context aware handler for VoiceStateUpdate events.
+func (eh voiceStateUpdateContextEventHandler) HandleContext(ctx context.Context, s *Session, i interface{}) {
+	if t, ok := i.(*VoiceStateUpdate); ok {
+		eh(ctx, s, t)
+	}
+}
+
 // webhooksUpdateEventHandler is an event handler for WebhooksUpdate events.
 type webhooksUpdateEventHandler func(*Session, *WebhooksUpdate)
 
@@ -913,98 +2334,261 @@ func (eh webhooksUpdateEventHandler) Handle(s *Session, i interface{}) {
 	}
 }
 
+// webhooksUpdateContextEventHandler is an event handler for WebhooksUpdate events
+// that receives the context of the event.
+type webhooksUpdateContextEventHandler func(context.Context, *Session, *WebhooksUpdate)
+
+// Type returns the event type for WebhooksUpdate events.
+func (eh webhooksUpdateContextEventHandler) Type() string {
+	return webhooksUpdateEventType
+}
+
+// Handle is the handler for WebhooksUpdate events.
+func (eh webhooksUpdateContextEventHandler) Handle(s *Session, i interface{}) {
+	eh.HandleContext(context.Background(), s, i)
+}
+
+// HandleContext is the context aware handler for WebhooksUpdate events.
+func (eh webhooksUpdateContextEventHandler) HandleContext(ctx context.Context, s *Session, i interface{}) {
+	if t, ok := i.(*WebhooksUpdate); ok {
+		eh(ctx, s, t)
+	}
+}
+
 func handlerForInterface(handler interface{}) EventHandler {
 	switch v := handler.(type) {
 	case func(*Session, interface{}):
 		return interfaceEventHandler(v)
+	case func(context.Context, *Session, interface{}):
+		return interfaceContextEventHandler(v)
+	case func(*Session, *BatchJobComplete):
+		return batchJobCompleteEventHandler(v)
+	case func(context.Context, *Session, *BatchJobComplete):
+		return batchJobCompleteContextEventHandler(v)
 	case func(*Session, *ChannelCreate):
 		return channelCreateEventHandler(v)
+	case func(context.Context, *Session, *ChannelCreate):
+		return channelCreateContextEventHandler(v)
 	case func(*Session, *ChannelDelete):
 		return channelDeleteEventHandler(v)
+	case func(context.Context, *Session, *ChannelDelete):
+		return channelDeleteContextEventHandler(v)
 	case func(*Session, *ChannelPinsUpdate):
 		return channelPinsUpdateEventHandler(v)
+	case func(context.Context, *Session, *ChannelPinsUpdate):
+		return channelPinsUpdateContextEventHandler(v)
 	case func(*Session, *ChannelUpdate):
 		return channelUpdateEventHandler(v)
+	case func(context.Context, *Session, *ChannelUpdate):
+		return channelUpdateContextEventHandler(v)
 	case func(*Session, *Connect):
 		return connectEventHandler(v)
+	case func(context.Context, *Session, *Connect):
+		return connectContextEventHandler(v)
 	case func(*Session, *Disconnect):
 		return disconnectEventHandler(v)
+	case func(context.Context, *Session, *Disconnect):
+		return disconnectContextEventHandler(v)
 	case func(*Session, *Event):
 		return eventEventHandler(v)
+	case func(context.Context, *Session, *Event):
+		return eventContextEventHandler(v)
 	case func(*Session, *GuildBanAdd):
 		return guildBanAddEventHandler(v)
+	case func(context.Context, *Session, *GuildBanAdd):
+		return guildBanAddContextEventHandler(v)
 	case func(*Session, *GuildBanRemove):
 		return guildBanRemoveEventHandler(v)
+	case func(context.Context, *Session, *GuildBanRemove):
+		return guildBanRemoveContextEventHandler(v)
 	case func(*Session, *GuildCreate):
 		return guildCreateEventHandler(v)
+	case func(context.Context, *Session, *GuildCreate):
+		return guildCreateContextEventHandler(v)
 	case func(*Session, *GuildDelete):
 		return guildDeleteEventHandler(v)
+	case func(context.Context, *Session, *GuildDelete):
+		return guildDeleteContextEventHandler(v)
 	case func(*Session, *GuildEmojisUpdate):
 		return guildEmojisUpdateEventHandler(v)
+	case func(context.Context, *Session, *GuildEmojisUpdate):
+		return guildEmojisUpdateContextEventHandler(v)
 	case func(*Session, *GuildIntegrationsUpdate):
 		return guildIntegrationsUpdateEventHandler(v)
+	case func(context.Context, *Session, *GuildIntegrationsUpdate):
+		return guildIntegrationsUpdateContextEventHandler(v)
 	case func(*Session, *GuildMemberAdd):
 		return guildMemberAddEventHandler(v)
+	case func(context.Context, *Session, *GuildMemberAdd):
+		return guildMemberAddContextEventHandler(v)
 	case func(*Session, *GuildMemberRemove):
 		return guildMemberRemoveEventHandler(v)
+	case func(context.Context, *Session, *GuildMemberRemove):
+		return guildMemberRemoveContextEventHandler(v)
 	case func(*Session, *GuildMemberUpdate):
 		return guildMemberUpdateEventHandler(v)
+	case func(context.Context, *Session, *GuildMemberUpdate):
+		return guildMemberUpdateContextEventHandler(v)
 	case func(*Session, *GuildMembersChunk):
 		return guildMembersChunkEventHandler(v)
+	case func(context.Context, *Session, *GuildMembersChunk):
+		return guildMembersChunkContextEventHandler(v)
+	case func(*Session, *GuildOutage):
+		return guildOutageEventHandler(v)
+	case func(context.Context, *Session, *GuildOutage):
+		return guildOutageContextEventHandler(v)
 	case func(*Session, *GuildRoleCreate):
 		return guildRoleCreateEventHandler(v)
+	case func(context.Context, *Session, *GuildRoleCreate):
+		return guildRoleCreateContextEventHandler(v)
 	case func(*Session, *GuildRoleDelete):
 		return guildRoleDeleteEventHandler(v)
+	case func(context.Context, *Session, *GuildRoleDelete):
+		return guildRoleDeleteContextEventHandler(v)
 	case func(*Session, *GuildRoleUpdate):
 		return guildRoleUpdateEventHandler(v)
+	case func(context.Context, *Session, *GuildRoleUpdate):
+		return guildRoleUpdateContextEventHandler(v)
 	case func(*Session, *GuildUpdate):
 		return guildUpdateEventHandler(v)
+	case func(context.Context, *Session, *GuildUpdate):
+		return guildUpdateContextEventHandler(v)
+	case func(*Session, *IntegrationCreate):
+		return integrationCreateEventHandler(v)
+	case func(context.Context, *Session, *IntegrationCreate):
+		return integrationCreateContextEventHandler(v)
+	case func(*Session, *IntegrationDelete):
+		return integrationDeleteEventHandler(v)
+	case func(context.Context, *Session, *IntegrationDelete):
+		return integrationDeleteContextEventHandler(v)
+	case func(*Session, *IntegrationUpdate):
+		return integrationUpdateEventHandler(v)
+	case func(context.Context, *Session, *IntegrationUpdate):
+		return integrationUpdateContextEventHandler(v)
+	case func(*Session, *InteractionCreate):
+		return interactionCreateEventHandler(v)
+	case func(context.Context, *Session, *InteractionCreate):
+		return interactionCreateContextEventHandler(v)
+	case func(*Session, *InvalidRequestLimit):
+		return invalidRequestLimitEventHandler(v)
+	case func(context.Context, *Session, *InvalidRequestLimit):
+		return invalidRequestLimitContextEventHandler(v)
+	case func(*Session, *MemberGated):
+		return memberGatedEventHandler(v)
+	case func(context.Context, *Session, *MemberGated):
+		return memberGatedContextEventHandler(v)
 	case func(*Session, *MessageAck):
 		return messageAckEventHandler(v)
+	case func(context.Context, *Session, *MessageAck):
+		return messageAckContextEventHandler(v)
 	case func(*Session, *MessageCreate):
 		return messageCreateEventHandler(v)
+	case func(context.Context, *Session, *MessageCreate):
+		return messageCreateContextEventHandler(v)
 	case func(*Session, *MessageDelete):
 		return messageDeleteEventHandler(v)
+	case func(context.Context, *Session, *MessageDelete):
+		return messageDeleteContextEventHandler(v)
 	case func(*Session, *MessageDeleteBulk):
 		return messageDeleteBulkEventHandler(v)
+	case func(context.Context, *Session, *MessageDeleteBulk):
+		return messageDeleteBulkContextEventHandler(v)
 	case func(*Session, *MessageReactionAdd):
 		return messageReactionAddEventHandler(v)
+	case func(context.Context, *Session, *MessageReactionAdd):
+		return messageReactionAddContextEventHandler(v)
 	case func(*Session, *MessageReactionRemove):
 		return messageReactionRemoveEventHandler(v)
+	case func(context.Context, *Session, *MessageReactionRemove):
+		return messageReactionRemoveContextEventHandler(v)
 	case func(*Session, *MessageReactionRemoveAll):
 		return messageReactionRemoveAllEventHandler(v)
+	case func(context.Context, *Session, *MessageReactionRemoveAll):
+		return messageReactionRemoveAllContextEventHandler(v)
+	case func(*Session, *MessageReactionRemoveEmoji):
+		return messageReactionRemoveEmojiEventHandler(v)
+	case func(context.Context, *Session, *MessageReactionRemoveEmoji):
+		return messageReactionRemoveEmojiContextEventHandler(v)
 	case func(*Session, *MessageUpdate):
 		return messageUpdateEventHandler(v)
+	case func(context.Context, *Session, *MessageUpdate):
+		return messageUpdateContextEventHandler(v)
 	case func(*Session, *PresenceUpdate):
 		return presenceUpdateEventHandler(v)
+	case func(context.Context, *Session, *PresenceUpdate):
+		return presenceUpdateContextEventHandler(v)
 	case func(*Session, *PresencesReplace):
 		return presencesReplaceEventHandler(v)
+	case func(context.Context, *Session, *PresencesReplace):
+		return presencesReplaceContextEventHandler(v)
 	case func(*Session, *RateLimit):
 		return rateLimitEventHandler(v)
+	case func(context.Context, *Session, *RateLimit):
+		return rateLimitContextEventHandler(v)
 	case func(*Session, *Ready):
 		return readyEventHandler(v)
+	case func(context.Context, *Session, *Ready):
+		return readyContextEventHandler(v)
 	case func(*Session, *RelationshipAdd):
 		return relationshipAddEventHandler(v)
+	case func(context.Context, *Session, *RelationshipAdd):
+		return relationshipAddContextEventHandler(v)
 	case func(*Session, *RelationshipRemove):
 		return relationshipRemoveEventHandler(v)
+	case func(context.Context, *Session, *RelationshipRemove):
+		return relationshipRemoveContextEventHandler(v)
 	case func(*Session, *Resumed):
 		return resumedEventHandler(v)
+	case func(context.Context, *Session, *Resumed):
+		return resumedContextEventHandler(v)
+	case func(*Session, *SpamDetected):
+		return spamDetectedEventHandler(v)
+	case func(context.Context, *Session, *SpamDetected):
+		return spamDetectedContextEventHandler(v)
+	case func(*Session, *ThreadCreate):
+		return threadCreateEventHandler(v)
+	case func(context.Context, *Session, *ThreadCreate):
+		return threadCreateContextEventHandler(v)
 	case func(*Session, *TypingStart):
 		return typingStartEventHandler(v)
+	case func(context.Context, *Session, *TypingStart):
+		return typingStartContextEventHandler(v)
 	case func(*Session, *UserGuildSettingsUpdate):
 		return userGuildSettingsUpdateEventHandler(v)
+	case func(context.Context, *Session, *UserGuildSettingsUpdate):
+		return userGuildSettingsUpdateContextEventHandler(v)
 	case func(*Session, *UserNoteUpdate):
 		return userNoteUpdateEventHandler(v)
+	case func(context.Context, *Session, *UserNoteUpdate):
+		return userNoteUpdateContextEventHandler(v)
 	case func(*Session, *UserSettingsUpdate):
 		return userSettingsUpdateEventHandler(v)
+	case func(context.Context, *Session, *UserSettingsUpdate):
+		return userSettingsUpdateContextEventHandler(v)
 	case func(*Session, *UserUpdate):
 		return userUpdateEventHandler(v)
+	case func(context.Context, *Session, *UserUpdate):
+		return userUpdateContextEventHandler(v)
+	case func(*Session, *VoiceChannelEmpty):
+		return voiceChannelEmptyEventHandler(v)
+	case func(context.Context, *Session, *VoiceChannelEmpty):
+		return voiceChannelEmptyContextEventHandler(v)
+	case func(*Session, *VoiceChannelFull):
+		return voiceChannelFullEventHandler(v)
+	case func(context.Context, *Session, *VoiceChannelFull):
+		return voiceChannelFullContextEventHandler(v)
 	case func(*Session, *VoiceServerUpdate):
 		return voiceServerUpdateEventHandler(v)
+	case func(context.Context, *Session, *VoiceServerUpdate):
+		return voiceServerUpdateContextEventHandler(v)
 	case func(*Session, *VoiceStateUpdate):
 		return voiceStateUpdateEventHandler(v)
+	case func(context.Context, *Session, *VoiceStateUpdate):
+		return voiceStateUpdateContextEventHandler(v)
 	case func(*Session, *WebhooksUpdate):
 		return webhooksUpdateEventHandler(v)
+	case func(context.Context, *Session, *WebhooksUpdate):
+		return webhooksUpdateContextEventHandler(v)
 	}
 
 	return nil
@@ -1029,6 +2613,11 @@ func init() {
 	registerInterfaceProvider(guildRoleDeleteEventHandler(nil))
 	registerInterfaceProvider(guildRoleUpdateEventHandler(nil))
 	registerInterfaceProvider(guildUpdateEventHandler(nil))
+	registerInterfaceProvider(integrationCreateEventHandler(nil))
+	registerInterfaceProvider(integrationDeleteEventHandler(nil))
+	registerInterfaceProvider(integrationUpdateEventHandler(nil))
+	registerInterfaceProvider(interactionCreateEventHandler(nil))
+	registerInterfaceProvider(memberGatedEventHandler(nil))
 	registerInterfaceProvider(messageAckEventHandler(nil))
 	registerInterfaceProvider(messageCreateEventHandler(nil))
 	registerInterfaceProvider(messageDeleteEventHandler(nil))
@@ -1036,6 +2625,7 @@ func init() {
 	registerInterfaceProvider(messageReactionAddEventHandler(nil))
 	registerInterfaceProvider(messageReactionRemoveEventHandler(nil))
 	registerInterfaceProvider(messageReactionRemoveAllEventHandler(nil))
+	registerInterfaceProvider(messageReactionRemoveEmojiEventHandler(nil))
 	registerInterfaceProvider(messageUpdateEventHandler(nil))
 	registerInterfaceProvider(presenceUpdateEventHandler(nil))
 	registerInterfaceProvider(presencesReplaceEventHandler(nil))
@@ -1043,6 +2633,7 @@ func init() {
 	registerInterfaceProvider(relationshipAddEventHandler(nil))
 	registerInterfaceProvider(relationshipRemoveEventHandler(nil))
 	registerInterfaceProvider(resumedEventHandler(nil))
+	registerInterfaceProvider(threadCreateEventHandler(nil))
 	registerInterfaceProvider(typingStartEventHandler(nil))
 	registerInterfaceProvider(userGuildSettingsUpdateEventHandler(nil))
 	registerInterfaceProvider(userNoteUpdateEventHandler(nil))
@@ -2,6 +2,7 @@ package discordgo
 
 import (
 	"encoding/json"
+	"time"
 )
 
 // This file contains all the possible structs that can be
@@ -15,7 +16,11 @@ type Connect struct{}
 
 // Disconnect is the data for a Disconnect event.
 // This is a synthetic event and is not dispatched by Discord.
-type Disconnect struct{}
+type Disconnect struct {
+	// Why Discord closed the connection, nil if it was closed by Close or
+	// for any other reason.
+	Err *GatewayCloseError
+}
 
 // RateLimit is the data for a RateLimit event.
 // This is a synthetic event and is not dispatched by Discord.
@@ -24,6 +29,44 @@ type RateLimit struct {
 	URL string
 }
 
+// BatchJobComplete is the data for a BatchJobComplete event, which is fired
+// when a BatchScheduler ran all the operations of a job.
+// This is a synthetic event and is not dispatched by Discord.
+type BatchJobComplete struct {
+	*BatchJob
+}
+
+// InvalidRequestLimit is the data for an InvalidRequestLimit event, which is
+// fired when the session made Threshold invalid requests in the last
+// InvalidRequestWindow. Its requests return ErrInvalidRequestLimit until the
+// count drops below the threshold again, at the earliest at Until.
+// This is a synthetic event and is not dispatched by Discord.
+type InvalidRequestLimit struct {
+	Count     int
+	Threshold int
+	Until     time.Time
+}
+
+// SpamDetected is the data for a SpamDetected event, see SpamDetector.
+// This is a synthetic event and is not dispatched by Discord.
+type SpamDetected struct {
+	*SpamDetection
+}
+
+// MemberGated is the data for a MemberGated event, which is fired when an
+// AccountAgeGate took its action on a member.
+// This is a synthetic event and is not dispatched by Discord.
+type MemberGated struct {
+	*Member
+	Action GateAction
+
+	// The age of the account of the member when they joined.
+	AccountAge time.Duration
+
+	// The error of the action, nil if it succeeded.
+	Err error
+}
+
 // Event provides a basic initial struct for all websocket events.
 type Event struct {
 	Operation int             `json:"op"`
@@ -36,13 +79,27 @@ type Event struct {
 
 // A Ready stores all data for the websocket READY event.
 type Ready struct {
-	Version         int          `json:"v"`
-	SessionID       string       `json:"session_id"`
+	Version   int    `json:"v"`
+	SessionID string `json:"session_id"`
+
+	// The gateway URL to resume the session with.
+	ResumeGatewayURL string `json:"resume_gateway_url"`
+
+	SessionType     string       `json:"session_type"`
 	User            *User        `json:"user"`
 	ReadState       []*ReadState `json:"read_state"`
 	PrivateChannels []*Channel   `json:"private_channels"`
 	Guilds          []*Guild     `json:"guilds"`
 
+	// The shard ID and count of the session, if it is sharded.
+	Shard *[2]int `json:"shard"`
+
+	// The application of the bot, with only its ID and flags.
+	Application *Application `json:"application"`
+
+	// The IDs of the voice regions, ordered by their distance to the bot.
+	GeoOrderedRTCRegions []string `json:"geo_ordered_rtc_regions"`
+
 	// Undocumented fields
 	Settings          *Settings            `json:"user_settings"`
 	UserGuildSettings []*UserGuildSettings `json:"user_guild_settings"`
@@ -56,9 +113,22 @@ type ChannelCreate struct {
 	*Channel
 }
 
+// ThreadCreate is the data for a ThreadCreate event, sent when a thread is
+// created or the bot is added to a private thread.
+type ThreadCreate struct {
+	*Channel
+
+	// Whether the thread was created, false when the bot was added to it.
+	NewlyCreated bool `json:"newly_created"`
+}
+
 // ChannelUpdate is the data for a ChannelUpdate event.
 type ChannelUpdate struct {
 	*Channel
+	// BeforeUpdate will be nil if the Channel was not previously cached in the state cache.
+	BeforeUpdate *Channel `json:"-"`
+	// Changes are the fields which changed, nil if BeforeUpdate is nil.
+	Changes *ChannelChanges `json:"-"`
 }
 
 // ChannelDelete is the data for a ChannelDelete event.
@@ -67,10 +137,11 @@ type ChannelDelete struct {
 }
 
 // ChannelPinsUpdate stores data for a ChannelPinsUpdate event.
+// LastPinTimestamp is empty when the last pinned message was unpinned.
 type ChannelPinsUpdate struct {
-	LastPinTimestamp string `json:"last_pin_timestamp"`
-	ChannelID        string `json:"channel_id"`
-	GuildID          string `json:"guild_id,omitempty"`
+	LastPinTimestamp Timestamp `json:"last_pin_timestamp"`
+	ChannelID        string    `json:"channel_id"`
+	GuildID          string    `json:"guild_id,omitempty"`
 }
 
 // GuildCreate is the data for a GuildCreate event.
@@ -81,6 +152,8 @@ type GuildCreate struct {
 // GuildUpdate is the data for a GuildUpdate event.
 type GuildUpdate struct {
 	*Guild
+	// BeforeUpdate will be nil if the Guild was not previously cached in the state cache.
+	BeforeUpdate *Guild `json:"-"`
 }
 
 // GuildDelete is the data for a GuildDelete event.
@@ -108,6 +181,8 @@ type GuildMemberAdd struct {
 // GuildMemberUpdate is the data for a GuildMemberUpdate event.
 type GuildMemberUpdate struct {
 	*Member
+	// BeforeUpdate will be nil if the Member was not previously cached in the state cache.
+	BeforeUpdate *Member `json:"-"`
 }
 
 // GuildMemberRemove is the data for a GuildMemberRemove event.
@@ -123,6 +198,8 @@ type GuildRoleCreate struct {
 // GuildRoleUpdate is the data for a GuildRoleUpdate event.
 type GuildRoleUpdate struct {
 	*GuildRole
+	// BeforeUpdate will be nil if the Role was not previously cached in the state cache.
+	BeforeUpdate *Role `json:"-"`
 }
 
 // A GuildRoleDelete is the data for a GuildRoleDelete event.
@@ -151,6 +228,30 @@ type GuildIntegrationsUpdate struct {
 	GuildID string `json:"guild_id"`
 }
 
+// InteractionCreate is the data for an InteractionCreate event.
+type InteractionCreate struct {
+	*Interaction
+}
+
+// IntegrationCreate is the data for an IntegrationCreate event.
+type IntegrationCreate struct {
+	*Integration
+}
+
+// IntegrationUpdate is the data for an IntegrationUpdate event.
+type IntegrationUpdate struct {
+	*Integration
+}
+
+// IntegrationDelete is the data for an IntegrationDelete event.
+type IntegrationDelete struct {
+	ID      string `json:"id"`
+	GuildID string `json:"guild_id"`
+
+	// The ID of the bot or OAuth2 application of the integration, if any.
+	ApplicationID string `json:"application_id,omitempty"`
+}
+
 // MessageAck is the data for a MessageAck event.
 type MessageAck struct {
 	MessageID string `json:"message_id"`
@@ -190,6 +291,13 @@ type MessageReactionRemoveAll struct {
 	*MessageReaction
 }
 
+// MessageReactionRemoveEmoji is the data for a MessageReactionRemoveEmoji
+// event, sent when all reactions of an emoji are removed from a message.
+// The UserID is empty.
+type MessageReactionRemoveEmoji struct {
+	*MessageReaction
+}
+
 // PresencesReplace is the data for a PresencesReplace event.
 type PresencesReplace []*Presence
 
@@ -242,6 +350,30 @@ type UserNoteUpdate struct {
 	Note string `json:"note"`
 }
 
+// GuildOutage is the data for a GuildOutage event, which is fired when the
+// fraction of the guilds which are unavailable reaches the OutageThreshold
+// of the state, usually during a Discord outage, and again with Ended set
+// when it drops below it. It requires the state to be enabled.
+type GuildOutage struct {
+	Unavailable int
+	Total       int
+	Ended       bool
+}
+
+// VoiceChannelFull is the data for a VoiceChannelFull event, which is fired
+// when a voice channel with a user limit becomes full. It requires the
+// state to track voice states.
+type VoiceChannelFull struct {
+	*VoiceChannelOccupancy
+}
+
+// VoiceChannelEmpty is the data for a VoiceChannelEmpty event, which is
+// fired when the last user leaves a voice channel. It requires the state
+// to track voice states.
+type VoiceChannelEmpty struct {
+	*VoiceChannelOccupancy
+}
+
 // VoiceServerUpdate is the data for a VoiceServerUpdate event.
 type VoiceServerUpdate struct {
 	Token    string `json:"token"`
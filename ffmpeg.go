@@ -0,0 +1,463 @@
+package discordgo
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ffmpegStderrLimit is the number of bytes of the stderr output of ffmpeg
+// kept by an FFmpegSource.
+const ffmpegStderrLimit = 4096
+
+// FFmpegOptions are the options of an FFmpegSource.
+type FFmpegOptions struct {
+	// The path of the ffmpeg binary, "ffmpeg" if empty.
+	Path string
+
+	// Arguments before the input, eg. "-ss", "30" to start at 30 seconds or
+	// "-reconnect", "1" for URLs.
+	InputArgs []string
+
+	// Arguments of the output before the opus encoding arguments, eg.
+	// "-af", "loudnorm".
+	Args []string
+
+	// The bitrate of the opus stream in kbit/s, 64 if 0.
+	Bitrate int
+
+	// Called with the progress of the transcoding, from the goroutine
+	// reading the output of ffmpeg. Optional.
+	OnProgress func(p *FFmpegProgress)
+
+	// Filters applied to the audio, which can be changed while it plays.
+	// Optional, filtering needs a second ffmpeg process to encode the
+	// filtered audio.
+	Filters *PCMFilterChain
+}
+
+// FFmpegProgress is the progress of an FFmpegSource.
+type FFmpegProgress struct {
+	// The position of the transcoded audio.
+	Time time.Duration
+
+	// The transcoding speed relative to realtime, eg. 2 is twice as fast.
+	Speed float64
+
+	// Whether the transcoding finished.
+	Done bool
+}
+
+// An FFmpegError is returned when ffmpeg fails, with the end of its output.
+type FFmpegError struct {
+	Err    error
+	Stderr string
+}
+
+// Error returns the error of ffmpeg and its output.
+func (e *FFmpegError) Error() string {
+	msg := "ffmpeg: " + e.Err.Error()
+	if e.Stderr != "" {
+		msg += ": " + e.Stderr
+	}
+	return msg
+}
+
+// ffmpegFilterAhead is the number of 20ms frames an FFmpegSource with
+// filters filters ahead of playback, which is the delay until changed
+// filters are heard.
+const ffmpegFilterAhead = 25
+
+// An FFmpegSource transcodes a file or URL with ffmpeg into 20ms opus
+// packets, see VoiceConnection.PlayOpus. ffmpeg must be installed.
+//
+// With Filters in the options, the audio is decoded to PCM by one ffmpeg
+// process, filtered, and encoded to opus by a second one.
+type FFmpegSource struct {
+	reader *OggOpusReader
+	cmds   []*exec.Cmd
+	stdout *os.File
+
+	// The pipes between the processes with filters, and the frames
+	// filtered ahead of playback.
+	pipes []*os.File
+	ahead chan struct{}
+
+	stderrMu sync.Mutex
+	stderr   []byte
+
+	// stopMu guards stopped and err.
+	stopMu    sync.Mutex
+	stopped   bool
+	stop      chan struct{}
+	closeOnce sync.Once
+
+	wg   sync.WaitGroup
+	done chan struct{}
+	err  error
+}
+
+// NewFFmpegSource starts ffmpeg transcoding the input, a file or URL, and
+// returns after ffmpeg wrote the opus headers. Stop must be called to end
+// the process when the source isn't read to the end.
+// input   : The file or URL to transcode.
+// options : The options of ffmpeg, optional.
+func NewFFmpegSource(input string, options *FFmpegOptions) (*FFmpegSource, error) {
+	if options == nil {
+		options = &FFmpegOptions{}
+	}
+
+	path := options.Path
+	if path == "" {
+		path = "ffmpeg"
+	}
+
+	f := &FFmpegSource{stop: make(chan struct{}), done: make(chan struct{})}
+
+	var err error
+	if options.Filters == nil {
+		f.stdout, err = f.start(path, ffmpegArgs(input, options, ffmpegOpusArgs(options)), nil, options.OnProgress)
+	} else {
+		err = f.startFiltered(path, input, options)
+	}
+
+	go func() {
+		f.wg.Wait()
+		close(f.done)
+	}()
+
+	if err != nil {
+		f.Stop()
+		return nil, err
+	}
+
+	f.reader, err = NewOggOpusReader(f.stdout)
+	if err != nil {
+		// ffmpeg usually exits when it fails to open the input, otherwise
+		// it wrote something other than Ogg Opus.
+		select {
+		case <-f.done:
+			f.closeOnce.Do(f.closePipes)
+			if ferr := f.processError(); ferr != nil {
+				return nil, ferr
+			}
+		case <-time.After(5 * time.Second):
+			f.Stop()
+		}
+		return nil, err
+	}
+	return f, nil
+}
+
+// start starts an ffmpeg process and returns its stdout.
+func (f *FFmpegSource) start(path string, args []string, stdin *os.File, onProgress func(p *FFmpegProgress)) (*os.File, error) {
+	cmd := exec.Command(path, args...)
+	if stdin != nil {
+		cmd.Stdin = stdin
+		defer stdin.Close()
+	}
+
+	stdout, w, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	cmd.Stdout = w
+
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		stdout.Close()
+		w.Close()
+		return nil, err
+	}
+
+	err = cmd.Start()
+	w.Close()
+	if err != nil {
+		stdout.Close()
+		return nil, &FFmpegError{Err: err}
+	}
+
+	f.cmds = append(f.cmds, cmd)
+	f.wg.Add(1)
+	go f.wait(cmd, stderr, onProgress)
+	return stdout, nil
+}
+
+// startFiltered starts an ffmpeg process decoding the input to PCM and one
+// encoding the filtered PCM to opus.
+func (f *FFmpegSource) startFiltered(path, input string, options *FFmpegOptions) error {
+	pcm, err := f.start(path, ffmpegArgs(input, options, append(ffmpegPCMArgs(), "pipe:1")), nil, options.OnProgress)
+	if err != nil {
+		return err
+	}
+	f.pipes = append(f.pipes, pcm)
+
+	stdin, w, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+	f.pipes = append(f.pipes, w)
+
+	args := append(append(ffmpegGlobalArgs(), ffmpegPCMArgs()...), "-i", "pipe:0")
+	if f.stdout, err = f.start(path, append(args, ffmpegOpusArgs(options)...), stdin, nil); err != nil {
+		return err
+	}
+
+	f.ahead = make(chan struct{}, ffmpegFilterAhead)
+	f.wg.Add(1)
+	go f.filter(pcm, w, options.Filters)
+	return nil
+}
+
+// ffmpegGlobalArgs returns the arguments of every ffmpeg process.
+func ffmpegGlobalArgs() []string {
+	return []string{"-hide_banner", "-nostdin", "-nostats", "-loglevel", "error"}
+}
+
+// ffmpegPCMArgs returns the arguments of the PCM format of the filters.
+func ffmpegPCMArgs() []string {
+	return []string{"-f", "s16le", "-ar", strconv.Itoa(pcmSampleRate), "-ac", strconv.Itoa(pcmChannels)}
+}
+
+// ffmpegArgs returns the arguments of ffmpeg to transcode the input to the
+// output, reporting the progress on stderr.
+func ffmpegArgs(input string, options *FFmpegOptions, output []string) []string {
+	args := append(ffmpegGlobalArgs(), "-progress", "pipe:2")
+	args = append(args, options.InputArgs...)
+	args = append(args, "-i", input, "-vn")
+	args = append(args, options.Args...)
+	return append(args, output...)
+}
+
+// ffmpegOpusArgs returns the arguments of ffmpeg to encode an Ogg Opus
+// stream on stdout.
+func ffmpegOpusArgs(options *FFmpegOptions) []string {
+	bitrate := options.Bitrate
+	if bitrate == 0 {
+		bitrate = 64
+	}
+
+	return []string{
+		"-c:a", "libopus",
+		"-b:a", strconv.Itoa(bitrate) + "k",
+		"-frame_duration", "20",
+		"-ar", "48000",
+		"-ac", "2",
+		"-page_duration", "20000",
+		"-f", "ogg",
+		"pipe:1",
+	}
+}
+
+// filter filters the PCM audio of the decoder and writes it to the encoder,
+// staying at most ffmpegFilterAhead frames ahead of playback.
+func (f *FFmpegSource) filter(pcm io.Reader, encoder io.WriteCloser, chain *PCMFilterChain) {
+	defer f.wg.Done()
+	defer encoder.Close()
+
+	const frameSamples = pcmSampleRate / 50 * pcmChannels
+
+	buf := make([]byte, 2*frameSamples)
+	samples := make([]int16, frameSamples)
+	pending := 0
+	for {
+		n, err := io.ReadFull(pcm, buf)
+
+		s := samples[:n/2]
+		for i := range s {
+			s[i] = int16(binary.LittleEndian.Uint16(buf[2*i:]))
+		}
+		s = chain.FilterPCM(s)
+
+		out := make([]byte, 2*len(s))
+		for i, sample := range s {
+			binary.LittleEndian.PutUint16(out[2*i:], uint16(sample))
+		}
+
+		for pending += len(s); pending >= frameSamples; pending -= frameSamples {
+			select {
+			case f.ahead <- struct{}{}:
+			case <-f.stop:
+				return
+			}
+		}
+
+		if _, werr := encoder.Write(out); werr != nil || err != nil {
+			return
+		}
+	}
+}
+
+// wait reads the stderr output of an ffmpeg process until it exits.
+func (f *FFmpegSource) wait(cmd *exec.Cmd, stderr io.Reader, onProgress func(p *FFmpegProgress)) {
+	defer f.wg.Done()
+
+	progress := &FFmpegProgress{}
+
+	scanner := bufio.NewScanner(stderr)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !parseFFmpegProgress(progress, line) {
+			f.writeStderr(line)
+			continue
+		}
+
+		if onProgress != nil && strings.HasPrefix(line, "progress=") {
+			p := *progress
+			onProgress(&p)
+		}
+	}
+
+	err := cmd.Wait()
+
+	f.stopMu.Lock()
+	if err != nil && !f.stopped && f.err == nil {
+		f.err = err
+	}
+	f.stopMu.Unlock()
+}
+
+// parseFFmpegProgress updates the progress with a line of the progress
+// output of ffmpeg, and returns false if the line is not progress output.
+func parseFFmpegProgress(p *FFmpegProgress, line string) bool {
+	i := strings.IndexByte(line, '=')
+	if i <= 0 || strings.ContainsAny(line[:i], " \t") {
+		return false
+	}
+
+	key, value := line[:i], strings.TrimSpace(line[i+1:])
+	switch key {
+	case "out_time_us", "out_time_ms":
+		// Both are in microseconds.
+		if us, err := strconv.ParseInt(value, 10, 64); err == nil {
+			p.Time = time.Duration(us) * time.Microsecond
+		}
+	case "speed":
+		if speed, err := strconv.ParseFloat(strings.TrimSuffix(value, "x"), 64); err == nil {
+			p.Speed = speed
+		}
+	case "progress":
+		p.Done = value == "end"
+	default:
+		// The other keys are only known to be progress output by their
+		// format, eg. "bitrate=128.0kbits/s".
+		if strings.ContainsAny(value, " ") {
+			return false
+		}
+	}
+	return true
+}
+
+// writeStderr appends a line to the captured stderr output, keeping the end.
+func (f *FFmpegSource) writeStderr(line string) {
+	f.stderrMu.Lock()
+	defer f.stderrMu.Unlock()
+
+	if len(f.stderr) > 0 {
+		f.stderr = append(f.stderr, '\n')
+	}
+	f.stderr = append(f.stderr, line...)
+	if len(f.stderr) > ffmpegStderrLimit {
+		f.stderr = f.stderr[len(f.stderr)-ffmpegStderrLimit:]
+	}
+}
+
+// Stderr returns the end of the error output of ffmpeg.
+func (f *FFmpegSource) Stderr() string {
+	f.stderrMu.Lock()
+	defer f.stderrMu.Unlock()
+
+	return string(f.stderr)
+}
+
+// processError returns an *FFmpegError if ffmpeg exited with an error.
+func (f *FFmpegSource) processError() error {
+	<-f.done
+
+	if f.err == nil {
+		return nil
+	}
+	return &FFmpegError{Err: f.err, Stderr: f.Stderr()}
+}
+
+// ReadFrame returns the next opus packet, or io.EOF when ffmpeg finished or
+// was stopped. An *FFmpegError is returned if ffmpeg failed.
+func (f *FFmpegSource) ReadFrame() ([]byte, error) {
+	frame, err := f.reader.ReadFrame()
+	if err == nil {
+		if f.ahead != nil {
+			select {
+			case <-f.ahead:
+			default:
+			}
+		}
+		return frame, nil
+	}
+
+	if f.isStopped() {
+		return nil, io.EOF
+	}
+
+	if err != io.EOF {
+		select {
+		case <-f.done:
+		default:
+			// The stream is broken while ffmpeg is still running.
+			f.Stop()
+			return nil, err
+		}
+	}
+
+	f.closeOnce.Do(f.closePipes)
+	if ferr := f.processError(); ferr != nil {
+		return nil, ferr
+	}
+	return nil, err
+}
+
+// closePipes closes the pipes of the processes.
+func (f *FFmpegSource) closePipes() {
+	if f.stdout != nil {
+		f.stdout.Close()
+	}
+	for _, p := range f.pipes {
+		p.Close()
+	}
+}
+
+// isStopped returns whether Stop was called.
+func (f *FFmpegSource) isStopped() bool {
+	f.stopMu.Lock()
+	defer f.stopMu.Unlock()
+
+	return f.stopped
+}
+
+// Stop kills ffmpeg and waits for it to exit. It can be called at any time,
+// eg. to skip a track, and more than once.
+func (f *FFmpegSource) Stop() {
+	f.stopMu.Lock()
+	if !f.stopped {
+		f.stopped = true
+		close(f.stop)
+		for _, cmd := range f.cmds {
+			// Fails for processes which already exited.
+			cmd.Process.Kill()
+		}
+	}
+	f.stopMu.Unlock()
+
+	f.closeOnce.Do(f.closePipes)
+	<-f.done
+}
+
+// Done returns a channel which is closed when ffmpeg exited.
+func (f *FFmpegSource) Done() <-chan struct{} {
+	return f.done
+}
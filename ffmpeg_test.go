@@ -0,0 +1,142 @@
+package discordgo
+
+import (
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestParseFFmpegProgress(t *testing.T) {
+	p := &FFmpegProgress{}
+	for _, line := range []string{"bitrate=  64.0kbits/s", "out_time_us=1500000", "speed=2.5x", "progress=end"} {
+		if !parseFFmpegProgress(p, line) {
+			t.Errorf("%q is progress output", line)
+		}
+	}
+	if p.Time != 1500*time.Millisecond || p.Speed != 2.5 || !p.Done {
+		t.Errorf("unexpected progress %+v", p)
+	}
+
+	if parseFFmpegProgress(p, "input.mp3: No such file or directory") {
+		t.Error("error output is not progress output")
+	}
+}
+
+func TestFFmpegSourceMissingBinary(t *testing.T) {
+	_, err := NewFFmpegSource("input.mp3", &FFmpegOptions{Path: "/nonexistent/ffmpeg"})
+	if _, ok := err.(*FFmpegError); !ok {
+		t.Errorf("expected an *FFmpegError, got %v", err)
+	}
+}
+
+func TestFFmpegSource(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake ffmpeg is a shell script")
+	}
+
+	dir, err := ioutil.TempDir("", "ffmpeg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// The fake ffmpeg writes a prepared stream, the last argument is the output.
+	stream := filepath.Join(dir, "stream.ogg")
+	if err = ioutil.WriteFile(stream, oggTestStream(), 0600); err != nil {
+		t.Fatal(err)
+	}
+	script := filepath.Join(dir, "ffmpeg")
+	err = ioutil.WriteFile(script, []byte("#!/bin/sh\necho 'out_time_us=40000' >&2\necho 'progress=end' >&2\ncat "+stream+"\n"), 0700)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var progress *FFmpegProgress
+	f, err := NewFFmpegSource("input.mp3", &FFmpegOptions{Path: script, OnProgress: func(p *FFmpegProgress) { progress = p }})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Stop()
+
+	frames := 0
+	for {
+		_, err := f.ReadFrame()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		frames++
+	}
+
+	if frames != 6 {
+		t.Errorf("expected 6 frames, got %d", frames)
+	}
+	if progress == nil || !progress.Done || progress.Time != 40*time.Millisecond {
+		t.Errorf("unexpected progress %+v", progress)
+	}
+}
+
+func TestFFmpegSourceFilters(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake ffmpeg is a shell script")
+	}
+
+	dir, err := ioutil.TempDir("", "ffmpeg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// The fake decoder writes 10 frames of PCM, the fake encoder keeps the
+	// filtered PCM and writes a prepared stream.
+	pcm := make([]byte, 10*960*pcmChannels*2)
+	for i := 0; i < len(pcm); i += 2 {
+		binary.LittleEndian.PutUint16(pcm[i:], 1000)
+	}
+	files := map[string][]byte{"input.pcm": pcm, "stream.ogg": oggTestStream()}
+	for name, data := range files {
+		if err = ioutil.WriteFile(filepath.Join(dir, name), data, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	script := filepath.Join(dir, "ffmpeg")
+	err = ioutil.WriteFile(script, []byte(`#!/bin/sh
+cd `+dir+`
+case "$*" in
+*pipe:0*) cat > filtered.pcm; cat stream.ogg ;;
+*) cat input.pcm ;;
+esac
+`), 0700)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := NewFFmpegSource("input.mp3", &FFmpegOptions{Path: script, Filters: NewPCMFilterChain(VolumeFilter(0.5))})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Stop()
+
+	for {
+		if _, err := f.ReadFrame(); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	filtered, err := ioutil.ReadFile(filepath.Join(dir, "filtered.pcm"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(filtered) != len(pcm) || binary.LittleEndian.Uint16(filtered) != 500 {
+		t.Errorf("unexpected filtered audio of %d bytes", len(filtered))
+	}
+}
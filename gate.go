@@ -0,0 +1,215 @@
+package discordgo
+
+import (
+	"sync"
+	"time"
+)
+
+// GateAction is the action an AccountAgeGate takes on members with new
+// accounts.
+type GateAction int
+
+// Valid GateAction values
+const (
+	// Only fire a MemberGated event, eg. to alert the moderators.
+	GateActionFlag GateAction = iota
+
+	// Time the member out for the TimeoutDuration of the gate.
+	GateActionTimeout
+
+	// Kick the member from the guild.
+	GateActionKick
+)
+
+// DefaultRaidDuration is how long a guild stays armed after a join burst
+// if the RaidDuration of an AccountAgeGate is zero.
+const DefaultRaidDuration = 10 * time.Minute
+
+// MaxTimeoutDuration is the longest timeout of a member.
+const MaxTimeoutDuration = 28 * 24 * time.Hour
+
+// An AccountAgeGate is a Module which takes an action on members joining a
+// guild with an account younger than MinAccountAge, eg. to hold off a raid
+// of new accounts. A MemberGated event is fired for every gated member.
+// Bots are never gated.
+//
+// The gate acts in all guilds the module is enabled in, see
+// ModuleGuildEnable. With RaidOnly it only acts in guilds which are armed,
+// with Arm or by the join bursts detected by a SpamDetector of the session.
+// Set Detector so the member completing a join burst is gated too, as the
+// SpamDetected events are dispatched after the member joined.
+type AccountAgeGate struct {
+	sync.Mutex
+
+	// The name of the module, "account-age-gate" if empty.
+	ModuleName string
+
+	// The options can be changed at any time while holding the lock.
+	MinAccountAge time.Duration
+	Action        GateAction
+
+	// How long members are timed out for with GateActionTimeout,
+	// MaxTimeoutDuration if zero or longer.
+	TimeoutDuration time.Duration
+
+	// The reason of kicks, shown in the audit log.
+	Reason string
+
+	// Whether the gate only acts in armed guilds, and how long guilds stay
+	// armed after a join burst, DefaultRaidDuration if zero.
+	RaidOnly     bool
+	RaidDuration time.Duration
+
+	// The detector whose join bursts arm the gate before it gates the
+	// joining member, optional. It must be set before the gate is added.
+	Detector *SpamDetector
+
+	armed      map[string]time.Time
+	removeHook func()
+}
+
+// Name returns the name of the module.
+func (g *AccountAgeGate) Name() string {
+	if g.ModuleName == "" {
+		return "account-age-gate"
+	}
+	return g.ModuleName
+}
+
+// Setup adds the handlers of the gate.
+func (g *AccountAgeGate) Setup(s *Session) error {
+	if g.Detector != nil {
+		g.removeHook = g.Detector.addJoinHook(g.onDetectedJoin)
+	} else {
+		s.AddModuleHandler(g.Name(), g.onGuildMemberAdd)
+	}
+	s.AddModuleHandler(g.Name(), g.onSpamDetected)
+	return nil
+}
+
+// Teardown disarms all guilds.
+func (g *AccountAgeGate) Teardown(s *Session) error {
+	g.Lock()
+	g.armed = nil
+	removeHook := g.removeHook
+	g.removeHook = nil
+	g.Unlock()
+
+	if removeHook != nil {
+		removeHook()
+	}
+	return nil
+}
+
+// Arm makes the gate act in a guild for the given duration when RaidOnly
+// is set, eg. when the moderators notice a raid. The guilds which are no
+// longer armed are forgotten.
+// guildID : The ID of a Guild.
+// d       : How long the guild stays armed.
+func (g *AccountAgeGate) Arm(guildID string, d time.Duration) {
+	g.Lock()
+	defer g.Unlock()
+
+	now := time.Now()
+	if g.armed == nil {
+		g.armed = make(map[string]time.Time)
+	}
+	for id, until := range g.armed {
+		if !now.Before(until) {
+			delete(g.armed, id)
+		}
+	}
+	if until := now.Add(d); until.After(g.armed[guildID]) {
+		g.armed[guildID] = until
+	}
+}
+
+// Disarm stops the gate from acting in a guild when RaidOnly is set.
+// guildID : The ID of a Guild.
+func (g *AccountAgeGate) Disarm(guildID string) {
+	g.Lock()
+	delete(g.armed, guildID)
+	g.Unlock()
+}
+
+// Armed returns whether a guild is armed.
+// guildID : The ID of a Guild.
+func (g *AccountAgeGate) Armed(guildID string) bool {
+	g.Lock()
+	defer g.Unlock()
+
+	return time.Now().Before(g.armed[guildID])
+}
+
+func (g *AccountAgeGate) onSpamDetected(s *Session, d *SpamDetected) {
+	g.arm(d.SpamDetection)
+}
+
+// onDetectedJoin arms the gate with the detections of a join of its
+// Detector, then gates the member.
+func (g *AccountAgeGate) onDetectedJoin(s *Session, m *GuildMemberAdd, detections []*SpamDetection) {
+	if !s.ModuleEnabled(g.Name(), m.GuildID) {
+		return
+	}
+
+	for _, d := range detections {
+		g.arm(d)
+	}
+	g.onGuildMemberAdd(s, m)
+}
+
+// arm arms the guild of a join burst for the RaidDuration.
+func (g *AccountAgeGate) arm(d *SpamDetection) {
+	if d.Type != SpamDetectionJoinBurst {
+		return
+	}
+
+	g.Lock()
+	duration := g.RaidDuration
+	g.Unlock()
+
+	if duration == 0 {
+		duration = DefaultRaidDuration
+	}
+	g.Arm(d.GuildID, duration)
+}
+
+func (g *AccountAgeGate) onGuildMemberAdd(s *Session, m *GuildMemberAdd) {
+	if m.Member == nil || m.User == nil || m.User.Bot {
+		return
+	}
+
+	createdAt, err := m.User.CreatedAt()
+	if err != nil {
+		s.log(LogWarning, "error parsing the ID of user %s, %s", m.User.ID, err)
+		return
+	}
+	age := time.Since(createdAt)
+
+	armed := g.Armed(m.GuildID)
+
+	g.Lock()
+	minAge, action, timeout, reason := g.MinAccountAge, g.Action, g.TimeoutDuration, g.Reason
+	raidOnly := g.RaidOnly
+	g.Unlock()
+
+	if age >= minAge || raidOnly && !armed {
+		return
+	}
+
+	switch action {
+	case GateActionTimeout:
+		if timeout <= 0 || timeout > MaxTimeoutDuration {
+			timeout = MaxTimeoutDuration
+		}
+		until := time.Now().Add(timeout)
+		err = s.GuildMemberTimeout(m.GuildID, m.User.ID, &until)
+	case GateActionKick:
+		err = s.GuildMemberDeleteWithReason(m.GuildID, m.User.ID, reason)
+	}
+	if err != nil {
+		s.log(LogError, "error gating member %s of guild %s, %s", m.User.ID, m.GuildID, err)
+	}
+
+	go s.handleEvent(memberGatedEventType, &MemberGated{Member: m.Member, Action: action, AccountAge: age, Err: err})
+}
@@ -0,0 +1,180 @@
+package discordgo
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// snowflakeAt returns a snowflake ID of the given time.
+func snowflakeAt(t time.Time) string {
+	ms := t.UnixNano()/int64(time.Millisecond) - 1420070400000
+	return strconv.FormatInt(ms<<22, 10)
+}
+
+func TestUserCreatedAt(t *testing.T) {
+	expected := time.Date(2015, 8, 10, 17, 26, 37, 529000000, time.UTC)
+	u := &User{ID: snowflakeAt(expected)}
+	createdAt, err := u.CreatedAt()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !createdAt.Equal(expected) {
+		t.Errorf("expected %s, got %s", expected, createdAt.UTC())
+	}
+}
+
+func TestMemberJoinedAgo(t *testing.T) {
+	m := &Member{JoinedAt: Timestamp(time.Now().Add(-time.Hour).Format(time.RFC3339))}
+	ago, err := m.JoinedAgo()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ago < time.Hour-time.Second || ago > time.Hour+time.Minute {
+		t.Errorf("expected about an hour, got %s", ago)
+	}
+
+	if _, err := (&Member{}).JoinedAgo(); err == nil {
+		t.Error("expected an error without a join time")
+	}
+}
+
+func TestAccountAgeGate(t *testing.T) {
+	now := time.Now()
+	newID, oldID, botID := snowflakeAt(now.Add(-time.Hour)), snowflakeAt(now.Add(-30*24*time.Hour)), snowflakeAt(now)
+
+	tr := &routeTransport{routes: map[string]string{
+		"DELETE " + EndpointGuildMember("1", newID) + "?reason=raid": ``,
+		"PATCH " + EndpointGuildMember("1", newID):                   ``,
+	}}
+	s := newTestSession(tr)
+	s.SyncEvents = true
+
+	// MemberGated is dispatched in a goroutine.
+	events := make(chan *MemberGated, 10)
+	s.AddHandler(func(s *Session, m *MemberGated) {
+		events <- m
+	})
+	var gated []*MemberGated
+	wait := func(n int) {
+		for len(gated) < n {
+			select {
+			case m := <-events:
+				gated = append(gated, m)
+			case <-time.After(time.Second):
+				t.Fatalf("expected %d MemberGated events, got %d", n, len(gated))
+			}
+		}
+	}
+
+	g := &AccountAgeGate{MinAccountAge: 7 * 24 * time.Hour, Action: GateActionKick, Reason: "raid"}
+	if err := s.ModuleAdd(g); err != nil {
+		t.Fatal(err)
+	}
+
+	join := func(id string, bot bool) {
+		g.onGuildMemberAdd(s, &GuildMemberAdd{&Member{GuildID: "1", User: &User{ID: id, Bot: bot}}})
+	}
+	join(newID, false)
+	join(oldID, false)
+	join(botID, true)
+
+	wait(1)
+	if len(gated) != 1 || gated[0].User.ID != newID || gated[0].Action != GateActionKick || gated[0].Err != nil {
+		t.Fatalf("expected the new account to be kicked, got %v", gated)
+	}
+	if gated[0].AccountAge < time.Hour || gated[0].AccountAge > 2*time.Hour {
+		t.Errorf("expected an account age of about an hour, got %s", gated[0].AccountAge)
+	}
+
+	g.Action, g.RaidOnly = GateActionTimeout, true
+	join(newID, false)
+	if len(gated) != 1 || len(events) != 0 || tr.count() != 1 {
+		t.Fatalf("expected no action in an unarmed guild, got %d events", len(gated))
+	}
+
+	g.onSpamDetected(s, &SpamDetected{&SpamDetection{Type: SpamDetectionJoinBurst, GuildID: "1"}})
+	if !g.Armed("1") {
+		t.Fatal("expected a join burst to arm the guild")
+	}
+	join(newID, false)
+	wait(2)
+	if len(gated) != 2 || gated[1].Action != GateActionTimeout || gated[1].Err != nil {
+		t.Fatalf("expected the new account to be timed out, got %v", gated)
+	}
+
+	// Without a duration members are timed out for the longest timeout.
+	var timeout struct {
+		Until time.Time `json:"communication_disabled_until"`
+	}
+	json.Unmarshal([]byte(tr.bodies[1]), &timeout)
+	if d := time.Until(timeout.Until); d < MaxTimeoutDuration-time.Minute || d > MaxTimeoutDuration {
+		t.Errorf("expected a timeout of 28 days, got %s", d)
+	}
+
+	g.Disarm("1")
+	if g.Armed("1") {
+		t.Error("expected the guild to be disarmed")
+	}
+
+	// Guilds are forgotten once they are no longer armed.
+	g.Arm("2", -time.Second)
+	g.Arm("3", time.Minute)
+	if len(g.armed) != 1 || g.Armed("2") || !g.Armed("3") {
+		t.Errorf("expected only guild 3 to be armed, got %v", g.armed)
+	}
+
+	expected := []string{
+		"DELETE " + EndpointGuildMember("1", newID) + "?reason=raid",
+		"PATCH " + EndpointGuildMember("1", newID),
+	}
+	if len(tr.requests) != len(expected) {
+		t.Fatalf("expected requests %v, got %v", expected, tr.requests)
+	}
+	for i, r := range expected {
+		if tr.requests[i] != r {
+			t.Errorf("expected request %s, got %s", r, tr.requests[i])
+		}
+	}
+}
+
+func TestAccountAgeGateDetector(t *testing.T) {
+	newID := snowflakeAt(time.Now().Add(-time.Hour))
+
+	tr := &routeTransport{handle: answer(``)}
+	s := newTestSession(tr)
+	s.SyncEvents = true
+
+	d := NewSpamDetector(s, SpamThresholds{JoinCount: 2, JoinWindow: time.Minute})
+	defer d.Close()
+
+	g := &AccountAgeGate{MinAccountAge: 7 * 24 * time.Hour, Action: GateActionKick, RaidOnly: true, Detector: d}
+	if err := s.ModuleAdd(g); err != nil {
+		t.Fatal(err)
+	}
+
+	join := func(id string) {
+		s.handleEvent(guildMemberAddEventType, &GuildMemberAdd{&Member{GuildID: "1", User: &User{ID: id}}})
+	}
+	join(snowflakeAt(time.Now().Add(-30 * 24 * time.Hour)))
+	if g.Armed("1") || tr.count() != 0 {
+		t.Fatal("expected a single join not to arm the guild")
+	}
+
+	// The member completing the burst is gated.
+	join(newID)
+	if !g.Armed("1") {
+		t.Fatal("expected the join burst to arm the guild")
+	}
+	if last := tr.last(); last != "DELETE "+EndpointGuildMember("1", newID) {
+		t.Errorf("expected the member completing the burst to be kicked, got %q", last)
+	}
+
+	if err := s.ModuleRemove(g.Name()); err != nil {
+		t.Fatal(err)
+	}
+	if len(d.joinHooks) != 0 {
+		t.Error("expected the join hook to be removed with the gate")
+	}
+}
@@ -0,0 +1,148 @@
+package discordgo
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrGatewayOpManaged is returned by GatewayWriteOp for ops which are sent
+// by the session itself or only received.
+var ErrGatewayOpManaged = errors.New("gateway op is managed by the session")
+
+// GatewayOp is an opcode of the gateway.
+// https://discord.com/developers/docs/topics/opcodes-and-status-codes#gateway-gateway-opcodes
+type GatewayOp int
+
+// Block contains the valid known GatewayOp values
+const (
+	GatewayOpDispatch            GatewayOp = 0
+	GatewayOpHeartbeat           GatewayOp = 1
+	GatewayOpIdentify            GatewayOp = 2
+	GatewayOpPresenceUpdate      GatewayOp = 3
+	GatewayOpVoiceStateUpdate    GatewayOp = 4
+	GatewayOpResume              GatewayOp = 6
+	GatewayOpReconnect           GatewayOp = 7
+	GatewayOpRequestGuildMembers GatewayOp = 8
+	GatewayOpInvalidSession      GatewayOp = 9
+	GatewayOpHello               GatewayOp = 10
+	GatewayOpHeartbeatAck        GatewayOp = 11
+)
+
+// Gateway send rate limit, per connection.
+const (
+	GatewaySendLimit  = 120
+	GatewaySendWindow = 60 * time.Second
+
+	// Sends kept free for heartbeats and identifies, which are never delayed.
+	gatewaySendReserve = 5
+)
+
+// gatewayOp is an op sent to the gateway.
+type gatewayOp struct {
+	Op   GatewayOp   `json:"op"`
+	Data interface{} `json:"d"`
+}
+
+// gatewaySendLimiter keeps the times of the recent gateway sends.
+type gatewaySendLimiter struct {
+	sync.Mutex
+
+	sends []time.Time
+}
+
+// prune removes the sends before the window, the lock must be held.
+func (l *gatewaySendLimiter) prune(now time.Time) {
+	i := 0
+	for i < len(l.sends) && now.Sub(l.sends[i]) >= GatewaySendWindow {
+		i++
+	}
+	l.sends = l.sends[i:]
+}
+
+// wait waits until a send is allowed and records it, returning the time of
+// the send. Priority sends, eg. heartbeats, are recorded without waiting.
+func (l *gatewaySendLimiter) wait(priority bool) time.Time {
+	l.Lock()
+	defer l.Unlock()
+
+	for {
+		now := time.Now()
+		l.prune(now)
+
+		if priority || len(l.sends) < GatewaySendLimit-gatewaySendReserve {
+			l.sends = append(l.sends, now)
+			return now
+		}
+
+		wait := GatewaySendWindow - now.Sub(l.sends[0])
+		l.Unlock()
+		time.Sleep(wait)
+		l.Lock()
+	}
+}
+
+// cancel removes a recorded send which wasn't sent, eg. as the write failed.
+func (l *gatewaySendLimiter) cancel(sent time.Time) {
+	l.Lock()
+	defer l.Unlock()
+
+	for i := len(l.sends) - 1; i >= 0; i-- {
+		if l.sends[i].Equal(sent) {
+			l.sends = append(l.sends[:i], l.sends[i+1:]...)
+			return
+		}
+	}
+}
+
+// count returns the number of sends in the current window.
+func (l *gatewaySendLimiter) count() int {
+	l.Lock()
+	defer l.Unlock()
+
+	l.prune(time.Now())
+	return len(l.sends)
+}
+
+// GatewaySends returns the number of ops sent to the gateway in the last
+// GatewaySendWindow, of the GatewaySendLimit.
+func (s *Session) GatewaySends() int {
+	return s.gatewaySends.count()
+}
+
+// gatewayWrite sends an op to the gateway, waiting for the send rate limit.
+// The send only counts once it was written.
+func (s *Session) gatewayWrite(v interface{}) (err error) {
+	sent := s.gatewaySends.wait(false)
+
+	s.RLock()
+	defer s.RUnlock()
+	if s.wsConn == nil {
+		s.gatewaySends.cancel(sent)
+		return ErrWSNotFound
+	}
+
+	s.wsMutex.Lock()
+	err = s.wsConn.WriteJSON(v)
+	s.wsMutex.Unlock()
+	if err != nil {
+		s.gatewaySends.cancel(sent)
+	}
+	return
+}
+
+// GatewayWriteOp sends an op to the gateway, eg. an op which isn't wrapped
+// by the library yet. The send waits when the send rate limit would be
+// exceeded, leaving room for heartbeats. Ops sent by the session itself,
+// like heartbeats and identifies, and ops which are only received return
+// ErrGatewayOpManaged.
+// op   : The op to send.
+// data : The data of the op, marshalled as its "d" field.
+func (s *Session) GatewayWriteOp(op GatewayOp, data interface{}) error {
+	switch op {
+	case GatewayOpDispatch, GatewayOpHeartbeat, GatewayOpIdentify, GatewayOpResume, GatewayOpReconnect, GatewayOpInvalidSession, GatewayOpHello, GatewayOpHeartbeatAck:
+		return ErrGatewayOpManaged
+	}
+
+	return s.gatewayWrite(gatewayOp{op, data})
+}
@@ -0,0 +1,67 @@
+package discordgo
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGatewaySendLimiter(t *testing.T) {
+	var l gatewaySendLimiter
+
+	// Sends of the previous window don't count.
+	l.sends = append(l.sends, time.Now().Add(-GatewaySendWindow))
+	for i := 0; i < GatewaySendLimit-gatewaySendReserve; i++ {
+		l.wait(false)
+	}
+	if n := l.count(); n != GatewaySendLimit-gatewaySendReserve {
+		t.Errorf("expected %d sends, got %d", GatewaySendLimit-gatewaySendReserve, n)
+	}
+
+	// Heartbeats may use the reserve.
+	l.wait(true)
+	if n := l.count(); n != GatewaySendLimit-gatewaySendReserve+1 {
+		t.Errorf("expected the heartbeat to be counted, got %d sends", n)
+	}
+}
+
+func TestGatewayWriteOp(t *testing.T) {
+	s := &Session{}
+	if err := s.GatewayWriteOp(GatewayOpIdentify, nil); err != ErrGatewayOpManaged {
+		t.Errorf("expected ErrGatewayOpManaged, got %v", err)
+	}
+	if err := s.GatewayWriteOp(GatewayOpRequestGuildMembers, nil); err != ErrWSNotFound {
+		t.Errorf("expected ErrWSNotFound without a connection, got %v", err)
+	}
+	if n := s.GatewaySends(); n != 0 {
+		t.Errorf("expected the failed send not to be counted, got %d sends", n)
+	}
+}
+
+func TestValidateIdentify(t *testing.T) {
+	tests := []struct {
+		id  Identify
+		err error
+	}{
+		{Identify{LargeThreshold: 250, Presence: GatewayStatusUpdate{Status: "idle"}}, nil},
+		{Identify{}, nil},
+		{Identify{LargeThreshold: 10}, ErrWSLargeThreshold},
+		{Identify{LargeThreshold: 251}, ErrWSLargeThreshold},
+		{Identify{Shard: &[2]int{1, 2}}, nil},
+		{Identify{Shard: &[2]int{2, 2}}, ErrWSShardBounds},
+		{Identify{Presence: GatewayStatusUpdate{Status: "away"}}, ErrWSInvalidStatus},
+	}
+
+	for i, test := range tests {
+		if err := validateIdentify(&test.id); err != test.err {
+			t.Errorf("%d: expected %v, got %v", i, test.err, err)
+		}
+	}
+}
+
+func TestUpdateStatusDataPresence(t *testing.T) {
+	usd := newUpdateStatusData(1000, GameTypeListening, "music", "")
+	p := usd.gatewayStatusUpdate()
+	if p.Since != 1000 || p.Status != "online" || p.Game.Name != "music" || p.Game.Type != ActivityType(GameTypeListening) {
+		t.Errorf("unexpected presence %+v", p)
+	}
+}
@@ -0,0 +1,128 @@
+package discordgo
+
+import (
+	"io"
+	"sync"
+	"time"
+)
+
+// GatewayStats holds the statistics of the messages a session received from
+// the gateway, to measure the cost of intents. Reset them with
+// ResetGatewayStats before a change to compare it.
+type GatewayStats struct {
+	// The time the statistics started at.
+	Since time.Time
+
+	Messages int64
+
+	// The bytes received, compressed if Compress is enabled, and their
+	// size after decompression.
+	BytesReceived     int64
+	BytesDecompressed int64
+
+	// The number of dispatched events by type.
+	Events map[string]int64
+}
+
+// CompressionRatio returns the size of the messages after decompression
+// divided by their received size, 1 without compression.
+func (g *GatewayStats) CompressionRatio() float64 {
+	if g.BytesReceived == 0 {
+		return 1
+	}
+	return float64(g.BytesDecompressed) / float64(g.BytesReceived)
+}
+
+// EventRates returns the number of events per second by type, averaged
+// since the statistics started.
+func (g *GatewayStats) EventRates() map[string]float64 {
+	rates := make(map[string]float64, len(g.Events))
+	seconds := time.Since(g.Since).Seconds()
+	if seconds <= 0 {
+		return rates
+	}
+
+	for t, n := range g.Events {
+		rates[t] = float64(n) / seconds
+	}
+	return rates
+}
+
+// gatewayStatsCounter counts the messages received from the gateway.
+type gatewayStatsCounter struct {
+	sync.Mutex
+
+	since                          time.Time
+	messages, received, decompress int64
+	events                         map[string]int64
+}
+
+// record counts a message, and its event if it is a dispatch.
+func (c *gatewayStatsCounter) record(eventType string, received, decompressed int64) {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.since.IsZero() {
+		c.since = time.Now()
+	}
+	c.messages++
+	c.received += received
+	c.decompress += decompressed
+
+	if eventType != "" {
+		if c.events == nil {
+			c.events = make(map[string]int64)
+		}
+		c.events[eventType]++
+	}
+}
+
+func (c *gatewayStatsCounter) snapshot() *GatewayStats {
+	c.Lock()
+	defer c.Unlock()
+
+	g := &GatewayStats{
+		Since:             c.since,
+		Messages:          c.messages,
+		BytesReceived:     c.received,
+		BytesDecompressed: c.decompress,
+		Events:            make(map[string]int64, len(c.events)),
+	}
+	for t, n := range c.events {
+		g.Events[t] = n
+	}
+	return g
+}
+
+func (c *gatewayStatsCounter) reset() {
+	c.Lock()
+	defer c.Unlock()
+
+	c.since = time.Now()
+	c.messages, c.received, c.decompress = 0, 0, 0
+	c.events = nil
+}
+
+// GatewayStats returns the statistics of the messages the session received
+// from the gateway.
+func (s *Session) GatewayStats() *GatewayStats {
+	return s.gatewayStats.snapshot()
+}
+
+// ResetGatewayStats resets the statistics of the messages the session
+// received from the gateway.
+func (s *Session) ResetGatewayStats() {
+	s.gatewayStats.reset()
+}
+
+// countingReader counts the bytes read from a reader.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (n int, err error) {
+	n, err = c.r.Read(p)
+	c.n += int64(n)
+	return
+}
@@ -0,0 +1,47 @@
+package discordgo
+
+import (
+	"bytes"
+	"compress/zlib"
+	"testing"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestGatewayStats(t *testing.T) {
+	s, _ := New("Bot token")
+	s.sequence = new(int64)
+
+	var b bytes.Buffer
+	z := zlib.NewWriter(&b)
+	z.Write([]byte(`{"op": 0, "s": 1, "t": "TYPING_START", "d": {"channel_id": "1", "user_id": "2", "padding": "` + string(bytes.Repeat([]byte("a"), 1000)) + `"}}`))
+	z.Close()
+
+	for i := 0; i < 2; i++ {
+		if _, err := s.onEvent(websocket.BinaryMessage, b.Bytes()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := s.onEvent(websocket.TextMessage, []byte(`{"op": 11}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	g := s.GatewayStats()
+	if g.Messages != 3 || g.Events["TYPING_START"] != 2 || len(g.Events) != 1 {
+		t.Errorf("unexpected stats %+v", g)
+	}
+	if g.BytesReceived != int64(2*b.Len()+len(`{"op": 11}`)) {
+		t.Errorf("unexpected received bytes %d", g.BytesReceived)
+	}
+	if r := g.CompressionRatio(); r < 5 {
+		t.Errorf("expected a compression ratio above 5, got %f", r)
+	}
+	if rates := g.EventRates(); rates["TYPING_START"] <= 0 {
+		t.Errorf("unexpected event rates %v", rates)
+	}
+
+	s.ResetGatewayStats()
+	if g = s.GatewayStats(); g.Messages != 0 || len(g.Events) != 0 {
+		t.Errorf("expected reset stats, got %+v", g)
+	}
+}
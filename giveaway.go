@@ -0,0 +1,397 @@
+package discordgo
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrGiveawayNotFound is returned when a message is not a giveaway of a GiveawayManager.
+var ErrGiveawayNotFound = errors.New("giveaway not found")
+
+// ErrGiveawayNotEnded is returned when rerolling a giveaway which has not ended yet.
+var ErrGiveawayNotEnded = errors.New("giveaway has not ended")
+
+// GiveawayEmoji is the emoji of the button members enter giveaways with.
+const GiveawayEmoji = "🎉"
+
+// GiveawayCustomID is the custom ID of the button of giveaway messages.
+const GiveawayCustomID = "giveaway:enter"
+
+// ScheduledActionGiveawayEnd is the scheduled action kind which ends giveaways.
+const ScheduledActionGiveawayEnd = "giveaway_end"
+
+// A Giveaway is a prize drawn among the members who pressed the button of
+// its message.
+type Giveaway struct {
+	GuildID   string    `json:"guild_id"`
+	ChannelID string    `json:"channel_id"`
+	MessageID string    `json:"message_id"`
+	HostID    string    `json:"host_id"`
+	Prize     string    `json:"prize"`
+	Winners   int       `json:"winners"`
+	EndsAt    time.Time `json:"ends_at"`
+
+	// The IDs of the entered users. Members enter and leave by pressing
+	// the button of the message.
+	Entrants []string `json:"entrants"`
+
+	Ended     bool     `json:"ended"`
+	WinnerIDs []string `json:"winner_ids,omitempty"`
+}
+
+// A GiveawayManager runs giveaways. Giveaways are ended by the Scheduler of
+// the session, so they survive restarts if the scheduler has a Store: add
+// the persisted giveaways with Add before loading the scheduler.
+type GiveawayManager struct {
+	sync.Mutex
+
+	// OnEnd is called after a giveaway ended or was rerolled, optional.
+	OnEnd func(g *Giveaway)
+
+	session   *Session
+	handlers  []HandlerID
+	giveaways map[string]*Giveaway
+}
+
+// NewGiveawayManager returns a new GiveawayManager which handles the button
+// interactions of s and the giveaway actions of its Scheduler. It returns
+// ErrNoScheduler if s has no Scheduler.
+func NewGiveawayManager(s *Session) (*GiveawayManager, error) {
+	if s.Scheduler == nil {
+		return nil, ErrNoScheduler
+	}
+
+	m := &GiveawayManager{
+		session:   s,
+		giveaways: map[string]*Giveaway{},
+	}
+
+	m.handlers = []HandlerID{
+		s.AddHandlerComplex(m.onInteraction, HandlerOptions{}),
+	}
+
+	s.Scheduler.AddHandler(ScheduledActionGiveawayEnd, func(s *Session, a *ScheduledAction) error {
+		var messageID string
+		if err := json.Unmarshal(a.Data, &messageID); err != nil {
+			return err
+		}
+		return m.End(messageID)
+	})
+	return m, nil
+}
+
+// Close removes the event handlers of the manager.
+func (m *GiveawayManager) Close() {
+	for _, id := range m.handlers {
+		m.session.RemoveHandler(id)
+	}
+}
+
+// Add adds giveaways to the manager, eg. giveaways persisted before a restart.
+func (m *GiveawayManager) Add(giveaways ...*Giveaway) {
+	m.Lock()
+	defer m.Unlock()
+
+	for _, g := range giveaways {
+		m.giveaways[g.MessageID] = g
+	}
+}
+
+// Giveaway returns a copy of the giveaway of a message.
+func (m *GiveawayManager) Giveaway(messageID string) (*Giveaway, error) {
+	m.Lock()
+	defer m.Unlock()
+
+	g, ok := m.giveaways[messageID]
+	if !ok {
+		return nil, ErrGiveawayNotFound
+	}
+	return g.copy(), nil
+}
+
+// Giveaways returns copies of all giveaways of the manager, eg. to persist them.
+func (m *GiveawayManager) Giveaways() (giveaways []*Giveaway) {
+	m.Lock()
+	defer m.Unlock()
+
+	for _, g := range m.giveaways {
+		giveaways = append(giveaways, g.copy())
+	}
+	return
+}
+
+// Remove removes an ended giveaway from the manager, so it can't be rerolled anymore.
+func (m *GiveawayManager) Remove(messageID string) {
+	m.Lock()
+	defer m.Unlock()
+
+	delete(m.giveaways, messageID)
+}
+
+func (g *Giveaway) copy() *Giveaway {
+	c := *g
+	c.Entrants = append([]string(nil), g.Entrants...)
+	c.WinnerIDs = append([]string(nil), g.WinnerIDs...)
+	return &c
+}
+
+// giveawayEmbed returns the embed of the giveaway message.
+func giveawayEmbed(g *Giveaway) *MessageEmbed {
+	e := &MessageEmbed{
+		Title:     g.Prize,
+		Timestamp: g.EndsAt.UTC().Format(time.RFC3339),
+	}
+
+	if !g.Ended {
+		e.Description = "Press the button to enter!\nWinners: " + strconv.Itoa(g.Winners) + "\nHosted by <@" + g.HostID + ">"
+		e.Footer = &MessageEmbedFooter{Text: "Ends at"}
+		return e
+	}
+
+	winners := "nobody entered"
+	if len(g.WinnerIDs) > 0 {
+		winners = "<@" + strings.Join(g.WinnerIDs, ">, <@") + ">"
+	}
+	e.Description = "Winners: " + winners + "\nHosted by <@" + g.HostID + ">"
+	e.Footer = &MessageEmbedFooter{Text: "Ended at"}
+	return e
+}
+
+// giveawayComponents returns the components of the giveaway message, with
+// the button disabled once the giveaway ended.
+func giveawayComponents(g *Giveaway) []*MessageComponent {
+	return []*MessageComponent{{
+		Type: ComponentTypeActionsRow,
+		Components: []*MessageComponent{{
+			Type:     ComponentTypeButton,
+			Style:    ButtonStylePrimary,
+			Label:    "Enter",
+			Emoji:    &ComponentEmoji{Name: GiveawayEmoji},
+			CustomID: GiveawayCustomID,
+			Disabled: g.Ended,
+		}},
+	}}
+}
+
+// Start posts a giveaway to the channel and schedules its end.
+// guildID   : The ID of the Guild of the channel.
+// channelID : The ID of the Channel to post the giveaway to.
+// hostID    : The ID of the user hosting the giveaway.
+// prize     : The prize of the giveaway.
+// winners   : The number of winners.
+// endsAt    : The time to draw the winners at.
+func (m *GiveawayManager) Start(guildID, channelID, hostID, prize string, winners int, endsAt time.Time) (g *Giveaway, err error) {
+	s := m.session
+	g = &Giveaway{
+		GuildID:   guildID,
+		ChannelID: channelID,
+		HostID:    hostID,
+		Prize:     prize,
+		Winners:   winners,
+		EndsAt:    endsAt,
+	}
+
+	msg, err := s.ChannelMessageSendComplex(channelID, &MessageSend{
+		Embed:      giveawayEmbed(g),
+		Components: giveawayComponents(g),
+	})
+	if err != nil {
+		return nil, err
+	}
+	g.MessageID = msg.ID
+
+	m.Add(g)
+	if _, err = s.Scheduler.Schedule(ScheduledActionGiveawayEnd, endsAt, msg.ID); err != nil {
+		// A giveaway which never ends is removed along with its message.
+		m.Remove(msg.ID)
+		if derr := s.ChannelMessageDelete(channelID, msg.ID); derr != nil {
+			s.log(LogWarning, "error deleting giveaway %s, %s", msg.ID, derr)
+		}
+		return nil, err
+	}
+	return g.copy(), nil
+}
+
+// onInteraction enters or withdraws the user who pressed the button of a
+// giveaway message.
+func (m *GiveawayManager) onInteraction(s *Session, i *InteractionCreate) {
+	if i.Type != InteractionMessageComponent || i.Message == nil {
+		return
+	}
+	data, err := i.MessageComponentData()
+	if err != nil || data.CustomID != GiveawayCustomID {
+		return
+	}
+	user := i.Author()
+	if user == nil {
+		return
+	}
+
+	content := m.toggle(i.Message.ID, user.ID)
+	err = s.InteractionRespond(i.Interaction, &InteractionResponse{
+		Type: InteractionResponseChannelMessageWithSource,
+		Data: &InteractionResponseData{Content: content, Flags: MessageFlagsEphemeral},
+	})
+	if err != nil {
+		s.log(LogWarning, "error responding to giveaway entry of %s, %s", user.ID, err)
+	}
+}
+
+// toggle enters a user in the giveaway of a message, or withdraws them if
+// they entered already, and returns the reply to the user.
+func (m *GiveawayManager) toggle(messageID, userID string) string {
+	m.Lock()
+	defer m.Unlock()
+
+	g, ok := m.giveaways[messageID]
+	if !ok || g.Ended {
+		return "This giveaway has ended."
+	}
+
+	for i, id := range g.Entrants {
+		if id == userID {
+			g.Entrants = append(g.Entrants[:i], g.Entrants[i+1:]...)
+			return "You left the giveaway."
+		}
+	}
+	g.Entrants = append(g.Entrants, userID)
+	return "You entered the giveaway, good luck!"
+}
+
+// End ends the giveaway of the message now, drawing its winners. Giveaways
+// are ended automatically at their end time.
+func (m *GiveawayManager) End(messageID string) error {
+	m.Lock()
+	g, ok := m.giveaways[messageID]
+	if !ok {
+		m.Unlock()
+		return ErrGiveawayNotFound
+	}
+	if g.Ended {
+		m.Unlock()
+		return nil
+	}
+
+	winners, err := pickWinners(g.Entrants, nil, g.Winners)
+	if err != nil {
+		m.Unlock()
+		return err
+	}
+	// The giveaway ends before the requests, so concurrent calls, eg. of
+	// the scheduler and a command, don't draw winners twice.
+	g.Ended, g.WinnerIDs = true, winners
+	ended := g.copy()
+	m.Unlock()
+
+	return m.announce(ended, "Congratulations")
+}
+
+// Reroll draws new winners for an ended giveaway among the entrants who
+// have not won it yet, replacing count of the winners. A count of zero
+// rerolls all winners.
+func (m *GiveawayManager) Reroll(messageID string, count int) (winners []string, err error) {
+	m.Lock()
+	g, ok := m.giveaways[messageID]
+	if !ok {
+		m.Unlock()
+		return nil, ErrGiveawayNotFound
+	}
+	if !g.Ended {
+		m.Unlock()
+		return nil, ErrGiveawayNotEnded
+	}
+
+	if count <= 0 || count > len(g.WinnerIDs) {
+		count = len(g.WinnerIDs)
+	}
+	if count == 0 {
+		count = g.Winners
+	}
+
+	winners, err = pickWinners(g.Entrants, g.WinnerIDs, count)
+	if err != nil {
+		m.Unlock()
+		return
+	}
+
+	// The first winners are replaced, the others keep their prize.
+	keep := len(g.WinnerIDs) - count
+	if keep < 0 {
+		keep = 0
+	}
+	g.WinnerIDs = append(append([]string(nil), g.WinnerIDs[len(g.WinnerIDs)-keep:]...), winners...)
+	rerolled := g.copy()
+	m.Unlock()
+
+	err = m.announce(rerolled, "Rerolled, congratulations")
+	return
+}
+
+// announce updates the message of the ended giveaway and announces the winners.
+func (m *GiveawayManager) announce(g *Giveaway, text string) error {
+	s := m.session
+	components := giveawayComponents(g)
+	_, err := s.ChannelMessageEditComplex(&MessageEdit{
+		ID:         g.MessageID,
+		Channel:    g.ChannelID,
+		Embed:      giveawayEmbed(g),
+		Components: &components,
+	})
+	if err != nil {
+		s.log(LogWarning, "error updating giveaway %s, %s", g.MessageID, err)
+	}
+
+	content := "Nobody entered the giveaway for **" + g.Prize + "**."
+	if len(g.WinnerIDs) > 0 {
+		content = text + " <@" + strings.Join(g.WinnerIDs, ">, <@") + ">! You won **" + g.Prize + "**!"
+	}
+
+	_, err = s.ChannelMessageSendReply(g.ChannelID, content, &MessageReference{
+		MessageID: g.MessageID,
+		ChannelID: g.ChannelID,
+		GuildID:   g.GuildID,
+	})
+
+	if m.OnEnd != nil {
+		m.OnEnd(g)
+	}
+	return err
+}
+
+// pickWinners picks count distinct winners among the entrants who are not
+// excluded, using crypto/rand so the draw can't be predicted.
+func pickWinners(entrants, exclude []string, count int) ([]string, error) {
+	excluded := make(map[string]bool, len(exclude))
+	for _, id := range exclude {
+		excluded[id] = true
+	}
+
+	pool := make([]string, 0, len(entrants))
+	for _, id := range entrants {
+		if !excluded[id] {
+			excluded[id] = true
+			pool = append(pool, id)
+		}
+	}
+
+	if count > len(pool) {
+		count = len(pool)
+	}
+
+	// A partial Fisher-Yates shuffle.
+	for i := 0; i < count; i++ {
+		j, err := rand.Int(rand.Reader, big.NewInt(int64(len(pool)-i)))
+		if err != nil {
+			return nil, err
+		}
+		k := i + int(j.Int64())
+		pool[i], pool[k] = pool[k], pool[i]
+	}
+	return pool[:count], nil
+}
@@ -0,0 +1,133 @@
+package discordgo
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNewGiveawayManager(t *testing.T) {
+	if _, err := NewGiveawayManager(&Session{}); err != ErrNoScheduler {
+		t.Errorf("expected ErrNoScheduler, got %v", err)
+	}
+}
+
+func TestGiveawayManager(t *testing.T) {
+	tr := &routeTransport{
+		routes: map[string]string{
+			"POST " + EndpointChannelMessages("1"):       `{"id": "10", "channel_id": "1"}`,
+			"PATCH " + EndpointChannelMessage("1", "10"): `{"id": "10", "channel_id": "1"}`,
+		},
+		handle: answer(``),
+	}
+	s := newTestSession(tr)
+	s.SyncEvents = true
+
+	m, err := NewGiveawayManager(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+
+	var ended []*Giveaway
+	m.OnEnd = func(g *Giveaway) {
+		ended = append(ended, g)
+	}
+
+	g, err := m.Start("2", "1", "3", "Nitro", 1, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sent MessageSend
+	json.Unmarshal([]byte(tr.bodies[0]), &sent)
+	if len(sent.Components) != 1 || sent.Components[0].Components[0].CustomID != GiveawayCustomID {
+		t.Fatalf("expected the giveaway button, got %+v", sent.Components)
+	}
+
+	press := func(userID string) {
+		s.handleEvent(interactionCreateEventType, &InteractionCreate{&Interaction{
+			ID:      "20",
+			Token:   "token",
+			Type:    InteractionMessageComponent,
+			Data:    []byte(`{"custom_id": "` + GiveawayCustomID + `", "component_type": 2}`),
+			Message: &Message{ID: g.MessageID},
+			Member:  &Member{User: &User{ID: userID}},
+		}})
+	}
+	press("4")
+	press("5")
+	press("5")
+	if g, _ = m.Giveaway(g.MessageID); len(g.Entrants) != 1 || g.Entrants[0] != "4" {
+		t.Fatalf("expected user 4 to be entered, got %v", g.Entrants)
+	}
+
+	// Concurrent ends draw the winners once.
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := m.End(g.MessageID); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if len(ended) != 1 || len(ended[0].WinnerIDs) != 1 || ended[0].WinnerIDs[0] != "4" {
+		t.Fatalf("expected a single draw won by user 4, got %+v", ended)
+	}
+	if edit := tr.bodies[len(tr.bodies)-2]; !strings.Contains(edit, `"disabled":true`) {
+		t.Errorf("expected the button to be disabled, got %s", edit)
+	}
+
+	press("6")
+	if g, _ = m.Giveaway(g.MessageID); len(g.Entrants) != 1 {
+		t.Errorf("expected no entries after the end, got %v", g.Entrants)
+	}
+
+	if _, err = m.Reroll("11", 1); err != ErrGiveawayNotFound {
+		t.Errorf("expected ErrGiveawayNotFound, got %v", err)
+	}
+	if winners, err := m.Reroll(g.MessageID, 1); err != nil || len(winners) != 0 || len(ended) != 2 {
+		t.Errorf("expected no new winner among the entrants, got %v, %v", winners, err)
+	}
+}
+
+// failingScheduleStore fails to save actions.
+type failingScheduleStore struct{}
+
+func (failingScheduleStore) ScheduleSave(*ScheduledAction) error {
+	return errors.New("store unavailable")
+}
+func (failingScheduleStore) ScheduleDelete(string) error               { return nil }
+func (failingScheduleStore) ScheduleLoad() ([]*ScheduledAction, error) { return nil, nil }
+
+func TestGiveawayManagerScheduleFailure(t *testing.T) {
+	tr := &routeTransport{
+		routes: map[string]string{
+			"POST " + EndpointChannelMessages("1"): `{"id": "10", "channel_id": "1"}`,
+		},
+		handle: answer(``),
+	}
+	s := newTestSession(tr)
+	s.Scheduler.Store = failingScheduleStore{}
+
+	m, err := NewGiveawayManager(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+
+	if _, err = m.Start("2", "1", "3", "Nitro", 1, time.Now().Add(time.Hour)); err == nil {
+		t.Fatal("expected the schedule error")
+	}
+	if _, err = m.Giveaway("10"); err != ErrGiveawayNotFound {
+		t.Errorf("expected the giveaway to be removed, got %v", err)
+	}
+	if last := tr.last(); last != "DELETE "+EndpointChannelMessage("1", "10") {
+		t.Errorf("expected the giveaway message to be deleted, got %s", last)
+	}
+}
@@ -0,0 +1,188 @@
+package discordgo
+
+import (
+	"sync"
+	"time"
+)
+
+// A GreeterConfig configures the welcome and leave messages of a guild.
+type GreeterConfig struct {
+	// Welcome is sent when a member joins, nil disables welcome messages.
+	Welcome *MessageTemplate
+
+	// The channel the welcome message is sent to, the message is sent to
+	// the member as a DM if it is empty.
+	WelcomeChannelID string
+
+	// Leave is sent to LeaveChannelID when a member leaves, nil or an empty
+	// LeaveChannelID disables leave messages.
+	Leave          *MessageTemplate
+	LeaveChannelID string
+}
+
+// A Greeter sends welcome and leave messages when members join or leave a
+// guild. The templates are rendered with the variables of the member (see
+// TemplateVars.AddMember), the guild if it is in the state (see
+// TemplateVars.AddGuild) and the target channel (see TemplateVars.AddChannel).
+//
+// To not flood channels during join raids, at most RaidLimit messages are
+// sent per guild within RaidWindow, the rest is dropped.
+type Greeter struct {
+	sync.Mutex
+
+	// RaidLimit and RaidWindow can be changed at any time while holding
+	// the lock, a RaidLimit of zero disables the limit.
+	RaidLimit  int
+	RaidWindow time.Duration
+
+	session  *Session
+	handlers []HandlerID
+	guilds   map[string]*GreeterConfig
+	sent     map[string][]time.Time
+}
+
+// NewGreeter returns a new Greeter which handles the member events of s,
+// it sends at most 5 messages per guild within 10 seconds by default.
+func NewGreeter(s *Session) *Greeter {
+	g := &Greeter{
+		RaidLimit:  5,
+		RaidWindow: 10 * time.Second,
+		session:    s,
+		guilds:     map[string]*GreeterConfig{},
+		sent:       map[string][]time.Time{},
+	}
+
+	g.handlers = []HandlerID{
+		s.AddHandlerComplex(func(s *Session, m *GuildMemberAdd) {
+			g.memberAdd(m.Member)
+		}, HandlerOptions{}),
+		s.AddHandlerComplex(func(s *Session, m *GuildMemberRemove) {
+			g.memberRemove(m.Member)
+		}, HandlerOptions{}),
+	}
+	return g
+}
+
+// Close removes the event handlers of the greeter.
+func (g *Greeter) Close() {
+	for _, id := range g.handlers {
+		g.session.RemoveHandler(id)
+	}
+}
+
+// GuildSet sets the configuration of a guild.
+func (g *Greeter) GuildSet(guildID string, config *GreeterConfig) {
+	g.Lock()
+	defer g.Unlock()
+
+	g.guilds[guildID] = config
+}
+
+// GuildRemove removes the configuration of a guild, disabling its messages.
+func (g *Greeter) GuildRemove(guildID string) {
+	g.Lock()
+	defer g.Unlock()
+
+	delete(g.guilds, guildID)
+}
+
+func (g *Greeter) guild(guildID string) *GreeterConfig {
+	g.Lock()
+	defer g.Unlock()
+
+	return g.guilds[guildID]
+}
+
+// allow returns whether a message may be sent for the member without
+// exceeding the raid limit of the guild, and records it if so.
+func (g *Greeter) allow(m *Member, kind string) bool {
+	if g.record(m.GuildID, time.Now()) {
+		return true
+	}
+	g.session.log(LogInformational, "dropped %s message for %s in guild %s, raid limit reached", kind, m.User.ID, m.GuildID)
+	return false
+}
+
+// record records a message sent in the guild at now, unless it exceeds
+// the raid limit.
+func (g *Greeter) record(guildID string, now time.Time) bool {
+	g.Lock()
+	defer g.Unlock()
+
+	if g.RaidLimit <= 0 {
+		return true
+	}
+
+	sent := g.sent[guildID]
+	i := 0
+	for i < len(sent) && now.Sub(sent[i]) > g.RaidWindow {
+		i++
+	}
+	sent = sent[i:]
+
+	if len(sent) >= g.RaidLimit {
+		g.sent[guildID] = sent
+		return false
+	}
+
+	g.sent[guildID] = append(sent, now)
+	return true
+}
+
+func (g *Greeter) memberAdd(m *Member) {
+	config := g.guild(m.GuildID)
+	if config == nil || config.Welcome == nil || m.User == nil || m.User.Bot {
+		return
+	}
+	// The limit is checked before the DM channel is created, which would
+	// spend the rate limit of the bot during a raid.
+	if !g.allow(m, "welcome") {
+		return
+	}
+
+	channelID := config.WelcomeChannelID
+	if channelID == "" {
+		c, err := g.session.UserChannelCreate(m.User.ID)
+		if err != nil {
+			g.session.log(LogError, "error creating welcome DM channel for %s, %s", m.User.ID, err)
+			return
+		}
+		channelID = c.ID
+	}
+
+	g.send(m, config.Welcome, channelID, "welcome")
+}
+
+func (g *Greeter) memberRemove(m *Member) {
+	config := g.guild(m.GuildID)
+	if config == nil || config.Leave == nil || config.LeaveChannelID == "" || m.User == nil || m.User.Bot {
+		return
+	}
+	if !g.allow(m, "leave") {
+		return
+	}
+
+	g.send(m, config.Leave, config.LeaveChannelID, "leave")
+}
+
+func (g *Greeter) send(m *Member, t *MessageTemplate, channelID, kind string) {
+	vars := TemplateVars{"guild.id": m.GuildID, "channel.id": channelID}.AddMember(m)
+	if st := g.session.State; st != nil {
+		if guild, err := st.Guild(m.GuildID); err == nil {
+			vars.AddGuild(guild)
+		}
+		if c, err := st.Channel(channelID); err == nil {
+			vars.AddChannel(c)
+		}
+	}
+
+	data, err := t.Render(vars)
+	if err != nil {
+		g.session.log(LogError, "error rendering %s message for guild %s, %s", kind, m.GuildID, err)
+		return
+	}
+
+	if _, err = g.session.ChannelMessageSendComplex(channelID, data); err != nil {
+		g.session.log(LogError, "error sending %s message for guild %s, %s", kind, m.GuildID, err)
+	}
+}
@@ -0,0 +1,60 @@
+package discordgo
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestGreeter(t *testing.T) {
+	tr := &routeTransport{routes: map[string]string{
+		"POST " + EndpointUserChannels("@me"):   `{"id": "10"}`,
+		"POST " + EndpointChannelMessages("10"): `{"id": "11"}`,
+		"POST " + EndpointChannelMessages("20"): `{"id": "21"}`,
+	}}
+	s := newTestSession(tr)
+
+	g := NewGreeter(s)
+	defer g.Close()
+	g.RaidLimit = 2
+
+	welcome, err := ParseMessageTemplate([]byte(`{"content": "Welcome {{member.nick}}"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.GuildSet("1", &GreeterConfig{Welcome: welcome, Leave: welcome, LeaveChannelID: "20"})
+
+	member := func(id string) *Member {
+		return &Member{GuildID: "1", User: &User{ID: id, Username: "user" + id}}
+	}
+	g.memberAdd(member("2"))
+
+	var sent MessageSend
+	json.Unmarshal([]byte(tr.bodies[1]), &sent)
+	if len(tr.requests) != 2 || tr.last() != "POST "+EndpointChannelMessages("10") || sent.Content != "Welcome user2" {
+		t.Fatalf("expected a welcome DM, got %v %+v", tr.requests, sent)
+	}
+
+	// Over the raid limit no DM channels are created.
+	g.memberRemove(member("3"))
+	g.memberAdd(member("4"))
+	g.memberAdd(member("5"))
+	if len(tr.requests) != 3 || tr.last() != "POST "+EndpointChannelMessages("20") {
+		t.Errorf("expected only the leave message, got %v", tr.requests)
+	}
+
+	g.Lock()
+	g.sent["1"][0] = time.Now().Add(-time.Minute)
+	g.Unlock()
+	g.memberAdd(member("6"))
+	if len(tr.requests) != 5 {
+		t.Errorf("expected a welcome DM once the window passed, got %v", tr.requests)
+	}
+
+	// Guilds without a config are ignored.
+	g.GuildRemove("1")
+	g.memberAdd(member("7"))
+	if len(tr.requests) != 5 {
+		t.Errorf("expected no messages, got %v", tr.requests)
+	}
+}
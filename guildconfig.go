@@ -0,0 +1,380 @@
+package discordgo
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ErrGuildConfigNotFound is returned by a GuildConfigStore when no value
+// is stored for the requested guild and key.
+var ErrGuildConfigNotFound = errors.New("guild config value not found")
+
+// A GuildConfigStore stores configuration values per guild, such as command
+// prefixes, locales or feature toggles. Values are encoded as JSON, so any
+// value that can be marshalled can be stored.
+type GuildConfigStore interface {
+	// Get decodes the value stored for the guild and key into v.
+	// ErrGuildConfigNotFound is returned if no value is stored.
+	Get(guildID, key string, v interface{}) error
+
+	// Set stores the value for the guild and key.
+	Set(guildID, key string, v interface{}) error
+
+	// Delete removes the value stored for the guild and key.
+	Delete(guildID, key string) error
+
+	// OnChange adds a function which is called after a value has been
+	// set or deleted. The returned function removes it again.
+	OnChange(handler func(*GuildConfigChange)) func()
+}
+
+// A GuildConfigChange is passed to GuildConfigStore change handlers.
+type GuildConfigChange struct {
+	GuildID string
+	Key     string
+
+	// The new JSON encoded value, nil if the value was deleted.
+	Value json.RawMessage
+}
+
+// guildConfigKey is the key of a cached guild config value.
+type guildConfigKey struct {
+	guildID string
+	key     string
+}
+
+// guildConfigCache caches the encoded values of a GuildConfigStore and
+// notifies the change handlers of it.
+type guildConfigCache struct {
+	sync.RWMutex
+	values     map[guildConfigKey]json.RawMessage
+	handlers   map[int]func(*GuildConfigChange)
+	lastHandle int
+}
+
+func newGuildConfigCache() *guildConfigCache {
+	return &guildConfigCache{
+		values:   make(map[guildConfigKey]json.RawMessage),
+		handlers: make(map[int]func(*GuildConfigChange)),
+	}
+}
+
+func (c *guildConfigCache) get(guildID, key string) (json.RawMessage, bool) {
+	c.RLock()
+	defer c.RUnlock()
+
+	value, ok := c.values[guildConfigKey{guildID, key}]
+	return value, ok
+}
+
+// set caches the value, a nil value removes it from the cache.
+func (c *guildConfigCache) set(guildID, key string, value json.RawMessage) {
+	c.Lock()
+	defer c.Unlock()
+
+	if value == nil {
+		delete(c.values, guildConfigKey{guildID, key})
+	} else {
+		c.values[guildConfigKey{guildID, key}] = value
+	}
+}
+
+func (c *guildConfigCache) notify(guildID, key string, value json.RawMessage) {
+	c.RLock()
+	handlers := make([]func(*GuildConfigChange), 0, len(c.handlers))
+	for _, h := range c.handlers {
+		handlers = append(handlers, h)
+	}
+	c.RUnlock()
+
+	for _, h := range handlers {
+		h(&GuildConfigChange{GuildID: guildID, Key: key, Value: value})
+	}
+}
+
+// OnChange adds a function which is called after a value has been
+// set or deleted. The returned function removes it again.
+func (c *guildConfigCache) OnChange(handler func(*GuildConfigChange)) func() {
+	c.Lock()
+	defer c.Unlock()
+
+	c.lastHandle++
+	handle := c.lastHandle
+	c.handlers[handle] = handler
+
+	return func() {
+		c.Lock()
+		delete(c.handlers, handle)
+		c.Unlock()
+	}
+}
+
+// MemoryGuildConfigStore is a GuildConfigStore which keeps all values in memory.
+type MemoryGuildConfigStore struct {
+	*guildConfigCache
+}
+
+// NewMemoryGuildConfigStore returns a new, empty MemoryGuildConfigStore.
+func NewMemoryGuildConfigStore() *MemoryGuildConfigStore {
+	return &MemoryGuildConfigStore{newGuildConfigCache()}
+}
+
+// Get decodes the value stored for the guild and key into v.
+func (m *MemoryGuildConfigStore) Get(guildID, key string, v interface{}) error {
+	value, ok := m.get(guildID, key)
+	if !ok {
+		return ErrGuildConfigNotFound
+	}
+	return json.Unmarshal(value, v)
+}
+
+// Set stores the value for the guild and key.
+func (m *MemoryGuildConfigStore) Set(guildID, key string, v interface{}) error {
+	value, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	m.set(guildID, key, value)
+	m.notify(guildID, key, value)
+	return nil
+}
+
+// Delete removes the value stored for the guild and key.
+func (m *MemoryGuildConfigStore) Delete(guildID, key string) error {
+	m.set(guildID, key, nil)
+	m.notify(guildID, key, nil)
+	return nil
+}
+
+// SQLGuildConfigStore is a GuildConfigStore backed by a database/sql
+// database. Values read from the database are cached in memory, so the
+// store assumes it is the only writer of its table.
+//
+// The table needs guild_id, config_key and config_value columns, with
+// a unique constraint on (guild_id, config_key), eg:
+//
+//	CREATE TABLE guild_config (
+//	    guild_id     VARCHAR(20)  NOT NULL,
+//	    config_key   VARCHAR(100) NOT NULL,
+//	    config_value TEXT         NOT NULL,
+//	    PRIMARY KEY (guild_id, config_key)
+//	)
+type SQLGuildConfigStore struct {
+	*guildConfigCache
+
+	DB    *sql.DB
+	Table string
+
+	// Placeholder returns the query placeholder of the n-th (starting
+	// at 1) query argument. It defaults to "?", set it to
+	// PostgresPlaceholder when using PostgreSQL.
+	Placeholder func(n int) string
+}
+
+// PostgresPlaceholder returns PostgreSQL style ($1, $2, ...) query placeholders.
+func PostgresPlaceholder(n int) string {
+	return "$" + strconv.Itoa(n)
+}
+
+// NewSQLGuildConfigStore returns a new SQLGuildConfigStore using the given
+// database table.
+func NewSQLGuildConfigStore(db *sql.DB, table string) *SQLGuildConfigStore {
+	return &SQLGuildConfigStore{
+		guildConfigCache: newGuildConfigCache(),
+		DB:               db,
+		Table:            table,
+		Placeholder: func(int) string {
+			return "?"
+		},
+	}
+}
+
+// query replaces the {table} and the {1} to {n} argument tokens of a query.
+func (s *SQLGuildConfigStore) query(query string, n int) string {
+	oldnew := []string{"{table}", s.Table}
+	for i := 1; i <= n; i++ {
+		oldnew = append(oldnew, "{"+strconv.Itoa(i)+"}", s.Placeholder(i))
+	}
+	return strings.NewReplacer(oldnew...).Replace(query)
+}
+
+// Get decodes the value stored for the guild and key into v.
+func (s *SQLGuildConfigStore) Get(guildID, key string, v interface{}) error {
+	value, ok := s.get(guildID, key)
+	if !ok {
+		var raw string
+		err := s.DB.QueryRow(s.query("SELECT config_value FROM {table} WHERE guild_id = {1} AND config_key = {2}", 2), guildID, key).Scan(&raw)
+		if err == sql.ErrNoRows {
+			return ErrGuildConfigNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		value = json.RawMessage(raw)
+		s.set(guildID, key, value)
+	}
+
+	return json.Unmarshal(value, v)
+}
+
+// Set stores the value for the guild and key.
+func (s *SQLGuildConfigStore) Set(guildID, key string, v interface{}) error {
+	value, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	// Upserts are not portable between databases, so look the row up
+	// first. Updating first and inserting when no row was affected doesn't
+	// work with MySQL, which doesn't count rows updated to the same value.
+	tx, err := s.DB.Begin()
+	if err != nil {
+		return err
+	}
+
+	var exists int
+	err = tx.QueryRow(s.query("SELECT COUNT(*) FROM {table} WHERE guild_id = {1} AND config_key = {2}", 2), guildID, key).Scan(&exists)
+	if err == nil && exists > 0 {
+		_, err = tx.Exec(s.query("UPDATE {table} SET config_value = {1} WHERE guild_id = {2} AND config_key = {3}", 3), string(value), guildID, key)
+	} else if err == nil {
+		_, err = tx.Exec(s.query("INSERT INTO {table} (guild_id, config_key, config_value) VALUES ({1}, {2}, {3})", 3), guildID, key, string(value))
+	}
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err = tx.Commit(); err != nil {
+		return err
+	}
+
+	s.set(guildID, key, value)
+	s.notify(guildID, key, value)
+	return nil
+}
+
+// Delete removes the value stored for the guild and key.
+func (s *SQLGuildConfigStore) Delete(guildID, key string) error {
+	_, err := s.DB.Exec(s.query("DELETE FROM {table} WHERE guild_id = {1} AND config_key = {2}", 2), guildID, key)
+	if err != nil {
+		return err
+	}
+
+	s.set(guildID, key, nil)
+	s.notify(guildID, key, nil)
+	return nil
+}
+
+// GuildConfigModulePrefix is the prefix of the guild config keys which
+// toggle modules in a guild, see ModuleGuildConfig.
+const GuildConfigModulePrefix = "module."
+
+// ModuleGuildConfig keeps the modules of the session enabled or disabled
+// per guild according to boolean values of the store, stored with the
+// GuildConfigModulePrefix followed by the module name as key. The values
+// of the modules are loaded for the guilds of the State and for every guild
+// which becomes available, and changes of the store are applied as they are
+// made. The returned function stops it again.
+func (s *Session) ModuleGuildConfig(store GuildConfigStore) func() {
+	remove := store.OnChange(func(c *GuildConfigChange) {
+		if !strings.HasPrefix(c.Key, GuildConfigModulePrefix) {
+			return
+		}
+		name := strings.TrimPrefix(c.Key, GuildConfigModulePrefix)
+
+		enabled := true
+		if c.Value != nil {
+			if err := json.Unmarshal(c.Value, &enabled); err != nil {
+				s.log(LogWarning, "invalid module toggle %s for guild %s, %s", c.Key, c.GuildID, err)
+				return
+			}
+		}
+		s.moduleGuildToggle(name, c.GuildID, enabled)
+	})
+
+	handler := s.AddHandlerComplex(func(s *Session, g *GuildCreate) {
+		s.loadModuleGuildConfig(store, g.ID)
+	}, HandlerOptions{})
+
+	if s.State != nil {
+		s.State.RLock()
+		guildIDs := make([]string, len(s.State.Guilds))
+		for i, g := range s.State.Guilds {
+			guildIDs[i] = g.ID
+		}
+		s.State.RUnlock()
+
+		for _, id := range guildIDs {
+			s.loadModuleGuildConfig(store, id)
+		}
+	}
+
+	return func() {
+		remove()
+		s.RemoveHandler(handler)
+	}
+}
+
+// loadModuleGuildConfig enables or disables the modules in a guild
+// according to the values stored for them, see ModuleGuildConfig.
+func (s *Session) loadModuleGuildConfig(store GuildConfigStore, guildID string) {
+	for _, m := range s.Modules() {
+		key := GuildConfigModulePrefix + m.Name()
+
+		var enabled bool
+		err := store.Get(guildID, key, &enabled)
+		if err == ErrGuildConfigNotFound {
+			continue
+		}
+		if err != nil {
+			s.log(LogWarning, "error loading module toggle %s for guild %s, %s", key, guildID, err)
+			continue
+		}
+		s.moduleGuildToggle(m.Name(), guildID, enabled)
+	}
+}
+
+func (s *Session) moduleGuildToggle(name, guildID string, enabled bool) {
+	if enabled {
+		s.ModuleGuildEnable(name, guildID)
+	} else {
+		s.ModuleGuildDisable(name, guildID)
+	}
+}
+
+// The guild config keys of the command prefix and the locale of a guild.
+const (
+	GuildConfigPrefixKey = "prefix"
+	GuildConfigLocaleKey = "locale"
+)
+
+// GuildPrefix returns the command prefix stored for a guild, eg. for the
+// message command router of a bot.
+// store    : The store of the guild config.
+// guildID  : The ID of a Guild.
+// fallback : The prefix of guilds without one, or when it can't be read.
+func GuildPrefix(store GuildConfigStore, guildID, fallback string) string {
+	return guildConfigString(store, guildID, GuildConfigPrefixKey, fallback)
+}
+
+// GuildLocale returns the locale stored for a guild, eg. to answer an
+// interaction in the locale chosen by the guild instead of its GuildLocale.
+// store    : The store of the guild config.
+// guildID  : The ID of a Guild.
+// fallback : The locale of guilds without one, or when it can't be read.
+func GuildLocale(store GuildConfigStore, guildID, fallback string) string {
+	return guildConfigString(store, guildID, GuildConfigLocaleKey, fallback)
+}
+
+func guildConfigString(store GuildConfigStore, guildID, key, fallback string) string {
+	var v string
+	if err := store.Get(guildID, key, &v); err != nil || v == "" {
+		return fallback
+	}
+	return v
+}
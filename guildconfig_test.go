@@ -0,0 +1,253 @@
+package discordgo
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestMemoryGuildConfigStore(t *testing.T) {
+	store := NewMemoryGuildConfigStore()
+
+	var prefix string
+	if err := store.Get("1", "prefix", &prefix); err != ErrGuildConfigNotFound {
+		t.Fatalf("expected ErrGuildConfigNotFound, got %v", err)
+	}
+
+	var changes int
+	remove := store.OnChange(func(c *GuildConfigChange) {
+		changes++
+	})
+
+	if err := store.Set("1", "prefix", "!"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Get("1", "prefix", &prefix); err != nil || prefix != "!" {
+		t.Fatalf("expected prefix !, got %q (%v)", prefix, err)
+	}
+	if err := store.Get("2", "prefix", &prefix); err != ErrGuildConfigNotFound {
+		t.Fatalf("expected ErrGuildConfigNotFound for other guild, got %v", err)
+	}
+
+	remove()
+	store.Delete("1", "prefix")
+	if changes != 1 {
+		t.Fatalf("expected 1 change notification, got %d", changes)
+	}
+	if err := store.Get("1", "prefix", &prefix); err != ErrGuildConfigNotFound {
+		t.Fatalf("expected ErrGuildConfigNotFound after delete, got %v", err)
+	}
+}
+
+func TestModuleGuildConfig(t *testing.T) {
+	s := &Session{}
+	if err := s.ModuleAdd(&testModule{}); err != nil {
+		t.Fatal(err)
+	}
+
+	store := NewMemoryGuildConfigStore()
+	defer s.ModuleGuildConfig(store)()
+
+	store.Set("1", GuildConfigModulePrefix+"test", false)
+	if s.ModuleEnabled("test", "1") {
+		t.Fatal("expected module to be disabled in guild")
+	}
+
+	store.Delete("1", GuildConfigModulePrefix+"test")
+	if !s.ModuleEnabled("test", "1") {
+		t.Fatal("expected module to be enabled in guild")
+	}
+}
+
+// mysqlDriver is a fake database/sql driver of a guild config table which
+// behaves like MySQL: updates only count the rows they changed, and
+// inserts fail on duplicate keys.
+type mysqlDriver struct {
+	sync.Mutex
+	rows map[[2]string]string
+}
+
+func (d *mysqlDriver) Open(name string) (driver.Conn, error) { return &mysqlConn{d}, nil }
+
+type mysqlConn struct{ d *mysqlDriver }
+
+func (c *mysqlConn) Prepare(query string) (driver.Stmt, error) { return &mysqlStmt{c.d, query}, nil }
+func (c *mysqlConn) Close() error                              { return nil }
+func (c *mysqlConn) Begin() (driver.Tx, error)                 { return c, nil }
+func (c *mysqlConn) Commit() error                             { return nil }
+func (c *mysqlConn) Rollback() error                           { return nil }
+
+type mysqlStmt struct {
+	d     *mysqlDriver
+	query string
+}
+
+func (s *mysqlStmt) Close() error  { return nil }
+func (s *mysqlStmt) NumInput() int { return -1 }
+
+func (s *mysqlStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.d.Lock()
+	defer s.d.Unlock()
+
+	switch {
+	case strings.HasPrefix(s.query, "UPDATE"):
+		k := [2]string{args[1].(string), args[2].(string)}
+		if v, ok := s.d.rows[k]; !ok || v == args[0].(string) {
+			return driver.RowsAffected(0), nil
+		}
+		s.d.rows[k] = args[0].(string)
+		return driver.RowsAffected(1), nil
+	case strings.HasPrefix(s.query, "INSERT"):
+		k := [2]string{args[0].(string), args[1].(string)}
+		if _, ok := s.d.rows[k]; ok {
+			return nil, errors.New("Error 1062: Duplicate entry")
+		}
+		s.d.rows[k] = args[2].(string)
+		return driver.RowsAffected(1), nil
+	case strings.HasPrefix(s.query, "DELETE"):
+		delete(s.d.rows, [2]string{args[0].(string), args[1].(string)})
+		return driver.RowsAffected(1), nil
+	}
+	return nil, errors.New("unexpected query " + s.query)
+}
+
+func (s *mysqlStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.d.Lock()
+	defer s.d.Unlock()
+
+	v, ok := s.d.rows[[2]string{args[0].(string), args[1].(string)}]
+	switch {
+	case strings.HasPrefix(s.query, "SELECT COUNT(*)"):
+		n := int64(0)
+		if ok {
+			n = 1
+		}
+		return &mysqlRows{values: []driver.Value{n}}, nil
+	case strings.HasPrefix(s.query, "SELECT config_value") && ok:
+		return &mysqlRows{values: []driver.Value{v}}, nil
+	case strings.HasPrefix(s.query, "SELECT config_value"):
+		return &mysqlRows{}, nil
+	}
+	return nil, errors.New("unexpected query " + s.query)
+}
+
+type mysqlRows struct {
+	values []driver.Value
+	done   bool
+}
+
+func (r *mysqlRows) Columns() []string { return []string{"value"} }
+func (r *mysqlRows) Close() error      { return nil }
+
+func (r *mysqlRows) Next(dest []driver.Value) error {
+	if r.done || r.values == nil {
+		return io.EOF
+	}
+	r.done = true
+	copy(dest, r.values)
+	return nil
+}
+
+var testMySQLDriver = &mysqlDriver{rows: map[[2]string]string{}}
+
+func init() {
+	sql.Register("guildconfigtest", testMySQLDriver)
+}
+
+func TestSQLGuildConfigStore(t *testing.T) {
+	db, err := sql.Open("guildconfigtest", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	store := NewSQLGuildConfigStore(db, "guild_config")
+	var prefix string
+	if err = store.Get("1", "prefix", &prefix); err != ErrGuildConfigNotFound {
+		t.Fatalf("expected ErrGuildConfigNotFound, got %v", err)
+	}
+
+	// Setting the same value twice updates no rows in MySQL.
+	for _, v := range []string{"!", "!", "?"} {
+		if err = store.Set("1", "prefix", v); err != nil {
+			t.Fatalf("Set(%q) returned error: %v", v, err)
+		}
+	}
+	if v := testMySQLDriver.rows[[2]string{"1", "prefix"}]; v != `"?"` {
+		t.Errorf("expected the stored value \"?\", got %s", v)
+	}
+
+	// A new store reads the value from the database.
+	store = NewSQLGuildConfigStore(db, "guild_config")
+	if err = store.Get("1", "prefix", &prefix); err != nil || prefix != "?" {
+		t.Fatalf("expected prefix ?, got %q (%v)", prefix, err)
+	}
+
+	if err = store.Delete("1", "prefix"); err != nil {
+		t.Fatal(err)
+	}
+	if err = store.Get("1", "prefix", &prefix); err != ErrGuildConfigNotFound {
+		t.Fatalf("expected ErrGuildConfigNotFound after delete, got %v", err)
+	}
+}
+
+func TestModuleGuildConfigLoad(t *testing.T) {
+	store := NewMemoryGuildConfigStore()
+	store.Set("1", GuildConfigModulePrefix+"test", false)
+	store.Set("2", GuildConfigModulePrefix+"test", false)
+	store.Set("3", GuildConfigModulePrefix+"test", "invalid")
+
+	s := &Session{SyncEvents: true, State: NewState()}
+	if err := s.ModuleAdd(&testModule{}); err != nil {
+		t.Fatal(err)
+	}
+	s.State.GuildAdd(&Guild{ID: "1"})
+
+	// The values stored before a restart apply to the guilds of the state,
+	// and to the guilds which become available later.
+	stop := s.ModuleGuildConfig(store)
+	if s.ModuleEnabled("test", "1") {
+		t.Error("expected the module to be disabled in the cached guild")
+	}
+	if !s.ModuleEnabled("test", "2") {
+		t.Error("expected the module to be enabled until the guild is available")
+	}
+	s.handleEvent(guildCreateEventType, &GuildCreate{&Guild{ID: "2"}})
+	s.handleEvent(guildCreateEventType, &GuildCreate{&Guild{ID: "3"}})
+	if s.ModuleEnabled("test", "2") {
+		t.Error("expected the module to be disabled in the available guild")
+	}
+	if !s.ModuleEnabled("test", "3") {
+		t.Error("expected an invalid value to be ignored")
+	}
+
+	stop()
+	s.ModuleGuildEnable("test", "2")
+	s.handleEvent(guildCreateEventType, &GuildCreate{&Guild{ID: "2"}})
+	if !s.ModuleEnabled("test", "2") {
+		t.Error("expected no values to be loaded after stopping")
+	}
+}
+
+func TestGuildPrefixLocale(t *testing.T) {
+	store := NewMemoryGuildConfigStore()
+	store.Set("1", GuildConfigPrefixKey, "?")
+	store.Set("1", GuildConfigLocaleKey, "fr")
+
+	if p := GuildPrefix(store, "1", "!"); p != "?" {
+		t.Errorf("expected the stored prefix, got %q", p)
+	}
+	if p := GuildPrefix(store, "2", "!"); p != "!" {
+		t.Errorf("expected the fallback prefix, got %q", p)
+	}
+	if l := GuildLocale(store, "1", "en-US"); l != "fr" {
+		t.Errorf("expected the stored locale, got %q", l)
+	}
+	if l := GuildLocale(store, "2", "en-US"); l != "en-US" {
+		t.Errorf("expected the fallback locale, got %q", l)
+	}
+}
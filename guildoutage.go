@@ -0,0 +1,89 @@
+package discordgo
+
+// DefaultOutageThreshold is the default OutageThreshold of a State.
+const DefaultOutageThreshold = 0.1
+
+// guildOutageMinGuilds is the number of guilds needed for GuildOutage
+// events, a few unavailable guilds of a small bot aren't an outage.
+const guildOutageMinGuilds = 10
+
+// UnavailableGuilds returns the guilds which became unavailable, eg. during
+// an outage, with their last known state, until they are available again.
+func (s *State) UnavailableGuilds() []*Guild {
+	if s == nil {
+		return nil
+	}
+
+	s.RLock()
+	defer s.RUnlock()
+
+	guilds := make([]*Guild, 0, len(s.unavailableGuilds))
+	for _, g := range s.unavailableGuilds {
+		guilds = append(guilds, g)
+	}
+	return guilds
+}
+
+// guildUnavailable moves a guild to the unavailable guilds.
+func (s *State) guildUnavailable(guild *Guild) {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.unavailableGuilds == nil {
+		s.unavailableGuilds = make(map[string]*Guild)
+	}
+
+	g, ok := s.guildMap[guild.ID]
+	if !ok {
+		g = guild
+	}
+	g.Unavailable = true
+	s.unavailableGuilds[guild.ID] = g
+}
+
+// guildAvailable removes a guild from the unavailable guilds.
+func (s *State) guildAvailable(guildID string) {
+	s.Lock()
+	defer s.Unlock()
+
+	delete(s.unavailableGuilds, guildID)
+}
+
+// guildOutage returns the GuildOutage event to fire, if the outage started
+// or ended.
+func (s *State) guildOutage() *GuildOutage {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.OutageThreshold <= 0 {
+		return nil
+	}
+
+	e := &GuildOutage{Unavailable: len(s.unavailableGuilds), Total: len(s.guildMap)}
+	for id := range s.unavailableGuilds {
+		if _, ok := s.guildMap[id]; !ok {
+			e.Total++
+		}
+	}
+
+	outage := e.Total >= guildOutageMinGuilds && float64(e.Unavailable) >= s.OutageThreshold*float64(e.Total)
+	if outage == s.outage {
+		return nil
+	}
+	s.outage = outage
+	e.Ended = !outage
+	return e
+}
+
+// guildOutageEvents fires a GuildOutage event if an outage started or ended,
+// after the state was updated.
+func (s *Session) guildOutageEvents() {
+	if e := s.State.guildOutage(); e != nil {
+		if e.Ended {
+			s.log(LogInformational, "guild outage ended, %d of %d guilds unavailable", e.Unavailable, e.Total)
+		} else {
+			s.log(LogWarning, "guild outage, %d of %d guilds unavailable", e.Unavailable, e.Total)
+		}
+		go s.handleEvent(guildOutageEventType, e)
+	}
+}
@@ -0,0 +1,39 @@
+package discordgo
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestGuildOutage(t *testing.T) {
+	se := &Session{StateEnabled: true, State: NewState()}
+	for i := 0; i < 20; i++ {
+		se.State.OnInterface(se, &GuildCreate{&Guild{ID: strconv.Itoa(i)}})
+	}
+	if e := se.State.guildOutage(); e != nil {
+		t.Fatalf("unexpected outage %+v", e)
+	}
+
+	se.State.OnInterface(se, &GuildDelete{&Guild{ID: "0", Unavailable: true}})
+	if e := se.State.guildOutage(); e != nil {
+		t.Fatalf("unexpected outage %+v", e)
+	}
+	se.State.OnInterface(se, &GuildDelete{&Guild{ID: "1", Unavailable: true}})
+	if e := se.State.guildOutage(); e == nil || e.Ended || e.Unavailable != 2 || e.Total != 20 {
+		t.Fatalf("expected an outage of 2 of 20 guilds, got %+v", e)
+	}
+	if n := len(se.State.UnavailableGuilds()); n != 2 {
+		t.Errorf("expected 2 unavailable guilds, got %d", n)
+	}
+	if _, err := se.State.Guild("1"); err != ErrStateNotFound {
+		t.Errorf("expected the unavailable guild to be removed, got %v", err)
+	}
+
+	se.State.OnInterface(se, &GuildCreate{&Guild{ID: "1"}})
+	if e := se.State.guildOutage(); e == nil || !e.Ended {
+		t.Fatalf("expected the outage to end, got %+v", e)
+	}
+	if n := len(se.State.UnavailableGuilds()); n != 1 {
+		t.Errorf("expected 1 unavailable guild, got %d", n)
+	}
+}
@@ -0,0 +1,130 @@
+package discordgo
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+)
+
+// MaxEmojiImageSize is the maximum size of the image of an emoji in bytes.
+const MaxEmojiImageSize = 256 * 1024
+
+// Errors returned by GuildEmojiCreate before sending the request.
+var (
+	ErrEmojiName          = errors.New("emoji names must be 2 to 32 letters, digits or underscores")
+	ErrEmojiImageFormat   = errors.New("emoji images must be a base64 data URI of a PNG, JPEG or GIF image, eg. data:image/png;base64,...")
+	ErrEmojiImageTooLarge = errors.New("emoji images must be at most 256 KiB")
+)
+
+// emojiImageContentTypes are the image formats of emojis.
+var emojiImageContentTypes = []string{"image/png", "image/jpeg", "image/gif"}
+
+// EmojiSlots returns the number of static emojis the guild can have, which
+// is also the number of animated emojis it can have, by its premium tier.
+func (g *Guild) EmojiSlots() int {
+	slots := [...]int{50, 100, 150, 250}[g.premiumTier()]
+	if slots < 200 && g.hasFeature("MORE_EMOJI") {
+		slots = 200
+	}
+	return slots
+}
+
+// EmojiSlotsRemaining returns the number of static and animated emojis
+// which can still be created in the guild. The emojis of integrations,
+// eg. Twitch, don't take a slot.
+func (g *Guild) EmojiSlotsRemaining() (static, animated int) {
+	static, animated = g.EmojiSlots(), g.EmojiSlots()
+	for _, e := range g.Emojis {
+		switch {
+		case e.Managed:
+		case e.Animated:
+			animated--
+		default:
+			static--
+		}
+	}
+
+	if static < 0 {
+		static = 0
+	}
+	if animated < 0 {
+		animated = 0
+	}
+	return
+}
+
+// StickerSlots returns the number of stickers the guild can have, by its
+// premium tier.
+func (g *Guild) StickerSlots() int {
+	slots := [...]int{5, 15, 30, 60}[g.premiumTier()]
+	if slots < 60 && g.hasFeature("MORE_STICKERS") {
+		slots = 60
+	}
+	return slots
+}
+
+// StickerSlotsRemaining returns the number of stickers which can still be
+// created in the guild.
+func (g *Guild) StickerSlotsRemaining() int {
+	if n := g.StickerSlots() - len(g.Stickers); n > 0 {
+		return n
+	}
+	return 0
+}
+
+// premiumTier returns the premium tier of the guild, limited to the known tiers.
+func (g *Guild) premiumTier() PremiumTier {
+	switch {
+	case g.PremiumTier < PremiumTierNone:
+		return PremiumTierNone
+	case g.PremiumTier > PremiumTier3:
+		return PremiumTier3
+	}
+	return g.PremiumTier
+}
+
+// hasFeature returns whether the guild has a feature.
+func (g *Guild) hasFeature(feature string) bool {
+	for _, f := range g.Features {
+		if f == feature {
+			return true
+		}
+	}
+	return false
+}
+
+// validateEmoji returns an error if Discord would reject the name or image
+// of a new emoji.
+func validateEmoji(name, image string) error {
+	if len(name) < 2 || len(name) > 32 {
+		return ErrEmojiName
+	}
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_') {
+			return ErrEmojiName
+		}
+	}
+
+	if !strings.HasPrefix(image, "data:") {
+		return ErrEmojiImageFormat
+	}
+	i := strings.Index(image, ";base64,")
+	if i < 0 {
+		return ErrEmojiImageFormat
+	}
+
+	contentType, data := image[len("data:"):i], image[i+len(";base64,"):]
+	known := false
+	for _, t := range emojiImageContentTypes {
+		known = known || contentType == t
+	}
+	if !known {
+		return ErrEmojiImageFormat
+	}
+
+	size := base64.StdEncoding.DecodedLen(len(data)) - (len(data) - len(strings.TrimRight(data, "=")))
+	if size > MaxEmojiImageSize {
+		return ErrEmojiImageTooLarge
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package discordgo
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+func TestGuildEmojiSlotsRemaining(t *testing.T) {
+	g := &Guild{PremiumTier: PremiumTier1, Emojis: []*Emoji{
+		{ID: "1"},
+		{ID: "2", Animated: true},
+		{ID: "3", Managed: true},
+	}}
+	if static, animated := g.EmojiSlotsRemaining(); static != 99 || animated != 99 {
+		t.Errorf("expected 99 and 99 slots, got %d and %d", static, animated)
+	}
+
+	g.Features = []string{"MORE_EMOJI"}
+	if n := g.EmojiSlots(); n != 200 {
+		t.Errorf("expected 200 slots, got %d", n)
+	}
+
+	g.Stickers = []*Sticker{{ID: "4"}}
+	if n := g.StickerSlotsRemaining(); n != 14 {
+		t.Errorf("expected 14 sticker slots, got %d", n)
+	}
+}
+
+func TestValidateEmoji(t *testing.T) {
+	png := "data:image/png;base64," + base64.StdEncoding.EncodeToString([]byte("png"))
+	large := "data:image/png;base64," + base64.StdEncoding.EncodeToString([]byte(strings.Repeat("a", MaxEmojiImageSize+1)))
+
+	tests := []struct {
+		name, image string
+		err         error
+	}{
+		{"emoji_1", png, nil},
+		{"e", png, ErrEmojiName},
+		{"emoji-1", png, ErrEmojiName},
+		{"emoji", base64.StdEncoding.EncodeToString([]byte("png")), ErrEmojiImageFormat},
+		{"emoji", "data:image/bmp;base64,AAAA", ErrEmojiImageFormat},
+		{"emoji", large, ErrEmojiImageTooLarge},
+	}
+	for _, test := range tests {
+		if err := validateEmoji(test.name, test.image); err != test.err {
+			t.Errorf("expected %v for %s, got %v", test.err, test.name, err)
+		}
+	}
+}
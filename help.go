@@ -0,0 +1,240 @@
+package discordgo
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultHelpPageSize is the number of commands on a page of a HelpMenu
+// created without a page size.
+const DefaultHelpPageSize = 5
+
+// maxHelpPageSize is the maximum number of embeds of a message.
+const maxHelpPageSize = 10
+
+// A HelpMenu is a Module which adds a help command listing the commands
+// added to the session with AddModuleCommand. The commands are grouped in a
+// category per module, picked with a select menu, and shown as an embed per
+// command on pages turned with buttons. The names and descriptions of the
+// commands are localized to the locale of the user. The menu is ephemeral,
+// so only the user who asked for it can use it.
+type HelpMenu struct {
+	// The name of the module and of its command, "help" if empty.
+	ModuleName string
+
+	// The description of the command, "Shows the commands" if empty.
+	Description string
+
+	// The descriptions of the categories by module name, optional.
+	Categories map[string]string
+
+	// The number of commands on a page, DefaultHelpPageSize if zero and
+	// at most 10.
+	PageSize int
+
+	// The color of the embeds.
+	Color int
+}
+
+// Name returns the name of the module.
+func (h *HelpMenu) Name() string {
+	if h.ModuleName == "" {
+		return "help"
+	}
+	return h.ModuleName
+}
+
+// Setup adds the help command and the handler of the menu components.
+func (h *HelpMenu) Setup(s *Session) error {
+	description := h.Description
+	if description == "" {
+		description = "Shows the commands"
+	}
+
+	err := s.AddModuleCommand(h.Name(), &ApplicationCommand{Name: h.Name(), Description: description}, func(s *Session, i *InteractionCreate) error {
+		data := h.Page(s, i.Locale, "", 0)
+		data.Flags = MessageFlagsEphemeral
+		return s.InteractionRespond(i.Interaction, &InteractionResponse{
+			Type: InteractionResponseChannelMessageWithSource,
+			Data: data,
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	s.AddModuleHandler(h.Name(), h.onComponent)
+	return nil
+}
+
+// Teardown does nothing, the menu has no state.
+func (h *HelpMenu) Teardown(s *Session) error {
+	return nil
+}
+
+// The custom IDs of the components of the menu are the name of the module
+// and the action, followed by the category and page for buttons.
+func (h *HelpMenu) customID(parts ...string) string {
+	return strings.Join(append([]string{h.Name()}, parts...), ":")
+}
+
+func (h *HelpMenu) onComponent(s *Session, i *InteractionCreate) {
+	if i.Type != InteractionMessageComponent {
+		return
+	}
+	data, err := i.MessageComponentData()
+	if err != nil {
+		return
+	}
+
+	var category string
+	var page int
+	parts := strings.Split(data.CustomID, ":")
+	switch {
+	case len(parts) == 2 && parts[0] == h.Name() && parts[1] == "category" && len(data.Values) == 1:
+		category = data.Values[0]
+	case len(parts) == 4 && parts[0] == h.Name() && parts[1] == "page":
+		category = parts[2]
+		if page, err = strconv.Atoi(parts[3]); err != nil {
+			return
+		}
+	default:
+		return
+	}
+
+	err = s.InteractionRespond(i.Interaction, &InteractionResponse{
+		Type: InteractionResponseUpdateMessage,
+		Data: h.Page(s, i.Locale, category, page),
+	})
+	if err != nil {
+		s.log(LogError, "error updating help menu, %s", err)
+	}
+}
+
+// Page returns a page of the menu.
+// s        : The session with the modules.
+// locale   : The locale of the user, eg. "fr".
+// category : The name of the module, the first module if empty or unknown.
+// page     : The page of the category, from 0.
+func (h *HelpMenu) Page(s *Session, locale, category string, page int) *InteractionResponseData {
+	var categories []string
+	for _, m := range s.Modules() {
+		if len(s.ModuleCommands(m.Name())) > 0 {
+			categories = append(categories, m.Name())
+		}
+	}
+	sort.Strings(categories)
+	// A select menu has up to 25 options.
+	if len(categories) > 25 {
+		categories = categories[:25]
+	}
+	if len(categories) == 0 {
+		return &InteractionResponseData{Content: "No commands."}
+	}
+
+	found := false
+	for _, c := range categories {
+		found = found || c == category
+	}
+	if !found {
+		category = categories[0]
+	}
+
+	size := h.PageSize
+	if size <= 0 {
+		size = DefaultHelpPageSize
+	}
+	if size > maxHelpPageSize {
+		size = maxHelpPageSize
+	}
+
+	commands := s.ModuleCommands(category)
+	pages := (len(commands) + size - 1) / size
+	if page >= pages {
+		page = pages - 1
+	}
+	if page < 0 {
+		page = 0
+	}
+	commands = commands[page*size:]
+	if len(commands) > size {
+		commands = commands[:size]
+	}
+
+	data := &InteractionResponseData{}
+	for _, cmd := range commands {
+		data.Embeds = append(data.Embeds, h.embed(cmd, locale))
+	}
+
+	menu := &MessageComponent{Type: ComponentTypeSelectMenu, CustomID: h.customID("category")}
+	for _, c := range categories {
+		menu.Options = append(menu.Options, &SelectMenuOption{
+			Label:       c,
+			Value:       c,
+			Description: h.Categories[c],
+			Default:     c == category,
+		})
+	}
+
+	buttons := []*MessageComponent{
+		{
+			Type:     ComponentTypeButton,
+			Style:    ButtonStyleSecondary,
+			Label:    "◀",
+			CustomID: h.customID("page", category, strconv.Itoa(page-1)),
+			Disabled: page == 0,
+		},
+		{
+			Type:     ComponentTypeButton,
+			Style:    ButtonStyleSecondary,
+			Label:    strconv.Itoa(page+1) + "/" + strconv.Itoa(pages),
+			CustomID: h.customID("current"),
+			Disabled: true,
+		},
+		{
+			Type:     ComponentTypeButton,
+			Style:    ButtonStyleSecondary,
+			Label:    "▶",
+			CustomID: h.customID("page", category, strconv.Itoa(page+1)),
+			Disabled: page == pages-1,
+		},
+	}
+
+	data.Components = []*MessageComponent{
+		{Type: ComponentTypeActionsRow, Components: []*MessageComponent{menu}},
+		{Type: ComponentTypeActionsRow, Components: buttons},
+	}
+	return data
+}
+
+// embed returns the embed of a command, localized to the locale.
+func (h *HelpMenu) embed(cmd *ApplicationCommand, locale string) *MessageEmbed {
+	e := &MessageEmbed{
+		Title:       localized(cmd.NameLocalizations, locale, cmd.Name),
+		Description: localized(cmd.DescriptionLocalizations, locale, cmd.Description),
+		Color:       h.Color,
+	}
+
+	if commandType(cmd.Type) == ChatApplicationCommand {
+		e.Title = "/" + e.Title
+	}
+
+	for _, o := range cmd.Options {
+		name := localized(o.NameLocalizations, locale, o.Name)
+		if o.Required {
+			name += " *"
+		}
+		e.AddField(name, localized(o.DescriptionLocalizations, locale, o.Description), false)
+	}
+	return e
+}
+
+// localized returns the localization of a name or description for the
+// locale, or the fallback if it has none.
+func localized(localizations map[string]string, locale, fallback string) string {
+	if l, ok := localizations[locale]; ok && l != "" {
+		return l
+	}
+	return fallback
+}
@@ -0,0 +1,100 @@
+package discordgo
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+)
+
+// commandsModule adds the commands with the given names.
+type commandsModule struct {
+	name     string
+	commands []*ApplicationCommand
+}
+
+func (m *commandsModule) Name() string { return m.name }
+
+func (m *commandsModule) Setup(s *Session) error {
+	for _, cmd := range m.commands {
+		if err := s.AddModuleCommand(m.name, cmd, func(*Session, *InteractionCreate) error { return nil }); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *commandsModule) Teardown(s *Session) error { return nil }
+
+func TestHelpMenu(t *testing.T) {
+	tr := &routeTransport{handle: answer(``)}
+	s := newTestSession(tr)
+	s.SyncEvents = true
+
+	moderation := &commandsModule{name: "moderation"}
+	for i := 0; i < 7; i++ {
+		moderation.commands = append(moderation.commands, &ApplicationCommand{
+			Name:                     "mod" + strconv.Itoa(i),
+			Description:              "Moderates",
+			DescriptionLocalizations: map[string]string{"fr": "Modère"},
+			Options: []*ApplicationCommandOption{
+				{Name: "user", NameLocalizations: map[string]string{"fr": "membre"}, Description: "The user", Required: true},
+			},
+		})
+	}
+	h := &HelpMenu{Categories: map[string]string{"moderation": "Keeps the peace"}}
+	for _, m := range []Module{moderation, h} {
+		if err := s.ModuleAdd(m); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	data := h.Page(s, "fr", "", 0)
+	if len(data.Embeds) != 1 || data.Embeds[0].Title != "/help" {
+		t.Fatalf("expected the help category first, got %+v", data.Embeds)
+	}
+	menu := data.Components[0].Components[0]
+	if len(menu.Options) != 2 || menu.Options[0].Value != "help" || !menu.Options[0].Default ||
+		menu.Options[1].Description != "Keeps the peace" {
+		t.Errorf("unexpected categories %+v", menu.Options)
+	}
+
+	data = h.Page(s, "fr", "moderation", 1)
+	if len(data.Embeds) != 2 || data.Embeds[0].Title != "/mod5" || data.Embeds[0].Description != "Modère" ||
+		data.Embeds[0].Fields[0].Name != "membre *" {
+		t.Errorf("expected the localized second page, got %+v", data.Embeds)
+	}
+	buttons := data.Components[1].Components
+	if buttons[0].Disabled || buttons[1].Label != "2/2" || !buttons[2].Disabled || buttons[0].CustomID != "help:page:moderation:0" {
+		t.Errorf("unexpected buttons %+v %+v %+v", buttons[0], buttons[1], buttons[2])
+	}
+
+	// Pages past the end show the last page.
+	if data := h.Page(s, "", "moderation", 5); len(data.Embeds) != 2 || data.Embeds[0].Title != "/mod5" {
+		t.Errorf("expected the last page, got %+v", data.Embeds)
+	}
+
+	s.handleEvent(interactionCreateEventType, &InteractionCreate{&Interaction{
+		ID: "1", Token: "token", Type: InteractionApplicationCommand, Data: []byte(`{"name": "help"}`),
+	}})
+	s.handleEvent(interactionCreateEventType, &InteractionCreate{&Interaction{
+		ID: "2", Token: "token", Type: InteractionMessageComponent, Locale: "fr",
+		Data: []byte(`{"custom_id": "help:category", "component_type": 3, "values": ["moderation"]}`),
+	}})
+
+	if len(tr.bodies) != 2 {
+		t.Fatalf("expected 2 responses, got %v", tr.requests)
+	}
+	var resp InteractionResponse
+	if err := json.Unmarshal([]byte(tr.bodies[0]), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Type != InteractionResponseChannelMessageWithSource || resp.Data.Flags != MessageFlagsEphemeral {
+		t.Errorf("expected an ephemeral message, got %+v", resp)
+	}
+	if err := json.Unmarshal([]byte(tr.bodies[1]), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Type != InteractionResponseUpdateMessage || len(resp.Data.Embeds) != DefaultHelpPageSize || resp.Data.Embeds[0].Description != "Modère" {
+		t.Errorf("expected the moderation category, got %+v", resp)
+	}
+}
@@ -0,0 +1,173 @@
+package discordgo
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"crypto/tls"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultMaxResponseSize is the default MaxResponseSize of a Session.
+const DefaultMaxResponseSize = 32 << 20
+
+// ErrUnsupportedEncoding is returned by REST functions when a response has
+// a content encoding other than gzip or deflate.
+var ErrUnsupportedEncoding = errors.New("unsupported content encoding of the response")
+
+// An ErrResponseTooLarge is returned by REST functions when a decompressed
+// response is larger than Session.MaxResponseSize.
+type ErrResponseTooLarge struct {
+	URL   string
+	Limit int64
+}
+
+// Error returns the URL and the limit.
+func (e *ErrResponseTooLarge) Error() string {
+	return "response of " + e.URL + " larger than " + strconv.FormatInt(e.Limit, 10) + " bytes"
+}
+
+// HTTPOptions tunes the HTTP client of the REST API, eg. for bots sending
+// many requests. Zero values use the defaults.
+type HTTPOptions struct {
+	// The time limit of a request, including reading the response body.
+	// Defaults to 20 seconds.
+	Timeout time.Duration
+
+	// The time limits of connecting and of the TLS handshake. Default to 10 seconds.
+	DialTimeout         time.Duration
+	TLSHandshakeTimeout time.Duration
+
+	// The time limit of waiting for the response headers after sending a
+	// request, none by default.
+	ResponseHeaderTimeout time.Duration
+
+	// The number of idle connections kept open to Discord, defaults to 16.
+	// Go's default of 2 makes bursts of requests open new connections.
+	MaxIdleConnsPerHost int
+
+	// How long idle connections are kept, defaults to 90 seconds.
+	IdleConnTimeout time.Duration
+
+	// The number of TLS sessions cached to resume them when reconnecting,
+	// defaults to 64. Negative disables the cache.
+	TLSSessionCacheSize int
+
+	// Disables HTTP/2, which multiplexes the requests over a single
+	// connection instead of keeping MaxIdleConnsPerHost connections.
+	DisableHTTP2 bool
+}
+
+// Default values of HTTPOptions.
+const (
+	DefaultHTTPTimeout             = 20 * time.Second
+	DefaultHTTPDialTimeout         = 10 * time.Second
+	DefaultHTTPTLSHandshakeTimeout = 10 * time.Second
+	DefaultHTTPMaxIdleConnsPerHost = 16
+	DefaultHTTPIdleConnTimeout     = 90 * time.Second
+	DefaultHTTPTLSSessionCacheSize = 64
+)
+
+// NewHTTPClient returns a new HTTP client for the REST API, see
+// Session.SetHTTPOptions.
+func NewHTTPClient(o HTTPOptions) *http.Client {
+	if o.Timeout == 0 {
+		o.Timeout = DefaultHTTPTimeout
+	}
+	if o.DialTimeout == 0 {
+		o.DialTimeout = DefaultHTTPDialTimeout
+	}
+	if o.TLSHandshakeTimeout == 0 {
+		o.TLSHandshakeTimeout = DefaultHTTPTLSHandshakeTimeout
+	}
+	if o.MaxIdleConnsPerHost == 0 {
+		o.MaxIdleConnsPerHost = DefaultHTTPMaxIdleConnsPerHost
+	}
+	if o.IdleConnTimeout == 0 {
+		o.IdleConnTimeout = DefaultHTTPIdleConnTimeout
+	}
+	if o.TLSSessionCacheSize == 0 {
+		o.TLSSessionCacheSize = DefaultHTTPTLSSessionCacheSize
+	}
+
+	tlsConfig := &tls.Config{}
+	if o.TLSSessionCacheSize > 0 {
+		tlsConfig.ClientSessionCache = tls.NewLRUClientSessionCache(o.TLSSessionCacheSize)
+	}
+
+	t := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   o.DialTimeout,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		TLSClientConfig:       tlsConfig,
+		TLSHandshakeTimeout:   o.TLSHandshakeTimeout,
+		ResponseHeaderTimeout: o.ResponseHeaderTimeout,
+		MaxIdleConns:          o.MaxIdleConnsPerHost,
+		MaxIdleConnsPerHost:   o.MaxIdleConnsPerHost,
+		IdleConnTimeout:       o.IdleConnTimeout,
+		ExpectContinueTimeout: time.Second,
+	}
+	forceHTTP2(t, !o.DisableHTTP2)
+	if o.DisableHTTP2 {
+		// A non nil map keeps the transport from negotiating HTTP/2.
+		t.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	}
+
+	return &http.Client{Timeout: o.Timeout, Transport: t}
+}
+
+// SetHTTPOptions replaces the HTTP client of the REST API with one tuned
+// with the options. It must not be called while requests are made.
+func (s *Session) SetHTTPOptions(o HTTPOptions) {
+	s.Client = NewHTTPClient(o)
+}
+
+// readResponse reads the body of a response, decompressing it and
+// enforcing MaxResponseSize.
+func (s *Session) readResponse(urlStr string, resp *http.Response) ([]byte, error) {
+	var body io.Reader = resp.Body
+	switch strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding"))) {
+	case "", "identity":
+	case "gzip":
+		zr, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		defer zr.Close()
+		body = zr
+	case "deflate":
+		zr, err := zlib.NewReader(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		defer zr.Close()
+		body = zr
+	default:
+		return nil, ErrUnsupportedEncoding
+	}
+
+	limit := s.MaxResponseSize
+	if limit == 0 {
+		limit = DefaultMaxResponseSize
+	}
+	if limit < 0 {
+		return ioutil.ReadAll(body)
+	}
+
+	response, err := ioutil.ReadAll(io.LimitReader(body, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(response)) > limit {
+		return nil, &ErrResponseTooLarge{URL: urlStr, Limit: limit}
+	}
+	return response, nil
+}
@@ -0,0 +1,10 @@
+//go:build !go1.13
+// +build !go1.13
+
+package discordgo
+
+import "net/http"
+
+// forceHTTP2 does nothing before Go 1.13, whose transports don't attempt
+// HTTP/2 with a custom dialer and TLS config.
+func forceHTTP2(t *http.Transport, force bool) {}
@@ -0,0 +1,12 @@
+//go:build go1.13
+// +build go1.13
+
+package discordgo
+
+import "net/http"
+
+// forceHTTP2 sets whether the transport attempts HTTP/2 although it has a
+// custom dialer and TLS config.
+func forceHTTP2(t *http.Transport, force bool) {
+	t.ForceAttemptHTTP2 = force
+}
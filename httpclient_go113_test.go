@@ -0,0 +1,18 @@
+//go:build go1.13
+// +build go1.13
+
+package discordgo
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestNewHTTPClientForceHTTP2(t *testing.T) {
+	if tr := NewHTTPClient(HTTPOptions{}).Transport.(*http.Transport); !tr.ForceAttemptHTTP2 {
+		t.Error("expected the transport to attempt HTTP/2")
+	}
+	if tr := NewHTTPClient(HTTPOptions{DisableHTTP2: true}).Transport.(*http.Transport); tr.ForceAttemptHTTP2 {
+		t.Error("expected the transport not to attempt HTTP/2")
+	}
+}
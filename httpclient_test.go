@@ -0,0 +1,63 @@
+package discordgo
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestNewHTTPClient(t *testing.T) {
+	c := NewHTTPClient(HTTPOptions{MaxIdleConnsPerHost: 32, TLSSessionCacheSize: -1})
+	tr := c.Transport.(*http.Transport)
+	if c.Timeout != DefaultHTTPTimeout || tr.MaxIdleConnsPerHost != 32 || tr.IdleConnTimeout != DefaultHTTPIdleConnTimeout {
+		t.Errorf("unexpected client settings, timeout %s, idle conns %d", c.Timeout, tr.MaxIdleConnsPerHost)
+	}
+	if tr.TLSClientConfig.ClientSessionCache != nil || tr.TLSNextProto != nil {
+		t.Error("expected HTTP/2 without a TLS session cache")
+	}
+
+	s := &Session{}
+	s.SetHTTPOptions(HTTPOptions{Timeout: time.Minute, DisableHTTP2: true})
+	tr = s.Client.Transport.(*http.Transport)
+	if s.Client.Timeout != time.Minute || tr.TLSNextProto == nil || tr.TLSClientConfig.ClientSessionCache == nil {
+		t.Error("expected HTTP/1.1 with a TLS session cache")
+	}
+}
+
+func TestReadResponse(t *testing.T) {
+	var gz, zl bytes.Buffer
+	w := gzip.NewWriter(&gz)
+	w.Write([]byte(`{"id":"1"}`))
+	w.Close()
+	zw := zlib.NewWriter(&zl)
+	zw.Write([]byte(`{"id":"2"}`))
+	zw.Close()
+
+	response := func(encoding string, body []byte) *http.Response {
+		return &http.Response{
+			Header: http.Header{"Content-Encoding": {encoding}},
+			Body:   ioutil.NopCloser(bytes.NewReader(body)),
+		}
+	}
+
+	s := &Session{}
+	if b, err := s.readResponse("url", response("gzip", gz.Bytes())); err != nil || string(b) != `{"id":"1"}` {
+		t.Errorf("unexpected gzip response %q, %v", b, err)
+	}
+	if b, err := s.readResponse("url", response("deflate", zl.Bytes())); err != nil || string(b) != `{"id":"2"}` {
+		t.Errorf("unexpected deflate response %q, %v", b, err)
+	}
+	if _, err := s.readResponse("url", response("br", nil)); err != ErrUnsupportedEncoding {
+		t.Errorf("expected ErrUnsupportedEncoding, got %v", err)
+	}
+
+	s.MaxResponseSize = 5
+	_, err := s.readResponse("url", response("gzip", gz.Bytes()))
+	if e, ok := err.(*ErrResponseTooLarge); !ok || e.Limit != 5 {
+		t.Errorf("expected ErrResponseTooLarge, got %v", err)
+	}
+}
@@ -0,0 +1,120 @@
+package discordgo
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"image"
+	"image/color"
+	_ "image/gif" // decodes GIFs with image.Decode
+	"image/jpeg"
+	"image/png"
+	"net/http"
+)
+
+// MaxImageSize is the maximum size of avatars, icons and banners in bytes.
+// Emojis and role icons are limited to MaxEmojiImageSize.
+const MaxImageSize = 10 * 1024 * 1024
+
+// Errors returned by the image functions.
+var (
+	ErrImageFormat   = errors.New("images must be PNG, JPEG or GIF")
+	ErrImageTooLarge = errors.New("image can not be made small enough")
+)
+
+// ImageDataURI returns the data URI of a PNG, JPEG or GIF image, as sent
+// for avatars, icons, role icons and emojis. Images larger than maxSize
+// are re-encoded and scaled down until they fit, which drops the animation
+// of GIFs.
+// data    : The image file.
+// maxSize : The maximum size in bytes, 0 for MaxImageSize.
+func ImageDataURI(data []byte, maxSize int) (string, error) {
+	if maxSize == 0 {
+		maxSize = MaxImageSize
+	}
+
+	contentType := http.DetectContentType(data)
+	switch contentType {
+	case "image/png", "image/jpeg", "image/gif":
+	default:
+		return "", ErrImageFormat
+	}
+
+	if len(data) <= maxSize {
+		return dataURI(contentType, data), nil
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+	return EncodeImageDataURI(img, maxSize)
+}
+
+// EncodeImageDataURI encodes an image as the data URI of a PNG, or of a
+// JPEG if the image is opaque and the PNG is larger than maxSize. The image
+// is scaled down by half until it fits.
+// img     : The image.
+// maxSize : The maximum size in bytes, 0 for MaxImageSize.
+func EncodeImageDataURI(img image.Image, maxSize int) (string, error) {
+	if maxSize == 0 {
+		maxSize = MaxImageSize
+	}
+
+	opaque := false
+	if o, ok := img.(interface{ Opaque() bool }); ok {
+		opaque = o.Opaque()
+	}
+
+	for {
+		var b bytes.Buffer
+		if err := png.Encode(&b, img); err != nil {
+			return "", err
+		}
+		if b.Len() <= maxSize {
+			return dataURI("image/png", b.Bytes()), nil
+		}
+
+		if opaque {
+			b.Reset()
+			if err := jpeg.Encode(&b, img, &jpeg.Options{Quality: 85}); err != nil {
+				return "", err
+			}
+			if b.Len() <= maxSize {
+				return dataURI("image/jpeg", b.Bytes()), nil
+			}
+		}
+
+		size := img.Bounds().Size()
+		if size.X < 32 || size.Y < 32 {
+			return "", ErrImageTooLarge
+		}
+		img = halveImage(img)
+	}
+}
+
+// dataURI returns the base64 data URI of a file.
+func dataURI(contentType string, data []byte) string {
+	return "data:" + contentType + ";base64," + base64.StdEncoding.EncodeToString(data)
+}
+
+// halveImage scales an image down to half its size, averaging every 2x2
+// block of pixels.
+func halveImage(img image.Image) *image.RGBA {
+	b := img.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, b.Dx()/2, b.Dy()/2))
+
+	for y := 0; y < dst.Rect.Max.Y; y++ {
+		for x := 0; x < dst.Rect.Max.X; x++ {
+			var r, g, bl, a uint32
+			for dy := 0; dy < 2; dy++ {
+				for dx := 0; dx < 2; dx++ {
+					pr, pg, pb, pa := img.At(b.Min.X+2*x+dx, b.Min.Y+2*y+dy).RGBA()
+					r, g, bl, a = r+pr, g+pg, bl+pb, a+pa
+				}
+			}
+			dst.SetRGBA(x, y, color.RGBA{uint8(r >> 10), uint8(g >> 10), uint8(bl >> 10), uint8(a >> 10)})
+		}
+	}
+	return dst
+}
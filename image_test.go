@@ -0,0 +1,58 @@
+package discordgo
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/color"
+	"image/png"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestImageDataURI(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 128, 128))
+	r := rand.New(rand.NewSource(1))
+	for i := range img.Pix {
+		img.Pix[i] = uint8(r.Intn(256))
+	}
+	for i := 3; i < len(img.Pix); i += 4 {
+		img.Pix[i] = 255
+	}
+
+	var b bytes.Buffer
+	if err := png.Encode(&b, img); err != nil {
+		t.Fatal(err)
+	}
+
+	uri, err := ImageDataURI(b.Bytes(), 0)
+	if err != nil || uri != "data:image/png;base64,"+base64.StdEncoding.EncodeToString(b.Bytes()) {
+		t.Errorf("unexpected data URI, %v", err)
+	}
+
+	uri, err = ImageDataURI(b.Bytes(), 4096)
+	if err != nil {
+		t.Fatal(err)
+	}
+	i := strings.Index(uri, ";base64,")
+	if data, err := base64.StdEncoding.DecodeString(uri[i+len(";base64,"):]); err != nil || len(data) > 4096 {
+		t.Errorf("expected an image of at most 4096 bytes, got %d, %v", len(data), err)
+	}
+
+	if _, err = ImageDataURI([]byte("not an image"), 0); err != ErrImageFormat {
+		t.Errorf("expected ErrImageFormat, got %v", err)
+	}
+}
+
+func TestHalveImage(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	img.SetRGBA(0, 0, color.RGBA{200, 0, 0, 255})
+	img.SetRGBA(1, 1, color.RGBA{200, 0, 0, 255})
+	img.SetRGBA(0, 1, color.RGBA{0, 0, 0, 255})
+	img.SetRGBA(1, 0, color.RGBA{0, 0, 0, 255})
+
+	if c := halveImage(img).RGBAAt(0, 0); c.R != 100 || c.A != 255 {
+		t.Errorf("unexpected color %+v", c)
+	}
+}
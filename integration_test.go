@@ -0,0 +1,207 @@
+package discordgo
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+// The integration tests run against the live API in a sandbox guild. They
+// are opt-in as they create and delete channels, roles and messages:
+//
+//	DG_INTEGRATION=1 DGB_TOKEN=<bot token> DG_GUILD=<guild ID> go test -run Integration
+//
+// The bot needs the manage channels, manage roles and manage messages
+// permissions in the guild.
+var envIntegration = os.Getenv("DG_INTEGRATION") != ""
+
+// sandboxPrefix starts the names of the resources the tests create, so
+// the ones left over by an interrupted run can be found and deleted.
+const sandboxPrefix = "dgo-test-"
+
+// A sandbox creates resources in the sandbox guild and deletes them again
+// when the test is done, in the reverse order of their creation.
+type sandbox struct {
+	t       *testing.T
+	s       *Session
+	guildID string
+
+	cleanups []func() error
+}
+
+// newSandbox returns a sandbox for the test, or skips the test if the
+// integration tests are not enabled. Call cleanup when the test is done.
+func newSandbox(t *testing.T) *sandbox {
+	if !envIntegration {
+		t.Skip("Skipping, DG_INTEGRATION not set.")
+	}
+	if dgBot == nil {
+		t.Skip("Skipping, dgBot not set.")
+	}
+	if envGuild == "" {
+		t.Skip("Skipping, DG_GUILD not set.")
+	}
+
+	sb := &sandbox{t: t, s: dgBot, guildID: envGuild}
+	sb.sweep()
+	return sb
+}
+
+// sweep deletes the resources left over by interrupted runs.
+func (sb *sandbox) sweep() {
+	channels, err := sb.s.GuildChannels(sb.guildID)
+	if err != nil {
+		sb.t.Fatalf("GuildChannels() returned error: %+v", err)
+	}
+	for _, c := range channels {
+		if strings.HasPrefix(c.Name, sandboxPrefix) {
+			if _, err := sb.s.ChannelDelete(c.ID); err != nil {
+				sb.t.Logf("error deleting leftover channel %s: %+v", c.ID, err)
+			}
+		}
+	}
+
+	roles, err := sb.s.GuildRoles(sb.guildID)
+	if err != nil {
+		sb.t.Fatalf("GuildRoles() returned error: %+v", err)
+	}
+	for _, r := range roles {
+		if strings.HasPrefix(r.Name, sandboxPrefix) {
+			if err := sb.s.GuildRoleDelete(sb.guildID, r.ID); err != nil {
+				sb.t.Logf("error deleting leftover role %s: %+v", r.ID, err)
+			}
+		}
+	}
+}
+
+// cleanup deletes the resources created by the sandbox.
+func (sb *sandbox) cleanup() {
+	for i := len(sb.cleanups) - 1; i >= 0; i-- {
+		if err := sb.cleanups[i](); err != nil {
+			sb.t.Errorf("cleanup returned error: %+v", err)
+		}
+	}
+	sb.cleanups = nil
+}
+
+// channel creates a text channel.
+func (sb *sandbox) channel(name string) *Channel {
+	c, err := sb.s.GuildChannelCreate(sb.guildID, sandboxPrefix+name, ChannelTypeGuildText)
+	if err != nil {
+		sb.t.Fatalf("GuildChannelCreate() returned error: %+v", err)
+	}
+	sb.cleanups = append(sb.cleanups, func() error {
+		_, err := sb.s.ChannelDelete(c.ID)
+		return err
+	})
+	return c
+}
+
+// role creates a role.
+func (sb *sandbox) role(name string) *Role {
+	r, err := sb.s.GuildRoleCreate(sb.guildID)
+	if err != nil {
+		sb.t.Fatalf("GuildRoleCreate() returned error: %+v", err)
+	}
+	sb.cleanups = append(sb.cleanups, func() error {
+		return sb.s.GuildRoleDelete(sb.guildID, r.ID)
+	})
+
+	r, err = sb.s.GuildRoleEdit(sb.guildID, r.ID, sandboxPrefix+name, r.Color, r.Hoist, r.Permissions, r.Mentionable)
+	if err != nil {
+		sb.t.Fatalf("GuildRoleEdit() returned error: %+v", err)
+	}
+	return r
+}
+
+// message sends a message, it is deleted unless its channel was deleted.
+func (sb *sandbox) message(channelID, content string) *Message {
+	m, err := sb.s.ChannelMessageSend(channelID, content)
+	if err != nil {
+		sb.t.Fatalf("ChannelMessageSend() returned error: %+v", err)
+	}
+	sb.cleanups = append(sb.cleanups, func() error {
+		err := sb.s.ChannelMessageDelete(channelID, m.ID)
+		if code := restErrorCode(err); code == ErrCodeUnknownChannel || code == ErrCodeUnknownMessage {
+			return nil
+		}
+		return err
+	})
+	return m
+}
+
+func TestIntegrationChannel(t *testing.T) {
+	sb := newSandbox(t)
+	defer sb.cleanup()
+
+	c := sb.channel("channel")
+	if c.GuildID != sb.guildID || c.Type != ChannelTypeGuildText {
+		t.Fatalf("expected a text channel in guild %s, got %+v", sb.guildID, c)
+	}
+
+	rateLimit, topic := 5, "integration test"
+	_, err := sb.s.ChannelEditComplex(c.ID, &ChannelEdit{
+		Name:             sandboxPrefix + "edited",
+		Topic:            &topic,
+		RateLimitPerUser: &rateLimit,
+	})
+	if err != nil {
+		t.Fatalf("ChannelEditComplex() returned error: %+v", err)
+	}
+
+	c, err = sb.s.Channel(c.ID)
+	if err != nil {
+		t.Fatalf("Channel() returned error: %+v", err)
+	}
+	if c.Name != sandboxPrefix+"edited" || c.Topic != "integration test" || c.RateLimitPerUser != rateLimit {
+		t.Errorf("expected the edited channel, got %+v", c)
+	}
+}
+
+func TestIntegrationRole(t *testing.T) {
+	sb := newSandbox(t)
+	defer sb.cleanup()
+
+	r := sb.role("role")
+	if r.Name != sandboxPrefix+"role" {
+		t.Fatalf("expected role %s, got %s", sandboxPrefix+"role", r.Name)
+	}
+
+	roles, err := sb.s.GuildRoles(sb.guildID)
+	if err != nil {
+		t.Fatalf("GuildRoles() returned error: %+v", err)
+	}
+	found := false
+	for _, role := range roles {
+		found = found || role.ID == r.ID
+	}
+	if !found {
+		t.Errorf("expected role %s in the roles of the guild", r.ID)
+	}
+}
+
+func TestIntegrationMessage(t *testing.T) {
+	sb := newSandbox(t)
+	defer sb.cleanup()
+
+	c := sb.channel("messages")
+	m := sb.message(c.ID, "integration test")
+
+	if _, err := sb.s.ChannelMessageEdit(c.ID, m.ID, "integration test, edited"); err != nil {
+		t.Fatalf("ChannelMessageEdit() returned error: %+v", err)
+	}
+	if err := sb.s.MessageReactionAdd(c.ID, m.ID, UnicodeEmoji("👍")); err != nil {
+		t.Fatalf("MessageReactionAdd() returned error: %+v", err)
+	}
+	if unpinned, err := sb.s.PinWithOverflow(c.ID, m.ID, nil); err != nil || unpinned != nil {
+		t.Fatalf("PinWithOverflow() returned %v and error: %+v", unpinned, err)
+	}
+
+	m, err := sb.s.ChannelMessage(c.ID, m.ID)
+	if err != nil {
+		t.Fatalf("ChannelMessage() returned error: %+v", err)
+	}
+	if m.Content != "integration test, edited" || !m.Pinned || len(m.Reactions) != 1 {
+		t.Errorf("expected the edited, pinned and reacted message, got %+v", m)
+	}
+}
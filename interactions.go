@@ -0,0 +1,375 @@
+package discordgo
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrInteractionNoMessage is returned when an interaction has no message,
+// eg. when disabling the components of a slash command interaction.
+var ErrInteractionNoMessage = errors.New("interaction has no message")
+
+// InteractionType is the type of an Interaction.
+// https://discord.com/developers/docs/interactions/slash-commands#interaction-object-interaction-request-type
+type InteractionType int
+
+// Block contains the valid known InteractionType values
+const (
+	InteractionPing InteractionType = iota + 1
+	InteractionApplicationCommand
+	InteractionMessageComponent
+)
+
+// An Interaction is sent when a user uses an application command or a
+// message component. Interactions must be responded to within 3 seconds,
+// with InteractionRespond or one of the helpers of Interaction.
+type Interaction struct {
+	ID            string          `json:"id"`
+	ApplicationID string          `json:"application_id"`
+	Type          InteractionType `json:"type"`
+
+	// The data of the interaction, decode it with the method of the type
+	// of the interaction, eg. MessageComponentData.
+	Data json.RawMessage `json:"data"`
+
+	GuildID   string `json:"guild_id"`
+	ChannelID string `json:"channel_id"`
+
+	// The message of the component, for component interactions.
+	Message *Message `json:"message"`
+
+	// The member who created the interaction in a guild, User is only set
+	// in DMs.
+	Member *Member `json:"member"`
+	User   *User   `json:"user"`
+
+	// Where the interaction was created, and the IDs of the guild or user
+	// installations of the application which authorized it by integration
+	// type. The ID of guild installations is "0" in DMs with the bot.
+	Context                      InteractionContextType                `json:"context"`
+	AuthorizingIntegrationOwners map[ApplicationIntegrationType]string `json:"authorizing_integration_owners"`
+
+	// The locale of the user, and the preferred locale of the guild.
+	Locale      string `json:"locale"`
+	GuildLocale string `json:"guild_locale"`
+
+	// The token used to respond to the interaction, valid for 15 minutes.
+	Token   string `json:"token"`
+	Version int    `json:"version"`
+
+	// The followup messages created with FollowupMessageCreate. It is a
+	// pointer so interactions can be copied, copies share the followups.
+	followups *interactionFollowups
+}
+
+// interactionFollowups holds the IDs of the followup messages of an
+// interaction.
+type interactionFollowups struct {
+	sync.Mutex
+	ids []string
+}
+
+// followupsInit guards creating the followups of interactions, the IDs
+// themselves are guarded by the lock of each interaction.
+var followupsInit sync.Mutex
+
+// getFollowups returns the followups of the interaction, creating them
+// on first use.
+func (i *Interaction) getFollowups() *interactionFollowups {
+	followupsInit.Lock()
+	defer followupsInit.Unlock()
+
+	if i.followups == nil {
+		i.followups = &interactionFollowups{}
+	}
+	return i.followups
+}
+
+// Followups returns the IDs of the followup messages of the interaction
+// created with FollowupMessageCreate, in the order they were created.
+func (i *Interaction) Followups() []string {
+	f := i.getFollowups()
+	f.Lock()
+	defer f.Unlock()
+
+	return append([]string(nil), f.ids...)
+}
+
+// setFollowups replaces the IDs of the followup messages.
+func (i *Interaction) setFollowups(update func(ids []string) []string) {
+	f := i.getFollowups()
+	f.Lock()
+	defer f.Unlock()
+
+	f.ids = update(f.ids)
+}
+
+// UserInstalled returns whether the interaction was authorized by an
+// installation of the application to the user who created it.
+func (i *Interaction) UserInstalled() bool {
+	_, ok := i.AuthorizingIntegrationOwners[ApplicationIntegrationUserInstall]
+	return ok
+}
+
+// Author returns the user who created the interaction, in guilds and DMs.
+func (i *Interaction) Author() *User {
+	if i.Member != nil && i.Member.User != nil {
+		return i.Member.User
+	}
+	return i.User
+}
+
+// MessageComponentInteractionData is the data of a component interaction.
+type MessageComponentInteractionData struct {
+	CustomID      string        `json:"custom_id"`
+	ComponentType ComponentType `json:"component_type"`
+
+	// The values of the selected options, select menus only.
+	Values []string `json:"values"`
+}
+
+// MessageComponentData decodes the data of a component interaction.
+func (i *Interaction) MessageComponentData() (data *MessageComponentInteractionData, err error) {
+	err = unmarshal(i.Data, &data)
+	return
+}
+
+// InteractionResponseType is the type of an InteractionResponse.
+// https://discord.com/developers/docs/interactions/slash-commands#interaction-response-object-interaction-callback-type
+type InteractionResponseType int
+
+// Block contains the valid known InteractionResponseType values
+const (
+	InteractionResponsePong InteractionResponseType = 1
+
+	// Responds with a message.
+	InteractionResponseChannelMessageWithSource InteractionResponseType = 4
+
+	// Shows a loading state, the message is sent later with InteractionResponseEdit.
+	InteractionResponseDeferredChannelMessageWithSource InteractionResponseType = 5
+
+	// Component interactions only, acknowledges the interaction and
+	// edits the message of the component later with InteractionResponseEdit.
+	InteractionResponseDeferredMessageUpdate InteractionResponseType = 6
+
+	// Component interactions only, edits the message of the component.
+	InteractionResponseUpdateMessage InteractionResponseType = 7
+
+	// Launches the activity of the application, for applications with
+	// activities enabled.
+	InteractionResponseLaunchActivity InteractionResponseType = 12
+)
+
+// An InteractionResponse is the response to an Interaction.
+type InteractionResponse struct {
+	Type InteractionResponseType  `json:"type"`
+	Data *InteractionResponseData `json:"data,omitempty"`
+}
+
+// InteractionResponseData is the message of an InteractionResponse. When
+// updating a message, only the set fields are changed.
+type InteractionResponseData struct {
+	TTS             bool                    `json:"tts,omitempty"`
+	Content         string                  `json:"content,omitempty"`
+	Embeds          []*MessageEmbed         `json:"embeds,omitempty"`
+	AllowedMentions *MessageAllowedMentions `json:"allowed_mentions,omitempty"`
+	Components      []*MessageComponent     `json:"components,omitempty"`
+
+	// MessageFlagsEphemeral makes the message visible only to the author of the interaction.
+	Flags MessageFlags `json:"flags,omitempty"`
+}
+
+// An InteractionCallbackResponse is returned by InteractionRespondWithResponse.
+type InteractionCallbackResponse struct {
+	Interaction *InteractionCallback         `json:"interaction"`
+	Resource    *InteractionCallbackResource `json:"resource"`
+}
+
+// An InteractionCallback describes the interaction which was responded to.
+type InteractionCallback struct {
+	ID   string          `json:"id"`
+	Type InteractionType `json:"type"`
+
+	// The instance of the activity launched by the response, if any.
+	ActivityInstanceID string `json:"activity_instance_id,omitempty"`
+
+	// The message of the response, if any.
+	ResponseMessageID        string `json:"response_message_id,omitempty"`
+	ResponseMessageLoading   bool   `json:"response_message_loading,omitempty"`
+	ResponseMessageEphemeral bool   `json:"response_message_ephemeral,omitempty"`
+}
+
+// An InteractionCallbackResource is the resource created by the response to
+// an interaction, depending on the type of the response.
+type InteractionCallbackResource struct {
+	Type InteractionResponseType `json:"type"`
+
+	// The launched activity, InteractionResponseLaunchActivity only.
+	ActivityInstance *InteractionCallbackActivityInstance `json:"activity_instance,omitempty"`
+
+	// The created or updated message, message responses only.
+	Message *Message `json:"message,omitempty"`
+}
+
+// An InteractionCallbackActivityInstance is the activity instance launched by
+// an interaction response, see ApplicationActivityInstance.
+type InteractionCallbackActivityInstance struct {
+	ID string `json:"id"`
+}
+
+// Respond responds to the interaction, see InteractionRespond.
+func (i *Interaction) Respond(resp *InteractionResponse, session *Session) error {
+	return session.InteractionRespond(i, resp)
+}
+
+// UpdateMessage responds to a component interaction by editing the message
+// of the component.
+// data : The fields of the message to change.
+func (i *Interaction) UpdateMessage(data *InteractionResponseData, session *Session) error {
+	return i.Respond(&InteractionResponse{Type: InteractionResponseUpdateMessage, Data: data}, session)
+}
+
+// DeferUpdate acknowledges a component interaction without changing the
+// message of the component, it can be edited later with InteractionResponseEdit.
+func (i *Interaction) DeferUpdate(session *Session) error {
+	return i.Respond(&InteractionResponse{Type: InteractionResponseDeferredMessageUpdate}, session)
+}
+
+// DisableComponents responds to a component interaction by disabling all
+// components of the message, eg. after a one time confirmation. After the
+// interaction was deferred use InteractionResponseEdit with
+// DisabledComponents instead.
+func (i *Interaction) DisableComponents(session *Session) error {
+	if i.Message == nil {
+		return ErrInteractionNoMessage
+	}
+
+	return i.UpdateMessage(&InteractionResponseData{Components: DisabledComponents(i.Message.Components)}, session)
+}
+
+// AutoDeferDelay is the default time after which AutoDefer defers an
+// interaction, leaving some time of the 3 seconds Discord waits for a
+// response for the defer to arrive.
+const AutoDeferDelay = 2500 * time.Millisecond
+
+// An InteractionResponder responds to an interaction, deferring the
+// response when the handler takes too long, see AutoDefer.
+type InteractionResponder struct {
+	sync.Mutex
+
+	Interaction *Interaction
+
+	session   *Session
+	timer     *time.Timer
+	ephemeral bool
+	responded bool
+	deferred  bool
+}
+
+// AutoDefer wraps an InteractionCreate handler which may take longer than
+// Discord waits for a response, eg. because it calls other APIs. If the
+// handler didn't respond after the delay the interaction is deferred, and
+// the later response of the handler is sent as an edit of the deferred
+// response instead. Component interactions are deferred without changing
+// the message, other interactions with a loading state.
+// delay     : The time to wait before deferring, AutoDeferDelay if 0.
+// ephemeral : Whether the loading state and response of a deferred command is only visible to the user.
+// handler   : The handler, which must respond with the InteractionResponder.
+func AutoDefer(delay time.Duration, ephemeral bool, handler func(s *Session, i *InteractionCreate, r *InteractionResponder)) func(s *Session, i *InteractionCreate) {
+	if delay == 0 {
+		delay = AutoDeferDelay
+	}
+
+	return func(s *Session, i *InteractionCreate) {
+		r := &InteractionResponder{Interaction: i.Interaction, session: s, ephemeral: ephemeral}
+		r.timer = time.AfterFunc(delay, r.deferResponse)
+		defer r.timer.Stop()
+
+		handler(s, i, r)
+	}
+}
+
+// deferResponse defers the interaction unless the handler responded.
+func (r *InteractionResponder) deferResponse() {
+	r.Lock()
+	defer r.Unlock()
+
+	if r.responded {
+		return
+	}
+
+	resp := &InteractionResponse{Type: InteractionResponseDeferredChannelMessageWithSource}
+	if r.Interaction.Type == InteractionMessageComponent {
+		resp.Type = InteractionResponseDeferredMessageUpdate
+	} else if r.ephemeral {
+		resp.Data = &InteractionResponseData{Flags: MessageFlagsEphemeral}
+	}
+
+	err := r.session.InteractionRespond(r.Interaction, resp)
+	r.emitResponse(err)
+	if err != nil {
+		r.session.log(LogError, "error deferring interaction %s, %s", r.Interaction.ID, err)
+		return
+	}
+	r.responded, r.deferred = true, true
+}
+
+// emitResponse passes the CommandEventResponse event of the first response
+// to the CommandHooks of the session.
+func (r *InteractionResponder) emitResponse(err error) {
+	e := interactionCommandEvent(CommandEventResponse, r.Interaction)
+	if created, tsErr := SnowflakeTimestamp(r.Interaction.ID); tsErr == nil {
+		e.Latency = time.Since(created)
+	}
+	e.Err = err
+	r.session.emitCommandEvent(e)
+}
+
+// Deferred returns whether the interaction was deferred.
+func (r *InteractionResponder) Deferred() bool {
+	r.Lock()
+	defer r.Unlock()
+
+	return r.deferred
+}
+
+// Respond responds to the interaction. If the interaction was already
+// responded to, eg. because it was deferred, the message of the response is
+// sent as an edit of the original response instead. Deferred responses are
+// ignored in that case.
+func (r *InteractionResponder) Respond(resp *InteractionResponse) error {
+	r.timer.Stop()
+
+	// Holding the lock makes a response wait until a defer in flight was sent.
+	r.Lock()
+	responded := r.responded
+	r.responded = true
+	r.Unlock()
+
+	if !responded {
+		err := r.session.InteractionRespond(r.Interaction, resp)
+		r.emitResponse(err)
+		return err
+	}
+
+	if resp.Data == nil || resp.Type == InteractionResponseDeferredChannelMessageWithSource || resp.Type == InteractionResponseDeferredMessageUpdate {
+		return nil
+	}
+
+	data := resp.Data
+	edit := &WebhookEdit{AllowedMentions: data.AllowedMentions}
+	if data.Content != "" || resp.Type == InteractionResponseChannelMessageWithSource {
+		edit.Content = &data.Content
+	}
+	if data.Embeds != nil {
+		edit.Embeds = &data.Embeds
+	}
+	if data.Components != nil {
+		edit.Components = &data.Components
+	}
+
+	_, err := r.session.InteractionResponseEdit(r.Interaction, edit)
+	return err
+}
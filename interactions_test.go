@@ -0,0 +1,379 @@
+package discordgo
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDisabledComponents(t *testing.T) {
+	row := &MessageComponent{Type: ComponentTypeActionsRow, Components: []*MessageComponent{
+		{Type: ComponentTypeButton, Style: ButtonStylePrimary, CustomID: "confirm"},
+		{Type: ComponentTypeButton, Style: ButtonStyleLink, URL: "https://example.com"},
+	}}
+
+	disabled := DisabledComponents([]*MessageComponent{row})
+	if buttons := disabled[0].Components; !buttons[0].Disabled || buttons[1].Disabled {
+		t.Errorf("expected only the primary button to be disabled")
+	}
+	if row.Components[0].Disabled {
+		t.Errorf("expected the original components to be unchanged")
+	}
+}
+
+func TestInteractionComponentResponses(t *testing.T) {
+	tr := &routeTransport{handle: answer(``)}
+	s := newTestSession(tr)
+
+	message := &Message{Components: []*MessageComponent{{Type: ComponentTypeActionsRow, Components: []*MessageComponent{
+		{Type: ComponentTypeButton, Style: ButtonStylePrimary, CustomID: "confirm"},
+	}}}}
+	responses := []func(i *Interaction) error{
+		func(i *Interaction) error { return i.UpdateMessage(&InteractionResponseData{Content: "updated"}, s) },
+		func(i *Interaction) error { return i.DeferUpdate(s) },
+		func(i *Interaction) error { return i.DisableComponents(s) },
+	}
+	for n, respond := range responses {
+		i := &Interaction{ID: string(rune('1' + n)), Token: "token", Message: message}
+		if err := respond(i); err != nil {
+			t.Fatal(err)
+		}
+		if r := tr.last(); r != "POST "+EndpointInteractionResponse(i.ID, "token") {
+			t.Errorf("unexpected request %s", r)
+		}
+	}
+
+	var disabled InteractionResponse
+	json.Unmarshal([]byte(tr.bodies[2]), &disabled)
+	if disabled.Type != InteractionResponseUpdateMessage || !disabled.Data.Components[0].Components[0].Disabled {
+		t.Errorf("expected the components to be disabled, got %s", tr.bodies[2])
+	}
+	if !strings.Contains(tr.bodies[1], `"type":6`) {
+		t.Errorf("expected a deferred update, got %s", tr.bodies[1])
+	}
+
+	// The responses to all interactions share a bucket.
+	if n := s.Ratelimiter.Buckets(); n != 1 {
+		t.Errorf("expected 1 bucket, got %d", n)
+	}
+}
+
+func TestInteractionRespondWithResponse(t *testing.T) {
+	tr := &routeTransport{routes: map[string]string{
+		"POST " + EndpointInteractionResponse("1", "token") + "?with_response=true": `{
+			"interaction": {"id": "1", "type": 2, "response_message_id": "3", "response_message_ephemeral": true},
+			"resource": {"type": 4, "message": {"id": "3", "content": "hi", "flags": 64}}
+		}`,
+		"POST " + EndpointInteractionResponse("2", "token") + "?with_response=true": `{
+			"interaction": {"id": "2", "type": 2, "activity_instance_id": "4"},
+			"resource": {"type": 12, "activity_instance": {"id": "4"}}
+		}`,
+	}}
+	s := newTestSession(tr)
+
+	r, err := s.InteractionRespondWithResponse(&Interaction{ID: "1", Token: "token"}, &InteractionResponse{
+		Type: InteractionResponseChannelMessageWithSource,
+		Data: &InteractionResponseData{Content: "hi", Flags: MessageFlagsEphemeral},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Interaction.ResponseMessageID != "3" || !r.Interaction.ResponseMessageEphemeral {
+		t.Errorf("unexpected interaction %+v", r.Interaction)
+	}
+	if r.Resource.Type != InteractionResponseChannelMessageWithSource || r.Resource.Message == nil || r.Resource.Message.ID != "3" {
+		t.Errorf("expected the message of the response, got %+v", r.Resource)
+	}
+	if tr.bodies[0] != `{"type":4,"data":{"content":"hi","flags":64}}` {
+		t.Errorf("unexpected response %s", tr.bodies[0])
+	}
+
+	r, err = s.InteractionRespondWithResponse(&Interaction{ID: "2", Token: "token"}, &InteractionResponse{Type: InteractionResponseLaunchActivity})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Resource.ActivityInstance == nil || r.Resource.ActivityInstance.ID != r.Interaction.ActivityInstanceID {
+		t.Errorf("expected the launched activity, got %+v", r.Resource)
+	}
+
+	// Errors of the response are returned, and it shares the bucket of
+	// InteractionRespond.
+	if _, err = s.InteractionRespondWithResponse(&Interaction{ID: "5", Token: "token"}, &InteractionResponse{Type: InteractionResponseDeferredMessageUpdate}); restErrorCode(err) != 10063 {
+		t.Errorf("expected the error of the request, got %v", err)
+	}
+	if n := s.Ratelimiter.Buckets(); n != 1 {
+		t.Errorf("expected 1 bucket, got %d", n)
+	}
+}
+
+func TestInteractionMessageComponentData(t *testing.T) {
+	i := &Interaction{Type: InteractionMessageComponent, Data: []byte(`{"custom_id":"menu","component_type":3,"values":["a","b"]}`)}
+
+	data, err := i.MessageComponentData()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data.CustomID != "menu" || data.ComponentType != ComponentTypeSelectMenu || len(data.Values) != 2 {
+		t.Errorf("unexpected component data %+v", data)
+	}
+}
+
+func TestErrorTranslator(t *testing.T) {
+	tr := NewErrorTranslator()
+	tr.Messages["de"] = map[string]string{ErrorMessageMissingPermission: "Mir fehlt die Berechtigung {{permission}}."}
+
+	err := &ErrMissingPermission{Perm: PermissionEmbedLinks}
+	if msg := tr.Message(err, "de-DE"); msg != "Mir fehlt die Berechtigung Embed Links." {
+		t.Errorf("unexpected german message %q", msg)
+	}
+	if msg := tr.Message(err, "fr"); msg != "I need the Embed Links permission to do that." {
+		t.Errorf("unexpected fallback message %q", msg)
+	}
+
+	rerr := &RESTError{Message: &APIErrorMessage{Code: ErrCodeUnknownMessage}}
+	if msg := tr.Message(rerr, ""); msg != DefaultErrorMessages[ErrorMessageNotFound] {
+		t.Errorf("unexpected message %q for an unknown message error", msg)
+	}
+}
+
+func TestInteractionUserInstalled(t *testing.T) {
+	var i Interaction
+	if err := json.Unmarshal([]byte(`{"context":2,"authorizing_integration_owners":{"1":"42"}}`), &i); err != nil {
+		t.Fatal(err)
+	}
+	if i.Context != InteractionContextPrivateChannel || !i.UserInstalled() || i.AuthorizingIntegrationOwners[ApplicationIntegrationUserInstall] != "42" {
+		t.Errorf("unexpected interaction %+v", i)
+	}
+}
+
+func TestApplicationCommandMarshal(t *testing.T) {
+	b, err := json.Marshal(&ApplicationCommand{
+		Name:             "purge",
+		IntegrationTypes: []ApplicationIntegrationType{ApplicationIntegrationUserInstall},
+		Contexts:         []InteractionContextType{InteractionContextGuild, InteractionContextPrivateChannel},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"name":"purge","integration_types":[1],"contexts":[0,2]}`
+	if string(b) != want {
+		t.Errorf("got %s, want %s", b, want)
+	}
+}
+
+func TestAttachmentOption(t *testing.T) {
+	i := &Interaction{Type: InteractionApplicationCommand, Data: []byte(`{
+		"name": "upload",
+		"options": [{"name": "file", "type": 11, "value": "5"}],
+		"resolved": {"attachments": {"5": {"id": "5", "filename": "log.txt", "content_type": "text/plain"}}}
+	}`)}
+
+	data, err := i.ApplicationCommandData()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a := data.Options[0].AttachmentValue(data)
+	if a == nil || a.Filename != "log.txt" || a.ContentType != "text/plain" {
+		t.Errorf("unexpected attachment %+v", a)
+	}
+}
+
+func TestApplicationCommandValidate(t *testing.T) {
+	min, max := 10.0, 1.0
+	cmd := &ApplicationCommand{
+		Name:        "ban",
+		Description: "Bans a user",
+		Options: []*ApplicationCommandOption{
+			{Type: ApplicationCommandOptionUser, Name: "user", Description: "The user", Required: true},
+			{Type: ApplicationCommandOptionInteger, Name: "days", Description: "Days of messages to delete", MinValue: &min, MaxValue: &max},
+		},
+	}
+
+	err := cmd.Validate()
+	if verr, ok := err.(*ApplicationCommandError); !ok || verr.Path != "ban/days" {
+		t.Errorf("expected an error for ban/days, got %v", err)
+	}
+
+	min = 0
+	if err := cmd.Validate(); err != nil {
+		t.Errorf("expected a valid command, got %v", err)
+	}
+
+	cmd.Options[0].ChannelTypes = []ChannelType{ChannelTypeGuildText}
+	if err := cmd.Validate(); err == nil {
+		t.Errorf("expected channel types on a user option to be invalid")
+	}
+
+	cmd.Options[0].ChannelTypes = nil
+	cmd.Options = append(cmd.Options, &ApplicationCommandOption{Type: ApplicationCommandOptionString, Name: "reason", Description: "The reason"})
+	minLength, maxLength := 10, 5
+	cmd.Options[2].MinLength, cmd.Options[2].MaxLength = &minLength, &maxLength
+	if err := cmd.Validate(); err == nil {
+		t.Errorf("expected a min length greater than the max length to be invalid")
+	}
+
+	maxLength = 0
+	cmd.Options[2].MinLength = nil
+	if err := cmd.Validate(); err == nil {
+		t.Errorf("expected a max length of 0 to be invalid")
+	}
+}
+
+func TestApplicationCommandPermissions(t *testing.T) {
+	cmd := (&ApplicationCommand{Name: "purge", NSFW: true}).
+		SetDefaultMemberPermissions(PermissionManageMessages, PermissionReadMessageHistory).
+		SetDMPermission(false)
+
+	b, err := json.Marshal(cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"name":"purge","default_member_permissions":"73728","nsfw":true,"dm_permission":false}`
+	if string(b) != want {
+		t.Errorf("expected %s, got %s", want, b)
+	}
+
+	if cmd.SetDefaultMemberPermissions(); *cmd.DefaultMemberPermissions != 0 {
+		t.Errorf("expected administrators only, got %d", *cmd.DefaultMemberPermissions)
+	}
+}
+
+func TestFollowupMessages(t *testing.T) {
+	next := 10
+	tr := &routeTransport{
+		routes: map[string]string{
+			"DELETE " + EndpointWebhookMessage("1", "token", "11"): `{"code": 10008, "message": "Unknown Message"}`,
+		},
+		statuses: map[string]int{"DELETE " + EndpointWebhookMessage("1", "token", "11"): http.StatusNotFound},
+		handle: func(req *http.Request, body []byte) (int, string) {
+			if req.Method == "POST" {
+				next++
+				return http.StatusOK, `{"id": "` + strconv.Itoa(next) + `"}`
+			}
+			return http.StatusOK, `{}`
+		},
+	}
+	s := newTestSession(tr)
+
+	i := &Interaction{ApplicationID: "1", Token: "token"}
+	for n := 0; n < 3; n++ {
+		if _, err := s.FollowupMessageCreate(i, &WebhookParams{Content: "part"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if r := tr.last(); r != "POST "+EndpointWebhookToken("1", "token")+"?wait=true" {
+		t.Errorf("unexpected request %s", r)
+	}
+
+	// Copies of the interaction share the followups.
+	copied := *i
+	if err := s.FollowupMessageDelete(&copied, "12"); err != nil {
+		t.Fatal(err)
+	}
+	if ids := i.Followups(); len(ids) != 2 || ids[0] != "11" || ids[1] != "13" {
+		t.Fatalf("expected followups 11 and 13, got %v", ids)
+	}
+
+	n := tr.count()
+	if err := s.FollowupMessagesEdit(i, &WebhookEdit{}); err != nil {
+		t.Fatal(err)
+	}
+	if tr.count() != n+2 || tr.last() != "PATCH "+EndpointWebhookMessage("1", "token", "13") {
+		t.Errorf("expected both followups to be edited, got %v", tr.requests[n:])
+	}
+
+	// Followups which couldn't be deleted are kept.
+	err := s.FollowupMessagesDelete(i)
+	if errs, ok := err.(IDErrors); !ok || len(errs) != 1 || restErrorCode(errs["11"]) != ErrCodeUnknownMessage {
+		t.Errorf("expected an error for followup 11, got %v", err)
+	}
+	if ids := i.Followups(); len(ids) != 1 || ids[0] != "11" {
+		t.Errorf("expected followup 11 to be left, got %v", ids)
+	}
+}
+
+func TestAutoDefer(t *testing.T) {
+	tr := &routeTransport{handle: answer(`{}`)}
+	s := newTestSession(tr)
+	callback := "POST " + EndpointInteractionResponse("1", "token")
+	original := "PATCH " + EndpointWebhookMessage("2", "token", "@original")
+
+	command := &InteractionCreate{&Interaction{ID: "1", ApplicationID: "2", Token: "token", Type: InteractionApplicationCommand}}
+	reply := &InteractionResponse{Type: InteractionResponseChannelMessageWithSource, Data: &InteractionResponseData{Content: "done"}}
+
+	// A handler which responds in time isn't deferred.
+	AutoDefer(time.Hour, true, func(s *Session, i *InteractionCreate, r *InteractionResponder) {
+		if err := r.Respond(reply); err != nil {
+			t.Fatal(err)
+		}
+	})(s, command)
+	if len(tr.requests) != 1 || tr.requests[0] != callback || !strings.Contains(tr.bodies[0], `"type":4`) {
+		t.Fatalf("expected a response, got %v", tr.requests)
+	}
+
+	// A slow handler is deferred, and its response edits the deferred one.
+	tr.requests, tr.bodies = nil, nil
+	AutoDefer(time.Millisecond, true, func(s *Session, i *InteractionCreate, r *InteractionResponder) {
+		for !r.Deferred() {
+			time.Sleep(time.Millisecond)
+		}
+		if err := r.Respond(reply); err != nil {
+			t.Fatal(err)
+		}
+		// Deferring again after the response does nothing.
+		if err := r.Respond(&InteractionResponse{Type: InteractionResponseDeferredChannelMessageWithSource}); err != nil {
+			t.Fatal(err)
+		}
+	})(s, command)
+	if len(tr.requests) != 2 || tr.requests[0] != callback || tr.requests[1] != original {
+		t.Fatalf("expected a defer and an edit, got %v", tr.requests)
+	}
+	if tr.bodies[0] != `{"type":5,"data":{"flags":64}}` || tr.bodies[1] != `{"content":"done"}` {
+		t.Errorf("unexpected defer %s and edit %s", tr.bodies[0], tr.bodies[1])
+	}
+
+	// Components are deferred without a loading state.
+	tr.requests, tr.bodies = nil, nil
+	component := &InteractionCreate{&Interaction{ID: "1", ApplicationID: "2", Token: "token", Type: InteractionMessageComponent}}
+	AutoDefer(time.Millisecond, true, func(s *Session, i *InteractionCreate, r *InteractionResponder) {
+		for !r.Deferred() {
+			time.Sleep(time.Millisecond)
+		}
+	})(s, component)
+	if len(tr.requests) != 1 || tr.bodies[0] != `{"type":6}` {
+		t.Errorf("expected a deferred update, got %v %v", tr.requests, tr.bodies)
+	}
+}
+
+func TestAutoDeferRace(t *testing.T) {
+	tr := &routeTransport{handle: answer(`{}`)}
+	s := newTestSession(tr)
+	command := &InteractionCreate{&Interaction{ID: "1", ApplicationID: "2", Token: "token", Type: InteractionApplicationCommand}}
+
+	// Whether the timer or the handler comes first, the interaction is
+	// responded to once, and a deferred response is edited.
+	for n := 0; n < 50; n++ {
+		tr.requests, tr.bodies = nil, nil
+		var deferred bool
+		AutoDefer(time.Nanosecond, false, func(s *Session, i *InteractionCreate, r *InteractionResponder) {
+			r.Respond(&InteractionResponse{Type: InteractionResponseChannelMessageWithSource, Data: &InteractionResponseData{Content: "done"}})
+			deferred = r.Deferred()
+		})(s, command)
+
+		callbacks := 0
+		for _, r := range tr.requests {
+			if r == "POST "+EndpointInteractionResponse("1", "token") {
+				callbacks++
+			}
+		}
+		if callbacks != 1 || deferred != (len(tr.requests) == 2) {
+			t.Fatalf("expected one response, and an edit if deferred (%v), got %v", deferred, tr.requests)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package discordgo
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Cloudflare bans the IP of a client for a while when it sends
+// MaxInvalidRequests invalid requests, responded to with a 401, 403 or 429
+// status, in an InvalidRequestWindow. The 429 responses of shared rate
+// limits aren't counted.
+const (
+	MaxInvalidRequests   = 10000
+	InvalidRequestWindow = 10 * time.Minute
+)
+
+// DefaultInvalidRequestThreshold is the default InvalidRequestThreshold of a
+// Session, which leaves a margin to the requests in flight.
+const DefaultInvalidRequestThreshold = 9000
+
+// ErrInvalidRequestLimit is returned by the requests of a session while it
+// made InvalidRequestThreshold invalid requests in the last
+// InvalidRequestWindow, to protect it from a Cloudflare ban.
+var ErrInvalidRequestLimit = errors.New("too many invalid requests, requests are paused to avoid a Cloudflare ban")
+
+// RateLimitScope is the scope of a rate limit Discord responded to a
+// request with a 429 for, from the X-RateLimit-Scope header.
+type RateLimitScope string
+
+// Block contains the valid known RateLimitScope values
+const (
+	// The limit of the bot or user, per route or for all routes.
+	RateLimitScopeUser RateLimitScope = "user"
+
+	// The global limit of all the requests of the bot or user.
+	RateLimitScopeGlobal RateLimitScope = "global"
+
+	// The limit of a resource shared with other bots, eg. a channel, which
+	// isn't counted as an invalid request.
+	RateLimitScopeShared RateLimitScope = "shared"
+)
+
+// invalidRequest returns whether a response counts as an invalid request.
+func invalidRequest(resp *http.Response) bool {
+	switch resp.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return true
+	case http.StatusTooManyRequests:
+		return RateLimitScope(resp.Header.Get("X-RateLimit-Scope")) != RateLimitScopeShared
+	}
+	return false
+}
+
+// invalidRequestLimiter keeps the times of the recent invalid requests.
+type invalidRequestLimiter struct {
+	sync.Mutex
+
+	requests []time.Time
+	tripped  bool
+}
+
+// prune removes the requests before the window, the lock must be held.
+func (l *invalidRequestLimiter) prune(now time.Time) {
+	i := 0
+	for i < len(l.requests) && now.Sub(l.requests[i]) >= InvalidRequestWindow {
+		i++
+	}
+	l.requests = l.requests[i:]
+}
+
+// add records an invalid request, and returns whether the threshold was
+// reached by it.
+func (l *invalidRequestLimiter) add(threshold int) (tripped bool) {
+	l.Lock()
+	defer l.Unlock()
+
+	now := time.Now()
+	l.prune(now)
+	l.requests = append(l.requests, now)
+
+	if len(l.requests) >= threshold && !l.tripped {
+		l.tripped = true
+		return true
+	}
+	return false
+}
+
+// allowed returns whether fewer invalid requests than the threshold were
+// made in the window.
+func (l *invalidRequestLimiter) allowed(threshold int) bool {
+	l.Lock()
+	defer l.Unlock()
+
+	l.prune(time.Now())
+	if len(l.requests) < threshold {
+		l.tripped = false
+	}
+	return !l.tripped
+}
+
+// count returns the number of invalid requests in the current window, and
+// when the oldest leaves it.
+func (l *invalidRequestLimiter) count() (n int, next time.Time) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.prune(time.Now())
+	if len(l.requests) > 0 {
+		next = l.requests[0].Add(InvalidRequestWindow)
+	}
+	return len(l.requests), next
+}
+
+// InvalidRequests returns the number of invalid requests the session made
+// in the last InvalidRequestWindow.
+func (s *Session) InvalidRequests() int {
+	n, _ := s.invalidRequests.count()
+	return n
+}
+
+// invalidRequestThreshold returns the threshold of the invalid requests,
+// at most MaxInvalidRequests.
+func (s *Session) invalidRequestThreshold() int {
+	if s.InvalidRequestThreshold <= 0 || s.InvalidRequestThreshold > MaxInvalidRequests {
+		return DefaultInvalidRequestThreshold
+	}
+	return s.InvalidRequestThreshold
+}
+
+// countInvalidRequest records the response if it is an invalid request,
+// and sends an InvalidRequestLimit event when the requests are paused.
+func (s *Session) countInvalidRequest(resp *http.Response) {
+	if !invalidRequest(resp) {
+		return
+	}
+
+	threshold := s.invalidRequestThreshold()
+	if s.invalidRequests.add(threshold) {
+		n, next := s.invalidRequests.count()
+		s.log(LogError, "%d invalid requests in %s, pausing requests", n, InvalidRequestWindow)
+		go s.handleEvent(invalidRequestLimitEventType, &InvalidRequestLimit{Count: n, Threshold: threshold, Until: next})
+	}
+}
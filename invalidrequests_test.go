@@ -0,0 +1,73 @@
+package discordgo
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestInvalidRequest(t *testing.T) {
+	tests := []struct {
+		status int
+		scope  RateLimitScope
+		want   bool
+	}{
+		{http.StatusOK, "", false},
+		{http.StatusNotFound, "", false},
+		{http.StatusUnauthorized, "", true},
+		{http.StatusForbidden, "", true},
+		{http.StatusTooManyRequests, RateLimitScopeUser, true},
+		{http.StatusTooManyRequests, RateLimitScopeGlobal, true},
+		{http.StatusTooManyRequests, RateLimitScopeShared, false},
+	}
+
+	for _, test := range tests {
+		resp := &http.Response{StatusCode: test.status, Header: http.Header{}}
+		resp.Header.Set("X-RateLimit-Scope", string(test.scope))
+		if got := invalidRequest(resp); got != test.want {
+			t.Errorf("%d %s: expected %v, got %v", test.status, test.scope, test.want, got)
+		}
+	}
+}
+
+func TestInvalidRequestLimit(t *testing.T) {
+	tr := &routeTransport{}
+	s := newTestSession(tr)
+	s.InvalidRequestThreshold = 3
+
+	events := make(chan *InvalidRequestLimit, 1)
+	s.AddHandler(func(s *Session, e *InvalidRequestLimit) {
+		events <- e
+	})
+
+	for i := 0; i < 3; i++ {
+		// Missing routes answer with a 404, which isn't counted.
+		s.Channel("1")
+	}
+	if n := s.InvalidRequests(); n != 0 {
+		t.Fatalf("expected no invalid requests, got %d", n)
+	}
+
+	for i := 0; i < 3; i++ {
+		resp := &http.Response{StatusCode: http.StatusForbidden, Header: http.Header{}}
+		s.countInvalidRequest(resp)
+	}
+	if _, err := s.Channel("1"); err != ErrInvalidRequestLimit {
+		t.Errorf("expected ErrInvalidRequestLimit, got %v", err)
+	}
+
+	select {
+	case e := <-events:
+		if e.Count != 3 || e.Threshold != 3 || e.Until.Before(time.Now()) {
+			t.Errorf("unexpected event %+v", e)
+		}
+	case <-time.After(time.Second):
+		t.Error("expected an InvalidRequestLimit event")
+	}
+
+	// The requests resume once the invalid requests leave the window.
+	s.invalidRequests.requests = s.invalidRequests.requests[1:]
+	if !s.invalidRequests.allowed(3) {
+		t.Error("expected the requests to be allowed again")
+	}
+}
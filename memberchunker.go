@@ -0,0 +1,147 @@
+package discordgo
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultMemberChunkInterval is the default Interval of a MemberChunker,
+// which leaves half of the gateway rate limit to other sends.
+const DefaultMemberChunkInterval = time.Second
+
+// LargeGuilds returns a MemberChunker filter which matches the guilds Discord
+// flags as large, or with at least the given number of members.
+// members : The number of members, 0 to only match guilds flagged as large.
+func LargeGuilds(members int) func(g *Guild) bool {
+	return func(g *Guild) bool {
+		return g.Large || members > 0 && g.MemberCount >= members
+	}
+}
+
+// GuildIDs returns a MemberChunker filter which matches the guilds with the IDs.
+func GuildIDs(guildIDs ...string) func(g *Guild) bool {
+	ids := make(map[string]bool, len(guildIDs))
+	for _, id := range guildIDs {
+		ids[id] = true
+	}
+	return func(g *Guild) bool {
+		return ids[g.ID]
+	}
+}
+
+// A MemberChunker requests the members of guilds when they are created, so
+// the member caches of large guilds, which Discord only sends partially,
+// are filled. The members arrive as GuildMembersChunk events, and are added
+// to the state if it tracks members.
+//
+// The requests are sent one at a time, an Interval apart, so connecting to
+// many large guilds doesn't use up the gateway rate limit. The members
+// intent is required for the requests.
+type MemberChunker struct {
+	sync.Mutex
+
+	// How long to wait between two requests.
+	Interval time.Duration
+
+	// Whether to request the presences of the members too, which requires
+	// the presences intent.
+	Presences bool
+
+	session *Session
+	filter  func(g *Guild) bool
+
+	handlers []HandlerID
+
+	queue   []string
+	pending map[string]bool
+	working bool
+	closed  bool
+}
+
+// NewMemberChunker returns a new MemberChunker which requests the members
+// of the guilds of s matching the filter, eg. LargeGuilds(0).
+func NewMemberChunker(s *Session, filter func(g *Guild) bool) *MemberChunker {
+	c := &MemberChunker{
+		Interval: DefaultMemberChunkInterval,
+		session:  s,
+		filter:   filter,
+		pending:  make(map[string]bool),
+	}
+
+	c.handlers = []HandlerID{
+		s.AddHandlerComplex(c.onGuildCreate, HandlerOptions{}),
+	}
+	return c
+}
+
+// Close removes the event handlers of the chunker and drops the queued requests.
+func (c *MemberChunker) Close() {
+	for _, id := range c.handlers {
+		c.session.RemoveHandler(id)
+	}
+
+	c.Lock()
+	c.closed = true
+	c.queue = nil
+	c.pending = make(map[string]bool)
+	c.Unlock()
+}
+
+// Queued returns the number of guilds whose members weren't requested yet.
+func (c *MemberChunker) Queued() int {
+	c.Lock()
+	defer c.Unlock()
+
+	return len(c.queue)
+}
+
+func (c *MemberChunker) onGuildCreate(s *Session, g *GuildCreate) {
+	if g.Guild == nil || g.Unavailable || !c.filter(g.Guild) {
+		return
+	}
+	c.Request(g.ID)
+}
+
+// Request queues a request of the members of a guild, and starts the worker
+// if it isn't running.
+func (c *MemberChunker) Request(guildID string) {
+	c.Lock()
+	if c.closed || c.pending[guildID] {
+		c.Unlock()
+		return
+	}
+	c.pending[guildID] = true
+	c.queue = append(c.queue, guildID)
+
+	start := !c.working
+	c.working = true
+	c.Unlock()
+
+	if start {
+		go c.work()
+	}
+}
+
+// work sends the queued requests until the queue is empty.
+func (c *MemberChunker) work() {
+	for {
+		c.Lock()
+		if len(c.queue) == 0 || c.closed {
+			c.working = false
+			c.Unlock()
+			return
+		}
+		guildID := c.queue[0]
+		c.queue = c.queue[1:]
+		delete(c.pending, guildID)
+		interval, presences := c.Interval, c.Presences
+		c.Unlock()
+
+		err := c.session.RequestGuildMembers(guildID, "", 0, presences)
+		if err != nil {
+			c.session.log(LogError, "error requesting members of guild %s, %s", guildID, err)
+		}
+
+		time.Sleep(interval)
+	}
+}
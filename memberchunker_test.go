@@ -0,0 +1,39 @@
+package discordgo
+
+import "testing"
+
+func TestMemberChunkerFilters(t *testing.T) {
+	large := LargeGuilds(1000)
+	if !large(&Guild{Large: true}) || !large(&Guild{MemberCount: 1000}) || large(&Guild{MemberCount: 999}) {
+		t.Error("unexpected LargeGuilds matches")
+	}
+	if LargeGuilds(0)(&Guild{MemberCount: 100000}) {
+		t.Error("LargeGuilds(0) should only match guilds flagged as large")
+	}
+
+	ids := GuildIDs("1", "2")
+	if !ids(&Guild{ID: "2"}) || ids(&Guild{ID: "3"}) {
+		t.Error("unexpected GuildIDs matches")
+	}
+}
+
+func TestMemberChunkerQueue(t *testing.T) {
+	c := NewMemberChunker(&Session{}, LargeGuilds(0))
+
+	// Keep the worker from starting.
+	c.working = true
+
+	c.onGuildCreate(nil, &GuildCreate{&Guild{ID: "1", Large: true}})
+	c.onGuildCreate(nil, &GuildCreate{&Guild{ID: "1", Large: true}})
+	c.onGuildCreate(nil, &GuildCreate{&Guild{ID: "2"}})
+	c.onGuildCreate(nil, &GuildCreate{&Guild{ID: "3", Large: true, Unavailable: true}})
+	if n := c.Queued(); n != 1 {
+		t.Errorf("expected 1 queued request, got %d", n)
+	}
+
+	c.Close()
+	c.Request("4")
+	if n := c.Queued(); n != 0 {
+		t.Errorf("expected no queued requests after Close, got %d", n)
+	}
+}
@@ -10,9 +10,12 @@
 package discordgo
 
 import (
+	"context"
+	"fmt"
 	"io"
 	"regexp"
 	"strings"
+	"unicode/utf8"
 )
 
 // MessageType is the type of Message
@@ -121,10 +124,28 @@ type Message struct {
 	// This is a combination of bit masks; the presence of a certain permission can
 	// be checked by performing a bitwise AND between this int and the flag.
 	Flags MessageFlags `json:"flags"`
+
+	// The action rows of buttons and select menus of the message.
+	Components []*MessageComponent `json:"components"`
+
+	// The interaction this message responds to, if any.
+	Interaction *MessageInteraction `json:"interaction"`
+}
+
+// MessageInteraction is the interaction a message responds to.
+type MessageInteraction struct {
+	ID   string          `json:"id"`
+	Type InteractionType `json:"type"`
+
+	// The name of the application command.
+	Name string `json:"name"`
+	User *User  `json:"user"`
 }
 
+// GetChannel returns the channel of the message from the state, or from
+// the API if it isn't cached, nil if both fail.
 func (msg *Message) GetChannel(session *Session) *Channel {
-	channel, err := session.Channel(msg.ChannelID)
+	channel, err := session.resolveChannel(context.Background(), msg.ChannelID)
 	if err != nil {
 		return nil
 	}
@@ -142,6 +163,9 @@ const (
 	MessageFlagsSupressEmbeds
 	MessageFlagsSourceMessageDeleted
 	MessageFlagsUrgent
+	MessageFlagsHasThread
+	MessageFlagsEphemeral
+	MessageFlagsLoading
 )
 
 // File stores info about files you e.g. send in messages.
@@ -159,6 +183,7 @@ type MessageSend struct {
 	Files           []*File                 `json:"-"`
 	AllowedMentions *MessageAllowedMentions `json:"allowed_mentions,omitempty"`
 	Reference       *MessageReference       `json:"message_reference,omitempty"`
+	Components      []*MessageComponent     `json:"components,omitempty"`
 
 	// TODO: Remove this when compatibility is not required.
 	File *File `json:"-"`
@@ -171,6 +196,9 @@ type MessageEdit struct {
 	Embed           *MessageEmbed           `json:"embed,omitempty"`
 	AllowedMentions *MessageAllowedMentions `json:"allowed_mentions,omitempty"`
 
+	// An empty slice removes all components.
+	Components *[]*MessageComponent `json:"components,omitempty"`
+
 	ID      string
 	Channel string
 }
@@ -198,6 +226,16 @@ func (m *MessageEdit) SetEmbed(embed *MessageEmbed) *MessageEdit {
 	return m
 }
 
+// SetComponents is a convenience function for setting the components,
+// so you can chain commands.
+func (m *MessageEdit) SetComponents(components ...*MessageComponent) *MessageEdit {
+	if components == nil {
+		components = []*MessageComponent{}
+	}
+	m.Components = &components
+	return m
+}
+
 // AllowedMentionType describes the types of mentions used
 // in the MessageAllowedMentions type.
 type AllowedMentionType string
@@ -243,6 +281,13 @@ type MessageAttachment struct {
 	Width    int    `json:"width"`
 	Height   int    `json:"height"`
 	Size     int    `json:"size"`
+
+	// The media type of the attachment, eg. "image/png".
+	ContentType string `json:"content_type,omitempty"`
+
+	// Whether the attachment is only kept until the ephemeral message it
+	// belongs to is deleted, or the interaction it was uploaded with ends.
+	Ephemeral bool `json:"ephemeral,omitempty"`
 }
 
 // MessageEmbedFooter is a part of a MessageEmbed struct.
@@ -322,6 +367,93 @@ func (e *MessageEmbed) AddField(title string, content string, inline bool) {
 	})
 }
 
+// Limits of message content and embeds, lengths are counted in characters.
+// https://discord.com/developers/docs/resources/channel#embed-limits
+const (
+	MessageContentLimit   = 2000
+	EmbedTitleLimit       = 256
+	EmbedDescriptionLimit = 4096
+	EmbedFieldsLimit      = 25
+	EmbedFieldNameLimit   = 256
+	EmbedFieldValueLimit  = 1024
+	EmbedFooterTextLimit  = 2048
+	EmbedAuthorNameLimit  = 256
+	EmbedTotalLimit       = 6000
+)
+
+// A MessageLimitError is returned when validating a message or embed
+// which exceeds one of the limits of Discord.
+type MessageLimitError struct {
+	Field  string
+	Length int
+	Limit  int
+}
+
+// Error returns a readable description of the exceeded limit.
+func (e *MessageLimitError) Error() string {
+	return fmt.Sprintf("%s has a length of %d, the limit is %d", e.Field, e.Length, e.Limit)
+}
+
+// checkLimit returns a MessageLimitError if the length exceeds the limit.
+func checkLimit(field string, length, limit int) error {
+	if length > limit {
+		return &MessageLimitError{field, length, limit}
+	}
+	return nil
+}
+
+// Validate checks the embed against the embed limits of Discord,
+// a *MessageLimitError is returned for the first exceeded limit.
+func (e *MessageEmbed) Validate() error {
+	total := 0
+	check := func(field, value string, limit int) error {
+		n := utf8.RuneCountInString(value)
+		total += n
+		return checkLimit(field, n, limit)
+	}
+
+	if err := check("embed title", e.Title, EmbedTitleLimit); err != nil {
+		return err
+	}
+	if err := check("embed description", e.Description, EmbedDescriptionLimit); err != nil {
+		return err
+	}
+	if err := checkLimit("embed fields", len(e.Fields), EmbedFieldsLimit); err != nil {
+		return err
+	}
+	for i, f := range e.Fields {
+		if err := check(fmt.Sprintf("embed field %d name", i), f.Name, EmbedFieldNameLimit); err != nil {
+			return err
+		}
+		if err := check(fmt.Sprintf("embed field %d value", i), f.Value, EmbedFieldValueLimit); err != nil {
+			return err
+		}
+	}
+	if e.Footer != nil {
+		if err := check("embed footer text", e.Footer.Text, EmbedFooterTextLimit); err != nil {
+			return err
+		}
+	}
+	if e.Author != nil {
+		if err := check("embed author name", e.Author.Name, EmbedAuthorNameLimit); err != nil {
+			return err
+		}
+	}
+	return checkLimit("embed", total, EmbedTotalLimit)
+}
+
+// Validate checks the message content and embed against the limits of
+// Discord, a *MessageLimitError is returned for the first exceeded limit.
+func (m *MessageSend) Validate() error {
+	if err := checkLimit("message content", utf8.RuneCountInString(m.Content), MessageContentLimit); err != nil {
+		return err
+	}
+	if m.Embed != nil {
+		return m.Embed.Validate()
+	}
+	return nil
+}
+
 // EmbedType is the type of embed
 // https://discord.com/developers/docs/resources/channel#embed-object-embed-types
 type EmbedType string
@@ -341,6 +473,21 @@ type MessageReactions struct {
 	Count int    `json:"count"`
 	Me    bool   `json:"me"`
 	Emoji *Emoji `json:"emoji"`
+
+	// The counts of normal and super reactions, Count is their sum.
+	CountDetails *ReactionCountDetails `json:"count_details"`
+
+	// Whether the current user super reacted, and the colors of the
+	// super reactions as hex strings.
+	MeBurst     bool     `json:"me_burst"`
+	BurstColors []string `json:"burst_colors"`
+}
+
+// ReactionCountDetails holds the counts of the normal and super reactions
+// of an emoji.
+type ReactionCountDetails struct {
+	Burst  int `json:"burst"`
+	Normal int `json:"normal"`
 }
 
 // MessageActivity is sent with Rich Presence-related chat embeds
@@ -0,0 +1,80 @@
+package discordgo
+
+import (
+	"context"
+	"time"
+)
+
+// ContentFallbackTimeout is the time the events of messages chosen by
+// Session.ContentFallback wait for their content to be fetched.
+var ContentFallbackTimeout = 5 * time.Second
+
+// HasContent returns whether the message has content, embeds, attachments
+// or components. Without the message content intent they are empty, except
+// in DMs, messages mentioning the bot and the messages of the bot.
+func (msg *Message) HasContent() bool {
+	return msg.Content != "" || len(msg.Embeds) > 0 || len(msg.Attachments) > 0 || len(msg.Components) > 0
+}
+
+// FetchContent fetches the content, embeds, attachments and components of
+// a message received without them from the API, eg. in the event of a
+// session identifying without IntentsMessageContent. The API only returns
+// them if the message content intent is enabled for the application.
+// Messages with content are left as they are.
+func (msg *Message) FetchContent(session *Session) error {
+	return msg.fetchContent(context.Background(), session)
+}
+
+func (msg *Message) fetchContent(ctx context.Context, session *Session) error {
+	if msg.HasContent() {
+		return nil
+	}
+
+	body, err := session.Do(ctx, "GET", EndpointChannelMessage(msg.ChannelID, msg.ID), nil, WithBucketID(EndpointChannelMessage(msg.ChannelID, "")))
+	if err != nil {
+		return err
+	}
+
+	var m *Message
+	if err = session.unmarshal(body, &m); err != nil {
+		return err
+	}
+	msg.Content, msg.Embeds, msg.Attachments, msg.Components = m.Content, m.Embeds, m.Attachments, m.Components
+	return nil
+}
+
+// contentFallbackMessage returns the message of a MessageCreate or
+// MessageUpdate event whose content is fetched, see ContentFallback.
+func (s *Session) contentFallbackMessage(i interface{}) *Message {
+	if s.ContentFallback == nil || s.Identify.Intents != nil && *s.Identify.Intents&IntentsMessageContent != 0 {
+		return nil
+	}
+
+	var m *Message
+	switch t := i.(type) {
+	case *MessageCreate:
+		m = t.Message
+	case *MessageUpdate:
+		m = t.Message
+	}
+	if m == nil || m.HasContent() || !s.ContentFallback(m) {
+		return nil
+	}
+	return m
+}
+
+// fetchEventContent fetches the content of the message of an event chosen
+// by ContentFallback, waiting up to ContentFallbackTimeout. The event is
+// handled without the content if the request fails.
+func (s *Session) fetchEventContent(i interface{}) {
+	m := s.contentFallbackMessage(i)
+	if m == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), ContentFallbackTimeout)
+	defer cancel()
+	if err := m.fetchContent(ctx, s); err != nil {
+		s.log(LogWarning, "error fetching the content of message %s, %s", m.ID, err)
+	}
+}
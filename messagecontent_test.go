@@ -0,0 +1,82 @@
+package discordgo
+
+import (
+	"testing"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestMessageFetchContent(t *testing.T) {
+	tr := &routeTransport{routes: map[string]string{
+		"GET " + EndpointChannelMessage("1", "2"): `{"id": "2", "channel_id": "1", "content": "!ping"}`,
+	}}
+	s := newTestSession(tr)
+	s.SyncEvents = true
+
+	m := &Message{ID: "2", ChannelID: "1", Content: "hello"}
+	if err := m.FetchContent(s); err != nil || m.Content != "hello" || tr.count() != 0 {
+		t.Errorf("expected a message with content to be left as it is, got %q, %v", m.Content, err)
+	}
+
+	created := make(chan *MessageCreate, 1)
+	s.AddHandler(func(s *Session, m *MessageCreate) {
+		created <- m
+	})
+	event := []byte(`{"op": 0, "s": 1, "t": "MESSAGE_CREATE", "d": {"id": "2", "channel_id": "1", "content": ""}}`)
+
+	s.ContentFallback = func(m *Message) bool { return m.ChannelID == "1" }
+	s.Identify.Intents = MakeIntent(IntentsAllWithoutPrivileged)
+	if _, err := s.onEvent(websocket.TextMessage, event); err != nil {
+		t.Fatal(err)
+	}
+	if m := <-created; m.Content != "!ping" {
+		t.Errorf("expected the fetched content, got %q", m.Content)
+	}
+
+	s.Identify.Intents = MakeIntent(IntentsAllWithoutPrivileged | IntentsMessageContent)
+	if _, err := s.onEvent(websocket.TextMessage, event); err != nil {
+		t.Fatal(err)
+	}
+	if m := <-created; m.Content != "" || tr.count() != 1 {
+		t.Errorf("expected no fetch with the message content intent, got %q", m.Content)
+	}
+}
+
+func TestMessageFetchContentOrder(t *testing.T) {
+	tr := &routeTransport{routes: map[string]string{
+		"GET " + EndpointChannelMessage("1", "2"): `{"id": "2", "channel_id": "1", "content": "!ping"}`,
+	}}
+	s := newTestSession(tr)
+	s.SyncEvents = true
+	s.StateEnabled = true
+	s.State.MaxMessageCount = 10
+	s.State.GuildAdd(&Guild{ID: "3", Channels: []*Channel{{ID: "1", GuildID: "3"}}})
+	s.ContentFallback = func(m *Message) bool { return true }
+	s.Identify.Intents = MakeIntent(IntentsAllWithoutPrivileged)
+
+	var events []string
+	s.AddHandler(func(s *Session, e *Event) {
+		events = append(events, e.Type)
+	})
+	s.AddHandler(func(s *Session, m *MessageCreate) {
+		events = append(events, "create "+m.Content)
+	})
+
+	// The message is deleted right after it was created, before its
+	// content could have been fetched in the background.
+	for _, event := range []string{
+		`{"op": 0, "s": 1, "t": "MESSAGE_CREATE", "d": {"id": "2", "channel_id": "1", "guild_id": "3", "content": ""}}`,
+		`{"op": 0, "s": 2, "t": "MESSAGE_DELETE", "d": {"id": "2", "channel_id": "1", "guild_id": "3"}}`,
+	} {
+		if _, err := s.onEvent(websocket.TextMessage, []byte(event)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(events) != 3 || events[0] != "create !ping" || events[1] != "MESSAGE_CREATE" || events[2] != "MESSAGE_DELETE" {
+		t.Errorf("expected the events in order, got %v", events)
+	}
+	if m, err := s.State.Message("1", "2"); err == nil {
+		t.Errorf("expected the deleted message to be removed from the state, got %+v", m)
+	}
+}
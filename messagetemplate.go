@@ -0,0 +1,216 @@
+package discordgo
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// TemplateVars holds the values of the variables of a MessageTemplate by name.
+type TemplateVars map[string]string
+
+// AddUser adds the user.id, user.name, user.tag, user.mention and
+// user.avatar variables.
+func (v TemplateVars) AddUser(u *User) TemplateVars {
+	v["user.id"] = u.ID
+	v["user.name"] = u.Username
+	v["user.tag"] = u.String()
+	v["user.mention"] = u.Mention()
+	v["user.avatar"] = u.AvatarURL("")
+	return v
+}
+
+// AddMember adds the member.nick and member.mention variables, and the
+// variables of AddUser for the user of the member. member.nick is the
+// username if the member has no nickname.
+func (v TemplateVars) AddMember(m *Member) TemplateVars {
+	if m.User != nil {
+		v.AddUser(m.User)
+		v["member.nick"] = m.User.Username
+	}
+	if m.Nick != "" {
+		v["member.nick"] = m.Nick
+	}
+	v["member.mention"] = m.Mention()
+	return v
+}
+
+// AddGuild adds the guild.id, guild.name, guild.icon and guild.member_count variables.
+func (v TemplateVars) AddGuild(g *Guild) TemplateVars {
+	v["guild.id"] = g.ID
+	v["guild.name"] = g.Name
+	v["guild.icon"] = g.IconURL()
+	v["guild.member_count"] = strconv.Itoa(g.MemberCount)
+	return v
+}
+
+// AddChannel adds the channel.id, channel.name and channel.mention variables.
+func (v TemplateVars) AddChannel(c *Channel) TemplateVars {
+	v["channel.id"] = c.ID
+	v["channel.name"] = c.Name
+	v["channel.mention"] = c.Mention()
+	return v
+}
+
+// templateVarRegexp matches {{variable}} placeholders.
+var templateVarRegexp = regexp.MustCompile(`{{\s*([\w.]+)\s*}}`)
+
+// Replace replaces the {{variable}} placeholders in s by the values of the variables.
+func (v TemplateVars) Replace(s string) string {
+	return templateVarRegexp.ReplaceAllStringFunc(s, func(match string) string {
+		if value, ok := v[templateVarRegexp.FindStringSubmatch(match)[1]]; ok {
+			return value
+		}
+		return match
+	})
+}
+
+// A MessageTemplate is a message definition with {{variable}} placeholders,
+// eg. for configurable welcome messages. Placeholders of unknown variables
+// are left as they are.
+type MessageTemplate struct {
+	message MessageSend
+}
+
+// ParseMessageTemplate parses a message template from the JSON encoding
+// of a message, as used by the create message endpoint.
+func ParseMessageTemplate(data []byte) (t *MessageTemplate, err error) {
+	t = &MessageTemplate{}
+	if err = json.Unmarshal(data, &t.message); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// ParseEmbedTemplate parses a template of a message with only an embed
+// from the JSON encoding of the embed.
+func ParseEmbedTemplate(data []byte) (t *MessageTemplate, err error) {
+	t = &MessageTemplate{}
+	if err = json.Unmarshal(data, &t.message.Embed); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// ParseMessageTemplateYAML parses a message template from YAML, using the
+// same field names as the JSON encoding. unmarshal is the Unmarshal function
+// of a YAML package, such as gopkg.in/yaml.v2.
+func ParseMessageTemplateYAML(data []byte, unmarshal func([]byte, interface{}) error) (*MessageTemplate, error) {
+	data, err := yamlToJSON(data, unmarshal)
+	if err != nil {
+		return nil, err
+	}
+	return ParseMessageTemplate(data)
+}
+
+// ParseEmbedTemplateYAML parses an embed template from YAML, see
+// ParseMessageTemplateYAML.
+func ParseEmbedTemplateYAML(data []byte, unmarshal func([]byte, interface{}) error) (*MessageTemplate, error) {
+	data, err := yamlToJSON(data, unmarshal)
+	if err != nil {
+		return nil, err
+	}
+	return ParseEmbedTemplate(data)
+}
+
+// yamlToJSON converts a YAML document to JSON, so it can be unmarshalled
+// using the json struct tags.
+func yamlToJSON(data []byte, unmarshal func([]byte, interface{}) error) ([]byte, error) {
+	var v interface{}
+	if err := unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+
+	v, err := yamlToJSONValue(v)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
+
+// yamlToJSONValue converts the map[interface{}]interface{} maps produced by
+// some YAML packages, which can't be encoded as JSON.
+func yamlToJSONValue(v interface{}) (interface{}, error) {
+	switch t := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(t))
+		for key, value := range t {
+			k, ok := key.(string)
+			if !ok {
+				return nil, fmt.Errorf("unsupported non-string key %v", key)
+			}
+
+			var err error
+			if m[k], err = yamlToJSONValue(value); err != nil {
+				return nil, err
+			}
+		}
+		return m, nil
+	case map[string]interface{}:
+		for key, value := range t {
+			var err error
+			if t[key], err = yamlToJSONValue(value); err != nil {
+				return nil, err
+			}
+		}
+	case []interface{}:
+		for i, value := range t {
+			var err error
+			if t[i], err = yamlToJSONValue(value); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return v, nil
+}
+
+// Render returns a message with the placeholders replaced by the values of
+// vars. The message is validated against the limits of Discord after
+// rendering, in which case the message is returned together with a
+// *MessageLimitError.
+func (t *MessageTemplate) Render(vars TemplateVars) (*MessageSend, error) {
+	m := t.message
+	m.Content = vars.Replace(m.Content)
+	if m.Embed != nil {
+		m.Embed = renderEmbed(m.Embed, vars.Replace)
+	}
+
+	return &m, m.Validate()
+}
+
+// renderEmbed returns a copy of the embed with r applied to all its texts and URLs.
+func renderEmbed(embed *MessageEmbed, r func(string) string) *MessageEmbed {
+	e := *embed
+	e.URL = r(e.URL)
+	e.Title = r(e.Title)
+	e.Description = r(e.Description)
+	e.Timestamp = r(e.Timestamp)
+
+	if e.Footer != nil {
+		f := *e.Footer
+		f.Text, f.IconURL = r(f.Text), r(f.IconURL)
+		e.Footer = &f
+	}
+	if e.Image != nil {
+		i := *e.Image
+		i.URL = r(i.URL)
+		e.Image = &i
+	}
+	if e.Thumbnail != nil {
+		th := *e.Thumbnail
+		th.URL = r(th.URL)
+		e.Thumbnail = &th
+	}
+	if e.Author != nil {
+		a := *e.Author
+		a.Name, a.URL, a.IconURL = r(a.Name), r(a.URL), r(a.IconURL)
+		e.Author = &a
+	}
+
+	e.Fields = make([]*MessageEmbedField, len(embed.Fields))
+	for i, f := range embed.Fields {
+		e.Fields[i] = &MessageEmbedField{Name: r(f.Name), Value: r(f.Value), Inline: f.Inline}
+	}
+	return &e
+}
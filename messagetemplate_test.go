@@ -0,0 +1,36 @@
+package discordgo
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMessageTemplate(t *testing.T) {
+	tmpl, err := ParseMessageTemplate([]byte(`{
+		"content": "Welcome {{ user.mention }}!",
+		"embed": {"title": "{{guild.name}}", "description": "{{unknown}}", "fields": [{"name": "Members", "value": "{{guild.member_count}}"}]}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vars := TemplateVars{}.AddUser(&User{ID: "1", Username: "a"}).AddGuild(&Guild{ID: "2", Name: "Guild", MemberCount: 3})
+	m, err := tmpl.Render(vars)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if m.Content != "Welcome <@1>!" {
+		t.Errorf("unexpected content %q", m.Content)
+	}
+	if m.Embed.Title != "Guild" || m.Embed.Description != "{{unknown}}" || m.Embed.Fields[0].Value != "3" {
+		t.Errorf("unexpected embed %+v", m.Embed)
+	}
+
+	vars["guild.name"] = strings.Repeat("x", EmbedTitleLimit+1)
+	if _, err = tmpl.Render(vars); err == nil {
+		t.Error("expected a limit error for a long title")
+	} else if lerr, ok := err.(*MessageLimitError); !ok || lerr.Field != "embed title" {
+		t.Errorf("expected an embed title limit error, got %v", err)
+	}
+}
@@ -0,0 +1,276 @@
+package discordgo
+
+import (
+	"context"
+	"sort"
+	"strings"
+)
+
+// IDErrors holds the errors of an operation on many entities, eg. channels
+// or messages, by the ID of the entity.
+type IDErrors map[string]error
+
+// Error returns the errors of all entities.
+func (e IDErrors) Error() string {
+	return "errors for IDs " + joinErrors(e)
+}
+
+// errorOrNil returns nil for an empty IDErrors, so callers can compare the
+// result to nil.
+func (e IDErrors) errorOrNil() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// joinErrors joins errors by ID, sorted by ID.
+func joinErrors(errs map[string]error) string {
+	ids := make([]string, 0, len(errs))
+	for id := range errs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	msgs := make([]string, len(ids))
+	for i, id := range ids {
+		msgs[i] = id + ": " + errs[id].Error()
+	}
+	return strings.Join(msgs, ", ")
+}
+
+// ChannelsSlowmodeSet sets the slowmode of all the given channels.
+// All channels are attempted, if any fail an IDErrors is returned.
+// channelIDs : The IDs of the channels
+// seconds    : The time users have to wait between messages, 0 disables slowmode
+func (s *Session) ChannelsSlowmodeSet(channelIDs []string, seconds int) error {
+	errs := IDErrors{}
+	for _, id := range channelIDs {
+		if _, err := s.ChannelSlowmodeSet(id, seconds); err != nil {
+			errs[id] = err
+		}
+	}
+	return errs.errorOrNil()
+}
+
+// A GuildLockdown holds the @everyone permission overwrites of channels
+// saved by LockdownGuild, so Unlockdown can restore them.
+type GuildLockdown struct {
+	GuildID string `json:"guild_id"`
+
+	// The saved overwrites by channel ID, nil for channels which had no
+	// @everyone overwrite.
+	Overwrites map[string]*PermissionOverwrite `json:"overwrites"`
+}
+
+// LockdownGuild denies @everyone to send messages in the text and news
+// channels of a guild, or only in the given channels. The previous
+// overwrites are returned, even if some channels failed, so they can be
+// restored with Unlockdown. If any channel fails an IDErrors is returned.
+// guildID    : The ID of a Guild
+// channelIDs : The IDs of the channels to lock, all channels if empty
+func (s *Session) LockdownGuild(guildID string, channelIDs ...string) (lockdown *GuildLockdown, err error) {
+	channels, err := s.GuildChannels(guildID)
+	if err != nil {
+		return
+	}
+
+	locked := make(map[string]bool, len(channelIDs))
+	for _, id := range channelIDs {
+		locked[id] = true
+	}
+
+	lockdown = &GuildLockdown{
+		GuildID:    guildID,
+		Overwrites: map[string]*PermissionOverwrite{},
+	}
+	errs := IDErrors{}
+
+	for _, c := range channels {
+		if len(channelIDs) > 0 && !locked[c.ID] {
+			continue
+		}
+		if len(channelIDs) == 0 && c.Type != ChannelTypeGuildText && c.Type != ChannelTypeGuildNews {
+			continue
+		}
+
+		var saved *PermissionOverwrite
+		allow, deny := 0, 0
+		for _, o := range c.PermissionOverwrites {
+			// The ID of the @everyone role is the guild ID.
+			if o.ID == guildID {
+				saved = &PermissionOverwrite{ID: o.ID, Type: o.Type, Allow: o.Allow, Deny: o.Deny}
+				allow, deny = o.Allow, o.Deny
+				break
+			}
+		}
+
+		if err := s.ChannelPermissionSet(c.ID, guildID, "role", allow&^PermissionSendMessages, deny|PermissionSendMessages); err != nil {
+			errs[c.ID] = err
+			continue
+		}
+		lockdown.Overwrites[c.ID] = saved
+	}
+
+	err = errs.errorOrNil()
+	return
+}
+
+// Unlockdown restores the @everyone permission overwrites saved by
+// LockdownGuild. If any channel fails an IDErrors is returned and the
+// restored channels are removed from the lockdown, so it can be retried.
+func (s *Session) Unlockdown(lockdown *GuildLockdown) error {
+	errs := IDErrors{}
+
+	for channelID, o := range lockdown.Overwrites {
+		var err error
+		if o == nil {
+			err = s.ChannelPermissionDelete(channelID, lockdown.GuildID)
+		} else {
+			err = s.ChannelPermissionSet(channelID, o.ID, o.Type, o.Allow, o.Deny)
+		}
+
+		if err != nil {
+			errs[channelID] = err
+			continue
+		}
+		delete(lockdown.Overwrites, channelID)
+	}
+	return errs.errorOrNil()
+}
+
+// GuildMembersRoleAdd adds a role to many members. The requests are sent
+// one after the other, so they wait for the rate limit of the route instead
+// of running into it. Members which already have the role according to the
+// state are skipped, and so are the members pending membership screening,
+// with an ErrMemberPending. All members are attempted, if any fail an
+// IDErrors is returned.
+// guildID  : The ID of a Guild.
+// userIDs  : The IDs of the users.
+// roleID   : The ID of a Role to be assigned to the users.
+// progress : Called after every member with the number of done members, optional.
+func (s *Session) GuildMembersRoleAdd(guildID string, userIDs []string, roleID string, progress func(done, total int)) error {
+	return s.guildMembersRoleEdit(guildID, userIDs, roleID, true, progress)
+}
+
+// GuildMembersRoleRemove removes a role from many members, see GuildMembersRoleAdd.
+// guildID  : The ID of a Guild.
+// userIDs  : The IDs of the users.
+// roleID   : The ID of a Role to be removed from the users.
+// progress : Called after every member with the number of done members, optional.
+func (s *Session) GuildMembersRoleRemove(guildID string, userIDs []string, roleID string, progress func(done, total int)) error {
+	return s.guildMembersRoleEdit(guildID, userIDs, roleID, false, progress)
+}
+
+func (s *Session) guildMembersRoleEdit(guildID string, userIDs []string, roleID string, add bool, progress func(done, total int)) error {
+	errs := IDErrors{}
+	for i, userID := range userIDs {
+		var member *Member
+		if s.State != nil {
+			member, _ = s.State.Member(guildID, userID)
+		}
+
+		var err error
+		switch {
+		case member != nil && member.Pending:
+			err = ErrMemberPending
+		case member != nil && memberHasRole(member, roleID) == add:
+		case add:
+			err = s.GuildMemberRoleAdd(guildID, userID, roleID)
+		default:
+			err = s.GuildMemberRoleRemove(guildID, userID, roleID)
+		}
+		if err != nil {
+			errs[userID] = err
+		}
+
+		if progress != nil {
+			progress(i+1, len(userIDs))
+		}
+	}
+	return errs.errorOrNil()
+}
+
+// GuildMemberDeleteWithNotice DMs the user a notice before removing them
+// from the guild, see sendModerationNotice for the variables of the notice.
+// Users who can't be DMed are still removed, notified reports whether the
+// notice was delivered. If the user can't be removed, eg. for a lack of
+// permissions, the notice is deleted again.
+// guildID : The ID of a Guild.
+// userID  : The ID of a User.
+// reason  : The reason for the kick, also shown in the notice.
+// notice  : The template of the notice.
+func (s *Session) GuildMemberDeleteWithNotice(guildID, userID, reason string, notice *MessageTemplate) (notified bool, err error) {
+	sent := s.sendModerationNotice(guildID, userID, "kick", reason, notice)
+	if err = s.GuildMemberDeleteWithReason(guildID, userID, reason); err != nil {
+		s.deleteModerationNotice(sent)
+		return false, err
+	}
+	return sent != nil, nil
+}
+
+// GuildBanCreateWithNotice DMs the user a notice before banning them from
+// the guild, see GuildMemberDeleteWithNotice.
+// guildID : The ID of a Guild.
+// userID  : The ID of a User.
+// data    : The options of the ban, its reason is also shown in the notice.
+// notice  : The template of the notice.
+func (s *Session) GuildBanCreateWithNotice(guildID, userID string, data *BanCreateData, notice *MessageTemplate) (notified bool, err error) {
+	reason := ""
+	if data != nil {
+		reason = data.Reason
+	}
+
+	sent := s.sendModerationNotice(guildID, userID, "ban", reason, notice)
+	if err = s.GuildBanCreateComplex(guildID, userID, data); err != nil {
+		s.deleteModerationNotice(sent)
+		return false, err
+	}
+	return sent != nil, nil
+}
+
+// sendModerationNotice DMs a user the notice of a moderation action and
+// returns the message, nil if it wasn't delivered. The notice is rendered
+// with the variables of the guild and the member, or the user if they
+// aren't a member, and the action and reason variables. Users who closed
+// their DMs are expected, other errors are logged.
+func (s *Session) sendModerationNotice(guildID, userID, action, reason string, notice *MessageTemplate) *Message {
+	vars := TemplateVars{"action": action, "reason": reason}
+	if g, err := s.resolveGuild(context.Background(), guildID); err == nil {
+		vars.AddGuild(g)
+	}
+	if m, err := s.resolveMember(context.Background(), guildID, userID); err == nil {
+		vars.AddMember(m)
+	} else if u, err := s.User(userID); err == nil {
+		vars.AddUser(u)
+	}
+
+	data, err := notice.Render(vars)
+	if err != nil {
+		s.log(LogError, "error rendering %s notice for %s, %s", action, userID, err)
+		return nil
+	}
+
+	c, err := s.UserChannelCreate(userID)
+	var m *Message
+	if err == nil {
+		m, err = s.ChannelMessageSendComplex(c.ID, data)
+	}
+	if err != nil {
+		if restErrorCode(err) != ErrCodeCannotSendMessagesToThisUser {
+			s.log(LogWarning, "error sending %s notice to %s, %s", action, userID, err)
+		}
+		return nil
+	}
+	return m
+}
+
+// deleteModerationNotice deletes the notice of an action which failed.
+func (s *Session) deleteModerationNotice(m *Message) {
+	if m == nil {
+		return
+	}
+	if err := s.ChannelMessageDelete(m.ChannelID, m.ID); err != nil {
+		s.log(LogWarning, "error deleting notice %s, %s", m.ID, err)
+	}
+}
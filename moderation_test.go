@@ -0,0 +1,119 @@
+package discordgo
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestGuildMemberDeleteWithNotice(t *testing.T) {
+	tr := &routeTransport{routes: map[string]string{
+		"GET " + EndpointGuild("1"):                                `{"id": "1", "name": "Guild"}`,
+		"GET " + EndpointGuildMember("1", "2"):                     `{"user": {"id": "2", "username": "user"}}`,
+		"POST " + EndpointUserChannels("@me"):                      `{"id": "10"}`,
+		"POST " + EndpointChannelMessages("10"):                    `{"id": "11", "channel_id": "10"}`,
+		"DELETE " + EndpointChannelMessage("10", "11"):             ``,
+		"DELETE " + EndpointGuildMember("1", "2") + "?reason=spam": ``,
+	}}
+	s := newTestSession(tr)
+
+	notice, err := ParseMessageTemplate([]byte(`{"content": "You were {{action}}ed from {{guild.name}}: {{reason}}"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	notified, err := s.GuildMemberDeleteWithNotice("1", "2", "spam", notice)
+	if err != nil || !notified {
+		t.Errorf("expected a notified kick, got %v, %v", notified, err)
+	}
+
+	tr.routes["POST "+EndpointChannelMessages("10")] = `{"code": 50007, "message": "Cannot send messages to this user"}`
+	tr.statuses = map[string]int{"POST " + EndpointChannelMessages("10"): http.StatusForbidden}
+	tr.requests = nil
+
+	notified, err = s.GuildMemberDeleteWithNotice("1", "2", "spam", notice)
+	if err != nil || notified {
+		t.Errorf("expected a kick without notice, got %v, %v", notified, err)
+	}
+	if last := tr.requests[len(tr.requests)-1]; last != "DELETE "+EndpointGuildMember("1", "2")+"?reason=spam" {
+		t.Errorf("expected the kick last, got %s", last)
+	}
+
+	// The notice is deleted when the kick fails.
+	tr.routes["POST "+EndpointChannelMessages("10")] = `{"id": "11", "channel_id": "10"}`
+	tr.routes["DELETE "+EndpointGuildMember("1", "2")+"?reason=spam"] = `{"code": 50013, "message": "Missing Permissions"}`
+	tr.statuses = map[string]int{"DELETE " + EndpointGuildMember("1", "2") + "?reason=spam": http.StatusForbidden}
+
+	notified, err = s.GuildMemberDeleteWithNotice("1", "2", "spam", notice)
+	if err == nil || notified {
+		t.Errorf("expected a failed kick without notice, got %v, %v", notified, err)
+	}
+	if last := tr.last(); last != "DELETE "+EndpointChannelMessage("10", "11") {
+		t.Errorf("expected the notice to be deleted, got %s", last)
+	}
+}
+
+func TestIDErrors(t *testing.T) {
+	if err := (IDErrors{}).errorOrNil(); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+
+	errs := IDErrors{"2": ErrStateNotFound, "1": ErrNotBanned}
+	expected := "errors for IDs 1: " + ErrNotBanned.Error() + ", 2: " + ErrStateNotFound.Error()
+	if err := errs.errorOrNil(); err == nil || err.Error() != expected {
+		t.Errorf("expected %q, got %v", expected, err)
+	}
+}
+
+func TestGuildMembersRoleAdd(t *testing.T) {
+	tr := &routeTransport{routes: map[string]string{
+		"PUT " + EndpointGuildMemberRole("1", "4", "9"): ``,
+		"PUT " + EndpointGuildMemberRole("1", "5", "9"): ``,
+	}}
+	s := newTestSession(tr)
+	s.State.GuildAdd(&Guild{ID: "1", Members: []*Member{
+		{User: &User{ID: "2"}, Roles: []string{"9"}},
+		{User: &User{ID: "3"}, Pending: true},
+		{User: &User{ID: "5"}},
+	}})
+
+	done := 0
+	err := s.GuildMembersRoleAdd("1", []string{"2", "3", "4", "5"}, "9", func(n, total int) {
+		done = n
+	})
+	if errs, ok := err.(IDErrors); !ok || len(errs) != 1 || errs["3"] != ErrMemberPending {
+		t.Errorf("expected the pending member to be skipped, got %v", err)
+	}
+	if done != 4 || len(tr.requests) != 2 || tr.last() != "PUT "+EndpointGuildMemberRole("1", "5", "9") {
+		t.Errorf("expected the role to be added to members 4 and 5, got %v", tr.requests)
+	}
+}
+
+func TestGuildMembersRoleRemove(t *testing.T) {
+	tr := &routeTransport{routes: map[string]string{
+		"DELETE " + EndpointGuildMemberRole("1", "2", "9"): ``,
+	}}
+	s := newTestSession(tr)
+	s.State.GuildAdd(&Guild{ID: "1", Members: []*Member{
+		{User: &User{ID: "2"}, Roles: []string{"9"}},
+		{User: &User{ID: "3"}},
+	}})
+
+	var progress []int
+	err := s.GuildMembersRoleRemove("1", []string{"2", "3", "4"}, "9", func(n, total int) {
+		if total != 3 {
+			t.Errorf("expected a total of 3, got %d", total)
+		}
+		progress = append(progress, n)
+	})
+
+	// Member 3 doesn't have the role, and the request of member 4 fails.
+	if errs, ok := err.(IDErrors); !ok || len(errs) != 1 || restErrorCode(errs["4"]) != 10063 {
+		t.Errorf("expected the error of member 4, got %v", err)
+	}
+	if len(progress) != 3 || progress[2] != 3 {
+		t.Errorf("expected progress after every member, got %v", progress)
+	}
+	if len(tr.requests) != 2 || tr.requests[0] != "DELETE "+EndpointGuildMemberRole("1", "2", "9") || tr.requests[1] != "DELETE "+EndpointGuildMemberRole("1", "4", "9") {
+		t.Errorf("expected the role to be removed from members 2 and 4, got %v", tr.requests)
+	}
+}
@@ -0,0 +1,475 @@
+package discordgo
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+)
+
+// ErrModuleExists is returned when adding a module with the name of an
+// already added module.
+var ErrModuleExists = errors.New("module already added")
+
+// ErrModuleNotFound is returned when a module with the given name was
+// not added to the session.
+var ErrModuleNotFound = errors.New("module not found")
+
+// ErrCommandExists is returned when adding a command with the name and type
+// of a command already added by a module.
+var ErrCommandExists = errors.New("command already added")
+
+// A CommandHandler handles the interactions of a command added with
+// AddModuleCommand. The returned error is logged, and passed to the
+// CommandHooks of the session with the CommandEventRun event.
+type CommandHandler func(s *Session, i *InteractionCreate) error
+
+// A Module packages a bot feature, such as its event handlers, so it can be
+// added to, enabled and disabled on, and removed from a Session as a whole.
+type Module interface {
+	// Name returns the unique name of the module. It is also used as the
+	// name of the handler group of the module's handlers.
+	Name() string
+
+	// Setup is called when the module is added to a Session, this is
+	// where the module should add its handlers with AddModuleHandler and
+	// its commands with AddModuleCommand.
+	Setup(s *Session) error
+
+	// Teardown is called when the module is removed from a Session.
+	// Handlers and commands added with AddModuleHandler and
+	// AddModuleCommand are removed automatically.
+	Teardown(s *Session) error
+}
+
+// ModuleToggler can be implemented by a Module to be notified when it
+// is enabled or disabled with ModuleEnable and ModuleDisable.
+type ModuleToggler interface {
+	OnEnable(s *Session)
+	OnDisable(s *Session)
+}
+
+// moduleEntry holds a module added to a Session.
+type moduleEntry struct {
+	module         Module
+	disabledGuilds map[string]bool
+	commands       []*ApplicationCommand
+}
+
+// ModuleAdd adds the module to the session and calls its Setup method.
+// If Setup fails the handlers the module has already added are removed.
+func (s *Session) ModuleAdd(m Module) (err error) {
+	s.modulesMu.Lock()
+	if s.modules == nil {
+		s.modules = map[string]*moduleEntry{}
+	}
+	if _, ok := s.modules[m.Name()]; ok {
+		s.modulesMu.Unlock()
+		return ErrModuleExists
+	}
+	s.modules[m.Name()] = &moduleEntry{
+		module:         m,
+		disabledGuilds: map[string]bool{},
+	}
+	s.modulesMu.Unlock()
+
+	if err = m.Setup(s); err != nil {
+		s.RemoveHandlerGroup(m.Name())
+
+		s.modulesMu.Lock()
+		delete(s.modules, m.Name())
+		s.modulesMu.Unlock()
+	}
+	return
+}
+
+// ModuleRemove removes the module with the given name from the session,
+// removing its handlers and calling its Teardown method.
+func (s *Session) ModuleRemove(name string) error {
+	s.modulesMu.Lock()
+	entry, ok := s.modules[name]
+	delete(s.modules, name)
+	s.modulesMu.Unlock()
+
+	if !ok {
+		return ErrModuleNotFound
+	}
+
+	s.RemoveHandlerGroup(name)
+	return entry.module.Teardown(s)
+}
+
+// Module returns the module with the given name.
+func (s *Session) Module(name string) (Module, error) {
+	s.modulesMu.RLock()
+	defer s.modulesMu.RUnlock()
+
+	entry, ok := s.modules[name]
+	if !ok {
+		return nil, ErrModuleNotFound
+	}
+	return entry.module, nil
+}
+
+// Modules returns all the modules added to the session.
+func (s *Session) Modules() (modules []Module) {
+	s.modulesMu.RLock()
+	defer s.modulesMu.RUnlock()
+
+	for _, entry := range s.modules {
+		modules = append(modules, entry.module)
+	}
+	return
+}
+
+// ModuleEnable enables a module disabled with ModuleDisable.
+func (s *Session) ModuleEnable(name string) error {
+	return s.moduleToggle(name, true)
+}
+
+// ModuleDisable stops the handlers of a module from being fired in any
+// guild, without removing the module.
+func (s *Session) ModuleDisable(name string) error {
+	return s.moduleToggle(name, false)
+}
+
+func (s *Session) moduleToggle(name string, enable bool) error {
+	s.modulesMu.RLock()
+	entry, ok := s.modules[name]
+	s.modulesMu.RUnlock()
+
+	if !ok {
+		return ErrModuleNotFound
+	}
+
+	if enable {
+		s.EnableHandlerGroup(name)
+	} else {
+		s.DisableHandlerGroup(name)
+	}
+
+	if t, ok := entry.module.(ModuleToggler); ok {
+		if enable {
+			t.OnEnable(s)
+		} else {
+			t.OnDisable(s)
+		}
+	}
+	return nil
+}
+
+// ModuleGuildEnable enables a module in a guild it was disabled in
+// with ModuleGuildDisable.
+func (s *Session) ModuleGuildEnable(name, guildID string) error {
+	s.modulesMu.Lock()
+	defer s.modulesMu.Unlock()
+
+	entry, ok := s.modules[name]
+	if !ok {
+		return ErrModuleNotFound
+	}
+	delete(entry.disabledGuilds, guildID)
+	return nil
+}
+
+// ModuleGuildDisable stops the handlers of a module from being fired
+// for events of the given guild.
+func (s *Session) ModuleGuildDisable(name, guildID string) error {
+	s.modulesMu.Lock()
+	defer s.modulesMu.Unlock()
+
+	entry, ok := s.modules[name]
+	if !ok {
+		return ErrModuleNotFound
+	}
+	entry.disabledGuilds[guildID] = true
+	return nil
+}
+
+// ModuleEnabled returns whether a module is enabled in the given guild,
+// if guildID is empty only whether the module is enabled at all is checked.
+func (s *Session) ModuleEnabled(name, guildID string) bool {
+	return s.HandlerGroupEnabled(name) && s.moduleGuildEnabled(name, guildID)
+}
+
+// moduleGuildEnabled returns whether a module is enabled in the given guild,
+// without checking whether the handler group of the module is enabled.
+func (s *Session) moduleGuildEnabled(name, guildID string) bool {
+	s.modulesMu.RLock()
+	defer s.modulesMu.RUnlock()
+
+	entry, ok := s.modules[name]
+	if !ok {
+		return false
+	}
+	return guildID == "" || !entry.disabledGuilds[guildID]
+}
+
+// moduleEventHandler wraps the event handler of a module so it is only
+// fired for guilds the module is enabled in.
+type moduleEventHandler struct {
+	EventHandler
+	module string
+}
+
+// Handle is the handler for the module's events.
+func (eh *moduleEventHandler) Handle(s *Session, i interface{}) {
+	eh.HandleContext(context.Background(), s, i)
+}
+
+// HandleContext is the context aware handler for the module's events.
+func (eh *moduleEventHandler) HandleContext(ctx context.Context, s *Session, i interface{}) {
+	// Disabled handler groups are already skipped when dispatching events.
+	if !s.moduleGuildEnabled(eh.module, eventGuildID(i)) {
+		return
+	}
+
+	if ceh, ok := eh.EventHandler.(ContextEventHandler); ok {
+		ceh.HandleContext(ctx, s, i)
+		return
+	}
+	eh.EventHandler.Handle(s, i)
+}
+
+// AddModuleHandler adds an event handler for the module with the given name.
+// The handler is added to the module's handler group and is only fired for
+// events of guilds the module is enabled in. See AddHandler for the
+// supported handler types.
+func (s *Session) AddModuleHandler(module string, handler interface{}) HandlerID {
+	return s.AddModuleHandlerComplex(module, handler, HandlerOptions{})
+}
+
+// AddModuleHandlerComplex adds an event handler for a module in the same
+// way as AddModuleHandler, opts.Group is always set to the module name.
+func (s *Session) AddModuleHandlerComplex(module string, handler interface{}, opts HandlerOptions) HandlerID {
+	eh := handlerForInterface(handler)
+
+	if eh == nil {
+		s.log(LogError, "Invalid handler type, handler will never be called")
+		return 0
+	}
+
+	opts.Group = module
+	return s.addEventHandlerComplex(&moduleEventHandler{eh, module}, opts).id
+}
+
+// AddModuleCommand adds an application command for the module with the
+// given name. The handler is added with AddModuleHandler, so it is only
+// fired for guilds the module is enabled in and is removed with the module.
+// The command isn't created in Discord, deploy the commands returned by
+// ModuleCommands, eg. with a CommandDeployment.
+// module  : The name of the module.
+// cmd     : The command, its name and type must be unique.
+// handler : Handles the interactions of the command.
+func (s *Session) AddModuleCommand(module string, cmd *ApplicationCommand, handler CommandHandler) error {
+	s.modulesMu.Lock()
+	entry, ok := s.modules[module]
+	if !ok {
+		s.modulesMu.Unlock()
+		return ErrModuleNotFound
+	}
+	for _, e := range s.modules {
+		for _, c := range e.commands {
+			if c.Name == cmd.Name && commandType(c.Type) == commandType(cmd.Type) {
+				s.modulesMu.Unlock()
+				return ErrCommandExists
+			}
+		}
+	}
+	entry.commands = append(entry.commands, cmd)
+	s.modulesMu.Unlock()
+
+	s.AddModuleHandler(module, func(s *Session, i *InteractionCreate) {
+		if i.Type != InteractionApplicationCommand {
+			return
+		}
+		data, err := i.ApplicationCommandData()
+		if err != nil || data.Name != cmd.Name || commandType(data.CommandType) != commandType(cmd.Type) {
+			return
+		}
+
+		start := time.Now()
+		err = handler(s, i)
+		if err != nil {
+			s.log(LogError, "error handling command %s of module %s, %s", cmd.Name, module, err)
+		}
+
+		e := interactionCommandEvent(CommandEventRun, i.Interaction)
+		e.Module, e.Latency, e.Err = module, time.Since(start), err
+		s.emitCommandEvent(e)
+	})
+	return nil
+}
+
+// ModuleCommands returns the commands added by a module with
+// AddModuleCommand, sorted by name. If module is empty the commands of all
+// modules are returned.
+// module : The name of the module.
+func (s *Session) ModuleCommands(module string) (commands []*ApplicationCommand) {
+	s.modulesMu.RLock()
+	defer s.modulesMu.RUnlock()
+
+	for name, entry := range s.modules {
+		if module == "" || name == module {
+			commands = append(commands, entry.commands...)
+		}
+	}
+	sort.Slice(commands, func(i, j int) bool {
+		if commands[i].Name != commands[j].Name {
+			return commands[i].Name < commands[j].Name
+		}
+		return commandType(commands[i].Type) < commandType(commands[j].Type)
+	})
+	return
+}
+
+// commandType returns the type of a command, ChatApplicationCommand for the
+// zero type.
+func commandType(t ApplicationCommandType) ApplicationCommandType {
+	if t == 0 {
+		return ChatApplicationCommand
+	}
+	return t
+}
+
+// eventGuildID returns the ID of the guild an event belongs to, an
+// empty string is returned for events which do not belong to a guild.
+func eventGuildID(i interface{}) string {
+	switch t := i.(type) {
+	case *ChannelCreate:
+		if t.Channel != nil {
+			return t.GuildID
+		}
+	case *ThreadCreate:
+		if t.Channel != nil {
+			return t.GuildID
+		}
+	case *ChannelUpdate:
+		if t.Channel != nil {
+			return t.GuildID
+		}
+	case *ChannelDelete:
+		if t.Channel != nil {
+			return t.GuildID
+		}
+	case *ChannelPinsUpdate:
+		return t.GuildID
+	case *GuildCreate:
+		if t.Guild != nil {
+			return t.ID
+		}
+	case *GuildUpdate:
+		if t.Guild != nil {
+			return t.ID
+		}
+	case *GuildDelete:
+		if t.Guild != nil {
+			return t.ID
+		}
+	case *GuildBanAdd:
+		return t.GuildID
+	case *GuildBanRemove:
+		return t.GuildID
+	case *GuildMemberAdd:
+		if t.Member != nil {
+			return t.GuildID
+		}
+	case *GuildMemberUpdate:
+		if t.Member != nil {
+			return t.GuildID
+		}
+	case *GuildMemberRemove:
+		if t.Member != nil {
+			return t.GuildID
+		}
+	case *GuildRoleCreate:
+		if t.GuildRole != nil {
+			return t.GuildID
+		}
+	case *GuildRoleUpdate:
+		if t.GuildRole != nil {
+			return t.GuildID
+		}
+	case *GuildRoleDelete:
+		return t.GuildID
+	case *GuildEmojisUpdate:
+		return t.GuildID
+	case *GuildMembersChunk:
+		return t.GuildID
+	case *GuildIntegrationsUpdate:
+		return t.GuildID
+	case *MessageCreate:
+		if t.Message != nil {
+			return t.GuildID
+		}
+	case *MessageUpdate:
+		if t.Message != nil {
+			return t.GuildID
+		}
+	case *MessageDelete:
+		if t.Message != nil {
+			return t.GuildID
+		}
+	case *MessageDeleteBulk:
+		return t.GuildID
+	case *MessageReactionAdd:
+		if t.MessageReaction != nil {
+			return t.GuildID
+		}
+	case *MessageReactionRemove:
+		if t.MessageReaction != nil {
+			return t.GuildID
+		}
+	case *MessageReactionRemoveAll:
+		if t.MessageReaction != nil {
+			return t.GuildID
+		}
+	case *MessageReactionRemoveEmoji:
+		if t.MessageReaction != nil {
+			return t.GuildID
+		}
+	case *PresenceUpdate:
+		return t.GuildID
+	case *TypingStart:
+		return t.GuildID
+	case *VoiceServerUpdate:
+		return t.GuildID
+	case *VoiceStateUpdate:
+		if t.VoiceState != nil {
+			return t.GuildID
+		}
+	case *WebhooksUpdate:
+		return t.GuildID
+	case *SpamDetected:
+		if t.SpamDetection != nil {
+			return t.GuildID
+		}
+	case *MemberGated:
+		if t.Member != nil {
+			return t.GuildID
+		}
+	case *IntegrationCreate:
+		if t.Integration != nil {
+			return t.GuildID
+		}
+	case *IntegrationUpdate:
+		if t.Integration != nil {
+			return t.GuildID
+		}
+	case *IntegrationDelete:
+		return t.GuildID
+	case *InteractionCreate:
+		if t.Interaction != nil {
+			return t.GuildID
+		}
+	case *VoiceChannelFull:
+		if t.VoiceChannelOccupancy != nil {
+			return t.GuildID
+		}
+	case *VoiceChannelEmpty:
+		if t.VoiceChannelOccupancy != nil {
+			return t.GuildID
+		}
+	}
+	return ""
+}
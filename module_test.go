@@ -0,0 +1,121 @@
+package discordgo
+
+import (
+	"testing"
+)
+
+type testModule struct {
+	called   []string
+	torndown bool
+}
+
+func (m *testModule) Name() string { return "test" }
+
+func (m *testModule) Setup(s *Session) error {
+	s.AddModuleHandler(m.Name(), func(s *Session, mc *MessageCreate) {
+		m.called = append(m.called, mc.GuildID)
+	})
+	return nil
+}
+
+func (m *testModule) Teardown(s *Session) error {
+	m.torndown = true
+	return nil
+}
+
+func TestModule(t *testing.T) {
+	d := Session{SyncEvents: true}
+	m := &testModule{}
+
+	if err := d.ModuleAdd(m); err != nil {
+		t.Fatalf("ModuleAdd returned error: %v", err)
+	}
+	if err := d.ModuleAdd(m); err != ErrModuleExists {
+		t.Fatalf("adding a module twice returned %v, expected ErrModuleExists", err)
+	}
+
+	d.ModuleGuildDisable(m.Name(), "disabled")
+	d.handleEvent(messageCreateEventType, &MessageCreate{&Message{GuildID: "enabled"}})
+	d.handleEvent(messageCreateEventType, &MessageCreate{&Message{GuildID: "disabled"}})
+
+	d.ModuleDisable(m.Name())
+	d.handleEvent(messageCreateEventType, &MessageCreate{&Message{GuildID: "enabled"}})
+
+	if len(m.called) != 1 || m.called[0] != "enabled" {
+		t.Fatalf("module handler was called for %v, expected [enabled]", m.called)
+	}
+
+	if err := d.ModuleRemove(m.Name()); err != nil {
+		t.Fatalf("ModuleRemove returned error: %v", err)
+	}
+	if !m.torndown {
+		t.Fatal("Teardown was not called")
+	}
+	if len(d.handlers[messageCreateEventType]) != 0 {
+		t.Fatal("module handlers were not removed")
+	}
+}
+
+// commandModule adds a command which records the users who used it.
+type commandModule struct {
+	name  string
+	users []string
+}
+
+func (m *commandModule) Name() string { return m.name }
+
+func (m *commandModule) Setup(s *Session) error {
+	return s.AddModuleCommand(m.name, &ApplicationCommand{Name: "ping", Description: "Pong"}, func(s *Session, i *InteractionCreate) error {
+		m.users = append(m.users, i.Author().ID)
+		return nil
+	})
+}
+
+func (m *commandModule) Teardown(s *Session) error { return nil }
+
+func TestModuleCommands(t *testing.T) {
+	d := Session{SyncEvents: true}
+	m := &commandModule{name: "ping"}
+
+	if err := d.AddModuleCommand("unknown", &ApplicationCommand{Name: "ping"}, nil); err != ErrModuleNotFound {
+		t.Errorf("expected ErrModuleNotFound, got %v", err)
+	}
+	if err := d.ModuleAdd(m); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.ModuleAdd(&commandModule{name: "duplicate"}); err != ErrCommandExists {
+		t.Errorf("expected ErrCommandExists, got %v", err)
+	}
+	if err := d.AddModuleCommand("ping", &ApplicationCommand{Name: "ping", Type: UserApplicationCommand}, nil); err != nil {
+		t.Errorf("expected a user command with the name of a slash command, got %v", err)
+	}
+
+	commands := d.ModuleCommands("")
+	if len(commands) != 2 || commands[0].Type != 0 || commands[1].Type != UserApplicationCommand {
+		t.Fatalf("unexpected commands %+v", commands)
+	}
+	if commands := d.ModuleCommands("duplicate"); len(commands) != 0 {
+		t.Errorf("expected no commands of the failed module, got %+v", commands)
+	}
+
+	use := func(guildID, name string) {
+		d.handleEvent(interactionCreateEventType, &InteractionCreate{&Interaction{
+			Type:    InteractionApplicationCommand,
+			GuildID: guildID,
+			Data:    []byte(`{"name": "` + name + `", "type": 1}`),
+			Member:  &Member{User: &User{ID: guildID}},
+		}})
+	}
+	d.ModuleGuildDisable("ping", "2")
+	use("1", "ping")
+	use("1", "pong")
+	use("2", "ping")
+	if len(m.users) != 1 || m.users[0] != "1" {
+		t.Errorf("expected the command to be used in guild 1 only, got %v", m.users)
+	}
+
+	d.ModuleRemove("ping")
+	if commands := d.ModuleCommands(""); len(commands) != 0 {
+		t.Errorf("expected the commands to be removed with the module, got %+v", commands)
+	}
+}
@@ -0,0 +1,263 @@
+package discordgo
+
+import (
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// NicknameRules are the rules a NicknameNormalizer applies to names, see
+// the NicknameRule* consts.
+type NicknameRules int
+
+// Valid NicknameRules values
+const (
+	// Remove the punctuation and symbols at the start of names, which sort
+	// the member above the others in the member list.
+	NicknameRuleHoist NicknameRules = 1 << iota
+
+	// Remove stacked combining marks and invisible characters.
+	NicknameRuleZalgo
+
+	// Replace stylized letters and digits, eg. fullwidth or mathematical
+	// bold ones, with their ASCII counterparts. Cyrillic and Greek letters
+	// which look like Latin ones are only replaced in names mixing them
+	// with Latin letters.
+	NicknameRuleConfusables
+
+	// Trim names and collapse runs of whitespace into single spaces, eg.
+	// the gaps left by the other rules.
+	NicknameRuleWhitespace
+
+	NicknameRulesAll = NicknameRuleHoist | NicknameRuleZalgo | NicknameRuleConfusables | NicknameRuleWhitespace
+)
+
+// NicknameStrategy is how a NicknameNormalizer replaces names which break
+// its rules.
+type NicknameStrategy int
+
+// Valid NicknameStrategy values
+const (
+	// Set the nickname to the normalized name, or to the placeholder if
+	// nothing is left of it.
+	NicknameStrategyNormalize NicknameStrategy = iota
+
+	// Set the nickname to the placeholder.
+	NicknameStrategyPlaceholder
+
+	// Remove the nickname, which falls back to the normalized username if
+	// the username breaks the rules too.
+	NicknameStrategyReset
+)
+
+// NormalizeNickname applies the rules to a name and returns the result,
+// which may be empty.
+// name  : The nickname or username.
+// rules : The rules to apply.
+func NormalizeNickname(name string, rules NicknameRules) string {
+	if rules&NicknameRuleConfusables != 0 {
+		name = replaceConfusables(name)
+	}
+
+	if rules&NicknameRuleZalgo != 0 {
+		var b strings.Builder
+		marks := 0
+		for _, r := range name {
+			switch {
+			case unicode.In(r, unicode.Mn, unicode.Me):
+				// Keep a single mark per letter, eg. for decomposed accents.
+				marks++
+				if marks > 1 {
+					continue
+				}
+			case unicode.Is(unicode.Cf, r):
+				continue
+			default:
+				marks = 0
+			}
+			b.WriteRune(r)
+		}
+		name = b.String()
+	}
+
+	if rules&NicknameRuleHoist != 0 {
+		name = strings.TrimLeftFunc(name, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+		})
+	}
+
+	if rules&NicknameRuleWhitespace != 0 {
+		name = strings.Join(strings.Fields(name), " ")
+	}
+	return name
+}
+
+// lookalikes maps the Cyrillic and Greek letters which look like Latin
+// letters to them.
+var lookalikes = map[rune]rune{
+	'А': 'A', 'В': 'B', 'Е': 'E', 'К': 'K', 'М': 'M', 'Н': 'H', 'О': 'O',
+	'Р': 'P', 'С': 'C', 'Т': 'T', 'Х': 'X', 'а': 'a', 'е': 'e', 'о': 'o',
+	'р': 'p', 'с': 'c', 'у': 'y', 'х': 'x', 'і': 'i', 'ј': 'j', 'ѕ': 's',
+	'Α': 'A', 'Β': 'B', 'Ε': 'E', 'Ζ': 'Z', 'Η': 'H', 'Ι': 'I', 'Κ': 'K',
+	'Μ': 'M', 'Ν': 'N', 'Ο': 'O', 'Ρ': 'P', 'Τ': 'T', 'Υ': 'Y', 'Χ': 'X',
+	'ο': 'o', 'ι': 'i',
+}
+
+// replaceConfusables replaces the stylized letters and digits of a name,
+// and its lookalike letters if it contains Latin letters.
+func replaceConfusables(name string) string {
+	name = strings.Map(unstylize, name)
+
+	latin := false
+	for _, r := range name {
+		latin = latin || r < unicode.MaxASCII && unicode.IsLetter(r)
+	}
+	if !latin {
+		return name
+	}
+
+	return strings.Map(func(r rune) rune {
+		if l, ok := lookalikes[r]; ok {
+			return l
+		}
+		return r
+	}, name)
+}
+
+// unstylize returns the ASCII counterpart of a stylized character.
+func unstylize(r rune) rune {
+	switch {
+	case r >= 0xFF01 && r <= 0xFF5E: // Fullwidth forms.
+		return r - 0xFF01 + '!'
+	case r >= 0x1D400 && r <= 0x1D6A3: // Mathematical letters, 13 styles of A-Z and a-z.
+		i := (r - 0x1D400) % 52
+		if i < 26 {
+			return 'A' + i
+		}
+		return 'a' + i - 26
+	case r >= 0x1D7CE && r <= 0x1D7FF: // Mathematical digits, 5 styles.
+		return '0' + (r-0x1D7CE)%10
+	case r >= 0x24B6 && r <= 0x24CF: // Circled capital letters.
+		return 'A' + r - 0x24B6
+	case r >= 0x24D0 && r <= 0x24E9: // Circled small letters.
+		return 'a' + r - 0x24D0
+	case r >= 0x1F130 && r <= 0x1F189: // Squared and negative circled or squared letters, 32 apart.
+		if i := (r - 0x1F130) % 32; i < 26 {
+			return 'A' + i
+		}
+	}
+	return r
+}
+
+// A NicknameNormalizer is a Module which replaces the names of members
+// breaking its rules, eg. hoisted or zalgo names, when they join and when
+// they change their nickname. Bots are ignored.
+type NicknameNormalizer struct {
+	sync.Mutex
+
+	// The name of the module, "nickname-normalizer" if empty.
+	ModuleName string
+
+	// The options can be changed at any time while holding the lock.
+	// Zero Rules apply NicknameRulesAll.
+	Rules    NicknameRules
+	Strategy NicknameStrategy
+
+	// The nickname of members whose name can't be normalized,
+	// "Moderated Nickname" if empty.
+	Placeholder string
+
+	// The reason of the changes, shown in the audit log.
+	Reason string
+}
+
+// Name returns the name of the module.
+func (n *NicknameNormalizer) Name() string {
+	if n.ModuleName == "" {
+		return "nickname-normalizer"
+	}
+	return n.ModuleName
+}
+
+// Setup adds the handlers of the normalizer.
+func (n *NicknameNormalizer) Setup(s *Session) error {
+	s.AddModuleHandler(n.Name(), func(s *Session, m *GuildMemberAdd) {
+		n.normalize(s, m.Member)
+	})
+	s.AddModuleHandler(n.Name(), func(s *Session, m *GuildMemberUpdate) {
+		// Skip updates of the roles of members the normalizer has checked.
+		if b := m.BeforeUpdate; b != nil && m.Member != nil && m.User != nil && b.User != nil &&
+			b.Nick == m.Nick && b.User.Username == m.User.Username {
+			return
+		}
+		n.normalize(s, m.Member)
+	})
+	return nil
+}
+
+// Teardown does nothing, the normalizer has no state.
+func (n *NicknameNormalizer) Teardown(s *Session) error {
+	return nil
+}
+
+// Nickname returns the nickname the normalizer sets for a member, and
+// whether it changes it.
+// m : The member.
+func (n *NicknameNormalizer) Nickname(m *Member) (nick string, change bool) {
+	n.Lock()
+	rules, strategy, placeholder := n.Rules, n.Strategy, n.Placeholder
+	n.Unlock()
+
+	if rules == 0 {
+		rules = NicknameRulesAll
+	}
+	if placeholder == "" {
+		placeholder = "Moderated Nickname"
+	}
+
+	name := m.Nick
+	if name == "" {
+		name = m.User.Username
+	}
+	normalized := NormalizeNickname(name, rules)
+	if normalized == name {
+		return m.Nick, false
+	}
+
+	switch strategy {
+	case NicknameStrategyPlaceholder:
+		nick = placeholder
+	case NicknameStrategyReset:
+		if username := NormalizeNickname(m.User.Username, rules); username != m.User.Username {
+			nick = username
+			if nick == "" {
+				nick = placeholder
+			}
+		}
+	default:
+		nick = normalized
+		if nick == "" {
+			nick = placeholder
+		}
+	}
+	return nick, nick != m.Nick
+}
+
+func (n *NicknameNormalizer) normalize(s *Session, m *Member) {
+	if m == nil || m.User == nil || m.User.Bot {
+		return
+	}
+
+	nick, change := n.Nickname(m)
+	if !change {
+		return
+	}
+
+	n.Lock()
+	reason := n.Reason
+	n.Unlock()
+
+	if err := s.GuildMemberNicknameWithReason(m.GuildID, m.User.ID, nick, reason); err != nil {
+		s.log(LogError, "error normalizing the nickname of member %s of guild %s, %s", m.User.ID, m.GuildID, err)
+	}
+}
@@ -0,0 +1,79 @@
+package discordgo
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestNormalizeNickname(t *testing.T) {
+	tests := []struct {
+		name     string
+		rules    NicknameRules
+		expected string
+	}{
+		{"!!! Alice", NicknameRulesAll, "Alice"},
+		{"!!! Alice", NicknameRuleZalgo, "!!! Alice"},
+		{"  . 2pac", NicknameRuleHoist, "2pac"},
+		{"Z\u0351\u0352\u0353a\u030a\u0346lgo", NicknameRuleZalgo, "Z\u0351a\u030algo"},
+		{"Jo\u200bhn", NicknameRuleZalgo, "John"},
+		{"\U0001d401\U0001d428\U0001d41b", NicknameRuleConfusables, "Bob"},
+		{"ＡＢＣ１２３", NicknameRuleConfusables, "ABC123"},
+		{"Ⓐⓑ\U0001f150", NicknameRuleConfusables, "AbA"},
+		{"Pаypаl", NicknameRuleConfusables, "Paypal"},
+		{"Настя", NicknameRuleConfusables, "Настя"},
+		{"!!!", NicknameRulesAll, ""},
+		{"Jo\u200b  hn ", NicknameRuleZalgo, "Jo  hn "},
+		{" Jo \t hn ", NicknameRuleWhitespace, "Jo hn"},
+	}
+	for _, test := range tests {
+		if n := NormalizeNickname(test.name, test.rules); n != test.expected {
+			t.Errorf("expected %q for %q, got %q", test.expected, test.name, n)
+		}
+	}
+}
+
+func TestNicknameNormalizerNickname(t *testing.T) {
+	tests := []struct {
+		strategy NicknameStrategy
+		member   *Member
+		nick     string
+		change   bool
+	}{
+		{NicknameStrategyNormalize, &Member{Nick: "Alice", User: &User{Username: "alice"}}, "Alice", false},
+		{NicknameStrategyNormalize, &Member{Nick: "!Alice", User: &User{Username: "alice"}}, "Alice", true},
+		{NicknameStrategyNormalize, &Member{User: &User{Username: "!!!"}}, "Moderated Nickname", true},
+		{NicknameStrategyPlaceholder, &Member{Nick: "!Alice", User: &User{Username: "alice"}}, "Moderated Nickname", true},
+		{NicknameStrategyReset, &Member{Nick: "!Alice", User: &User{Username: "alice"}}, "", true},
+		{NicknameStrategyReset, &Member{Nick: "!Alice", User: &User{Username: "!alice"}}, "alice", true},
+	}
+	for i, test := range tests {
+		n := &NicknameNormalizer{Strategy: test.strategy}
+		nick, change := n.Nickname(test.member)
+		if nick != test.nick || change != test.change {
+			t.Errorf("%d: expected %q and %t, got %q and %t", i, test.nick, test.change, nick, change)
+		}
+	}
+}
+
+func TestNicknameNormalizer(t *testing.T) {
+	route := "PATCH " + EndpointGuildMember("1", "2") + "?reason=" + url.QueryEscape("Hoisted name")
+	tr := &routeTransport{routes: map[string]string{route: ``}}
+	s := newTestSession(tr)
+	s.SyncEvents = true
+
+	if err := s.ModuleAdd(&NicknameNormalizer{Reason: "Hoisted name"}); err != nil {
+		t.Fatal(err)
+	}
+
+	s.handleEvent(guildMemberAddEventType, &GuildMemberAdd{&Member{GuildID: "1", User: &User{ID: "2", Username: "!!alice"}}})
+	s.handleEvent(guildMemberAddEventType, &GuildMemberAdd{&Member{GuildID: "1", User: &User{ID: "3", Username: "bob"}}})
+	s.handleEvent(guildMemberAddEventType, &GuildMemberAdd{&Member{GuildID: "1", User: &User{ID: "4", Username: "!bot", Bot: true}}})
+
+	// The roles of the member changed, their nickname was already checked.
+	before := &Member{GuildID: "1", Nick: "!!alice", User: &User{ID: "2", Username: "alice"}}
+	s.handleEvent(guildMemberUpdateEventType, &GuildMemberUpdate{&Member{GuildID: "1", Nick: "!!alice", User: &User{ID: "2", Username: "alice"}, Roles: []string{"5"}}, before})
+
+	if len(tr.requests) != 1 || tr.requests[0] != route {
+		t.Errorf("expected request %s, got %v", route, tr.requests)
+	}
+}
@@ -0,0 +1,107 @@
+package discordgo
+
+import "context"
+
+// An NSFWError is returned when sending NSFW content to a channel which
+// isn't age-restricted.
+type NSFWError struct {
+	ChannelID string
+	GuildID   string
+}
+
+// Error returns the channel of the error.
+func (e *NSFWError) Error() string {
+	return "channel " + e.ChannelID + " is not age-restricted"
+}
+
+// ChannelAllowsNSFW returns whether NSFW content may be sent to a channel,
+// which is the case for channels marked as NSFW, the threads of such
+// channels, and all channels of age-restricted guilds. DMs don't allow it.
+// The channel and guild are taken from the state if they are cached.
+// channelID : The ID of a Channel.
+func (s *Session) ChannelAllowsNSFW(channelID string) (bool, error) {
+	ctx := context.Background()
+	c, err := s.resolveChannel(ctx, channelID)
+	if err != nil {
+		return false, err
+	}
+	if c.GuildID == "" {
+		return false, nil
+	}
+
+	if c.NSFW {
+		return true, nil
+	}
+	if isThread(c) && c.ParentID != "" {
+		parent, err := s.resolveChannel(ctx, c.ParentID)
+		if err != nil {
+			return false, err
+		}
+		if parent.NSFW {
+			return true, nil
+		}
+	}
+
+	g, err := s.resolveGuild(ctx, c.GuildID)
+	if err != nil {
+		return false, err
+	}
+	return g.NSFWLevel == GuildNSFWLevelAgeRestricted, nil
+}
+
+// isThread returns whether a channel is a thread.
+func isThread(c *Channel) bool {
+	switch c.Type {
+	case ChannelTypeGuildNewsThread, ChannelTypeGuildPublicThread, ChannelTypeGuildPrivateThread:
+		return true
+	}
+	return false
+}
+
+// An NSFWGuard sends NSFW content only to channels which allow it, see
+// ChannelAllowsNSFW. Content for other channels is rerouted to the NSFW
+// channel of their guild, or rejected with an NSFWError.
+type NSFWGuard struct {
+	// The channels the content is rerouted to, by guild ID, optional.
+	Channels map[string]string
+}
+
+// ChannelMessageSendComplex sends NSFW content to a channel, or to the
+// NSFW channel of its guild if the channel doesn't allow it.
+// s         : The session to send the message with.
+// channelID : The ID of a Channel.
+// data      : The message struct to send.
+func (g *NSFWGuard) ChannelMessageSendComplex(s *Session, channelID string, data *MessageSend) (*Message, error) {
+	channelID, err := g.Route(s, channelID)
+	if err != nil {
+		return nil, err
+	}
+	return s.ChannelMessageSendComplex(channelID, data)
+}
+
+// Route returns the channel NSFW content for a channel is sent to, the
+// channel itself or the NSFW channel of its guild. An NSFWError is
+// returned if neither allows NSFW content.
+// s         : The session to look the channels up with.
+// channelID : The ID of a Channel.
+func (g *NSFWGuard) Route(s *Session, channelID string) (string, error) {
+	ok, err := s.ChannelAllowsNSFW(channelID)
+	if err != nil || ok {
+		return channelID, err
+	}
+
+	c, err := s.resolveChannel(context.Background(), channelID)
+	if err != nil {
+		return "", err
+	}
+	if nsfwID := g.Channels[c.GuildID]; nsfwID != "" && nsfwID != channelID {
+		ok, err := s.ChannelAllowsNSFW(nsfwID)
+		if err != nil {
+			return "", err
+		}
+		if ok {
+			return nsfwID, nil
+		}
+	}
+	return "", &NSFWError{ChannelID: channelID, GuildID: c.GuildID}
+}
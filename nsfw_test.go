@@ -0,0 +1,39 @@
+package discordgo
+
+import (
+	"testing"
+)
+
+func TestNSFWGuard(t *testing.T) {
+	tr := &routeTransport{routes: map[string]string{
+		"POST " + EndpointChannelMessages("3"): `{"id": "100"}`,
+	}}
+	s := newTestSession(tr)
+	s.State.GuildAdd(&Guild{ID: "1"})
+	s.State.GuildAdd(&Guild{ID: "5", NSFWLevel: GuildNSFWLevelAgeRestricted})
+	s.State.ChannelAdd(&Channel{ID: "2", GuildID: "1"})
+	s.State.ChannelAdd(&Channel{ID: "3", GuildID: "1", NSFW: true})
+	s.State.ChannelAdd(&Channel{ID: "4", GuildID: "1", ParentID: "3", Type: ChannelTypeGuildPublicThread})
+	s.State.ChannelAdd(&Channel{ID: "6", GuildID: "5"})
+
+	for id, expected := range map[string]bool{"2": false, "3": true, "4": true, "6": true} {
+		if ok, err := s.ChannelAllowsNSFW(id); err != nil || ok != expected {
+			t.Errorf("expected %v for channel %s, got %v, %v", expected, id, ok, err)
+		}
+	}
+
+	g := &NSFWGuard{}
+	if _, err := g.ChannelMessageSendComplex(s, "2", &MessageSend{Content: "nsfw"}); err == nil {
+		t.Error("expected an error")
+	} else if e, ok := err.(*NSFWError); !ok || e.ChannelID != "2" || e.GuildID != "1" {
+		t.Errorf("expected an NSFWError, got %v", err)
+	}
+
+	g.Channels = map[string]string{"1": "3"}
+	if _, err := g.ChannelMessageSendComplex(s, "2", &MessageSend{Content: "nsfw"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(tr.requests) != 1 || tr.requests[0] != "POST "+EndpointChannelMessages("3") {
+		t.Errorf("expected the message to be rerouted, got %v", tr.requests)
+	}
+}
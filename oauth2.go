@@ -9,6 +9,12 @@
 
 package discordgo
 
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
 // ------------------------------------------------------------------------------------------------
 // Code specific to Discord OAuth2 Applications
 // ------------------------------------------------------------------------------------------------
@@ -22,12 +28,25 @@ const (
 	MembershipStateAccepted
 )
 
+// A TeamMemberRole is the role of a member of a Team
+// https://discord.com/developers/docs/topics/teams#team-member-roles
+type TeamMemberRole string
+
+// Block contains the valid known TeamMemberRole values, the owner of a
+// team isn't a role but the Team.OwnerID
+const (
+	TeamMemberRoleAdmin     TeamMemberRole = "admin"
+	TeamMemberRoleDeveloper TeamMemberRole = "developer"
+	TeamMemberRoleReadOnly  TeamMemberRole = "read_only"
+)
+
 // A TeamMember struct stores values for a single Team Member, extending the normal User data - note that the user field is partial
 type TeamMember struct {
 	User            *User           `json:"user"`
 	TeamID          string          `json:"team_id"`
 	MembershipState MembershipState `json:"membership_state"`
 	Permissions     []string        `json:"permissions"`
+	Role            TeamMemberRole  `json:"role"`
 }
 
 // A Team struct stores the members of a Discord Developer Team as well as some metadata about it
@@ -40,21 +59,157 @@ type Team struct {
 	Members     []*TeamMember `json:"members"`
 }
 
+// Owner returns the member of the team which owns it, or nil if it isn't
+// in the members.
+func (t *Team) Owner() *TeamMember {
+	for _, m := range t.Members {
+		if m.User != nil && m.User.ID == t.OwnerID {
+			return m
+		}
+	}
+	return nil
+}
+
+// Member returns the member of the team with the user ID, or nil if the
+// user isn't a member.
+func (t *Team) Member(userID string) *TeamMember {
+	for _, m := range t.Members {
+		if m.User != nil && m.User.ID == userID {
+			return m
+		}
+	}
+	return nil
+}
+
+// InstallParams stores the scopes and permissions an application is added
+// to a guild with, by its in-app authorization link.
+type InstallParams struct {
+	Scopes      []string `json:"scopes"`
+	Permissions int64    `json:"permissions,string"`
+}
+
+// InviteURL returns the URL adding the application to a guild with the
+// install params, see BotInviteURL.
+//   appID : The ID of the Application
+func (p *InstallParams) InviteURL(appID string) string {
+	return BotInviteURL(appID, p.Permissions, p.Scopes, "", false)
+}
+
+// ApplicationIntegrationTypeConfig stores the install configuration of an
+// application for an integration type.
+type ApplicationIntegrationTypeConfig struct {
+	OAuth2InstallParams *InstallParams `json:"oauth2_install_params,omitempty"`
+}
+
 // An Application struct stores values for a Discord OAuth2 Application
 type Application struct {
-	ID                  string    `json:"id,omitempty"`
-	Name                string    `json:"name"`
-	Description         string    `json:"description,omitempty"`
-	Icon                string    `json:"icon,omitempty"`
-	Secret              string    `json:"secret,omitempty"`
-	RedirectURIs        *[]string `json:"redirect_uris,omitempty"`
-	BotRequireCodeGrant bool      `json:"bot_require_code_grant,omitempty"`
-	BotPublic           bool      `json:"bot_public,omitempty"`
-	RPCApplicationState int       `json:"rpc_application_state,omitempty"`
-	Flags               int       `json:"flags,omitempty"`
-	Owner               *User     `json:"owner"`
-	Bot                 *User     `json:"bot"`
-	Team                *Team     `json:"team"`
+	ID                  string           `json:"id,omitempty"`
+	Name                string           `json:"name"`
+	Description         string           `json:"description,omitempty"`
+	Icon                string           `json:"icon,omitempty"`
+	Secret              string           `json:"secret,omitempty"`
+	RedirectURIs        *[]string        `json:"redirect_uris,omitempty"`
+	BotRequireCodeGrant bool             `json:"bot_require_code_grant,omitempty"`
+	BotPublic           bool             `json:"bot_public,omitempty"`
+	RPCApplicationState int              `json:"rpc_application_state,omitempty"`
+	Flags               ApplicationFlags `json:"flags,omitempty"`
+	Owner               *User            `json:"owner"`
+	Bot                 *User            `json:"bot"`
+	Team                *Team            `json:"team"`
+
+	// The tags describing the application, up to 5
+	Tags []string `json:"tags,omitempty"`
+
+	// The default in-app authorization link parameters, or the custom URL
+	// users are sent to instead
+	InstallParams    *InstallParams `json:"install_params,omitempty"`
+	CustomInstallURL string         `json:"custom_install_url,omitempty"`
+
+	// The install configurations of the integration types the application
+	// supports
+	IntegrationTypesConfig map[ApplicationIntegrationType]*ApplicationIntegrationTypeConfig `json:"integration_types_config,omitempty"`
+
+	// will only be filled when using ApplicationMe
+	ApproximateGuildCount int `json:"approximate_guild_count,omitempty"`
+}
+
+// ApplicationFlags are the public flags of an Application
+// https://discord.com/developers/docs/resources/application#application-object-application-flags
+type ApplicationFlags int
+
+// Block of valid ApplicationFlags
+const (
+	ApplicationFlagGatewayPresence               ApplicationFlags = 1 << 12
+	ApplicationFlagGatewayPresenceLimited        ApplicationFlags = 1 << 13
+	ApplicationFlagGatewayGuildMembers           ApplicationFlags = 1 << 14
+	ApplicationFlagGatewayGuildMembersLimited    ApplicationFlags = 1 << 15
+	ApplicationFlagVerificationPendingGuildLimit ApplicationFlags = 1 << 16
+	ApplicationFlagEmbedded                      ApplicationFlags = 1 << 17
+	ApplicationFlagGatewayMessageContent         ApplicationFlags = 1 << 18
+	ApplicationFlagGatewayMessageContentLimited  ApplicationFlags = 1 << 19
+)
+
+// ApplicationMe returns the Application of the current bot, including its
+// approximate guild count. Bots reaching 100 guilds need to be verified, and
+// bots with ApplicationFlagVerificationPendingGuildLimit can't join more
+// guilds until they are.
+func (s *Session) ApplicationMe() (st *Application, err error) {
+
+	body, err := s.RequestWithBucketID("GET", EndpointApplicationMe, nil, EndpointApplicationMe)
+	if err != nil {
+		return
+	}
+
+	err = s.unmarshal(body, &st)
+	return
+}
+
+// ActivityLocationKind is the kind of the location of an ActivityInstance.
+type ActivityLocationKind string
+
+// Block of valid ActivityLocationKinds
+const (
+	// The activity runs in a guild channel.
+	ActivityLocationKindGuildChannel ActivityLocationKind = "gc"
+
+	// The activity runs in a DM or group DM.
+	ActivityLocationKindPrivateChannel ActivityLocationKind = "pc"
+)
+
+// An ActivityLocation is the channel an activity instance runs in.
+type ActivityLocation struct {
+	ID        string               `json:"id"`
+	Kind      ActivityLocationKind `json:"kind"`
+	ChannelID string               `json:"channel_id"`
+	GuildID   string               `json:"guild_id,omitempty"`
+}
+
+// An ActivityInstance is a running instance of the activity of an
+// embedded application.
+type ActivityInstance struct {
+	ApplicationID string            `json:"application_id"`
+	InstanceID    string            `json:"instance_id"`
+	LaunchID      string            `json:"launch_id"`
+	Location      *ActivityLocation `json:"location"`
+
+	// The IDs of the users connected to the instance.
+	Users []string `json:"users"`
+}
+
+// ApplicationActivityInstance returns a running instance of the activity of
+// an application, eg. to check which users are connected before trusting
+// a request of the activity.
+//   appID      : The ID of an Application
+//   instanceID : The ID of the activity instance
+func (s *Session) ApplicationActivityInstance(appID, instanceID string) (st *ActivityInstance, err error) {
+
+	body, err := s.RequestWithBucketID("GET", EndpointApplicationActivityInstance(appID, instanceID), nil, EndpointApplicationActivityInstance(appID, ""))
+	if err != nil {
+		return
+	}
+
+	err = s.unmarshal(body, &st)
+	return
 }
 
 // Application returns an Application structure of a specific Application
@@ -66,7 +221,7 @@ func (s *Session) Application(appID string) (st *Application, err error) {
 		return
 	}
 
-	err = unmarshal(body, &st)
+	err = s.unmarshal(body, &st)
 	return
 }
 
@@ -78,7 +233,7 @@ func (s *Session) Applications() (st []*Application, err error) {
 		return
 	}
 
-	err = unmarshal(body, &st)
+	err = s.unmarshal(body, &st)
 	return
 }
 
@@ -98,7 +253,7 @@ func (s *Session) ApplicationCreate(ap *Application) (st *Application, err error
 		return
 	}
 
-	err = unmarshal(body, &st)
+	err = s.unmarshal(body, &st)
 	return
 }
 
@@ -117,7 +272,7 @@ func (s *Session) ApplicationUpdate(appID string, ap *Application) (st *Applicat
 		return
 	}
 
-	err = unmarshal(body, &st)
+	err = s.unmarshal(body, &st)
 	return
 }
 
@@ -148,7 +303,7 @@ func (s *Session) ApplicationAssets(appID string) (ass []*Asset, err error) {
 		return
 	}
 
-	err = unmarshal(body, &ass)
+	err = s.unmarshal(body, &ass)
 	return
 }
 
@@ -168,6 +323,44 @@ func (s *Session) ApplicationBotCreate(appID string) (st *User, err error) {
 		return
 	}
 
-	err = unmarshal(body, &st)
+	err = s.unmarshal(body, &st)
 	return
 }
+
+// ------------------------------------------------------------------------------------------------
+// Code specific to Discord OAuth2 authorization URLs
+// ------------------------------------------------------------------------------------------------
+
+// Block contains the valid known OAuth2 scopes of bot invites
+const (
+	OAuth2ScopeBot                  = "bot"
+	OAuth2ScopeApplicationsCommands = "applications.commands"
+	OAuth2ScopeWebhookIncoming      = "webhook.incoming"
+)
+
+// BotInviteURL returns the OAuth2 authorization URL which adds a bot to a guild.
+//   appID              : The ID of the Application of the bot
+//   permissions        : The permissions the bot is granted, 0 for none
+//   scopes             : The OAuth2 scopes, OAuth2ScopeBot if empty
+//   guildID            : The ID of the guild preselected in the dialog, optional
+//   disableGuildSelect : Whether the user can't select another guild than guildID
+func BotInviteURL(appID string, permissions int64, scopes []string, guildID string, disableGuildSelect bool) string {
+	if len(scopes) == 0 {
+		scopes = []string{OAuth2ScopeBot}
+	}
+
+	v := url.Values{}
+	v.Set("client_id", appID)
+	v.Set("scope", strings.Join(scopes, " "))
+	if permissions != 0 {
+		v.Set("permissions", strconv.FormatInt(permissions, 10))
+	}
+	if guildID != "" {
+		v.Set("guild_id", guildID)
+		if disableGuildSelect {
+			v.Set("disable_guild_select", "true")
+		}
+	}
+
+	return EndpointOauth2Authorize + "?" + v.Encode()
+}
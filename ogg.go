@@ -0,0 +1,407 @@
+package discordgo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// Errors returned by OggOpusReader.
+var (
+	ErrOggInvalidPage   = errors.New("invalid ogg page")
+	ErrOggChecksum      = errors.New("ogg page checksum mismatch")
+	ErrOggNotOpus       = errors.New("ogg stream is not an opus stream")
+	ErrOggOpusFrameSize = errors.New("opus packet is not 20ms long")
+	ErrOggNotSeekable   = errors.New("ogg reader doesn't support seeking")
+)
+
+// oggCapturePattern starts every ogg page.
+var oggCapturePattern = []byte("OggS")
+
+// Header type flags of an ogg page.
+const (
+	oggFlagContinued = 1 << iota
+	oggFlagBOS
+	oggFlagEOS
+)
+
+// oggCRCTable is the table of the CRC-32 of ogg pages, polynomial 0x04c11db7
+// without reflection.
+var oggCRCTable = func() (t [256]uint32) {
+	for i := range t {
+		r := uint32(i) << 24
+		for j := 0; j < 8; j++ {
+			if r&0x80000000 != 0 {
+				r = r<<1 ^ 0x04c11db7
+			} else {
+				r <<= 1
+			}
+		}
+		t[i] = r
+	}
+	return
+}()
+
+// oggCRC returns the checksum of an ogg page, whose checksum field is zeroed.
+func oggCRC(page []byte) (crc uint32) {
+	for _, b := range page {
+		crc = crc<<8 ^ oggCRCTable[byte(crc>>24)^b]
+	}
+	return
+}
+
+// oggPage is a page of an ogg stream.
+type oggPage struct {
+	flags    byte
+	granule  int64
+	segments []byte
+	body     []byte
+}
+
+// An OggOpusReader demuxes an Ogg Opus file or stream into opus packets,
+// which can be sent to VoiceConnection.OpusSend without re-encoding. The
+// stream must be encoded with 20ms frames, eg. with ffmpeg
+// "-c:a libopus -frame_duration 20 -ar 48000 -ac 2 -f ogg".
+type OggOpusReader struct {
+	r io.Reader
+
+	// The opus header of the stream.
+	Channels   int
+	PreSkip    int
+	SampleRate int
+
+	// Where the audio pages start, for seeking.
+	dataOffset int64
+
+	page    *oggPage
+	segment int
+	packet  []byte
+	granule int64
+}
+
+// NewOggOpusReader returns a new OggOpusReader reading from r, after reading
+// the opus headers. SeekGranule is supported if r is an io.ReadSeeker.
+func NewOggOpusReader(r io.Reader) (*OggOpusReader, error) {
+	o := &OggOpusReader{r: r}
+
+	head, err := o.headerPacket()
+	if err != nil {
+		return nil, err
+	}
+	if len(head) < 19 || !bytes.HasPrefix(head, []byte("OpusHead")) {
+		return nil, ErrOggNotOpus
+	}
+	o.Channels = int(head[9])
+	o.PreSkip = int(binary.LittleEndian.Uint16(head[10:12]))
+	o.SampleRate = int(binary.LittleEndian.Uint32(head[12:16]))
+
+	tags, err := o.headerPacket()
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.HasPrefix(tags, []byte("OpusTags")) {
+		return nil, ErrOggNotOpus
+	}
+
+	// The tags end their page, the audio starts on the next one. Pipes are
+	// Seekers too, but fail to seek and are not seekable.
+	if s, ok := r.(io.Seeker); ok {
+		if offset, err := s.Seek(0, io.SeekCurrent); err == nil {
+			o.dataOffset = offset
+		}
+	}
+	o.page = nil
+	return o, nil
+}
+
+// headerPacket reads a packet of the headers, which is not checked for its
+// duration.
+func (o *OggOpusReader) headerPacket() ([]byte, error) {
+	p, err := o.nextPacket()
+	if err == io.EOF {
+		err = ErrOggNotOpus
+	}
+	return p, err
+}
+
+// readPage reads the next page of the stream.
+func (o *OggOpusReader) readPage() (*oggPage, error) {
+	header := make([]byte, 27)
+	if _, err := io.ReadFull(o.r, header); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			err = ErrOggInvalidPage
+		}
+		return nil, err
+	}
+	if !bytes.Equal(header[:4], oggCapturePattern) || header[4] != 0 {
+		return nil, ErrOggInvalidPage
+	}
+
+	segments := make([]byte, header[26])
+	if _, err := io.ReadFull(o.r, segments); err != nil {
+		return nil, ErrOggInvalidPage
+	}
+
+	size := 0
+	for _, s := range segments {
+		size += int(s)
+	}
+	body := make([]byte, size)
+	if _, err := io.ReadFull(o.r, body); err != nil {
+		return nil, ErrOggInvalidPage
+	}
+
+	crc := binary.LittleEndian.Uint32(header[22:26])
+	copy(header[22:26], []byte{0, 0, 0, 0})
+	if oggCRC(append(append(header, segments...), body...)) != crc {
+		return nil, ErrOggChecksum
+	}
+
+	return &oggPage{
+		flags:    header[5],
+		granule:  int64(binary.LittleEndian.Uint64(header[6:14])),
+		segments: segments,
+		body:     body,
+	}, nil
+}
+
+// nextPacket returns the next packet of the stream, joining packets which
+// span pages.
+func (o *OggOpusReader) nextPacket() ([]byte, error) {
+	var packet []byte
+	for {
+		if o.page == nil || o.segment == len(o.page.segments) {
+			page, err := o.readPage()
+			if err != nil {
+				if err == io.EOF && len(packet) > 0 {
+					err = ErrOggInvalidPage
+				}
+				return nil, err
+			}
+			o.page, o.segment, o.packet = page, 0, page.body
+		}
+
+		for o.segment < len(o.page.segments) {
+			size := int(o.page.segments[o.segment])
+			o.segment++
+
+			packet = append(packet, o.packet[:size]...)
+			o.packet = o.packet[size:]
+
+			// Packets end with a segment shorter than 255 bytes.
+			if size < 255 {
+				if o.segment == len(o.page.segments) {
+					o.granule = o.page.granule
+				}
+				return packet, nil
+			}
+		}
+	}
+}
+
+// ReadFrame returns the next opus packet of the stream, or io.EOF at the end
+// of the stream. ErrOggOpusFrameSize is returned for packets which are not
+// 20ms long.
+func (o *OggOpusReader) ReadFrame() ([]byte, error) {
+	for {
+		packet, err := o.nextPacket()
+		if err != nil {
+			return nil, err
+		}
+
+		// Discord doesn't accept empty packets, Ogg muxers don't write them
+		// but they are allowed.
+		if len(packet) == 0 {
+			continue
+		}
+
+		if opusPacketDuration(packet) != 960 {
+			return nil, ErrOggOpusFrameSize
+		}
+		return packet, nil
+	}
+}
+
+// Granule returns the granule position of the last page whose packets were
+// all read, the number of 48kHz samples until the end of the page
+// including the PreSkip samples.
+func (o *OggOpusReader) Granule() int64 {
+	return o.granule
+}
+
+// SeekGranule seeks the stream to the page containing the granule position, eg.
+// PreSkip + 48000 * seconds. ReadFrame returns the first packet starting on
+// that page. The reader of the stream must be an io.ReadSeeker.
+func (o *OggOpusReader) SeekGranule(granule int64) error {
+	s, ok := o.r.(io.Seeker)
+	if !ok || o.dataOffset == 0 {
+		return ErrOggNotSeekable
+	}
+
+	offset := o.dataOffset
+	if _, err := s.Seek(offset, io.SeekStart); err != nil {
+		return err
+	}
+
+	// Pages are scanned linearly, ogg pages of opus are small enough that
+	// this is quick even for long files.
+	var previous int64
+	for {
+		page, err := o.readPage()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if page.granule >= granule || page.flags&oggFlagEOS != 0 {
+			break
+		}
+
+		if page.granule >= 0 {
+			previous = page.granule
+		}
+		if offset, err = s.Seek(0, io.SeekCurrent); err != nil {
+			return err
+		}
+	}
+
+	if _, err := s.Seek(offset, io.SeekStart); err != nil {
+		return err
+	}
+	o.page, o.granule = nil, previous
+
+	// Skip the end of a packet started on the previous page.
+	page, err := o.readPage()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	o.page, o.segment, o.packet = page, 0, page.body
+	if page.flags&oggFlagContinued != 0 {
+		for o.segment < len(page.segments) {
+			size := int(page.segments[o.segment])
+			o.segment++
+			o.packet = o.packet[size:]
+			if size < 255 {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// opusPacketDuration returns the number of 48kHz samples of an opus packet,
+// from its TOC byte. https://tools.ietf.org/html/rfc6716#section-3.1
+func opusPacketDuration(packet []byte) int {
+	if len(packet) == 0 {
+		return 0
+	}
+
+	// Frame sizes in 48kHz samples, of the SILK, hybrid and CELT modes.
+	var size int
+	switch config := packet[0] >> 3; {
+	case config < 12:
+		size = []int{480, 960, 1920, 2880}[config%4]
+	case config < 16:
+		size = []int{480, 960}[config%2]
+	default:
+		size = []int{120, 240, 480, 960}[config%4]
+	}
+
+	switch packet[0] & 3 {
+	case 0:
+		return size
+	case 1, 2:
+		return 2 * size
+	default:
+		if len(packet) < 2 {
+			return 0
+		}
+		return int(packet[1]&0x3f) * size
+	}
+}
+
+// An OggOpusWriter muxes opus packets into an Ogg Opus stream, eg. to
+// record received audio into a file.
+type OggOpusWriter struct {
+	w       io.Writer
+	serial  uint32
+	seq     uint32
+	granule int64
+}
+
+// NewOggOpusWriter returns a new OggOpusWriter writing to w, after writing
+// the opus headers of a 48kHz stream.
+// w        : The writer of the stream.
+// channels : The number of channels of the packets, 2 for Discord audio.
+// serial   : The serial number of the stream, which should be unique in a file.
+func NewOggOpusWriter(w io.Writer, channels int, serial uint32) (*OggOpusWriter, error) {
+	o := &OggOpusWriter{w: w, serial: serial}
+
+	head := make([]byte, 19)
+	copy(head, "OpusHead")
+	head[8] = 1
+	head[9] = byte(channels)
+	binary.LittleEndian.PutUint32(head[12:16], pcmSampleRate)
+	if err := o.writePage(oggFlagBOS, 0, head); err != nil {
+		return nil, err
+	}
+
+	vendor := "discordgo"
+	tags := make([]byte, 8+4+len(vendor)+4)
+	copy(tags, "OpusTags")
+	binary.LittleEndian.PutUint32(tags[8:], uint32(len(vendor)))
+	copy(tags[12:], vendor)
+	if err := o.writePage(0, 0, tags); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// writePage writes a page holding a packet, or no packet if it is nil.
+func (o *OggOpusWriter) writePage(flags byte, granule int64, packet []byte) error {
+	var segments []byte
+	if packet != nil {
+		n := len(packet)
+		for ; n >= 255; n -= 255 {
+			segments = append(segments, 255)
+		}
+		segments = append(segments, byte(n))
+	}
+
+	page := make([]byte, 27, 27+len(segments)+len(packet))
+	copy(page, oggCapturePattern)
+	page[5] = flags
+	binary.LittleEndian.PutUint64(page[6:], uint64(granule))
+	binary.LittleEndian.PutUint32(page[14:], o.serial)
+	binary.LittleEndian.PutUint32(page[18:], o.seq)
+	page[26] = byte(len(segments))
+	page = append(append(page, segments...), packet...)
+	binary.LittleEndian.PutUint32(page[22:], oggCRC(page))
+
+	o.seq++
+	_, err := o.w.Write(page)
+	return err
+}
+
+// WriteFrame writes an opus packet on its own page, so the stream can be
+// read while it is written.
+func (o *OggOpusWriter) WriteFrame(packet []byte) error {
+	o.granule += int64(opusPacketDuration(packet))
+	return o.writePage(0, o.granule, packet)
+}
+
+// Granule returns the number of 48kHz samples written.
+func (o *OggOpusWriter) Granule() int64 {
+	return o.granule
+}
+
+// Close ends the stream with an empty page, it doesn't close the writer.
+func (o *OggOpusWriter) Close() error {
+	return o.writePage(oggFlagEOS, o.granule, nil)
+}
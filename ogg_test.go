@@ -0,0 +1,113 @@
+package discordgo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"testing"
+)
+
+// oggTestPage returns an ogg page holding the packets.
+func oggTestPage(flags byte, granule int64, seq uint32, packets ...[]byte) []byte {
+	var segments, body []byte
+	for _, p := range packets {
+		n := len(p)
+		for ; n >= 255; n -= 255 {
+			segments = append(segments, 255)
+		}
+		segments = append(segments, byte(n))
+		body = append(body, p...)
+	}
+
+	header := make([]byte, 27)
+	copy(header, oggCapturePattern)
+	header[5] = flags
+	binary.LittleEndian.PutUint64(header[6:], uint64(granule))
+	binary.LittleEndian.PutUint32(header[18:], seq)
+	header[26] = byte(len(segments))
+
+	page := append(append(header, segments...), body...)
+	binary.LittleEndian.PutUint32(page[22:], oggCRC(page))
+	return page
+}
+
+func oggTestStream() []byte {
+	head := []byte("OpusHead\x01\x02\x38\x01\x80\xbb\x00\x00\x00\x00\x00")
+	var b bytes.Buffer
+	b.Write(oggTestPage(0x02, 0, 0, head))
+	b.Write(oggTestPage(0, 0, 1, []byte("OpusTags")))
+
+	// A 20ms CELT frame has config 31 (TOC 0xf8), the second byte numbers the
+	// frames.
+	for i := 0; i < 3; i++ {
+		flags := byte(0)
+		if i == 2 {
+			flags = 0x04
+		}
+		b.Write(oggTestPage(flags, int64(312+960*2*(i+1)), uint32(2+i), []byte{0xf8, byte(2 * i)}, []byte{0xf8, byte(2*i + 1)}))
+	}
+	return b.Bytes()
+}
+
+func TestOggOpusReader(t *testing.T) {
+	r, err := NewOggOpusReader(bytes.NewReader(oggTestStream()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Channels != 2 || r.PreSkip != 312 || r.SampleRate != 48000 {
+		t.Errorf("unexpected header %d %d %d", r.Channels, r.PreSkip, r.SampleRate)
+	}
+
+	for i := 0; i < 6; i++ {
+		frame, err := r.ReadFrame()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(frame, []byte{0xf8, byte(i)}) {
+			t.Errorf("frame %d: got %v", i, frame)
+		}
+	}
+	if _, err = r.ReadFrame(); err != io.EOF {
+		t.Errorf("expected io.EOF, got %v", err)
+	}
+
+	if err = r.SeekGranule(312 + 960*3); err != nil {
+		t.Fatal(err)
+	}
+	if frame, err := r.ReadFrame(); err != nil || frame[1] != 2 {
+		t.Errorf("expected the first frame of the second page, got %v, %v", frame, err)
+	}
+}
+
+func TestOggOpusReaderFrameSize(t *testing.T) {
+	stream := oggTestStream()
+	stream = append(stream, oggTestPage(0, 0, 5, []byte{0xf0})...)
+
+	r, err := NewOggOpusReader(bytes.NewReader(stream))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 6; i++ {
+		r.ReadFrame()
+	}
+	if _, err = r.ReadFrame(); err != ErrOggOpusFrameSize {
+		t.Errorf("expected ErrOggOpusFrameSize for a 10ms frame, got %v", err)
+	}
+}
+
+func TestOpusPacketDuration(t *testing.T) {
+	tests := []struct {
+		packet   []byte
+		duration int
+	}{
+		{[]byte{0xf8}, 960},
+		{[]byte{0xf9}, 1920},
+		{[]byte{0x08}, 960},
+		{[]byte{0x7b, 0x03}, 2880},
+	}
+	for _, test := range tests {
+		if d := opusPacketDuration(test.packet); d != test.duration {
+			t.Errorf("%x: got %d, want %d", test.packet, d, test.duration)
+		}
+	}
+}
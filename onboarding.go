@@ -0,0 +1,94 @@
+package discordgo
+
+// Option returns the option of a question of the onboarding by ID, nil if
+// no question has it.
+// optionID : The ID of a GuildOnboardingPromptOption.
+func (o *GuildOnboarding) Option(optionID string) *GuildOnboardingPromptOption {
+	for _, p := range o.Prompts {
+		for _, opt := range p.Options {
+			if opt.ID == optionID {
+				return opt
+			}
+		}
+	}
+	return nil
+}
+
+// Access returns the roles and channels a member gets for selecting the
+// given options, including the default channels, without duplicates.
+// Unknown options are ignored.
+// optionIDs : The IDs of the selected options.
+func (o *GuildOnboarding) Access(optionIDs ...string) (roleIDs, channelIDs []string) {
+	roles := map[string]bool{}
+	channels := map[string]bool{}
+	addChannels := func(ids []string) {
+		for _, id := range ids {
+			if !channels[id] {
+				channels[id] = true
+				channelIDs = append(channelIDs, id)
+			}
+		}
+	}
+
+	addChannels(o.DefaultChannelIDs)
+	for _, id := range optionIDs {
+		opt := o.Option(id)
+		if opt == nil {
+			continue
+		}
+
+		for _, r := range opt.RoleIDs {
+			if !roles[r] {
+				roles[r] = true
+				roleIDs = append(roleIDs, r)
+			}
+		}
+		addChannels(opt.ChannelIDs)
+	}
+	return
+}
+
+// OptionsWithRole returns the options which give a role, to audit which
+// answers grant access to what.
+// roleID : The ID of a Role.
+func (o *GuildOnboarding) OptionsWithRole(roleID string) (options []*GuildOnboardingPromptOption) {
+	for _, p := range o.Prompts {
+		for _, opt := range p.Options {
+			for _, r := range opt.RoleIDs {
+				if r == roleID {
+					options = append(options, opt)
+					break
+				}
+			}
+		}
+	}
+	return
+}
+
+// MemberOptions returns the options whose roles a member has. Discord
+// doesn't expose the answers of members to bots, so this is an estimate
+// from the roles: options without roles are never returned, and roles
+// given by other means are counted as answers. Whether the member
+// completed the onboarding is in their Flags.
+// m : The member.
+func (o *GuildOnboarding) MemberOptions(m *Member) (options []*GuildOnboardingPromptOption) {
+	for _, p := range o.Prompts {
+		for _, opt := range p.Options {
+			if len(opt.RoleIDs) == 0 {
+				continue
+			}
+
+			selected := true
+			for _, r := range opt.RoleIDs {
+				if !memberHasRole(m, r) {
+					selected = false
+					break
+				}
+			}
+			if selected {
+				options = append(options, opt)
+			}
+		}
+	}
+	return
+}
@@ -0,0 +1,39 @@
+package discordgo
+
+import "testing"
+
+func TestGuildOnboardingAccess(t *testing.T) {
+	o := &GuildOnboarding{
+		DefaultChannelIDs: []string{"100"},
+		Prompts: []*GuildOnboardingPrompt{
+			{ID: "1", Options: []*GuildOnboardingPromptOption{
+				{ID: "10", RoleIDs: []string{"200"}, ChannelIDs: []string{"101"}},
+				{ID: "11", RoleIDs: []string{"200", "201"}, ChannelIDs: []string{"100"}},
+			}},
+			{ID: "2", Options: []*GuildOnboardingPromptOption{
+				{ID: "20", ChannelIDs: []string{"102"}},
+			}},
+		},
+	}
+
+	if opt := o.Option("20"); opt == nil || opt.ChannelIDs[0] != "102" {
+		t.Errorf("unexpected option %+v", opt)
+	}
+
+	roles, channels := o.Access("10", "11", "20", "99")
+	if len(roles) != 2 || roles[0] != "200" || roles[1] != "201" {
+		t.Errorf("unexpected roles %v", roles)
+	}
+	if len(channels) != 3 || channels[0] != "100" || channels[1] != "101" || channels[2] != "102" {
+		t.Errorf("unexpected channels %v", channels)
+	}
+
+	if options := o.OptionsWithRole("200"); len(options) != 2 {
+		t.Errorf("expected 2 options with role 200, got %d", len(options))
+	}
+
+	options := o.MemberOptions(&Member{Roles: []string{"200"}})
+	if len(options) != 1 || options[0].ID != "10" {
+		t.Errorf("expected option 10, got %+v", options)
+	}
+}
@@ -0,0 +1,172 @@
+package discordgo
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ErrBindTarget is returned by BindOptions when the target isn't a pointer to a struct.
+var ErrBindTarget = errors.New("options can only be bound to a pointer to a struct")
+
+// ErrOptionRequired is the error of a required option which wasn't given.
+var ErrOptionRequired = errors.New("option is required")
+
+// OptionErrors holds the errors of binding the options of a command by option name.
+type OptionErrors map[string]error
+
+// Error returns the errors of all options.
+func (e OptionErrors) Error() string {
+	return "errors in options " + joinErrors(e)
+}
+
+// errorOrNil returns nil for an empty OptionErrors, so callers can
+// compare the result to nil.
+func (e OptionErrors) errorOrNil() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// BindOptions sets the fields of the struct v points to to the values of
+// the options of the command, or of its subcommand. Fields are matched by
+// their discord tag, `discord:"name"`, and `discord:"name,required"`
+// reports options which weren't given. Fields without the tag are left
+// as they are.
+//
+// String fields take string options and the IDs of user, channel, role,
+// mentionable and attachment options. Integer, float and bool fields
+// take the options of their type, and *User, *Member, *Role, *Channel
+// and *MessageAttachment fields the resolved objects. Pointers to the
+// other types are left nil if the option wasn't given.
+//
+// All fields are attempted, if any fail an OptionErrors is returned.
+func (d *ApplicationCommandInteractionData) BindOptions(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return ErrBindTarget
+	}
+	rv = rv.Elem()
+
+	options := d.Options
+	for len(options) == 1 && (options[0].Type == ApplicationCommandOptionSubCommand || options[0].Type == ApplicationCommandOptionSubCommandGroup) {
+		options = options[0].Options
+	}
+
+	byName := make(map[string]*ApplicationCommandInteractionDataOption, len(options))
+	for _, o := range options {
+		byName[o.Name] = o
+	}
+
+	errs := OptionErrors{}
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		tag := rt.Field(i).Tag.Get("discord")
+		if tag == "" || tag == "-" {
+			continue
+		}
+
+		name, required := tag, false
+		if j := strings.IndexByte(tag, ','); j >= 0 {
+			name, required = tag[:j], tag[j+1:] == "required"
+		}
+
+		o, ok := byName[name]
+		if !ok {
+			if required {
+				errs[name] = ErrOptionRequired
+			}
+			continue
+		}
+
+		if err := d.bindOption(rv.Field(i), o); err != nil {
+			errs[name] = err
+		}
+	}
+	return errs.errorOrNil()
+}
+
+// bindOption sets a field to the value of an option.
+func (d *ApplicationCommandInteractionData) bindOption(f reflect.Value, o *ApplicationCommandInteractionDataOption) error {
+	if !f.CanSet() {
+		return fmt.Errorf("unexported field of type %s can't be bound", f.Type())
+	}
+
+	switch reflect.Zero(f.Type()).Interface().(type) {
+	case *User:
+		return setResolved(f, o, o.UserValue(d))
+	case *Member:
+		var m *Member
+		if d.Resolved != nil && d.Resolved.Members[o.StringValue()] != nil {
+			member := *d.Resolved.Members[o.StringValue()]
+			member.User = o.UserValue(d)
+			m = &member
+		}
+		return setResolved(f, o, m)
+	case *Role:
+		return setResolved(f, o, o.RoleValue(d))
+	case *Channel:
+		return setResolved(f, o, o.ChannelValue(d))
+	case *MessageAttachment:
+		return setResolved(f, o, o.AttachmentValue(d))
+	}
+
+	if f.Kind() == reflect.Ptr {
+		p := reflect.New(f.Type().Elem())
+		if err := bindOptionValue(p.Elem(), o); err != nil {
+			return err
+		}
+		f.Set(p)
+		return nil
+	}
+	return bindOptionValue(f, o)
+}
+
+// setResolved sets a field to the resolved object of an option.
+func setResolved(f reflect.Value, o *ApplicationCommandInteractionDataOption, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return fmt.Errorf("option of type %d can't be bound to a %s field", o.Type, f.Type())
+	}
+	f.Set(rv)
+	return nil
+}
+
+// bindOptionValue sets a field of a basic type to the value of an option.
+func bindOptionValue(f reflect.Value, o *ApplicationCommandInteractionDataOption) error {
+	ok := false
+	switch f.Kind() {
+	case reflect.String:
+		switch o.Type {
+		case ApplicationCommandOptionString, ApplicationCommandOptionUser, ApplicationCommandOptionChannel,
+			ApplicationCommandOptionRole, ApplicationCommandOptionMentionable, ApplicationCommandOptionAttachment:
+			f.SetString(o.StringValue())
+			ok = true
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if o.Type == ApplicationCommandOptionInteger {
+			if f.OverflowInt(o.IntValue()) {
+				return fmt.Errorf("value %d overflows a %s field", o.IntValue(), f.Type())
+			}
+			f.SetInt(o.IntValue())
+			ok = true
+		}
+	case reflect.Float32, reflect.Float64:
+		if o.Type == ApplicationCommandOptionNumber || o.Type == ApplicationCommandOptionInteger {
+			f.SetFloat(o.FloatValue())
+			ok = true
+		}
+	case reflect.Bool:
+		if o.Type == ApplicationCommandOptionBoolean {
+			f.SetBool(o.BoolValue())
+			ok = true
+		}
+	}
+
+	if !ok {
+		return fmt.Errorf("option of type %d can't be bound to a %s field", o.Type, f.Type())
+	}
+	return nil
+}
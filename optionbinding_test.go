@@ -0,0 +1,49 @@
+package discordgo
+
+import "testing"
+
+func TestBindOptions(t *testing.T) {
+	i := &Interaction{Type: InteractionApplicationCommand, Data: []byte(`{
+		"name": "mod",
+		"options": [{"name": "ban", "type": 1, "options": [
+			{"name": "user", "type": 6, "value": "5"},
+			{"name": "days", "type": 4, "value": 7},
+			{"name": "silent", "type": 5, "value": true},
+			{"name": "reason", "type": 3, "value": "spam"}
+		]}],
+		"resolved": {"users": {"5": {"id": "5"}}, "members": {"5": {"nick": "nick"}}}
+	}`)}
+
+	data, err := i.ApplicationCommandData()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ban struct {
+		User   *User   `discord:"user,required"`
+		Member *Member `discord:"user"`
+		Days   int8    `discord:"days"`
+		Silent *bool   `discord:"silent"`
+		Reason string  `discord:"reason"`
+		Note   *string `discord:"note"`
+	}
+	if err = data.BindOptions(&ban); err != nil {
+		t.Fatal(err)
+	}
+	if ban.User.ID != "5" || ban.Member.Nick != "nick" || ban.Member.User.ID != "5" || ban.Days != 7 || ban.Silent == nil || !*ban.Silent || ban.Reason != "spam" || ban.Note != nil {
+		t.Errorf("unexpected binding %+v", ban)
+	}
+
+	var invalid struct {
+		Days    string `discord:"days"`
+		Channel string `discord:"channel,required"`
+	}
+	errs, ok := data.BindOptions(&invalid).(OptionErrors)
+	if !ok || len(errs) != 2 || errs["channel"] != ErrOptionRequired || errs["days"] == nil {
+		t.Errorf("unexpected errors %v", errs)
+	}
+
+	if err = data.BindOptions(invalid); err != ErrBindTarget {
+		t.Errorf("expected ErrBindTarget, got %v", err)
+	}
+}
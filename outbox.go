@@ -0,0 +1,269 @@
+package discordgo
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrOutboxMessageFiles is returned when queueing a message with files in an
+// Outbox, which can't be persisted.
+var ErrOutboxMessageFiles = errors.New("outbox messages can not contain files")
+
+// An OutboxMessage is a message waiting to be sent by an Outbox.
+type OutboxMessage struct {
+	ID        string       `json:"id"`
+	ChannelID string       `json:"channel_id"`
+	Message   *MessageSend `json:"message"`
+
+	// The number of failed attempts to send the message, and the time of
+	// the next attempt.
+	Attempts    int       `json:"attempts"`
+	NextAttempt time.Time `json:"next_attempt"`
+}
+
+// An OutboxStore persists the messages of an Outbox, so they survive a
+// restart of the bot.
+type OutboxStore interface {
+	// OutboxSave saves a new message, or updates the attempts of a saved message.
+	OutboxSave(m *OutboxMessage) error
+
+	// OutboxDelete deletes a message which was sent or can't be sent.
+	OutboxDelete(id string) error
+
+	// OutboxLoad returns all saved messages in the order they were queued.
+	OutboxLoad() ([]*OutboxMessage, error)
+}
+
+// An Outbox sends critical messages, such as moderation logs and alerts,
+// which must not be lost when the API is unavailable. Messages are saved to
+// the Store if one is set and retried with an exponential backoff until
+// Discord accepts them, call Load after creating the Outbox to queue the
+// saved messages again. Messages of a channel are sent in the order they
+// were queued. Messages rejected with a client error, eg. because of
+// missing permissions, are dropped.
+type Outbox struct {
+	sync.Mutex
+
+	// Store persists the messages, it is optional.
+	Store OutboxStore
+
+	// The delay after the first failed attempt, doubled after every further
+	// attempt up to MaxBackoff.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	// OnSent is called after a message was sent, optional.
+	OnSent func(m *OutboxMessage, st *Message)
+
+	session  *Session
+	messages []*OutboxMessage
+	lastID   int64
+	wake     chan struct{}
+	stop     chan struct{}
+}
+
+// NewOutbox returns a new Outbox which sends messages with s, and starts
+// sending queued messages. Call Close to stop it.
+func NewOutbox(s *Session) *Outbox {
+	o := &Outbox{
+		MinBackoff: time.Second,
+		MaxBackoff: 5 * time.Minute,
+		session:    s,
+		wake:       make(chan struct{}, 1),
+		stop:       make(chan struct{}),
+	}
+
+	go o.run()
+	return o
+}
+
+// Close stops sending messages. Messages which were not sent yet are kept
+// in the Store.
+func (o *Outbox) Close() {
+	close(o.stop)
+}
+
+// Send queues a message to be sent to the given channel. The message is
+// saved to the Store before Send returns. Messages with files can't be queued.
+// channelID : The ID of a Channel.
+// data      : The message struct to send.
+func (o *Outbox) Send(channelID string, data *MessageSend) (id string, err error) {
+	if len(data.Files) > 0 || data.File != nil {
+		return "", ErrOutboxMessageFiles
+	}
+
+	o.Lock()
+	// IDs only need to be unique, the time keeps them unique across restarts.
+	o.lastID++
+	id = strconv.FormatInt(time.Now().UnixNano(), 36) + "-" + strconv.FormatInt(o.lastID, 36)
+	o.Unlock()
+
+	m := &OutboxMessage{ID: id, ChannelID: channelID, Message: data}
+	if o.Store != nil {
+		if err = o.Store.OutboxSave(m); err != nil {
+			return "", err
+		}
+	}
+
+	o.add(m)
+	return
+}
+
+// Load queues the messages saved in the Store.
+func (o *Outbox) Load() error {
+	if o.Store == nil {
+		return nil
+	}
+
+	messages, err := o.Store.OutboxLoad()
+	if err != nil {
+		return err
+	}
+
+	o.add(messages...)
+	return nil
+}
+
+// Messages returns all messages waiting to be sent.
+func (o *Outbox) Messages() []*OutboxMessage {
+	o.Lock()
+	defer o.Unlock()
+
+	return append([]*OutboxMessage(nil), o.messages...)
+}
+
+// add queues messages and wakes up the sender.
+func (o *Outbox) add(messages ...*OutboxMessage) {
+	o.Lock()
+	o.messages = append(o.messages, messages...)
+	o.Unlock()
+
+	select {
+	case o.wake <- struct{}{}:
+	default:
+	}
+}
+
+// next returns the first message which is due, and otherwise the time
+// until the next message is due. Only the oldest message of every channel
+// is considered, so the messages of a channel stay in order.
+func (o *Outbox) next(now time.Time) (m *OutboxMessage, wait time.Duration) {
+	o.Lock()
+	defer o.Unlock()
+
+	wait = -1
+	seen := map[string]bool{}
+	for _, om := range o.messages {
+		if seen[om.ChannelID] {
+			continue
+		}
+		seen[om.ChannelID] = true
+
+		d := om.NextAttempt.Sub(now)
+		if d <= 0 {
+			return om, 0
+		}
+		if wait < 0 || d < wait {
+			wait = d
+		}
+	}
+	return
+}
+
+// remove removes a sent or dropped message.
+func (o *Outbox) remove(m *OutboxMessage) {
+	o.Lock()
+	for i, om := range o.messages {
+		if om == m {
+			o.messages = append(o.messages[:i], o.messages[i+1:]...)
+			break
+		}
+	}
+	o.Unlock()
+
+	if o.Store != nil {
+		if err := o.Store.OutboxDelete(m.ID); err != nil {
+			o.session.log(LogError, "error deleting outbox message %s, %s", m.ID, err)
+		}
+	}
+}
+
+// backoff returns the delay before the next attempt after the given number
+// of failed attempts.
+func (o *Outbox) backoff(attempts int) time.Duration {
+	d := o.MinBackoff
+	for i := 1; i < attempts && d < o.MaxBackoff; i++ {
+		d *= 2
+	}
+	if d > o.MaxBackoff {
+		d = o.MaxBackoff
+	}
+	return d
+}
+
+// outboxRetryable returns whether sending a message can succeed when retried.
+// Client errors other than rate limits are caused by the message itself.
+func outboxRetryable(err error) bool {
+	if rerr, ok := err.(*RESTError); ok && rerr.Response != nil {
+		code := rerr.Response.StatusCode
+		return code < 400 || code >= 500 || code == http.StatusTooManyRequests
+	}
+	return true
+}
+
+func (o *Outbox) run() {
+	for {
+		select {
+		case <-o.stop:
+			return
+		default:
+		}
+
+		m, wait := o.next(time.Now())
+		if m == nil {
+			var timer *time.Timer
+			var timeout <-chan time.Time
+			if wait >= 0 {
+				timer = time.NewTimer(wait)
+				timeout = timer.C
+			}
+
+			select {
+			case <-o.stop:
+			case <-o.wake:
+			case <-timeout:
+			}
+			if timer != nil {
+				timer.Stop()
+			}
+			continue
+		}
+
+		st, err := o.session.ChannelMessageSendComplex(m.ChannelID, m.Message)
+		switch {
+		case err == nil:
+			o.remove(m)
+			if o.OnSent != nil {
+				o.OnSent(m, st)
+			}
+		case !outboxRetryable(err):
+			o.session.log(LogError, "dropping outbox message %s to %s, %s", m.ID, m.ChannelID, err)
+			o.remove(m)
+		default:
+			o.Lock()
+			m.Attempts++
+			m.NextAttempt = time.Now().Add(o.backoff(m.Attempts))
+			o.Unlock()
+
+			o.session.log(LogWarning, "error sending outbox message %s to %s, retrying at %s, %s", m.ID, m.ChannelID, m.NextAttempt.Format(time.RFC3339), err)
+			if o.Store != nil {
+				if err := o.Store.OutboxSave(m); err != nil {
+					o.session.log(LogError, "error saving outbox message %s, %s", m.ID, err)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package discordgo
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestOutboxBackoff(t *testing.T) {
+	o := &Outbox{MinBackoff: time.Second, MaxBackoff: 10 * time.Second}
+
+	for attempts, want := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 4: 8 * time.Second, 5: 10 * time.Second, 50: 10 * time.Second} {
+		if d := o.backoff(attempts); d != want {
+			t.Errorf("backoff(%d) = %s, want %s", attempts, d, want)
+		}
+	}
+}
+
+func TestOutboxRetryable(t *testing.T) {
+	for code, want := range map[int]bool{http.StatusForbidden: false, http.StatusNotFound: false, http.StatusTooManyRequests: true, http.StatusBadGateway: true} {
+		err := &RESTError{Response: &http.Response{StatusCode: code}}
+		if outboxRetryable(err) != want {
+			t.Errorf("outboxRetryable(%d) != %t", code, want)
+		}
+	}
+}
+
+func TestOutboxNextKeepsChannelOrder(t *testing.T) {
+	now := time.Now()
+	o := &Outbox{messages: []*OutboxMessage{
+		{ID: "1", ChannelID: "a", NextAttempt: now.Add(time.Minute)},
+		{ID: "2", ChannelID: "a"},
+		{ID: "3", ChannelID: "b"},
+	}}
+
+	if m, _ := o.next(now); m == nil || m.ID != "3" {
+		t.Errorf("expected message 3 to be due, got %v", m)
+	}
+}
@@ -0,0 +1,205 @@
+package discordgo
+
+import (
+	"math"
+	"sync"
+)
+
+// Sample rate and channels of the PCM audio filtered by a PCMFilter, the
+// format of Discord's opus audio.
+const (
+	pcmSampleRate = 48000
+	pcmChannels   = 2
+)
+
+// A PCMFilter filters 48kHz stereo PCM audio, with interleaved samples. It
+// may change the samples in place, and may return a different number of
+// samples, eg. to change the speed.
+type PCMFilter interface {
+	FilterPCM(samples []int16) []int16
+}
+
+// A PCMFilterChain applies filters to audio, eg. of an FFmpegSource. The
+// filters can be changed at any time, they apply to the audio filtered
+// afterwards. A chain can be shared by the tracks of a player, to keep the
+// filters across tracks.
+type PCMFilterChain struct {
+	sync.RWMutex
+
+	filters []PCMFilter
+}
+
+// NewPCMFilterChain returns a new PCMFilterChain with the filters.
+func NewPCMFilterChain(filters ...PCMFilter) *PCMFilterChain {
+	return &PCMFilterChain{filters: filters}
+}
+
+// Set replaces the filters of the chain.
+func (c *PCMFilterChain) Set(filters ...PCMFilter) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.filters = filters
+}
+
+// Filters returns the filters of the chain.
+func (c *PCMFilterChain) Filters() []PCMFilter {
+	c.RLock()
+	defer c.RUnlock()
+
+	return append([]PCMFilter(nil), c.filters...)
+}
+
+// FilterPCM applies the filters of the chain in order.
+func (c *PCMFilterChain) FilterPCM(samples []int16) []int16 {
+	c.RLock()
+	defer c.RUnlock()
+
+	for _, f := range c.filters {
+		samples = f.FilterPCM(samples)
+	}
+	return samples
+}
+
+// clampPCM converts a sample to int16, clipping it.
+func clampPCM(s float64) int16 {
+	if s > math.MaxInt16 {
+		return math.MaxInt16
+	}
+	if s < math.MinInt16 {
+		return math.MinInt16
+	}
+	return int16(s)
+}
+
+// VolumeFilter scales the volume of the audio, eg. 0.5 for half the volume.
+type VolumeFilter float64
+
+// FilterPCM scales the samples.
+func (v VolumeFilter) FilterPCM(samples []int16) []int16 {
+	if v == 1 {
+		return samples
+	}
+
+	for i, s := range samples {
+		samples[i] = clampPCM(float64(s) * float64(v))
+	}
+	return samples
+}
+
+// biquad is a biquad filter with the state of each channel.
+type biquad struct {
+	b0, b1, b2, a1, a2 float64
+
+	x1, x2, y1, y2 [pcmChannels]float64
+}
+
+// newShelf returns a low or high shelf filter with the given gain in dB at
+// the frequency. https://www.w3.org/TR/audio-eq-cookbook/
+func newShelf(high bool, frequency, gain float64) *biquad {
+	a := math.Pow(10, gain/40)
+	w0 := 2 * math.Pi * frequency / pcmSampleRate
+	cos := math.Cos(w0)
+	alpha := math.Sin(w0) / 2 * math.Sqrt2
+	sqrtA := 2 * math.Sqrt(a) * alpha
+
+	sign := 1.0
+	if high {
+		sign = -1
+	}
+
+	b0 := a * ((a + 1) - sign*(a-1)*cos + sqrtA)
+	b1 := sign * 2 * a * ((a - 1) - sign*(a+1)*cos)
+	b2 := a * ((a + 1) - sign*(a-1)*cos - sqrtA)
+	a0 := (a + 1) + sign*(a-1)*cos + sqrtA
+	a1 := -sign * 2 * ((a - 1) + sign*(a+1)*cos)
+	a2 := (a + 1) + sign*(a-1)*cos - sqrtA
+
+	return &biquad{b0: b0 / a0, b1: b1 / a0, b2: b2 / a0, a1: a1 / a0, a2: a2 / a0}
+}
+
+// filter filters a sample of a channel.
+func (f *biquad) filter(ch int, x float64) float64 {
+	y := f.b0*x + f.b1*f.x1[ch] + f.b2*f.x2[ch] - f.a1*f.y1[ch] - f.a2*f.y2[ch]
+	f.x2[ch], f.x1[ch] = f.x1[ch], x
+	f.y2[ch], f.y1[ch] = f.y1[ch], y
+	return y
+}
+
+// An EQFilter is a simple equalizer which boosts or cuts the bass and the
+// treble of the audio.
+type EQFilter struct {
+	bass, treble *biquad
+}
+
+// NewEQFilter returns a new EQFilter.
+// bass   : The gain of the bass below 200Hz in dB, eg. 6 to boost or -6 to cut.
+// treble : The gain of the treble above 4kHz in dB.
+func NewEQFilter(bass, treble float64) *EQFilter {
+	return &EQFilter{
+		bass:   newShelf(false, 200, bass),
+		treble: newShelf(true, 4000, treble),
+	}
+}
+
+// FilterPCM equalizes the samples.
+func (f *EQFilter) FilterPCM(samples []int16) []int16 {
+	for i, s := range samples {
+		ch := i % pcmChannels
+		samples[i] = clampPCM(f.treble.filter(ch, f.bass.filter(ch, float64(s))))
+	}
+	return samples
+}
+
+// A SpeedFilter changes the speed of the audio by resampling it, which also
+// changes its pitch.
+type SpeedFilter struct {
+	speed float64
+
+	// The position in the samples of the previous and the current call,
+	// and the last frame of the previous call.
+	pos     float64
+	last    [pcmChannels]float64
+	started bool
+}
+
+// NewSpeedFilter returns a new SpeedFilter.
+// speed : The speed of the audio, eg. 1.25 to play it 25% faster.
+func NewSpeedFilter(speed float64) *SpeedFilter {
+	return &SpeedFilter{speed: speed}
+}
+
+// FilterPCM resamples the samples with linear interpolation.
+func (f *SpeedFilter) FilterPCM(samples []int16) []int16 {
+	frames := len(samples) / pcmChannels
+	if frames == 0 || f.speed <= 0 {
+		return samples
+	}
+	if !f.started {
+		// Start at the first frame, there is no previous frame.
+		f.pos, f.started = 1, true
+	}
+
+	// Frame 0 is the last frame of the previous call.
+	frame := func(i, ch int) float64 {
+		if i == 0 {
+			return f.last[ch]
+		}
+		return float64(samples[(i-1)*pcmChannels+ch])
+	}
+
+	out := make([]int16, 0, int(float64(len(samples))/f.speed)+pcmChannels)
+	for ; f.pos < float64(frames); f.pos += f.speed {
+		i := int(f.pos)
+		frac := f.pos - float64(i)
+		for ch := 0; ch < pcmChannels; ch++ {
+			out = append(out, clampPCM(frame(i, ch)+(frame(i+1, ch)-frame(i, ch))*frac))
+		}
+	}
+
+	f.pos -= float64(frames)
+	for ch := 0; ch < pcmChannels; ch++ {
+		f.last[ch] = frame(frames, ch)
+	}
+	return out
+}
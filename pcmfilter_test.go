@@ -0,0 +1,50 @@
+package discordgo
+
+import "testing"
+
+func TestPCMFilterChain(t *testing.T) {
+	c := NewPCMFilterChain(VolumeFilter(0.5))
+
+	samples := c.FilterPCM([]int16{1000, -1000, 20000, -20000})
+	if samples[0] != 500 || samples[1] != -500 || samples[2] != 10000 || samples[3] != -10000 {
+		t.Errorf("unexpected samples %v", samples)
+	}
+
+	c.Set(VolumeFilter(4))
+	if samples = c.FilterPCM([]int16{20000, -20000}); samples[0] != 32767 || samples[1] != -32768 {
+		t.Errorf("expected clipped samples, got %v", samples)
+	}
+}
+
+func TestEQFilterFlat(t *testing.T) {
+	f := NewEQFilter(0, 0)
+
+	samples := make([]int16, 200)
+	for i := range samples {
+		samples[i] = int16(i * 100)
+	}
+	want := append([]int16(nil), samples...)
+
+	for i, s := range f.FilterPCM(samples) {
+		if d := s - want[i]; d < -1 || d > 1 {
+			t.Fatalf("sample %d: got %d, want %d", i, s, want[i])
+		}
+	}
+}
+
+func TestSpeedFilter(t *testing.T) {
+	f := NewSpeedFilter(2)
+
+	total := 0
+	for i := 0; i < 10; i++ {
+		total += len(f.FilterPCM(make([]int16, 960*pcmChannels)))
+	}
+	if total != 960*pcmChannels*10/2 {
+		t.Errorf("expected half the samples at double speed, got %d", total)
+	}
+
+	f = NewSpeedFilter(0.5)
+	if out := f.FilterPCM([]int16{0, 0, 100, 100}); len(out) != 4 || out[0] != 0 || out[2] != 50 {
+		t.Errorf("unexpected samples at half speed %v", out)
+	}
+}
@@ -0,0 +1,68 @@
+package discordgo
+
+// MaxPins is the maximum number of pinned messages in a channel.
+const MaxPins = 50
+
+// A PinOverflowPolicy chooses the message to unpin from a channel with
+// MaxPins pinned messages, nil to unpin none. The pins are sorted from the
+// most to the least recently pinned, and don't include the message to pin.
+type PinOverflowPolicy func(pins []*Message) *Message
+
+// PinOverflowOldestPin unpins the least recently pinned message.
+func PinOverflowOldestPin(pins []*Message) *Message {
+	if len(pins) == 0 {
+		return nil
+	}
+	return pins[len(pins)-1]
+}
+
+// PinOverflowOldestMessage unpins the oldest message.
+func PinOverflowOldestMessage(pins []*Message) (oldest *Message) {
+	for _, m := range pins {
+		if oldest == nil || len(m.ID) < len(oldest.ID) || len(m.ID) == len(oldest.ID) && m.ID < oldest.ID {
+			oldest = m
+		}
+	}
+	return
+}
+
+// PinWithOverflow pins a message, and when the channel has MaxPins pinned
+// messages unpins the message chosen by the policy first. It returns the
+// unpinned message, nil if there was room for the pin. If the policy
+// chooses no message the error of the pin is returned.
+// channelID : The ID of a Channel.
+// messageID : The ID of a Message.
+// policy    : Chooses the message to unpin, nil for PinOverflowOldestPin.
+func (s *Session) PinWithOverflow(channelID, messageID string, policy PinOverflowPolicy) (unpinned *Message, err error) {
+	pinErr := s.ChannelMessagePin(channelID, messageID)
+	if restErrorCode(pinErr) != ErrCodeMaximumPinsReached {
+		return nil, pinErr
+	}
+
+	pins, err := s.ChannelMessagesPinned(channelID)
+	if err != nil {
+		return nil, err
+	}
+	for i, m := range pins {
+		if m.ID == messageID {
+			pins = append(pins[:i:i], pins[i+1:]...)
+			break
+		}
+	}
+
+	if policy == nil {
+		policy = PinOverflowOldestPin
+	}
+	unpinned = policy(pins)
+	if unpinned == nil {
+		return nil, pinErr
+	}
+
+	if err = s.ChannelMessageUnpin(channelID, unpinned.ID); err != nil {
+		return nil, err
+	}
+	if err = s.ChannelMessagePin(channelID, messageID); err != nil {
+		return unpinned, err
+	}
+	return unpinned, nil
+}
@@ -0,0 +1,83 @@
+package discordgo
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// pinTransport returns a transport which keeps the pins of a channel in
+// pins, most recently pinned first, and rejects pins over MaxPins like
+// Discord.
+func pinTransport(pins *[]string) *routeTransport {
+	return &routeTransport{handle: func(req *http.Request, body []byte) (int, string) {
+		id := req.URL.Path[strings.LastIndex(req.URL.Path, "/")+1:]
+		switch req.Method {
+		case "GET":
+			var messages []*Message
+			for _, id := range *pins {
+				messages = append(messages, &Message{ID: id})
+			}
+			b, _ := json.Marshal(messages)
+			return http.StatusOK, string(b)
+		case "PUT":
+			if len(*pins) >= MaxPins {
+				return http.StatusBadRequest, `{"code": 30003, "message": "Maximum number of pins reached (50)"}`
+			}
+			*pins = append([]string{id}, *pins...)
+		case "DELETE":
+			for i, pin := range *pins {
+				if pin == id {
+					*pins = append((*pins)[:i], (*pins)[i+1:]...)
+					break
+				}
+			}
+		}
+		return http.StatusNoContent, ``
+	}}
+}
+
+func TestPinWithOverflow(t *testing.T) {
+	var pins []string
+	tr := pinTransport(&pins)
+	s := newTestSession(tr)
+
+	unpinned, err := s.PinWithOverflow("1", "100", nil)
+	if err != nil || unpinned != nil {
+		t.Fatalf("expected a pin without overflow, got %v and %v", unpinned, err)
+	}
+	if len(tr.requests) != 1 {
+		t.Errorf("expected a single request, got %v", tr.requests)
+	}
+
+	// Pin messages 149 down to 101, so the oldest message is pinned last.
+	for i := 149; i > 100; i-- {
+		pins = append([]string{strconv.Itoa(i)}, pins...)
+	}
+
+	unpinned, err = s.PinWithOverflow("1", "200", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if unpinned == nil || unpinned.ID != "100" {
+		t.Errorf("expected the oldest pin 100 to be unpinned, got %v", unpinned)
+	}
+	if pins[0] != "200" || len(pins) != MaxPins {
+		t.Errorf("expected 200 to be pinned, got %v", pins)
+	}
+
+	unpinned, err = s.PinWithOverflow("1", "201", PinOverflowOldestMessage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if unpinned == nil || unpinned.ID != "101" {
+		t.Errorf("expected the oldest message 101 to be unpinned, got %v", unpinned)
+	}
+
+	_, err = s.PinWithOverflow("1", "202", func([]*Message) *Message { return nil })
+	if restErrorCode(err) != ErrCodeMaximumPinsReached {
+		t.Errorf("expected the maximum pins error, got %v", err)
+	}
+}
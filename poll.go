@@ -0,0 +1,363 @@
+package discordgo
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrPollNotFound is returned when a message is not a poll of a PollManager.
+var ErrPollNotFound = errors.New("poll not found")
+
+// ErrPollOptions is returned when creating a poll with less than two or
+// more than ten options.
+var ErrPollOptions = errors.New("polls need between 2 and 10 options")
+
+// PollEmojis are the emojis of the buttons of the options of a poll, in order.
+var PollEmojis = []string{"1️⃣", "2️⃣", "3️⃣", "4️⃣", "5️⃣", "6️⃣", "7️⃣", "8️⃣", "9️⃣", "🔟"}
+
+// pollCustomIDPrefix starts the custom IDs of the buttons of poll messages,
+// which end with the index of the option.
+const pollCustomIDPrefix = "poll:"
+
+// A PollResult holds the votes of an option of a poll.
+type PollResult struct {
+	Option   string   `json:"option"`
+	Votes    int      `json:"votes"`
+	VoterIDs []string `json:"voter_ids"`
+}
+
+// A Poll is a question members vote on by pressing the button of an option.
+type Poll struct {
+	ChannelID string   `json:"channel_id"`
+	MessageID string   `json:"message_id"`
+	Question  string   `json:"question"`
+	Options   []string `json:"options"`
+
+	// Whether members can only vote for one option, voting for another
+	// option replaces their vote.
+	SingleChoice bool `json:"single_choice"`
+
+	// The indexes of the options each user voted for, by user ID.
+	Votes map[string][]int `json:"votes"`
+
+	// Whether an update of the tally of the poll message is pending.
+	pending bool
+
+	// Whether the poll is being closed.
+	closed bool
+}
+
+// copy returns a copy of the poll and its votes.
+func (p *Poll) copy() *Poll {
+	c := *p
+	c.Options = append([]string(nil), p.Options...)
+	c.Votes = make(map[string][]int, len(p.Votes))
+	for userID, options := range p.Votes {
+		c.Votes[userID] = append([]int(nil), options...)
+	}
+	return &c
+}
+
+// A PollManager runs polls, updating the tally of the poll messages as
+// votes come in.
+type PollManager struct {
+	sync.Mutex
+
+	// TallyInterval is the minimum time between updates of the tally of
+	// a poll message, so voting doesn't flood the edit endpoint.
+	TallyInterval time.Duration
+
+	session  *Session
+	handlers []HandlerID
+	polls    map[string]*Poll
+}
+
+// NewPollManager returns a new PollManager which handles the button
+// interactions of s.
+func NewPollManager(s *Session) *PollManager {
+	m := &PollManager{
+		TallyInterval: 3 * time.Second,
+		session:       s,
+		polls:         map[string]*Poll{},
+	}
+
+	m.handlers = []HandlerID{
+		s.AddHandlerComplex(m.onInteraction, HandlerOptions{}),
+	}
+	return m
+}
+
+// Close removes the event handlers of the manager.
+func (m *PollManager) Close() {
+	for _, id := range m.handlers {
+		m.session.RemoveHandler(id)
+	}
+}
+
+// Add adds polls to the manager, eg. polls persisted before a restart.
+func (m *PollManager) Add(polls ...*Poll) {
+	m.Lock()
+	defer m.Unlock()
+
+	for _, p := range polls {
+		p = p.copy()
+		if p.Votes == nil {
+			p.Votes = map[string][]int{}
+		}
+		m.polls[p.MessageID] = p
+	}
+}
+
+// Polls returns copies of the open polls of the manager with their votes,
+// eg. to persist them.
+func (m *PollManager) Polls() (polls []*Poll) {
+	m.Lock()
+	defer m.Unlock()
+
+	for _, p := range m.polls {
+		polls = append(polls, p.copy())
+	}
+	return
+}
+
+// Create posts a poll to the channel.
+// channelID    : The ID of the Channel to post the poll to.
+// question     : The question of the poll.
+// options      : The options to vote for, between 2 and 10.
+// singleChoice : Whether members can only vote for one option.
+func (m *PollManager) Create(channelID, question string, options []string, singleChoice bool) (p *Poll, err error) {
+	if len(options) < 2 || len(options) > len(PollEmojis) {
+		return nil, ErrPollOptions
+	}
+
+	p = &Poll{
+		ChannelID:    channelID,
+		Question:     question,
+		Options:      options,
+		SingleChoice: singleChoice,
+		Votes:        map[string][]int{},
+	}
+
+	msg, err := m.session.ChannelMessageSendComplex(channelID, &MessageSend{
+		Embed:      p.embed(false),
+		Components: p.components(false),
+	})
+	if err != nil {
+		return nil, err
+	}
+	p.MessageID = msg.ID
+
+	m.Lock()
+	m.polls[msg.ID] = p
+	m.Unlock()
+	return p.copy(), nil
+}
+
+// tally returns the results of the poll.
+func (p *Poll) tally() []*PollResult {
+	results := make([]*PollResult, len(p.Options))
+	for i, o := range p.Options {
+		results[i] = &PollResult{Option: o, VoterIDs: []string{}}
+	}
+
+	for userID, options := range p.Votes {
+		for _, i := range options {
+			if i >= 0 && i < len(results) {
+				results[i].Votes++
+				results[i].VoterIDs = append(results[i].VoterIDs, userID)
+			}
+		}
+	}
+
+	for _, r := range results {
+		sort.Strings(r.VoterIDs)
+	}
+	return results
+}
+
+// embed returns the embed of the poll message with the current tally.
+func (p *Poll) embed(closed bool) *MessageEmbed {
+	results := p.tally()
+	total := 0
+	for _, r := range results {
+		total += r.Votes
+	}
+
+	lines := make([]string, len(results))
+	for i, r := range results {
+		percent := 0
+		if total > 0 {
+			percent = r.Votes * 100 / total
+		}
+		bar := strings.Repeat("█", percent/10) + strings.Repeat("░", 10-percent/10)
+		lines[i] = PollEmojis[i] + " " + r.Option + "\n" + bar + " " + strconv.Itoa(r.Votes) + " (" + strconv.Itoa(percent) + "%)"
+	}
+
+	footer := "Press a button to vote"
+	if closed {
+		footer = "Poll closed"
+	}
+
+	return &MessageEmbed{
+		Title:       p.Question,
+		Description: strings.Join(lines, "\n\n"),
+		Footer:      &MessageEmbedFooter{Text: footer + " • " + strconv.Itoa(total) + " votes"},
+	}
+}
+
+// components returns the buttons of the options, 5 per action row.
+func (p *Poll) components(closed bool) []*MessageComponent {
+	var rows []*MessageComponent
+	for i := range p.Options {
+		if i%5 == 0 {
+			rows = append(rows, &MessageComponent{Type: ComponentTypeActionsRow})
+		}
+		row := rows[len(rows)-1]
+		row.Components = append(row.Components, &MessageComponent{
+			Type:     ComponentTypeButton,
+			Style:    ButtonStyleSecondary,
+			Emoji:    &ComponentEmoji{Name: PollEmojis[i]},
+			CustomID: pollCustomIDPrefix + strconv.Itoa(i),
+			Disabled: closed,
+		})
+	}
+	return rows
+}
+
+// onInteraction votes for the option of a button of a poll message.
+func (m *PollManager) onInteraction(s *Session, i *InteractionCreate) {
+	if i.Type != InteractionMessageComponent || i.Message == nil {
+		return
+	}
+	data, err := i.MessageComponentData()
+	if err != nil || !strings.HasPrefix(data.CustomID, pollCustomIDPrefix) {
+		return
+	}
+	option, err := strconv.Atoi(strings.TrimPrefix(data.CustomID, pollCustomIDPrefix))
+	user := i.Author()
+	if err != nil || user == nil {
+		return
+	}
+
+	content := m.vote(i.Message.ID, user.ID, option)
+	err = s.InteractionRespond(i.Interaction, &InteractionResponse{
+		Type: InteractionResponseChannelMessageWithSource,
+		Data: &InteractionResponseData{Content: content, Flags: MessageFlagsEphemeral},
+	})
+	if err != nil {
+		s.log(LogWarning, "error responding to poll vote of %s, %s", user.ID, err)
+	}
+}
+
+// vote adds the vote of a user for an option, or removes it if they voted
+// for the option already, and returns the reply to the user.
+func (m *PollManager) vote(messageID, userID string, option int) string {
+	m.Lock()
+	defer m.Unlock()
+
+	p, ok := m.polls[messageID]
+	if !ok || p.closed {
+		return "This poll is closed."
+	}
+	if option < 0 || option >= len(p.Options) {
+		return "Unknown option."
+	}
+
+	votes := p.Votes[userID]
+	for i, o := range votes {
+		if o == option {
+			p.Votes[userID] = append(votes[:i:i], votes[i+1:]...)
+			if len(p.Votes[userID]) == 0 {
+				delete(p.Votes, userID)
+			}
+			m.scheduleTally(p)
+			return "You removed your vote for " + p.Options[option] + "."
+		}
+	}
+
+	if p.SingleChoice {
+		votes = nil
+	}
+	p.Votes[userID] = append(votes, option)
+	sort.Ints(p.Votes[userID])
+
+	m.scheduleTally(p)
+	return "You voted for " + p.Options[option] + "."
+}
+
+// scheduleTally updates the tally of the poll message after TallyInterval,
+// unless an update is already pending. It must be called with the lock held.
+func (m *PollManager) scheduleTally(p *Poll) {
+	if p.pending {
+		return
+	}
+	p.pending = true
+
+	time.AfterFunc(m.TallyInterval, func() {
+		m.Lock()
+		if _, ok := m.polls[p.MessageID]; !ok || p.closed {
+			// The poll was closed meanwhile. A close which fails reopens
+			// it, so later votes schedule their own update.
+			p.pending = false
+			m.Unlock()
+			return
+		}
+		p.pending = false
+		embed := p.embed(false)
+		m.Unlock()
+
+		if _, err := m.session.ChannelMessageEditEmbed(p.ChannelID, p.MessageID, embed); err != nil {
+			m.session.log(LogWarning, "error updating poll %s, %s", p.MessageID, err)
+		}
+	})
+}
+
+// Results returns the current results of the poll of the message.
+func (m *PollManager) Results(messageID string) ([]*PollResult, error) {
+	m.Lock()
+	defer m.Unlock()
+
+	p, ok := m.polls[messageID]
+	if !ok {
+		return nil, ErrPollNotFound
+	}
+	return p.tally(), nil
+}
+
+// ClosePoll closes the poll of the message, updating its message with the
+// final results and disabling its buttons, and returns the results. The
+// poll is removed from the manager once its message was updated, if that
+// fails it stays open.
+func (m *PollManager) ClosePoll(messageID string) ([]*PollResult, error) {
+	m.Lock()
+	p, ok := m.polls[messageID]
+	if !ok || p.closed {
+		m.Unlock()
+		return nil, ErrPollNotFound
+	}
+	p.closed = true
+	results := p.tally()
+	embed, components := p.embed(true), p.components(true)
+	m.Unlock()
+
+	_, err := m.session.ChannelMessageEditComplex(&MessageEdit{
+		ID:         p.MessageID,
+		Channel:    p.ChannelID,
+		Embed:      embed,
+		Components: &components,
+	})
+
+	m.Lock()
+	defer m.Unlock()
+
+	if err != nil {
+		p.closed = false
+		return nil, err
+	}
+	delete(m.polls, messageID)
+	return results, nil
+}
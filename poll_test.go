@@ -0,0 +1,140 @@
+package discordgo
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestPollManager(t *testing.T) {
+	tr := &routeTransport{
+		routes: map[string]string{
+			"POST " + EndpointChannelMessages("1"):       `{"id": "10", "channel_id": "1"}`,
+			"PATCH " + EndpointChannelMessage("1", "10"): `{"code": 50001, "message": "Missing Access"}`,
+		},
+		statuses: map[string]int{"PATCH " + EndpointChannelMessage("1", "10"): http.StatusForbidden},
+	}
+	s := newTestSession(tr)
+	s.SyncEvents = true
+
+	m := NewPollManager(s)
+	m.TallyInterval = time.Hour
+	defer m.Close()
+
+	if _, err := m.Create("1", "Pizza?", []string{"Yes"}, false); err != ErrPollOptions {
+		t.Errorf("expected ErrPollOptions, got %v", err)
+	}
+	p, err := m.Create("1", "Pizza?", []string{"Yes", "No", "Maybe", "Later", "Never", "Always"}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sent MessageSend
+	json.Unmarshal([]byte(tr.bodies[0]), &sent)
+	if len(sent.Components) != 2 || len(sent.Components[1].Components) != 1 || sent.Components[1].Components[0].CustomID != "poll:5" {
+		t.Fatalf("expected 6 buttons in 2 rows, got %+v", sent.Components)
+	}
+
+	vote := func(userID string, option int) {
+		s.handleEvent(interactionCreateEventType, &InteractionCreate{&Interaction{
+			ID:      "20",
+			Token:   "token",
+			Type:    InteractionMessageComponent,
+			Data:    []byte(`{"custom_id": "poll:` + string(rune('0'+option)) + `", "component_type": 2}`),
+			Message: &Message{ID: p.MessageID},
+			Member:  &Member{User: &User{ID: userID}},
+		}})
+	}
+	vote("2", 0)
+	vote("2", 1)
+	vote("3", 1)
+	vote("4", 2)
+	vote("4", 2)
+
+	results, err := m.Results(p.MessageID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results[0].Votes != 0 || results[1].Votes != 2 || results[2].Votes != 0 {
+		t.Errorf("expected single choice votes for option 1, got %+v %+v %+v", results[0], results[1], results[2])
+	}
+
+	polls := m.Polls()
+	if len(polls) != 1 || len(polls[0].Votes) != 2 || polls[0].Votes["2"][0] != 1 {
+		t.Fatalf("unexpected snapshot %+v", polls)
+	}
+	b, _ := json.Marshal(polls[0])
+	var restored Poll
+	if err = json.Unmarshal(b, &restored); err != nil || restored.Votes["3"][0] != 1 {
+		t.Fatalf("expected the votes to survive JSON, got %+v, %v", restored.Votes, err)
+	}
+
+	if _, err = m.ClosePoll(p.MessageID); err == nil {
+		t.Fatal("expected the error of the edit")
+	}
+	if _, err = m.Results(p.MessageID); err != nil {
+		t.Errorf("expected the poll to stay open after a failed close, got %v", err)
+	}
+
+	tr.routes["PATCH "+EndpointChannelMessage("1", "10")] = `{"id": "10", "channel_id": "1"}`
+	delete(tr.statuses, "PATCH "+EndpointChannelMessage("1", "10"))
+	if results, err = m.ClosePoll(p.MessageID); err != nil || results[1].Votes != 2 {
+		t.Fatalf("unexpected results %+v, %v", results, err)
+	}
+	if _, err = m.Results(p.MessageID); err != ErrPollNotFound {
+		t.Errorf("expected the poll to be removed, got %v", err)
+	}
+
+	m.Add(&restored)
+	if results, _ = m.Results(p.MessageID); results[1].Votes != 2 {
+		t.Errorf("expected the restored votes, got %+v", results[1])
+	}
+}
+
+func TestPollManagerFailedClose(t *testing.T) {
+	tr := &routeTransport{
+		routes: map[string]string{
+			"POST " + EndpointChannelMessages("1"):       `{"id": "10", "channel_id": "1"}`,
+			"PATCH " + EndpointChannelMessage("1", "10"): `{"code": 50001, "message": "Missing Access"}`,
+		},
+		statuses: map[string]int{"PATCH " + EndpointChannelMessage("1", "10"): http.StatusForbidden},
+	}
+	s := newTestSession(tr)
+
+	m := NewPollManager(s)
+	m.TallyInterval = 20 * time.Millisecond
+	defer m.Close()
+
+	p, err := m.Create("1", "Pizza?", []string{"Yes", "No"}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The tally of the vote is due while the poll is being closed.
+	m.vote(p.MessageID, "2", 0)
+	tr.release = make(chan struct{})
+	closed := make(chan error)
+	go func() {
+		_, err := m.ClosePoll(p.MessageID)
+		closed <- err
+	}()
+	for tr.count() < 2 {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(3 * m.TallyInterval)
+	close(tr.release)
+	if err = <-closed; err == nil {
+		t.Fatal("expected the error of the edit")
+	}
+
+	// The reopened poll still updates its tally.
+	m.vote(p.MessageID, "3", 1)
+	for start := time.Now(); tr.count() < 3; time.Sleep(time.Millisecond) {
+		if time.Since(start) > time.Second {
+			t.Fatal("expected the tally to be updated after the failed close")
+		}
+	}
+	if r := tr.last(); r != "PATCH "+EndpointChannelMessage("1", "10") {
+		t.Errorf("expected the tally update, got %s", r)
+	}
+}
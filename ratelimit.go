@@ -2,6 +2,7 @@ package discordgo
 
 import (
 	"net/http"
+	"regexp"
 	"strconv"
 	"strings"
 	"sync"
@@ -9,6 +10,51 @@ import (
 	"time"
 )
 
+// DefaultBucketRetention is the default BucketRetention of a RateLimiter.
+const DefaultBucketRetention = 10 * time.Minute
+
+// majorParameter matches the major parameters of a route, Discord limits
+// the routes of a bucket separately per major parameter.
+var majorParameter = regexp.MustCompile(`(channels|guilds|webhooks)/([0-9]+)`)
+
+// routeBucketKey returns the key of the bucket of a route, from the hash of
+// the bucket Discord sent in X-RateLimit-Bucket.
+func routeBucketKey(hash, route string) string {
+	major := ""
+	if m := majorParameter.FindStringSubmatch(route); m != nil {
+		major = m[1] + "/" + m[2]
+	}
+	return hash + ":" + major
+}
+
+// routeTemplate returns a route with its major parameters left out, the
+// routes of all channels or guilds share the same bucket hash.
+func routeTemplate(route string) string {
+	return majorParameter.ReplaceAllString(route, "$1/:id")
+}
+
+// RequestPriority is the priority of a request in its bucket. When requests
+// wait for the same bucket, the request with the highest priority is sent
+// first, and requests of the same priority in the order they came.
+type RequestPriority int
+
+// Block contains the valid known RequestPriority values
+const (
+	RequestPriorityLow    RequestPriority = -1
+	RequestPriorityNormal RequestPriority = 0
+	RequestPriorityHigh   RequestPriority = 1
+)
+
+// DefaultRequestPriority is the default RequestPriorityFunc of a Session,
+// which sends the responses to interactions, due within 3 seconds, with a
+// high priority.
+func DefaultRequestPriority(method, urlStr string) RequestPriority {
+	if strings.HasPrefix(urlStr, EndpointAPI+"interactions/") {
+		return RequestPriorityHigh
+	}
+	return RequestPriorityNormal
+}
+
 // customRateLimit holds information for defining a custom rate limit
 type customRateLimit struct {
 	suffix   string
@@ -23,14 +69,27 @@ type RateLimiter struct {
 	buckets          map[string]*Bucket
 	globalRateLimit  time.Duration
 	customRateLimits []*customRateLimit
+
+	// How long a bucket is kept after its last use and reset, the buckets
+	// of every channel and guild a bot talks to would accumulate otherwise.
+	// The idle buckets are collected once per retention, 0 disables it.
+	BucketRetention time.Duration
+
+	lastCollect time.Time
+
+	// The bucket hashes by route template, see routeTemplate.
+	hashes map[string]string
 }
 
 // NewRatelimiter returns a new RateLimiter
 func NewRatelimiter() *RateLimiter {
 
 	return &RateLimiter{
-		buckets: make(map[string]*Bucket),
-		global:  new(int64),
+		buckets:         make(map[string]*Bucket),
+		hashes:          make(map[string]string),
+		global:          new(int64),
+		BucketRetention: DefaultBucketRetention,
+		lastCollect:     time.Now(),
 		customRateLimits: []*customRateLimit{
 			&customRateLimit{
 				suffix:   "//reactions//",
@@ -41,12 +100,26 @@ func NewRatelimiter() *RateLimiter {
 	}
 }
 
-// GetBucket retrieves or creates a bucket
+// GetBucket retrieves or creates the bucket of a route. Once Discord sent
+// the X-RateLimit-Bucket hash of the route, its bucket is the bucket of the
+// hash and the major parameter of the route, shared with the other routes
+// of the hash, until then a bucket of its own.
 func (r *RateLimiter) GetBucket(key string) *Bucket {
 	r.Lock()
 	defer r.Unlock()
 
+	now := time.Now()
+	if r.BucketRetention > 0 && now.Sub(r.lastCollect) >= r.BucketRetention {
+		r.collectBuckets(now)
+	}
+
+	route, hash := key, ""
+	if h, ok := r.hashes[routeTemplate(key)]; ok {
+		key, route, hash = routeBucketKey(h, key), "", h
+	}
+
 	if bucket, ok := r.buckets[key]; ok {
+		bucket.lastUsed = now
 		return bucket
 	}
 
@@ -54,6 +127,10 @@ func (r *RateLimiter) GetBucket(key string) *Bucket {
 		Remaining: 1,
 		Key:       key,
 		global:    r.global,
+		lastUsed:  now,
+		limiter:   r,
+		route:     route,
+		hash:      hash,
 	}
 
 	// Check if there is a custom ratelimit set for this bucket ID.
@@ -68,6 +145,92 @@ func (r *RateLimiter) GetBucket(key string) *Bucket {
 	return b
 }
 
+// setBucketHash records the bucket hash of the route of a bucket, and
+// moves the bucket to the key of the hash if the hash has no bucket yet.
+func (r *RateLimiter) setBucketHash(b *Bucket, hash string) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.hashes[routeTemplate(b.route)] = hash
+
+	if r.buckets[b.Key] == b {
+		delete(r.buckets, b.Key)
+	}
+	key := routeBucketKey(hash, b.route)
+	if _, ok := r.buckets[key]; !ok {
+		b.Key = key
+		r.buckets[key] = b
+	}
+	b.route, b.hash = "", hash
+}
+
+// Buckets returns the number of buckets the rate limiter holds.
+func (r *RateLimiter) Buckets() int {
+	r.Lock()
+	defer r.Unlock()
+
+	return len(r.buckets)
+}
+
+// CollectBuckets removes the buckets which are neither locked nor rate
+// limited, and weren't used for BucketRetention, and returns how many were
+// removed. They are collected automatically once per BucketRetention. The
+// bucket hashes of routes without buckets left are forgotten with them.
+func (r *RateLimiter) CollectBuckets() int {
+	r.Lock()
+	defer r.Unlock()
+
+	return r.collectBuckets(time.Now())
+}
+
+func (r *RateLimiter) collectBuckets(now time.Time) (n int) {
+	r.lastCollect = now
+	for key, b := range r.buckets {
+		// The reset is only read once nobody holds the bucket.
+		if atomic.LoadInt32(&b.users) > 0 || now.Sub(b.lastUsed) < r.BucketRetention {
+			continue
+		}
+		b.Lock()
+		limited := b.reset.After(now)
+		b.Unlock()
+		if limited {
+			continue
+		}
+		delete(r.buckets, key)
+		n++
+	}
+	if n == 0 {
+		return
+	}
+
+	used := make(map[string]bool, len(r.buckets))
+	for _, b := range r.buckets {
+		used[b.hash] = true
+	}
+	for template, hash := range r.hashes {
+		if !used[hash] {
+			delete(r.hashes, template)
+		}
+	}
+	return
+}
+
+// budgetWait returns how long to wait until a request of the bucket of the
+// route leaves the given fraction of the limit of the bucket to others.
+func (r *RateLimiter) budgetWait(bucketID string, fraction float64) time.Duration {
+	b := r.GetBucket(bucketID)
+	b.Lock()
+	defer b.Unlock()
+
+	if b.limit == 0 || float64(b.limit-b.Remaining) < fraction*float64(b.limit) {
+		return 0
+	}
+	if wait := time.Until(b.reset); wait > 0 {
+		return wait
+	}
+	return 0
+}
+
 // GetWaitTime returns the duration you should wait for a Bucket
 func (r *RateLimiter) GetWaitTime(b *Bucket, minRemaining int) time.Duration {
 	// If we ran out of calls and the reset time is still ahead of us
@@ -90,9 +253,24 @@ func (r *RateLimiter) LockBucket(bucketID string) *Bucket {
 	return r.LockBucketObject(r.GetBucket(bucketID))
 }
 
+// LockBucketPriority locks until a request of the priority can be made
+func (r *RateLimiter) LockBucketPriority(bucketID string, priority RequestPriority) *Bucket {
+	return r.LockBucketObjectPriority(r.GetBucket(bucketID), priority)
+}
+
 // LockBucketObject Locks an already resolved bucket until a request can be made
 func (r *RateLimiter) LockBucketObject(b *Bucket) *Bucket {
+	return r.LockBucketObjectPriority(b, RequestPriorityNormal)
+}
+
+// LockBucketObjectPriority locks an already resolved bucket until a request
+// of the priority can be made, after the waiting requests of a higher
+// priority.
+func (r *RateLimiter) LockBucketObjectPriority(b *Bucket, priority RequestPriority) *Bucket {
+	atomic.AddInt32(&b.users, 1)
+	b.acquire(priority)
 	b.Lock()
+	b.priority = priority
 
 	if wait := r.GetWaitTime(b, 1); wait > 0 {
 		time.Sleep(wait)
@@ -114,11 +292,82 @@ type Bucket struct {
 	lastReset       time.Time
 	customRateLimit *customRateLimit
 	Userdata        interface{}
+
+	// The last time the bucket was retrieved, guarded by the RateLimiter,
+	// and the number of requests locking or holding it.
+	lastUsed time.Time
+	users    int32
+
+	// The route of a bucket whose bucket hash isn't known yet, and the
+	// hash of the bucket once it is, guarded by the RateLimiter.
+	limiter *RateLimiter
+	route   string
+	hash    string
+
+	// The requests waiting for the bucket, and the priority of the request
+	// holding it.
+	waitMu   sync.Mutex
+	held     bool
+	waiters  []*bucketWaiter
+	priority RequestPriority
+}
+
+// bucketWaiter is a request waiting for a bucket.
+type bucketWaiter struct {
+	priority RequestPriority
+	ready    chan struct{}
+}
+
+// acquire waits until the bucket is handed to the request.
+func (b *Bucket) acquire(priority RequestPriority) {
+	b.waitMu.Lock()
+	if !b.held {
+		b.held = true
+		b.waitMu.Unlock()
+		return
+	}
+	w := &bucketWaiter{priority, make(chan struct{})}
+	b.waiters = append(b.waiters, w)
+	b.waitMu.Unlock()
+
+	<-w.ready
+}
+
+// handOff hands the bucket to the first waiting request of the highest
+// priority, if any.
+func (b *Bucket) handOff() {
+	b.waitMu.Lock()
+	defer b.waitMu.Unlock()
+
+	if len(b.waiters) == 0 {
+		b.held = false
+		return
+	}
+
+	next := 0
+	for i, w := range b.waiters {
+		if w.priority > b.waiters[next].priority {
+			next = i
+		}
+	}
+	w := b.waiters[next]
+	b.waiters = append(b.waiters[:next], b.waiters[next+1:]...)
+	close(w.ready)
 }
 
 // Release unlocks the bucket and reads the headers to update the buckets ratelimit info
 // and locks up the whole thing in case if there's a global ratelimit.
 func (b *Bucket) Release(headers http.Header) error {
+	// The bucket hash is recorded once the bucket is unlocked, as the
+	// RateLimiter locks buckets while it holds its own lock.
+	var hash string
+	defer atomic.AddInt32(&b.users, -1)
+	defer b.handOff()
+	defer func() {
+		if hash != "" {
+			b.limiter.setBucketHash(b, hash)
+		}
+	}()
 	defer b.Unlock()
 
 	// Check if the bucket uses a custom ratelimiter
@@ -142,6 +391,10 @@ func (b *Bucket) Release(headers http.Header) error {
 	global := headers.Get("X-RateLimit-Global")
 	retryAfter := headers.Get("Retry-After")
 
+	if b.route != "" && b.limiter != nil {
+		hash = headers.Get("X-RateLimit-Bucket")
+	}
+
 	// Update global and per bucket reset time if the proper headers are available
 	// If global is set, then it will block all buckets until after Retry-After
 	// If Retry-After without global is provided it will use that for the new reset
@@ -181,6 +434,14 @@ func (b *Bucket) Release(headers http.Header) error {
 		b.reset = time.Now().Add(delta)
 	}
 
+	if limit := headers.Get("X-RateLimit-Limit"); limit != "" {
+		parsedLimit, err := strconv.ParseInt(limit, 10, 32)
+		if err != nil {
+			return err
+		}
+		b.limit = int(parsedLimit)
+	}
+
 	// Udpate remaining if header is present
 	if remaining != "" {
 		parsedRemaining, err := strconv.ParseInt(remaining, 10, 32)
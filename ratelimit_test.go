@@ -110,3 +110,119 @@ func sendBenchReq(endpoint string, rl *RateLimiter) {
 
 	bucket.Release(headers)
 }
+
+func TestRatelimitCollectBuckets(t *testing.T) {
+	rl := NewRatelimiter()
+	rl.BucketRetention = time.Hour
+
+	idle := rl.GetBucket("/channels/1/messages")
+	limited := rl.GetBucket("/channels/2/messages")
+	held := rl.LockBucket("/channels/3/messages")
+	rl.GetBucket("/channels/4/messages")
+
+	idle.lastUsed = time.Now().Add(-2 * time.Hour)
+	limited.lastUsed = idle.lastUsed
+	limited.reset = time.Now().Add(time.Minute)
+	held.lastUsed = idle.lastUsed
+
+	if n := rl.CollectBuckets(); n != 1 {
+		t.Errorf("expected 1 collected bucket, got %d", n)
+	}
+	if n := rl.Buckets(); n != 3 {
+		t.Errorf("expected 3 buckets left, got %d", n)
+	}
+
+	held.Release(nil)
+	if n := rl.CollectBuckets(); n != 1 || rl.Buckets() != 2 {
+		t.Errorf("expected the released bucket to be collected, got %d", n)
+	}
+
+	// Buckets are collected automatically once per retention.
+	limited.reset = time.Time{}
+	rl.lastCollect = time.Now().Add(-2 * time.Hour)
+	rl.GetBucket("/channels/5/messages")
+	if n := rl.Buckets(); n != 2 {
+		t.Errorf("expected 2 buckets after the automatic collection, got %d", n)
+	}
+}
+
+func TestRatelimitBucketHash(t *testing.T) {
+	rl := NewRatelimiter()
+	headers := http.Header{}
+	headers.Set("X-RateLimit-Bucket", "abcd")
+
+	// The first request of a route uses a bucket of its own until the hash is known.
+	first := rl.LockBucket(EndpointChannelMessages("1"))
+	if first.Key != EndpointChannelMessages("1") {
+		t.Errorf("unexpected key %s", first.Key)
+	}
+	first.Release(headers)
+	if first.Key != "abcd:channels/1" {
+		t.Errorf("expected the bucket to be moved to the hash, got %s", first.Key)
+	}
+
+	// The routes of a hash share the bucket of a major parameter.
+	if b := rl.GetBucket(EndpointChannelMessages("1")); b != first {
+		t.Errorf("expected the bucket of the hash, got %s", b.Key)
+	}
+	if b := rl.GetBucket(EndpointChannelMessages("2")); b == first || b.Key != "abcd:channels/2" {
+		t.Errorf("expected a bucket per major parameter, got %s", b.Key)
+	}
+	if n := rl.Buckets(); n != 2 {
+		t.Errorf("expected 2 buckets, got %d", n)
+	}
+	// The hash is forgotten with the last of its buckets.
+	rl.BucketRetention = time.Hour
+	first.lastUsed = time.Now().Add(-2 * time.Hour)
+	if n := rl.CollectBuckets(); n != 1 || len(rl.hashes) != 1 {
+		t.Errorf("expected the hash to be kept for the bucket left, got %d collected and %v", n, rl.hashes)
+	}
+	rl.GetBucket(EndpointChannelMessages("2")).lastUsed = first.lastUsed
+	if n := rl.CollectBuckets(); n != 1 || len(rl.hashes) != 0 {
+		t.Errorf("expected the hash to be forgotten, got %d collected and %v", n, rl.hashes)
+	}
+	if b := rl.GetBucket(EndpointChannelMessages("1")); b.Key != EndpointChannelMessages("1") {
+		t.Errorf("expected a bucket of the route, got %s", b.Key)
+	}
+}
+
+func TestRatelimitPriority(t *testing.T) {
+	rl := NewRatelimiter()
+	held := rl.LockBucket("/channels/1/messages")
+
+	order := make(chan RequestPriority, 3)
+	for _, p := range []RequestPriority{RequestPriorityLow, RequestPriorityNormal, RequestPriorityHigh} {
+		go func(p RequestPriority) {
+			b := rl.LockBucketPriority("/channels/1/messages", p)
+			order <- p
+			b.Release(nil)
+		}(p)
+
+		// Wait for the request to be queued.
+		for {
+			held.waitMu.Lock()
+			n := len(held.waiters)
+			held.waitMu.Unlock()
+			if n > int(p)+1 {
+				break
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	held.Release(nil)
+	for _, want := range []RequestPriority{RequestPriorityHigh, RequestPriorityNormal, RequestPriorityLow} {
+		if p := <-order; p != want {
+			t.Errorf("expected priority %d, got %d", want, p)
+		}
+	}
+}
+
+func TestDefaultRequestPriority(t *testing.T) {
+	if p := DefaultRequestPriority("POST", EndpointInteractionResponse("1", "token")); p != RequestPriorityHigh {
+		t.Errorf("expected interaction responses to have a high priority, got %d", p)
+	}
+	if p := DefaultRequestPriority("GET", EndpointChannelMessages("1")); p != RequestPriorityNormal {
+		t.Errorf("expected a normal priority, got %d", p)
+	}
+}
@@ -0,0 +1,370 @@
+package discordgo
+
+import (
+	"strings"
+	"sync"
+)
+
+// A ReactionRole gives members a role when they react to a message with an emoji.
+type ReactionRole struct {
+	GuildID   string `json:"guild_id"`
+	ChannelID string `json:"channel_id"`
+	MessageID string `json:"message_id"`
+
+	// Either the unicode emoji, or a guild emoji identifier (name:id).
+	Emoji string `json:"emoji"`
+
+	RoleID string `json:"role_id"`
+}
+
+// reactionRoleKey is the key of a ReactionRole in a ReactionRoleManager.
+type reactionRoleKey struct {
+	messageID string
+	emoji     string
+}
+
+// reactionRoleEmojiKey returns the emoji part of a reactionRoleKey, guild
+// emojis are identified by their ID alone so renaming them doesn't matter.
+func reactionRoleEmojiKey(emoji string) string {
+	if i := strings.LastIndex(emoji, ":"); i >= 0 {
+		return emoji[i+1:]
+	}
+	return emoji
+}
+
+// reactionRoleChange is a pending role change of a ReactionRoleManager.
+type reactionRoleChange struct {
+	guildID string
+	userID  string
+	roleID  string
+}
+
+// A ReactionRoleManager adds and removes the roles of members when they
+// react to the messages of its reaction roles.
+//
+// Role changes are applied one at a time in the background, so a burst of
+// reactions is spread out over the rate limit of the role endpoints rather
+// than flooding them. When a member removes a reaction before the role was
+// given, only the most recent state is applied. Roles of members who have
+// not passed the membership screening of the guild yet are held back
+// until they pass it, which requires the members to be tracked in the state.
+type ReactionRoleManager struct {
+	sync.RWMutex
+
+	session *Session
+	roles   map[reactionRoleKey]*ReactionRole
+
+	handlers []HandlerID
+
+	pending map[reactionRoleChange]bool
+	queue   []reactionRoleChange
+	working bool
+
+	// Changes of members pending membership screening.
+	screening map[guildUserKey]map[reactionRoleChange]bool
+}
+
+// NewReactionRoleManager returns a new ReactionRoleManager which handles
+// the reaction events of s.
+func NewReactionRoleManager(s *Session) *ReactionRoleManager {
+	m := &ReactionRoleManager{
+		session:   s,
+		roles:     make(map[reactionRoleKey]*ReactionRole),
+		pending:   make(map[reactionRoleChange]bool),
+		screening: make(map[guildUserKey]map[reactionRoleChange]bool),
+	}
+
+	m.handlers = []HandlerID{
+		s.AddHandlerComplex(m.onReactionAdd, HandlerOptions{}),
+		s.AddHandlerComplex(m.onReactionRemove, HandlerOptions{}),
+		s.AddHandlerComplex(m.onMemberUpdate, HandlerOptions{}),
+	}
+	return m
+}
+
+// Close removes the event handlers of the manager.
+func (m *ReactionRoleManager) Close() {
+	for _, id := range m.handlers {
+		m.session.RemoveHandler(id)
+	}
+}
+
+// Add adds reaction roles to the manager.
+func (m *ReactionRoleManager) Add(roles ...*ReactionRole) {
+	m.Lock()
+	defer m.Unlock()
+
+	for _, rr := range roles {
+		m.roles[reactionRoleKey{rr.MessageID, reactionRoleEmojiKey(rr.Emoji)}] = rr
+	}
+}
+
+// Remove removes the reaction role of the message and emoji from the manager.
+func (m *ReactionRoleManager) Remove(messageID, emoji string) {
+	m.Lock()
+	defer m.Unlock()
+
+	delete(m.roles, reactionRoleKey{messageID, reactionRoleEmojiKey(emoji)})
+}
+
+// ReactionRoles returns all reaction roles of the manager, eg. to persist them.
+func (m *ReactionRoleManager) ReactionRoles() (roles []*ReactionRole) {
+	m.RLock()
+	defer m.RUnlock()
+
+	for _, rr := range m.roles {
+		roles = append(roles, rr)
+	}
+	return
+}
+
+// reactionRole returns the reaction role of a reaction, or nil if there is none.
+func (m *ReactionRoleManager) reactionRole(r *MessageReaction) *ReactionRole {
+	emoji := r.Emoji.ID
+	if emoji == "" {
+		emoji = r.Emoji.Name
+	}
+
+	m.RLock()
+	defer m.RUnlock()
+
+	return m.roles[reactionRoleKey{r.MessageID, emoji}]
+}
+
+func (m *ReactionRoleManager) onReactionAdd(s *Session, r *MessageReactionAdd) {
+	if rr := m.reactionRole(r.MessageReaction); rr != nil && !m.ownUser(r.UserID) {
+		m.queueChange(reactionRoleChange{rr.GuildID, r.UserID, rr.RoleID}, true)
+	}
+}
+
+func (m *ReactionRoleManager) onReactionRemove(s *Session, r *MessageReactionRemove) {
+	if rr := m.reactionRole(r.MessageReaction); rr != nil && !m.ownUser(r.UserID) {
+		m.queueChange(reactionRoleChange{rr.GuildID, r.UserID, rr.RoleID}, false)
+	}
+}
+
+// ownUser returns whether the user is the user of the session, whose
+// reactions only serve as prompts.
+func (m *ReactionRoleManager) ownUser(userID string) bool {
+	s := m.session
+	if s.State == nil {
+		return false
+	}
+
+	s.State.RLock()
+	defer s.State.RUnlock()
+	return s.State.User != nil && s.State.User.ID == userID
+}
+
+func (m *ReactionRoleManager) onMemberUpdate(s *Session, u *GuildMemberUpdate) {
+	if u.Member == nil || u.User == nil || u.Pending {
+		return
+	}
+
+	key := guildUserKey{u.GuildID, u.User.ID}
+	m.Lock()
+	changes := m.screening[key]
+	delete(m.screening, key)
+	m.Unlock()
+
+	for c, add := range changes {
+		m.queueChange(c, add)
+	}
+}
+
+// queueChange queues a role change, replacing a pending change of the
+// same role, and starts the worker if it isn't running. Changes of members
+// pending membership screening are held back.
+func (m *ReactionRoleManager) queueChange(c reactionRoleChange, add bool) {
+	if memberPending(m.session, c.guildID, c.userID) {
+		key := guildUserKey{c.guildID, c.userID}
+		m.Lock()
+		if m.screening[key] == nil {
+			m.screening[key] = map[reactionRoleChange]bool{}
+		}
+		m.screening[key][c] = add
+		m.Unlock()
+		return
+	}
+
+	m.Lock()
+	if _, ok := m.pending[c]; !ok {
+		m.queue = append(m.queue, c)
+	}
+	m.pending[c] = add
+
+	start := !m.working
+	m.working = true
+	m.Unlock()
+
+	if start {
+		go m.work()
+	}
+}
+
+// work applies the queued role changes until the queue is empty.
+func (m *ReactionRoleManager) work() {
+	for {
+		m.Lock()
+		if len(m.queue) == 0 {
+			m.working = false
+			m.Unlock()
+			return
+		}
+		c := m.queue[0]
+		m.queue = m.queue[1:]
+		add := m.pending[c]
+		delete(m.pending, c)
+		m.Unlock()
+
+		var err error
+		if add {
+			err = m.session.GuildMemberRoleAdd(c.guildID, c.userID, c.roleID)
+		} else {
+			err = m.session.GuildMemberRoleRemove(c.guildID, c.userID, c.roleID)
+		}
+		if err != nil {
+			m.session.log(LogError, "error changing reaction role %s of user %s, %s", c.roleID, c.userID, err)
+		}
+	}
+}
+
+// Sync brings the roles of the manager back in line with the reactions,
+// eg. after a restart during which reactions were added or removed. The
+// reaction of the session user is added to messages it is missing from,
+// members who reacted without having the role are given it, and members
+// who have the role without having reacted lose it, so the roles of the
+// manager shouldn't be given in other ways. Bots are left as they are.
+//
+// The members of a guild are taken from the state when it has all of
+// them, and are otherwise fetched from the API, which requires the guild
+// members intent.
+func (m *ReactionRoleManager) Sync() error {
+	s := m.session
+
+	// The users who reacted by role, as a role can be given by reactions
+	// to several messages.
+	type guildRole struct {
+		guildID string
+		roleID  string
+	}
+	reacted := map[guildRole]map[string]bool{}
+	var roles []guildRole
+
+	for _, rr := range m.ReactionRoles() {
+		if err := s.MessageReactionAdd(rr.ChannelID, rr.MessageID, EmojiIdentifier(rr.Emoji)); err != nil {
+			return err
+		}
+
+		key := guildRole{rr.GuildID, rr.RoleID}
+		if reacted[key] == nil {
+			reacted[key] = map[string]bool{}
+			roles = append(roles, key)
+		}
+
+		afterID := ""
+		for {
+			users, err := s.MessageReactions(rr.ChannelID, rr.MessageID, EmojiIdentifier(rr.Emoji), 100, "", afterID)
+			if err != nil {
+				return err
+			}
+
+			for _, u := range users {
+				if !u.Bot {
+					reacted[key][u.ID] = true
+				}
+			}
+
+			if len(users) < 100 {
+				break
+			}
+			afterID = users[len(users)-1].ID
+		}
+	}
+
+	guilds := map[string]map[string]*Member{}
+	for _, key := range roles {
+		members, ok := guilds[key.guildID]
+		if !ok {
+			var err error
+			if members, err = m.guildMembers(key.guildID); err != nil {
+				return err
+			}
+			guilds[key.guildID] = members
+		}
+
+		for userID, member := range members {
+			if member.User.Bot || m.ownUser(userID) {
+				continue
+			}
+			if has := memberHasRole(member, key.roleID); has != reacted[key][userID] {
+				m.queueChange(reactionRoleChange{key.guildID, userID, key.roleID}, !has)
+			}
+		}
+	}
+	return nil
+}
+
+// guildMembers returns copies of the members of a guild by user ID, from
+// the state if it has all of them and otherwise from the API.
+func (m *ReactionRoleManager) guildMembers(guildID string) (map[string]*Member, error) {
+	s := m.session
+	members := map[string]*Member{}
+
+	if s.State != nil {
+		if g, err := s.State.Guild(guildID); err == nil {
+			s.State.RLock()
+			complete := g.MemberCount > 0 && len(g.Members) >= g.MemberCount
+			if complete {
+				for _, member := range g.Members {
+					copied := *member
+					copied.Roles = append([]string(nil), member.Roles...)
+					members[member.User.ID] = &copied
+				}
+			}
+			s.State.RUnlock()
+
+			if complete {
+				return members, nil
+			}
+		}
+	}
+
+	afterID := ""
+	for {
+		page, err := s.GuildMembers(guildID, afterID, 1000)
+		if err != nil {
+			return nil, err
+		}
+		for _, member := range page {
+			members[member.User.ID] = member
+		}
+
+		if len(page) < 1000 {
+			return members, nil
+		}
+		afterID = page[len(page)-1].User.ID
+	}
+}
+
+// ReactionRolePrompt sends a message to the channel, reacts to it with the
+// emojis of the roles and adds them to the manager. Only the Emoji and
+// RoleID of the roles need to be set, the rest is filled in by the prompt.
+func (m *ReactionRoleManager) ReactionRolePrompt(guildID, channelID string, data *MessageSend, roles ...*ReactionRole) (st *Message, err error) {
+	st, err = m.session.ChannelMessageSendComplex(channelID, data)
+	if err != nil {
+		return
+	}
+
+	for _, rr := range roles {
+		rr.GuildID = guildID
+		rr.ChannelID = channelID
+		rr.MessageID = st.ID
+		m.Add(rr)
+
+		if err = m.session.MessageReactionAdd(channelID, st.ID, EmojiIdentifier(rr.Emoji)); err != nil {
+			return
+		}
+	}
+	return
+}
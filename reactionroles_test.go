@@ -0,0 +1,119 @@
+package discordgo
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReactionRoleLookup(t *testing.T) {
+	m := NewReactionRoleManager(&Session{})
+	defer m.Close()
+
+	m.Add(&ReactionRole{MessageID: "1", Emoji: "blob:42", RoleID: "a"})
+	m.Add(&ReactionRole{MessageID: "1", Emoji: "👍", RoleID: "b"})
+
+	if rr := m.reactionRole(&MessageReaction{MessageID: "1", Emoji: Emoji{ID: "42", Name: "renamed"}}); rr == nil || rr.RoleID != "a" {
+		t.Fatalf("expected role a for guild emoji, got %v", rr)
+	}
+	if rr := m.reactionRole(&MessageReaction{MessageID: "1", Emoji: Emoji{Name: "👍"}}); rr == nil || rr.RoleID != "b" {
+		t.Fatalf("expected role b for unicode emoji, got %v", rr)
+	}
+	if rr := m.reactionRole(&MessageReaction{MessageID: "2", Emoji: Emoji{Name: "👍"}}); rr != nil {
+		t.Fatalf("expected no role for other message, got %v", rr)
+	}
+
+	m.Remove("1", "👍")
+	if len(m.ReactionRoles()) != 1 {
+		t.Fatalf("expected 1 reaction role after remove, got %d", len(m.ReactionRoles()))
+	}
+}
+
+// reactionRoleTransport answers the reactions of every message with users
+// 10 to 12, and lists the members of guild 1.
+func reactionRoleTransport() *routeTransport {
+	return &routeTransport{handle: func(req *http.Request, body []byte) (int, string) {
+		switch {
+		case req.Method == "GET" && strings.Contains(req.URL.Path, "/reactions/"):
+			return http.StatusOK, `[{"id": "10"}, {"id": "11"}, {"id": "12", "bot": true}]`
+		case req.Method == "GET" && strings.HasSuffix(req.URL.Path, "/members"):
+			return http.StatusOK, `[
+				{"user": {"id": "10"}, "roles": []},
+				{"user": {"id": "11"}, "roles": ["a"]},
+				{"user": {"id": "13"}, "roles": ["a", "b"]},
+				{"user": {"id": "14", "bot": true}, "roles": ["a"]}
+			]`
+		}
+		return http.StatusNoContent, ``
+	}}
+}
+
+// roleChanges waits for the role changes of a transport, as "PUT 10 a" or
+// "DELETE 13 a".
+func roleChanges(t *testing.T, tr *routeTransport, n int) map[string]bool {
+	changes := map[string]bool{}
+	for start := time.Now(); len(changes) < n && time.Since(start) < time.Second; time.Sleep(time.Millisecond) {
+		tr.Lock()
+		for _, r := range tr.requests {
+			if i := strings.Index(r, "/members/"); i >= 0 && strings.Contains(r, "/roles/") {
+				parts := strings.Split(r[i+len("/members/"):], "/")
+				changes[strings.Fields(r)[0]+" "+parts[0]+" "+parts[2]] = true
+			}
+		}
+		tr.Unlock()
+	}
+	return changes
+}
+
+func TestReactionRoleSync(t *testing.T) {
+	tr := reactionRoleTransport()
+	s := newTestSession(tr)
+	s.State = nil
+
+	m := NewReactionRoleManager(s)
+	defer m.Close()
+	m.Add(&ReactionRole{GuildID: "1", ChannelID: "2", MessageID: "3", Emoji: "👍", RoleID: "a"})
+
+	if err := m.Sync(); err != nil {
+		t.Fatal(err)
+	}
+
+	// 10 reacted without the role, 13 has the role without a reaction, and
+	// bots are left as they are.
+	changes := roleChanges(t, tr, 2)
+	if len(changes) != 2 || !changes["PUT 10 a"] || !changes["DELETE 13 a"] {
+		t.Errorf("expected the role to be added to 10 and removed from 13, got %v", changes)
+	}
+	if r := tr.requests[0]; r != "PUT "+EndpointMessageReaction("2", "3", EmojiIdentifier("👍").escaped(), "@me") {
+		t.Errorf("expected the reaction of the session user first, got %s", r)
+	}
+}
+
+func TestReactionRoleSyncState(t *testing.T) {
+	tr := reactionRoleTransport()
+	s := newTestSession(tr)
+	s.State.GuildAdd(&Guild{ID: "1", MemberCount: 3, Members: []*Member{
+		{GuildID: "1", User: &User{ID: "10"}, Roles: []string{"a"}},
+		{GuildID: "1", User: &User{ID: "11"}},
+		{GuildID: "1", User: &User{ID: "13"}, Roles: []string{"a"}},
+	}})
+
+	m := NewReactionRoleManager(s)
+	defer m.Close()
+	m.Add(&ReactionRole{GuildID: "1", ChannelID: "2", MessageID: "3", Emoji: "👍", RoleID: "a"})
+
+	if err := m.Sync(); err != nil {
+		t.Fatal(err)
+	}
+
+	changes := roleChanges(t, tr, 2)
+	if len(changes) != 2 || !changes["PUT 11 a"] || !changes["DELETE 13 a"] {
+		t.Errorf("expected the role to be added to 11 and removed from 13, got %v", changes)
+	}
+	for _, r := range tr.requests {
+		if strings.HasSuffix(r, "/members?limit=1000") {
+			t.Errorf("expected the members to be taken from the state, got %s", r)
+		}
+	}
+}
@@ -0,0 +1,313 @@
+package discordgo
+
+import (
+	"bytes"
+	"errors"
+	"hash/crc32"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// opusSilenceFrame is a 20ms opus frame of silence, written to fill the
+// gaps in recorded tracks.
+var opusSilenceFrame = []byte{0xf8, 0xff, 0xfe}
+
+// ErrVoiceRecorderEmpty is returned by VoiceRecorder.Mix when nothing was recorded.
+var ErrVoiceRecorderEmpty = errors.New("nothing was recorded")
+
+// recorderPendingLimit is the number of packets of an SSRC buffered until
+// the user of the SSRC is known.
+const recorderPendingLimit = 250
+
+// A VoiceRecorder records the audio received by a voice connection into an
+// Ogg Opus file per user. The tracks are aligned to the start of the
+// recording, users who join later are preceded by silence, and silences
+// while users don't speak are kept, so the files can be played or mixed in
+// sync, see Mix.
+type VoiceRecorder struct {
+	sync.Mutex
+
+	conn  *VoiceConnection
+	dir   string
+	start time.Time
+
+	// Removes the speaking update handler from the connection.
+	removeHandler func()
+
+	users   map[uint32]string
+	pending map[uint32][]*recorderPacket
+	tracks  map[string]*recorderTrack
+
+	stop    chan struct{}
+	done    chan struct{}
+	stopped bool
+	err     error
+}
+
+// recorderPacket is a received packet and its arrival time.
+type recorderPacket struct {
+	*Packet
+	received time.Time
+}
+
+// recorderTrack is the file of a user.
+type recorderTrack struct {
+	path   string
+	file   *os.File
+	writer *OggOpusWriter
+
+	// The SSRC the positions of the packets are based on, the RTP timestamp
+	// and position in the track of its first packet. A new SSRC, eg. after
+	// the user reconnected, starts a new base.
+	ssrc       uint32
+	baseTS     uint32
+	basePos    int64
+	hasBase    bool
+	lastPacket time.Time
+}
+
+// NewVoiceRecorder starts recording the audio received by the connection
+// into Ogg Opus files in the directory, named by the IDs of the users. The
+// recorder reads all packets of OpusRecv until Stop is called.
+// v   : The voice connection to record.
+// dir : The directory of the files, which must exist.
+func NewVoiceRecorder(v *VoiceConnection, dir string) (*VoiceRecorder, error) {
+	v.RLock()
+	recv := v.OpusRecv
+	v.RUnlock()
+	if recv == nil {
+		return nil, ErrVoiceNotReady
+	}
+
+	r := &VoiceRecorder{
+		conn:    v,
+		dir:     dir,
+		start:   time.Now(),
+		users:   map[uint32]string{},
+		pending: map[uint32][]*recorderPacket{},
+		tracks:  map[string]*recorderTrack{},
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+
+	// Speaking updates tell which user an SSRC belongs to.
+	r.removeHandler = v.AddHandler(func(vc *VoiceConnection, vs *VoiceSpeakingUpdate) {
+		r.setUser(uint32(vs.SSRC), vs.UserID)
+	})
+
+	go r.run(recv)
+	return r, nil
+}
+
+// run records the received packets until the recorder is stopped.
+func (r *VoiceRecorder) run(recv <-chan *Packet) {
+	defer close(r.done)
+
+	for {
+		select {
+		case <-r.stop:
+			return
+		case p, ok := <-recv:
+			if !ok {
+				return
+			}
+			if len(p.Opus) == 0 {
+				continue
+			}
+
+			r.Lock()
+			r.receive(&recorderPacket{p, time.Now()})
+			r.Unlock()
+		}
+	}
+}
+
+// setUser sets the user of an SSRC, and records the packets received before.
+func (r *VoiceRecorder) setUser(ssrc uint32, userID string) {
+	r.Lock()
+	defer r.Unlock()
+
+	if r.users[ssrc] == userID {
+		return
+	}
+	r.users[ssrc] = userID
+
+	pending := r.pending[ssrc]
+	delete(r.pending, ssrc)
+	for _, p := range pending {
+		r.receive(p)
+	}
+}
+
+// receive records a packet, the lock must be held.
+func (r *VoiceRecorder) receive(p *recorderPacket) {
+	if r.stopped || r.err != nil {
+		return
+	}
+
+	userID, ok := r.users[p.SSRC]
+	if !ok {
+		if len(r.pending[p.SSRC]) < recorderPendingLimit {
+			r.pending[p.SSRC] = append(r.pending[p.SSRC], p)
+		}
+		return
+	}
+
+	t, err := r.track(userID)
+	if err != nil {
+		r.fail(err)
+		return
+	}
+
+	// Packets of a new SSRC, or after a long pause, are placed by their
+	// arrival time. Otherwise the RTP timestamps keep the exact timing.
+	if !t.hasBase || t.ssrc != p.SSRC || p.received.Sub(t.lastPacket) > time.Minute {
+		pos := int64(p.received.Sub(r.start).Seconds() * pcmSampleRate)
+		if pos < t.writer.Granule() {
+			pos = t.writer.Granule()
+		}
+		t.ssrc, t.baseTS, t.basePos, t.hasBase = p.SSRC, p.Timestamp, pos, true
+	}
+	t.lastPacket = p.received
+
+	pos := t.basePos + int64(int32(p.Timestamp-t.baseTS))
+	if pos < t.writer.Granule() {
+		// A late or repeated packet.
+		return
+	}
+
+	// Fill the gap until the packet with silence.
+	for pos-t.writer.Granule() >= 960 {
+		if err = t.writer.WriteFrame(opusSilenceFrame); err != nil {
+			r.fail(err)
+			return
+		}
+	}
+
+	if err = t.writer.WriteFrame(p.Opus); err != nil {
+		r.fail(err)
+	}
+}
+
+// track returns the track of a user, creating it if needed.
+func (r *VoiceRecorder) track(userID string) (*recorderTrack, error) {
+	if t, ok := r.tracks[userID]; ok {
+		return t, nil
+	}
+
+	path := filepath.Join(r.dir, userID+".ogg")
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+
+	w, err := NewOggOpusWriter(f, pcmChannels, crc32.ChecksumIEEE([]byte(userID)))
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	t := &recorderTrack{path: path, file: f, writer: w}
+	r.tracks[userID] = t
+	return t, nil
+}
+
+// fail stops recording after an error, the lock must be held.
+func (r *VoiceRecorder) fail(err error) {
+	r.err = err
+	r.conn.log(LogError, "error recording voice, %s", err)
+}
+
+// Stop stops recording and closes the files. It returns the first error of
+// the recording.
+func (r *VoiceRecorder) Stop() error {
+	r.removeHandler()
+
+	r.Lock()
+	select {
+	case <-r.stop:
+	default:
+		close(r.stop)
+	}
+	r.Unlock()
+	<-r.done
+
+	r.Lock()
+	defer r.Unlock()
+
+	r.stopped = true
+	for _, t := range r.tracks {
+		if t.file == nil {
+			continue
+		}
+		if err := t.writer.Close(); err != nil && r.err == nil {
+			r.err = err
+		}
+		if err := t.file.Close(); err != nil && r.err == nil {
+			r.err = err
+		}
+		t.file = nil
+	}
+	return r.err
+}
+
+// Files returns the paths of the files of the users by user ID.
+func (r *VoiceRecorder) Files() map[string]string {
+	r.Lock()
+	defer r.Unlock()
+
+	files := make(map[string]string, len(r.tracks))
+	for userID, t := range r.tracks {
+		files[userID] = t.path
+	}
+	return files
+}
+
+// Mix mixes the files of all users into a single Ogg Opus file with ffmpeg,
+// after the recording was stopped.
+// output  : The path of the mixed file.
+// options : The ffmpeg binary and bitrate, optional.
+func (r *VoiceRecorder) Mix(output string, options *FFmpegOptions) error {
+	if options == nil {
+		options = &FFmpegOptions{}
+	}
+
+	path := options.Path
+	if path == "" {
+		path = "ffmpeg"
+	}
+
+	files := r.Files()
+	if len(files) == 0 {
+		return ErrVoiceRecorderEmpty
+	}
+
+	users := make([]string, 0, len(files))
+	for userID := range files {
+		users = append(users, userID)
+	}
+	sort.Strings(users)
+
+	args := append(ffmpegGlobalArgs(), "-y")
+	for _, userID := range users {
+		args = append(args, "-i", files[userID])
+	}
+	if len(users) > 1 {
+		args = append(args, "-filter_complex", "amix=inputs="+strconv.Itoa(len(users))+":duration=longest:normalize=0")
+	}
+	args = append(args, ffmpegOpusArgs(options)...)
+	args[len(args)-1] = output
+
+	var stderr bytes.Buffer
+	cmd := exec.Command(path, args...)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return &FFmpegError{Err: err, Stderr: stderr.String()}
+	}
+	return nil
+}
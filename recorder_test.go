@@ -0,0 +1,78 @@
+package discordgo
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestVoiceRecorder(t *testing.T) {
+	dir, err := ioutil.TempDir("", "recorder")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	recv := make(chan *Packet)
+	v := &VoiceConnection{OpusRecv: recv}
+	r, err := NewVoiceRecorder(v, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The first packet arrives before the speaking update of its SSRC.
+	frame := []byte{0xf8, 0x01}
+	recv <- &Packet{SSRC: 1, Timestamp: 1000, Opus: frame}
+	for _, h := range v.voiceSpeakingUpdateHandlers {
+		h.handler(v, &VoiceSpeakingUpdate{UserID: "user", SSRC: 1, Speaking: true})
+	}
+	recv <- &Packet{SSRC: 1, Timestamp: 1960, Opus: frame}
+	recv <- &Packet{SSRC: 1, Timestamp: 1960, Opus: frame}
+	recv <- &Packet{SSRC: 1, Timestamp: 1000 + 5*960, Opus: frame}
+
+	if err = r.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	if len(v.voiceSpeakingUpdateHandlers) != 0 {
+		t.Error("expected the speaking update handler to be removed")
+	}
+
+	path, ok := r.Files()["user"]
+	if !ok {
+		t.Fatal("no file for the user")
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	o, err := NewOggOpusReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var frames [][]byte
+	for {
+		f, err := o.ReadFrame()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		frames = append(frames, f)
+	}
+
+	// 3 packets, the repeated packet is dropped, and 3 frames of silence.
+	voice := 0
+	for _, f := range frames {
+		if bytes.Equal(f, frame) {
+			voice++
+		}
+	}
+	if voice != 3 || len(frames) < 6 || !bytes.Equal(frames[len(frames)-1], frame) || !bytes.Equal(frames[len(frames)-2], opusSilenceFrame) {
+		t.Errorf("unexpected frames %v", frames)
+	}
+}
@@ -0,0 +1,263 @@
+package discordgo
+
+import (
+	"context"
+	"sync"
+)
+
+// resolveCall is an API request of a Resolve function in flight, which
+// concurrent resolves of the same entity wait for.
+type resolveCall struct {
+	done chan struct{}
+	val  interface{}
+	err  error
+}
+
+// resolveGroup deduplicates the API requests of the Resolve functions.
+type resolveGroup struct {
+	sync.Mutex
+	calls map[string]*resolveCall
+}
+
+// do calls fetch, or waits for the call with the same key in flight. The
+// call continues when ctx is done, so the callers waiting for it get its
+// result.
+func (g *resolveGroup) do(ctx context.Context, key string, fetch func() (interface{}, error)) (interface{}, error) {
+	g.Lock()
+	c, ok := g.calls[key]
+	if !ok {
+		if g.calls == nil {
+			g.calls = make(map[string]*resolveCall)
+		}
+		c = &resolveCall{done: make(chan struct{})}
+		g.calls[key] = c
+
+		go func() {
+			c.val, c.err = fetch()
+
+			g.Lock()
+			delete(g.calls, key)
+			g.Unlock()
+			close(c.done)
+		}()
+	}
+	g.Unlock()
+
+	select {
+	case <-c.done:
+		return c.val, c.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// ResolveChannel returns channels from the State, or from the API if they
+// aren't cached. Concurrent resolves of a channel share one request, and
+// the fetched channels are added to the State if ResolveWriteBack is set.
+// The channels are returned in the order of the IDs, nil for the channels
+// which failed, with an IDErrors.
+// ctx        : Cancels waiting for the channels.
+// channelIDs : The IDs of the channels.
+func (s *Session) ResolveChannel(ctx context.Context, channelIDs ...string) ([]*Channel, error) {
+	channels := make([]*Channel, len(channelIDs))
+	errs := IDErrors{}
+	for i, id := range channelIDs {
+		c, err := s.resolveChannel(ctx, id)
+		if err != nil {
+			errs[id] = err
+			continue
+		}
+		channels[i] = c
+	}
+	return channels, errs.errorOrNil()
+}
+
+func (s *Session) resolveChannel(ctx context.Context, channelID string) (*Channel, error) {
+	if s.State != nil {
+		if c, err := s.State.Channel(channelID); err == nil {
+			return c, nil
+		}
+	}
+
+	v, err := s.resolves.do(ctx, "channel:"+channelID, func() (interface{}, error) {
+		c, err := s.Channel(channelID)
+		if err == nil && s.ResolveWriteBack && s.State != nil && s.State.TrackChannels {
+			s.State.ChannelAdd(c)
+		}
+		return c, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*Channel), nil
+}
+
+// ResolveGuild returns guilds from the State, or from the API if they
+// aren't cached, see ResolveChannel. The fetched guilds aren't added to the
+// State, as the API doesn't return their channels and members. The guilds
+// which failed are in an IDErrors.
+// ctx      : Cancels waiting for the guilds.
+// guildIDs : The IDs of the guilds.
+func (s *Session) ResolveGuild(ctx context.Context, guildIDs ...string) ([]*Guild, error) {
+	guilds := make([]*Guild, len(guildIDs))
+	errs := IDErrors{}
+	for i, id := range guildIDs {
+		g, err := s.resolveGuild(ctx, id)
+		if err != nil {
+			errs[id] = err
+			continue
+		}
+		guilds[i] = g
+	}
+	return guilds, errs.errorOrNil()
+}
+
+func (s *Session) resolveGuild(ctx context.Context, guildID string) (*Guild, error) {
+	if s.State != nil {
+		if g, err := s.State.Guild(guildID); err == nil {
+			return g, nil
+		}
+	}
+
+	v, err := s.resolves.do(ctx, "guild:"+guildID, func() (interface{}, error) {
+		return s.Guild(guildID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*Guild), nil
+}
+
+// ResolveMember returns members of a guild from the State, or from the
+// API if they aren't cached, see ResolveChannel. The members which failed
+// are in an IDErrors.
+// ctx     : Cancels waiting for the members.
+// guildID : The ID of the guild.
+// userIDs : The IDs of the users.
+func (s *Session) ResolveMember(ctx context.Context, guildID string, userIDs ...string) ([]*Member, error) {
+	members := make([]*Member, len(userIDs))
+	errs := IDErrors{}
+	for i, id := range userIDs {
+		m, err := s.resolveMember(ctx, guildID, id)
+		if err != nil {
+			errs[id] = err
+			continue
+		}
+		members[i] = m
+	}
+	return members, errs.errorOrNil()
+}
+
+func (s *Session) resolveMember(ctx context.Context, guildID, userID string) (*Member, error) {
+	if s.State != nil {
+		if m, err := s.State.Member(guildID, userID); err == nil {
+			return m, nil
+		}
+	}
+
+	v, err := s.resolves.do(ctx, "member:"+guildID+":"+userID, func() (interface{}, error) {
+		m, err := s.GuildMember(guildID, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		m.GuildID = guildID
+		if s.ResolveWriteBack && s.State != nil && s.State.TrackMembers {
+			s.State.MemberAdd(m)
+		}
+		return m, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*Member), nil
+}
+
+// ResolveRole returns roles of a guild from the State, or from the API if
+// they aren't cached, see ResolveChannel. The roles of a guild are fetched
+// together, roles the guild doesn't have fail with ErrStateNotFound. The
+// roles which failed are in an IDErrors.
+// ctx     : Cancels waiting for the roles.
+// guildID : The ID of the guild.
+// roleIDs : The IDs of the roles.
+func (s *Session) ResolveRole(ctx context.Context, guildID string, roleIDs ...string) ([]*Role, error) {
+	roles := make([]*Role, len(roleIDs))
+	errs := IDErrors{}
+	for i, id := range roleIDs {
+		r, err := s.resolveRole(ctx, guildID, id)
+		if err != nil {
+			errs[id] = err
+			continue
+		}
+		roles[i] = r
+	}
+	return roles, errs.errorOrNil()
+}
+
+func (s *Session) resolveRole(ctx context.Context, guildID, roleID string) (*Role, error) {
+	if s.State != nil {
+		if r, err := s.State.Role(guildID, roleID); err == nil {
+			return r, nil
+		}
+	}
+
+	v, err := s.resolves.do(ctx, "roles:"+guildID, func() (interface{}, error) {
+		roles, err := s.GuildRoles(guildID)
+		if err == nil && s.ResolveWriteBack && s.State != nil && s.State.TrackRoles {
+			for _, r := range roles {
+				s.State.RoleAdd(guildID, r)
+			}
+		}
+		return roles, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range v.([]*Role) {
+		if r.ID == roleID {
+			return r, nil
+		}
+	}
+	return nil, ErrStateNotFound
+}
+
+// ResolveMessage returns messages of a channel from the State, or from the
+// API if they aren't cached, see ResolveChannel. The messages which failed
+// are in an IDErrors.
+// ctx        : Cancels waiting for the messages.
+// channelID  : The ID of the channel.
+// messageIDs : The IDs of the messages.
+func (s *Session) ResolveMessage(ctx context.Context, channelID string, messageIDs ...string) ([]*Message, error) {
+	messages := make([]*Message, len(messageIDs))
+	errs := IDErrors{}
+	for i, id := range messageIDs {
+		m, err := s.resolveMessage(ctx, channelID, id)
+		if err != nil {
+			errs[id] = err
+			continue
+		}
+		messages[i] = m
+	}
+	return messages, errs.errorOrNil()
+}
+
+func (s *Session) resolveMessage(ctx context.Context, channelID, messageID string) (*Message, error) {
+	if s.State != nil {
+		if m, err := s.State.Message(channelID, messageID); err == nil {
+			return m, nil
+		}
+	}
+
+	v, err := s.resolves.do(ctx, "message:"+channelID+":"+messageID, func() (interface{}, error) {
+		m, err := s.ChannelMessage(channelID, messageID)
+		if err == nil && s.ResolveWriteBack && s.State != nil && s.State.MaxMessageCount > 0 {
+			s.State.MessageAdd(m)
+		}
+		return m, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*Message), nil
+}
@@ -0,0 +1,85 @@
+package discordgo
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestResolve(t *testing.T) {
+	tr := &routeTransport{routes: map[string]string{
+		"GET " + EndpointGuildMember("1", "3"): `{"user": {"id": "3"}, "roles": ["10"]}`,
+		"GET " + EndpointGuildRoles("1"):       `[{"id": "10"}, {"id": "11"}]`,
+		"GET " + EndpointGuild("4"):            `{"id": "4", "name": "fetched"}`,
+	}}
+	s := newTestSession(tr)
+	s.ResolveWriteBack = true
+	s.State.GuildAdd(&Guild{ID: "1"})
+	s.State.ChannelAdd(&Channel{ID: "2", GuildID: "1"})
+
+	ctx := context.Background()
+	channels, err := s.ResolveChannel(ctx, "2")
+	if err != nil || channels[0].ID != "2" || len(tr.requests) != 0 {
+		t.Fatalf("expected the channel from the state, got %v, %v", err, tr.requests)
+	}
+
+	for i := 0; i < 2; i++ {
+		members, err := s.ResolveMember(ctx, "1", "3")
+		if err != nil || members[0].User.ID != "3" || members[0].GuildID != "1" {
+			t.Fatalf("unexpected members %+v, %v", members, err)
+		}
+	}
+
+	roles, err := s.ResolveRole(ctx, "1", "10", "11", "12")
+	if roles[0].ID != "10" || roles[1].ID != "11" || roles[2] != nil {
+		t.Errorf("unexpected roles %+v", roles)
+	}
+	if errs, ok := err.(IDErrors); !ok || len(errs) != 1 || errs["12"] != ErrStateNotFound {
+		t.Errorf("expected an error for role 12, got %v", err)
+	}
+
+	guilds, err := s.ResolveGuild(ctx, "1", "4")
+	if err != nil || guilds[0].ID != "1" || guilds[1].Name != "fetched" {
+		t.Errorf("unexpected guilds %+v, %v", guilds, err)
+	}
+
+	// The second member and role 11 are found in the state, the unknown
+	// role 12 fetches the roles again, and guild 1 is in the state.
+	if len(tr.requests) != 4 {
+		t.Errorf("expected the fetched entities to be added to the state, got requests %v", tr.requests)
+	}
+}
+
+func TestResolveSingleFlight(t *testing.T) {
+	tr := &routeTransport{
+		routes:  map[string]string{"GET " + EndpointChannel("2"): `{"id": "2", "guild_id": "1"}`},
+		release: make(chan struct{}),
+	}
+	s := newTestSession(tr)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := s.ResolveChannel(ctx, "2"); err == nil {
+		t.Error("expected an error for a cancelled context")
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if channels, err := s.ResolveChannel(context.Background(), "2"); err != nil || channels[0].ID != "2" {
+				t.Errorf("unexpected channels %+v, %v", channels, err)
+			}
+		}()
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	close(tr.release)
+	wg.Wait()
+
+	if n := tr.count(); n != 1 {
+		t.Errorf("expected 1 request, got %d", n)
+	}
+}
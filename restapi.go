@@ -7,11 +7,16 @@
 
 // This file contains functions for interacting with the Discord REST/JSON API
 // at the lowest level.
+//
+// The functions of simple endpoints are generated from restbindings.json
+// into restbindings.go, add new endpoints there.
+//go:generate go run tools/cmd/restbindings/main.go
 
 package discordgo
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -19,7 +24,6 @@ import (
 	_ "image/jpeg" // For JPEG decoding
 	_ "image/png"  // For PNG decoding
 	"io"
-	"io/ioutil"
 	"log"
 	"mime/multipart"
 	"net/http"
@@ -36,11 +40,37 @@ var (
 	ErrStatusOffline           = errors.New("You can't set your Status to offline")
 	ErrVerificationLevelBounds = errors.New("VerificationLevel out of bounds, should be between 0 and 3")
 	ErrPruneDaysBounds         = errors.New("the number of days should be more than or equal to 1")
+	ErrBanDeleteSecondsBounds  = errors.New("the seconds of messages to delete should be between 0 and 604800")
+	ErrNotBanned               = errors.New("the user is not banned from the guild")
 	ErrGuildNoIcon             = errors.New("guild does not have an icon set")
 	ErrGuildNoSplash           = errors.New("guild does not have a splash set")
+	ErrGuildNoBanner           = errors.New("guild does not have a banner set")
+	ErrUserAccountsUnsupported = errors.New("the endpoint is only available to user accounts, not to bots or OAuth2 tokens")
+	ErrBearerTokenRequired     = errors.New("the endpoint requires an OAuth2 Bearer token")
 	ErrUnauthorized            = errors.New("HTTP request was unauthorized. This could be because the provided token was not a bot token. Please add \"Bot \" to the start of your token. https://discord.com/developers/docs/reference#authentication-example-bot-token-authorization-header")
 )
 
+// userAccountOnly returns ErrUserAccountsUnsupported if the session
+// authenticates as a bot or with an OAuth2 token, which Discord refuses for
+// the endpoints of user accounts.
+func (s *Session) userAccountOnly() error {
+	token := s.token()
+	if strings.HasPrefix(token, "Bot ") || strings.HasPrefix(token, "Bearer ") {
+		return ErrUserAccountsUnsupported
+	}
+	return nil
+}
+
+// bearerTokenOnly returns ErrBearerTokenRequired if the session doesn't
+// authenticate with an OAuth2 token, which Discord requires for the
+// endpoints acting on behalf of a user.
+func (s *Session) bearerTokenOnly() error {
+	if !strings.HasPrefix(s.token(), "Bearer ") {
+		return ErrBearerTokenRequired
+	}
+	return nil
+}
+
 // Request is the same as RequestWithBucketID but the bucket id is the same as the urlStr
 func (s *Session) Request(method, urlStr string, data interface{}) (response []byte, err error) {
 	return s.RequestWithBucketID(method, urlStr, data, strings.SplitN(urlStr, "?", 2)[0])
@@ -59,14 +89,148 @@ func (s *Session) RequestWithBucketID(method, urlStr string, data interface{}, b
 	return s.request(method, urlStr, "application/json", body, bucketID, 0)
 }
 
+// RequestWithPriority is the same as RequestWithBucketID but the request is
+// sent with the priority in its bucket instead of the priority of the
+// RequestPriorityFunc, eg. RequestPriorityLow for requests which can wait.
+func (s *Session) RequestWithPriority(method, urlStr string, data interface{}, bucketID string, priority RequestPriority) (response []byte, err error) {
+	var body []byte
+	if data != nil {
+		body, err = json.Marshal(data)
+		if err != nil {
+			return
+		}
+	}
+
+	return s.requestWithPriority(method, urlStr, "application/json", body, bucketID, priority, 0)
+}
+
+// A RequestOption configures a request of Do.
+type RequestOption func(*requestOptions)
+
+type requestOptions struct {
+	bucketID string
+	priority *RequestPriority
+}
+
+// WithBucketID sets the rate limit bucket of a request, eg. the URL without
+// the IDs which aren't major parameters. It defaults to the URL without
+// its query.
+func WithBucketID(bucketID string) RequestOption {
+	return func(o *requestOptions) {
+		o.bucketID = bucketID
+	}
+}
+
+// WithPriority sets the priority of a request in its bucket, see
+// RequestWithPriority.
+func WithPriority(priority RequestPriority) RequestOption {
+	return func(o *requestOptions) {
+		o.priority = &priority
+	}
+}
+
+// Do sends a request to an endpoint the library has no function for yet,
+// with the authentication, rate limiting, retries and RESTError of the
+// other requests. The data is sent as JSON if it isn't nil. URLs without
+// a scheme are relative to EndpointAPI, eg. "guilds/1/onboarding".
+//
+// When ctx is done before the response arrives, Do returns its error and
+// the request completes in the background, so the rate limits stay known.
+// ctx     : Cancels waiting for the response.
+// method  : The HTTP method.
+// urlStr  : The URL of the endpoint.
+// data    : The body of the request, or nil.
+// options : The bucket and priority of the request.
+func (s *Session) Do(ctx context.Context, method, urlStr string, data interface{}, options ...RequestOption) (response []byte, err error) {
+	if !strings.HasPrefix(urlStr, "https://") && !strings.HasPrefix(urlStr, "http://") {
+		urlStr = EndpointAPI + strings.TrimPrefix(urlStr, "/")
+	}
+
+	o := &requestOptions{}
+	for _, option := range options {
+		option(o)
+	}
+	priority := s.requestPriority(method, urlStr)
+	if o.priority != nil {
+		priority = *o.priority
+	}
+
+	var body []byte
+	if data != nil {
+		body, err = json.Marshal(data)
+		if err != nil {
+			return
+		}
+	}
+
+	if err = ctx.Err(); err != nil {
+		return
+	}
+
+	type result struct {
+		response []byte
+		err      error
+	}
+	done := make(chan result, 1)
+	go func() {
+		bucket, err := s.lockRequestBucket(urlStr, o.bucketID, priority)
+		if err == nil && ctx.Err() != nil {
+			// The request was cancelled while it waited for the bucket,
+			// which gets back the request it didn't send.
+			bucket.Remaining++
+			bucket.Release(nil)
+			err = ctx.Err()
+		}
+		if err != nil {
+			done <- result{nil, err}
+			return
+		}
+
+		response, err := s.RequestWithLockedBucket(method, urlStr, "application/json", body, bucket, 0)
+		done <- result{response, err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.response, r.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// requestPriority returns the priority of a request.
+func (s *Session) requestPriority(method, urlStr string) RequestPriority {
+	if s.RequestPriorityFunc != nil {
+		return s.RequestPriorityFunc(method, urlStr)
+	}
+	return DefaultRequestPriority(method, urlStr)
+}
+
 // request makes a (GET/POST/...) Requests to Discord REST API.
 // Sequence is the sequence number, if it fails with a 502 it will
 // retry with sequence+1 until it either succeeds or sequence >= session.MaxRestRetries
 func (s *Session) request(method, urlStr, contentType string, b []byte, bucketID string, sequence int) (response []byte, err error) {
+	return s.requestWithPriority(method, urlStr, contentType, b, bucketID, s.requestPriority(method, urlStr), sequence)
+}
+
+func (s *Session) requestWithPriority(method, urlStr, contentType string, b []byte, bucketID string, priority RequestPriority, sequence int) (response []byte, err error) {
+	bucket, err := s.lockRequestBucket(urlStr, bucketID, priority)
+	if err != nil {
+		return
+	}
+	return s.RequestWithLockedBucket(method, urlStr, contentType, b, bucket, sequence)
+}
+
+// lockRequestBucket locks the bucket of a request, the URL without its
+// query if bucketID is empty, unless the invalid request limit is reached.
+func (s *Session) lockRequestBucket(urlStr, bucketID string, priority RequestPriority) (*Bucket, error) {
 	if bucketID == "" {
 		bucketID = strings.SplitN(urlStr, "?", 2)[0]
 	}
-	return s.RequestWithLockedBucket(method, urlStr, contentType, b, s.Ratelimiter.LockBucket(bucketID), sequence)
+	if !s.invalidRequests.allowed(s.invalidRequestThreshold()) {
+		return nil, ErrInvalidRequestLimit
+	}
+	return s.Ratelimiter.LockBucketPriority(bucketID, priority), nil
 }
 
 // RequestWithLockedBucket makes a request using a bucket that's already been locked
@@ -76,6 +240,9 @@ func (s *Session) RequestWithLockedBucket(method, urlStr, contentType string, b
 		log.Printf("API REQUEST  PAYLOAD :: [%s]\n", string(b))
 	}
 
+	// Retries keep the priority of the request.
+	priority := bucket.priority
+
 	req, err := http.NewRequest(method, urlStr, bytes.NewBuffer(b))
 	if err != nil {
 		bucket.Release(nil)
@@ -84,8 +251,8 @@ func (s *Session) RequestWithLockedBucket(method, urlStr, contentType string, b
 
 	// Not used on initial login..
 	// TODO: Verify if a login, otherwise complain about no-token
-	if s.Token != "" {
-		req.Header.Set("authorization", s.Token)
+	if token := s.token(); token != "" {
+		req.Header.Set("authorization", token)
 	}
 
 	// Discord's API returns a 400 Bad Request is Content-Type is set, but the
@@ -96,6 +263,7 @@ func (s *Session) RequestWithLockedBucket(method, urlStr, contentType string, b
 
 	// TODO: Make a configurable static variable.
 	req.Header.Set("User-Agent", s.UserAgent)
+	req.Header.Set("Accept-Encoding", "gzip, deflate")
 
 	if s.Debug {
 		for k, v := range req.Header {
@@ -119,8 +287,9 @@ func (s *Session) RequestWithLockedBucket(method, urlStr, contentType string, b
 	if err != nil {
 		return
 	}
+	s.countInvalidRequest(resp)
 
-	response, err = ioutil.ReadAll(resp.Body)
+	response, err = s.readResponse(urlStr, resp)
 	if err != nil {
 		return
 	}
@@ -143,7 +312,7 @@ func (s *Session) RequestWithLockedBucket(method, urlStr, contentType string, b
 		if sequence < s.MaxRestRetries {
 
 			s.log(LogInformational, "%s Failed (%s), Retrying...", urlStr, resp.Status)
-			response, err = s.RequestWithLockedBucket(method, urlStr, contentType, b, s.Ratelimiter.LockBucketObject(bucket), sequence+1)
+			response, err = s.RequestWithLockedBucket(method, urlStr, contentType, b, s.Ratelimiter.LockBucketObjectPriority(bucket, priority), sequence+1)
 		} else {
 			err = fmt.Errorf("Exceeded Max retries HTTP %s, %s", resp.Status, response)
 		}
@@ -154,16 +323,22 @@ func (s *Session) RequestWithLockedBucket(method, urlStr, contentType string, b
 			s.log(LogError, "rate limit unmarshal error, %s", err)
 			return
 		}
-		s.log(LogInformational, "Rate Limiting %s, retry in %d", urlStr, rl.RetryAfter)
+		rl.Scope = RateLimitScope(resp.Header.Get("X-RateLimit-Scope"))
+		s.log(LogInformational, "Rate Limiting %s (%s), retry in %d", urlStr, rl.Scope, rl.RetryAfter)
 		s.handleEvent(rateLimitEventType, RateLimit{TooManyRequests: &rl, URL: urlStr})
 
+		if !s.invalidRequests.allowed(s.invalidRequestThreshold()) {
+			err = ErrInvalidRequestLimit
+			return
+		}
+
 		time.Sleep(rl.RetryAfter * time.Millisecond)
 		// we can make the above smarter
 		// this method can cause longer delays than required
 
-		response, err = s.RequestWithLockedBucket(method, urlStr, contentType, b, s.Ratelimiter.LockBucketObject(bucket), sequence)
+		response, err = s.RequestWithLockedBucket(method, urlStr, contentType, b, s.Ratelimiter.LockBucketObjectPriority(bucket, priority), sequence)
 	case http.StatusUnauthorized:
-		if strings.Index(s.Token, "Bot ") != 0 {
+		if strings.Index(s.token(), "Bot ") != 0 {
 			s.log(LogInformational, ErrUnauthorized.Error())
 			err = ErrUnauthorized
 		}
@@ -212,7 +387,7 @@ func (s *Session) Login(email, password string) (err error) {
 		MFA   bool   `json:"mfa"`
 	}{}
 
-	err = unmarshal(response, &temp)
+	err = s.unmarshal(response, &temp)
 	if err != nil {
 		return
 	}
@@ -240,7 +415,7 @@ func (s *Session) Register(username string) (token string, err error) {
 		Token string `json:"token"`
 	}{}
 
-	err = unmarshal(response, &temp)
+	err = s.unmarshal(response, &temp)
 	if err != nil {
 		return
 	}
@@ -282,7 +457,7 @@ func (s *Session) User(userID string) (st *User, err error) {
 		return
 	}
 
-	err = unmarshal(body, &st)
+	err = s.unmarshal(body, &st)
 	return
 }
 
@@ -314,7 +489,7 @@ func (s *Session) UserUpdate(email, password, username, avatar, newPassword stri
 
 	// NOTE: Avatar must be either the hash/id of existing Avatar or
 	// data:image/png;base64,BASE64_STRING_OF_NEW_AVATAR_PNG
-	// to set a new avatar.
+	// to set a new avatar, see ImageDataURI.
 	// If left blank, avatar will be set to null/blank
 
 	data := struct {
@@ -330,25 +505,38 @@ func (s *Session) UserUpdate(email, password, username, avatar, newPassword stri
 		return
 	}
 
-	err = unmarshal(body, &st)
+	err = s.unmarshal(body, &st)
 	return
 }
 
 // UserSettings returns the settings for a given user
+//
+// Deprecated: only user accounts have settings, for bots and OAuth2 tokens
+// ErrUserAccountsUnsupported is returned.
 func (s *Session) UserSettings() (st *Settings, err error) {
+	if err = s.userAccountOnly(); err != nil {
+		return
+	}
 
 	body, err := s.RequestWithBucketID("GET", EndpointUserSettings("@me"), nil, EndpointUserSettings(""))
 	if err != nil {
 		return
 	}
 
-	err = unmarshal(body, &st)
+	err = s.unmarshal(body, &st)
 	return
 }
 
 // UserUpdateStatus update the user status
 // status   : The new status (Actual valid status are 'online','idle','dnd','invisible')
+//
+// Deprecated: only user accounts have settings, for bots and OAuth2 tokens
+// ErrUserAccountsUnsupported is returned. Bots set their status with
+// UpdateStatusComplex.
 func (s *Session) UserUpdateStatus(status Status) (st *Settings, err error) {
+	if err = s.userAccountOnly(); err != nil {
+		return
+	}
 	if status == StatusOffline {
 		err = ErrStatusOffline
 		return
@@ -363,18 +551,19 @@ func (s *Session) UserUpdateStatus(status Status) (st *Settings, err error) {
 		return
 	}
 
-	err = unmarshal(body, &st)
+	err = s.unmarshal(body, &st)
 	return
 }
 
-// UserConnections returns the user's connections
+// UserConnections returns the user's connections.
+// For OAuth2 applications this requires a bearer token with the connections scope.
 func (s *Session) UserConnections() (conn []*UserConnection, err error) {
 	response, err := s.RequestWithBucketID("GET", EndpointUserConnections("@me"), nil, EndpointUserConnections("@me"))
 	if err != nil {
 		return nil, err
 	}
 
-	err = unmarshal(response, &conn)
+	err = s.unmarshal(response, &conn)
 	if err != nil {
 		return
 	}
@@ -391,7 +580,7 @@ func (s *Session) UserChannels() (st []*Channel, err error) {
 		return
 	}
 
-	err = unmarshal(body, &st)
+	err = s.unmarshal(body, &st)
 	return
 }
 
@@ -408,7 +597,7 @@ func (s *Session) UserChannelCreate(recipientID string) (st *Channel, err error)
 		return
 	}
 
-	err = unmarshal(body, &st)
+	err = s.unmarshal(body, &st)
 	return
 }
 
@@ -417,6 +606,15 @@ func (s *Session) UserChannelCreate(recipientID string) (st *Channel, err error)
 // beforeID  : If provided all guilds returned will be before given ID.
 // afterID   : If provided all guilds returned will be after given ID.
 func (s *Session) UserGuilds(limit int, beforeID, afterID string) (st []*UserGuild, err error) {
+	return s.UserGuildsComplex(limit, beforeID, afterID, false)
+}
+
+// UserGuildsComplex returns an array of UserGuild structures for all guilds.
+// limit      : The number guilds that can be returned. (max 200)
+// beforeID   : If provided all guilds returned will be before given ID.
+// afterID    : If provided all guilds returned will be after given ID.
+// withCounts : Whether to include the approximate member and presence counts of the guilds.
+func (s *Session) UserGuildsComplex(limit int, beforeID, afterID string, withCounts bool) (st []*UserGuild, err error) {
 
 	v := url.Values{}
 
@@ -429,6 +627,9 @@ func (s *Session) UserGuilds(limit int, beforeID, afterID string) (st []*UserGui
 	if beforeID != "" {
 		v.Set("before", beforeID)
 	}
+	if withCounts {
+		v.Set("with_counts", "true")
+	}
 
 	uri := EndpointUserGuilds("@me")
 
@@ -441,21 +642,105 @@ func (s *Session) UserGuilds(limit int, beforeID, afterID string) (st []*UserGui
 		return
 	}
 
-	err = unmarshal(body, &st)
+	err = s.unmarshal(body, &st)
+	return
+}
+
+// A UserGuildsIterator pages through all guilds of the current user,
+// see Session.UserGuildsIterator.
+type UserGuildsIterator struct {
+	session    *Session
+	withCounts bool
+
+	page  []*UserGuild
+	guild *UserGuild
+	done  bool
+	err   error
+}
+
+// UserGuildsIterator returns an iterator over all guilds of the current
+// user, which fetches them in pages of 200 guilds as they are needed.
+//   it := s.UserGuildsIterator(false)
+//   for it.Next() {
+//       fmt.Println(it.Guild().Name)
+//   }
+//   if it.Err() != nil { ... }
+// withCounts : Whether to include the approximate member and presence counts of the guilds.
+func (s *Session) UserGuildsIterator(withCounts bool) *UserGuildsIterator {
+	return &UserGuildsIterator{session: s, withCounts: withCounts}
+}
+
+// Next advances the iterator to the next guild, it returns false when
+// there are no more guilds or an error occurred.
+func (it *UserGuildsIterator) Next() bool {
+	if len(it.page) == 0 && !it.done {
+		afterID := ""
+		if it.guild != nil {
+			afterID = it.guild.ID
+		}
+
+		it.page, it.err = it.session.UserGuildsComplex(200, "", afterID, it.withCounts)
+		if it.err != nil || len(it.page) < 200 {
+			it.done = true
+		}
+	}
+
+	if len(it.page) == 0 {
+		it.guild = nil
+		return false
+	}
+
+	it.guild, it.page = it.page[0], it.page[1:]
+	return true
+}
+
+// Guild returns the current guild of the iterator.
+func (it *UserGuildsIterator) Guild() *UserGuild {
+	return it.guild
+}
+
+// Err returns the error which stopped the iterator, if any.
+func (it *UserGuildsIterator) Err() error {
+	return it.err
+}
+
+// UserGuildMember returns the member of the current user in a guild.
+// This requires an OAuth2 bearer token with the guilds.members.read scope.
+// guildID   : The ID of a Guild.
+func (s *Session) UserGuildMember(guildID string) (st *Member, err error) {
+
+	body, err := s.RequestWithBucketID("GET", EndpointUserGuildMember("@me", guildID), nil, EndpointUserGuildMember("", guildID))
+	if err != nil {
+		return
+	}
+
+	err = s.unmarshal(body, &st)
+	if err != nil {
+		return
+	}
+
+	// The guild ID is not contained in the response.
+	st.GuildID = guildID
 	return
 }
 
 // UserGuildSettingsEdit Edits the users notification settings for a guild
 // guildID   : The ID of the guild to edit the settings on
 // settings  : The settings to update
+//
+// Deprecated: only user accounts have guild settings, for bots and OAuth2
+// tokens ErrUserAccountsUnsupported is returned.
 func (s *Session) UserGuildSettingsEdit(guildID string, settings *UserGuildSettingsEdit) (st *UserGuildSettings, err error) {
+	if err = s.userAccountOnly(); err != nil {
+		return
+	}
 
 	body, err := s.RequestWithBucketID("PATCH", EndpointUserGuildSettings("@me", guildID), settings, EndpointUserGuildSettings("", guildID))
 	if err != nil {
 		return
 	}
 
-	err = unmarshal(body, &st)
+	err = s.unmarshal(body, &st)
 	return
 }
 
@@ -586,7 +871,7 @@ func (s *Session) Guild(guildID string) (st *Guild, err error) {
 		return
 	}
 
-	err = unmarshal(body, &st)
+	err = s.unmarshal(body, &st)
 	return
 }
 
@@ -603,7 +888,7 @@ func (s *Session) GuildCreate(name string) (st *Guild, err error) {
 		return
 	}
 
-	err = unmarshal(body, &st)
+	err = s.unmarshal(body, &st)
 	return
 }
 
@@ -645,7 +930,7 @@ func (s *Session) GuildEdit(guildID string, g GuildParams) (st *Guild, err error
 		return
 	}
 
-	err = unmarshal(body, &st)
+	err = s.unmarshal(body, &st)
 	return
 }
 
@@ -658,7 +943,7 @@ func (s *Session) GuildDelete(guildID string) (st *Guild, err error) {
 		return
 	}
 
-	err = unmarshal(body, &st)
+	err = s.unmarshal(body, &st)
 	return
 }
 
@@ -680,15 +965,34 @@ func (s *Session) GuildBans(guildID string) (st []*GuildBan, err error) {
 		return
 	}
 
-	err = unmarshal(body, &st)
+	err = s.unmarshal(body, &st)
 
 	return
 }
 
+// GuildBan returns the ban of a user from a guild, or ErrNotBanned if the
+// user isn't banned.
+// guildID   : The ID of a Guild.
+// userID    : The ID of a User
+func (s *Session) GuildBan(guildID, userID string) (st *GuildBan, err error) {
+
+	body, err := s.RequestWithBucketID("GET", EndpointGuildBan(guildID, userID), nil, EndpointGuildBan(guildID, ""))
+	if err != nil {
+		if restErr, ok := err.(*RESTError); ok && restErr.Message != nil && restErr.Message.Code == ErrCodeUnknownBan {
+			err = ErrNotBanned
+		}
+		return
+	}
+
+	err = s.unmarshal(body, &st)
+	return
+}
+
 // GuildBanCreate bans the given user from the given guild.
 // guildID   : The ID of a Guild.
 // userID    : The ID of a User
 // days      : The number of days of previous comments to delete.
+// Deprecated: use GuildBanCreateComplex, Discord deletes the messages by seconds.
 func (s *Session) GuildBanCreate(guildID, userID string, days int) (err error) {
 	return s.GuildBanCreateWithReason(guildID, userID, "", days)
 }
@@ -698,23 +1002,32 @@ func (s *Session) GuildBanCreate(guildID, userID string, days int) (err error) {
 // userID    : The ID of a User
 // reason    : The reason for this ban
 // days      : The number of days of previous comments to delete.
+// Deprecated: use GuildBanCreateComplex, Discord deletes the messages by seconds.
 func (s *Session) GuildBanCreateWithReason(guildID, userID, reason string, days int) (err error) {
+	return s.GuildBanCreateComplex(guildID, userID, &BanCreateData{
+		DeleteMessageSeconds: days * 24 * 60 * 60,
+		Reason:               reason,
+	})
+}
 
-	uri := EndpointGuildBan(guildID, userID)
-
-	queryParams := url.Values{}
-	if days > 0 {
-		queryParams.Set("delete-message-days", strconv.Itoa(days))
+// GuildBanCreateComplex bans the given user from the given guild.
+// guildID   : The ID of a Guild.
+// userID    : The ID of a User
+// data      : The options of the ban, nil for none.
+func (s *Session) GuildBanCreateComplex(guildID, userID string, data *BanCreateData) (err error) {
+	if data == nil {
+		data = &BanCreateData{}
 	}
-	if reason != "" {
-		queryParams.Set("reason", reason)
+	if data.DeleteMessageSeconds < 0 || data.DeleteMessageSeconds > 604800 {
+		return ErrBanDeleteSecondsBounds
 	}
 
-	if len(queryParams) > 0 {
-		uri += "?" + queryParams.Encode()
+	uri := EndpointGuildBan(guildID, userID)
+	if data.Reason != "" {
+		uri += "?reason=" + url.QueryEscape(data.Reason)
 	}
 
-	_, err = s.RequestWithBucketID("PUT", uri, nil, EndpointGuildBan(guildID, ""))
+	_, err = s.RequestWithBucketID("PUT", uri, data, EndpointGuildBan(guildID, ""))
 	return
 }
 
@@ -754,7 +1067,7 @@ func (s *Session) GuildMembers(guildID string, after string, limit int) (st []*M
 		return
 	}
 
-	err = unmarshal(body, &st)
+	err = s.unmarshal(body, &st)
 	return
 }
 
@@ -768,7 +1081,7 @@ func (s *Session) GuildMember(guildID, userID string) (st *Member, err error) {
 		return
 	}
 
-	err = unmarshal(body, &st)
+	err = s.unmarshal(body, &st)
 	return
 }
 
@@ -782,20 +1095,31 @@ func (s *Session) GuildMember(guildID, userID string) (st *Member, err error) {
 //  deaf          : If the user is deafened.
 func (s *Session) GuildMemberAdd(accessToken, guildID, userID, nick string, roles []string, mute, deaf bool) (err error) {
 
-	data := struct {
-		AccessToken string   `json:"access_token"`
-		Nick        string   `json:"nick,omitempty"`
-		Roles       []string `json:"roles,omitempty"`
-		Mute        bool     `json:"mute,omitempty"`
-		Deaf        bool     `json:"deaf,omitempty"`
-	}{accessToken, nick, roles, mute, deaf}
+	_, err = s.GuildMemberAddComplex(guildID, userID, &GuildMemberAddParams{
+		AccessToken: accessToken,
+		Nick:        nick,
+		Roles:       roles,
+		Mute:        mute,
+		Deaf:        deaf,
+	})
+	return
+}
 
-	_, err = s.RequestWithBucketID("PUT", EndpointGuildMember(guildID, userID), data, EndpointGuildMember(guildID, ""))
-	if err != nil {
-		return err
+// GuildMemberAddComplex adds a user who authorized the application with the
+// guilds.join scope to the guild, and returns the new member. If the user
+// is already a member of the guild nothing is changed and the member is nil.
+//  guildID : The ID of a Guild.
+//  userID  : The ID of a User.
+//  data    : The access token and the settings of the member.
+func (s *Session) GuildMemberAddComplex(guildID, userID string, data *GuildMemberAddParams) (st *Member, err error) {
+
+	body, err := s.RequestWithBucketID("PUT", EndpointGuildMember(guildID, userID), data, EndpointGuildMember(guildID, ""))
+	if err != nil || len(body) == 0 {
+		return
 	}
 
-	return err
+	err = s.unmarshal(body, &st)
+	return
 }
 
 // GuildMemberDelete removes the given user from the given guild.
@@ -857,6 +1181,16 @@ func (s *Session) GuildMemberMove(guildID string, userID string, channelID *stri
 // nickname  : The nickname of the member, "" will reset their nickname
 func (s *Session) GuildMemberNickname(guildID, userID, nickname string) (err error) {
 
+	return s.GuildMemberNicknameWithReason(guildID, userID, nickname, "")
+}
+
+// GuildMemberNicknameWithReason updates the nickname of a guild member
+// guildID   : The ID of a guild
+// userID    : The ID of a user or "@me" which is a shortcut of the current user ID
+// nickname  : The nickname of the member, "" will reset their nickname
+// reason    : The reason for the change, shown in the audit log
+func (s *Session) GuildMemberNicknameWithReason(guildID, userID, nickname, reason string) (err error) {
+
 	data := struct {
 		Nick string `json:"nick"`
 	}{nickname}
@@ -865,6 +1199,25 @@ func (s *Session) GuildMemberNickname(guildID, userID, nickname string) (err err
 		userID += "/nick"
 	}
 
+	uri := EndpointGuildMember(guildID, userID)
+	if reason != "" {
+		uri += "?reason=" + url.QueryEscape(reason)
+	}
+
+	_, err = s.RequestWithBucketID("PATCH", uri, data, EndpointGuildMember(guildID, ""))
+	return
+}
+
+// GuildMemberTimeout times a guild member out, they can't send messages,
+// react or join voice channels until the timeout ends.
+//  guildID   : The ID of a Guild.
+//  userID    : The ID of a User.
+//  until     : When the timeout ends, at most 28 days from now, nil to remove it.
+func (s *Session) GuildMemberTimeout(guildID, userID string, until *time.Time) (err error) {
+	data := struct {
+		CommunicationDisabledUntil *time.Time `json:"communication_disabled_until"`
+	}{until}
+
 	_, err = s.RequestWithBucketID("PATCH", EndpointGuildMember(guildID, userID), data, EndpointGuildMember(guildID, ""))
 	return
 }
@@ -927,7 +1280,7 @@ func (s *Session) GuildChannels(guildID string) (st []*Channel, err error) {
 		return
 	}
 
-	err = unmarshal(body, &st)
+	err = s.unmarshal(body, &st)
 
 	return
 }
@@ -944,6 +1297,18 @@ type GuildChannelCreateData struct {
 	PermissionOverwrites []*PermissionOverwrite `json:"permission_overwrites,omitempty"`
 	ParentID             string                 `json:"parent_id,omitempty"`
 	NSFW                 bool                   `json:"nsfw,omitempty"`
+
+	// Voice channels only, an empty RTCRegion selects the region automatically.
+	RTCRegion        string           `json:"rtc_region,omitempty"`
+	VideoQualityMode VideoQualityMode `json:"video_quality_mode,omitempty"`
+
+	// Defaults of new threads, and of the posts of forum channels.
+	DefaultAutoArchiveDuration    int                   `json:"default_auto_archive_duration,omitempty"`
+	DefaultThreadRateLimitPerUser int                   `json:"default_thread_rate_limit_per_user,omitempty"`
+	AvailableTags                 []*ForumTag           `json:"available_tags,omitempty"`
+	DefaultReactionEmoji          *ForumDefaultReaction `json:"default_reaction_emoji,omitempty"`
+	DefaultSortOrder              *ForumSortOrder       `json:"default_sort_order,omitempty"`
+	DefaultForumLayout            ForumLayout           `json:"default_forum_layout,omitempty"`
 }
 
 // GuildChannelCreateComplex creates a new channel in the given guild
@@ -955,7 +1320,7 @@ func (s *Session) GuildChannelCreateComplex(guildID string, data GuildChannelCre
 		return
 	}
 
-	err = unmarshal(body, &st)
+	err = s.unmarshal(body, &st)
 	return
 }
 
@@ -997,7 +1362,7 @@ func (s *Session) GuildInvites(guildID string) (st []*Invite, err error) {
 		return
 	}
 
-	err = unmarshal(body, &st)
+	err = s.unmarshal(body, &st)
 	return
 }
 
@@ -1010,7 +1375,7 @@ func (s *Session) GuildRoles(guildID string) (st []*Role, err error) {
 		return
 	}
 
-	err = unmarshal(body, &st)
+	err = s.unmarshal(body, &st)
 
 	return // TODO return pointer
 }
@@ -1024,7 +1389,7 @@ func (s *Session) GuildRoleCreate(guildID string) (st *Role, err error) {
 		return
 	}
 
-	err = unmarshal(body, &st)
+	err = s.unmarshal(body, &st)
 
 	return
 }
@@ -1058,7 +1423,7 @@ func (s *Session) GuildRoleEdit(guildID, roleID, name string, color int, hoist b
 		return
 	}
 
-	err = unmarshal(body, &st)
+	err = s.unmarshal(body, &st)
 
 	return
 }
@@ -1073,7 +1438,7 @@ func (s *Session) GuildRoleReorder(guildID string, roles []*Role) (st []*Role, e
 		return
 	}
 
-	err = unmarshal(body, &st)
+	err = s.unmarshal(body, &st)
 
 	return
 }
@@ -1110,7 +1475,7 @@ func (s *Session) GuildPruneCount(guildID string, days uint32) (count uint32, er
 		return
 	}
 
-	err = unmarshal(body, &p)
+	err = s.unmarshal(body, &p)
 	if err != nil {
 		return
 	}
@@ -1146,7 +1511,7 @@ func (s *Session) GuildPrune(guildID string, days uint32) (count uint32, err err
 		return
 	}
 
-	err = unmarshal(body, &p)
+	err = s.unmarshal(body, &p)
 	if err != nil {
 		return
 	}
@@ -1165,7 +1530,7 @@ func (s *Session) GuildIntegrations(guildID string) (st []*Integration, err erro
 		return
 	}
 
-	err = unmarshal(body, &st)
+	err = s.unmarshal(body, &st)
 
 	return
 }
@@ -1275,7 +1640,7 @@ func (s *Session) GuildEmbed(guildID string) (st *GuildEmbed, err error) {
 		return
 	}
 
-	err = unmarshal(body, &st)
+	err = s.unmarshal(body, &st)
 	return
 }
 
@@ -1289,6 +1654,33 @@ func (s *Session) GuildEmbedEdit(guildID string, enabled bool, channelID string)
 	return
 }
 
+// GuildMembershipScreening returns the membership screening form of a Guild.
+// guildID   : The ID of a Guild.
+func (s *Session) GuildMembershipScreening(guildID string) (st *MembershipScreening, err error) {
+
+	body, err := s.RequestWithBucketID("GET", EndpointGuildScreening(guildID), nil, EndpointGuildScreening(guildID))
+	if err != nil {
+		return
+	}
+
+	err = s.unmarshal(body, &st)
+	return
+}
+
+// GuildMembershipScreeningEdit edits the membership screening form of a Guild.
+// guildID   : The ID of a Guild.
+// data      : The changes of the form.
+func (s *Session) GuildMembershipScreeningEdit(guildID string, data *MembershipScreeningEdit) (st *MembershipScreening, err error) {
+
+	body, err := s.RequestWithBucketID("PATCH", EndpointGuildScreening(guildID), data, EndpointGuildScreening(guildID))
+	if err != nil {
+		return
+	}
+
+	err = s.unmarshal(body, &st)
+	return
+}
+
 // GuildAuditLog returns the audit log for a Guild.
 // guildID     : The ID of a Guild.
 // userID      : If provided the log will be filtered for the given ID.
@@ -1321,7 +1713,7 @@ func (s *Session) GuildAuditLog(guildID, userID, beforeID string, actionType, li
 		return
 	}
 
-	err = unmarshal(body, &st)
+	err = s.unmarshal(body, &st)
 	return
 }
 
@@ -1334,16 +1726,19 @@ func (s *Session) GuildEmojis(guildID string) (emoji []*Emoji, err error) {
 		return
 	}
 
-	err = unmarshal(body, &emoji)
+	err = s.unmarshal(body, &emoji)
 	return
 }
 
 // GuildEmojiCreate creates a new emoji
 // guildID : The ID of a Guild.
 // name    : The Name of the Emoji.
-// image   : The base64 encoded emoji image, has to be smaller than 256KB.
+// image   : The emoji image as a base64 data URI, has to be smaller than 256KB, see ImageDataURI.
 // roles   : The roles for which this emoji will be whitelisted, can be nil.
 func (s *Session) GuildEmojiCreate(guildID, name, image string, roles []string) (emoji *Emoji, err error) {
+	if err = validateEmoji(name, image); err != nil {
+		return
+	}
 
 	data := struct {
 		Name  string   `json:"name"`
@@ -1356,7 +1751,7 @@ func (s *Session) GuildEmojiCreate(guildID, name, image string, roles []string)
 		return
 	}
 
-	err = unmarshal(body, &emoji)
+	err = s.unmarshal(body, &emoji)
 	return
 }
 
@@ -1377,7 +1772,7 @@ func (s *Session) GuildEmojiEdit(guildID, emojiID, name string, roles []string)
 		return
 	}
 
-	err = unmarshal(body, &emoji)
+	err = s.unmarshal(body, &emoji)
 	return
 }
 
@@ -1402,7 +1797,7 @@ func (s *Session) Channel(channelID string) (st *Channel, err error) {
 		return
 	}
 
-	err = unmarshal(body, &st)
+	err = s.unmarshal(body, &st)
 	return
 }
 
@@ -1415,7 +1810,8 @@ func (s *Session) ChannelEdit(channelID, name string) (*Channel, error) {
 	})
 }
 
-// ChannelEditComplex edits an existing channel, replacing the parameters entirely with ChannelEdit struct
+// ChannelEditComplex edits an existing channel, only the fields set in the
+// ChannelEdit struct are changed. The updated channel is returned.
 // channelID  : The ID of a Channel
 // data          : The channel struct to send
 func (s *Session) ChannelEditComplex(channelID string, data *ChannelEdit) (st *Channel, err error) {
@@ -1424,7 +1820,25 @@ func (s *Session) ChannelEditComplex(channelID string, data *ChannelEdit) (st *C
 		return
 	}
 
-	err = unmarshal(body, &st)
+	err = s.unmarshal(body, &st)
+	return
+}
+
+// ChannelSlowmodeSet sets the slowmode of the given channel.
+// channelID  : The ID of a Channel
+// seconds    : The time users have to wait between messages (0-21600), 0 disables slowmode
+func (s *Session) ChannelSlowmodeSet(channelID string, seconds int) (st *Channel, err error) {
+
+	data := struct {
+		RateLimitPerUser int `json:"rate_limit_per_user"`
+	}{seconds}
+
+	body, err := s.RequestWithBucketID("PATCH", EndpointChannel(channelID), data, EndpointChannel(channelID))
+	if err != nil {
+		return
+	}
+
+	err = s.unmarshal(body, &st)
 	return
 }
 
@@ -1437,7 +1851,7 @@ func (s *Session) ChannelDelete(channelID string) (st *Channel, err error) {
 		return
 	}
 
-	err = unmarshal(body, &st)
+	err = s.unmarshal(body, &st)
 	return
 }
 
@@ -1483,7 +1897,7 @@ func (s *Session) ChannelMessages(channelID string, limit int, beforeID, afterID
 		return
 	}
 
-	err = unmarshal(body, &st)
+	err = s.unmarshal(body, &st)
 	return
 }
 
@@ -1497,7 +1911,7 @@ func (s *Session) ChannelMessage(channelID, messageID string) (st *Message, err
 		return
 	}
 
-	err = unmarshal(response, &st)
+	err = s.unmarshal(response, &st)
 	return
 }
 
@@ -1512,7 +1926,7 @@ func (s *Session) ChannelMessageAck(channelID, messageID, lastToken string) (st
 		return
 	}
 
-	err = unmarshal(body, &st)
+	err = s.unmarshal(body, &st)
 	return
 }
 
@@ -1606,7 +2020,7 @@ func (s *Session) ChannelMessageSendComplex(channelID string, data *MessageSend)
 		return
 	}
 
-	err = unmarshal(response, &st)
+	err = s.unmarshal(response, &st)
 	return
 }
 
@@ -1661,7 +2075,7 @@ func (s *Session) ChannelMessageEditComplex(m *MessageEdit) (st *Message, err er
 		return
 	}
 
-	err = unmarshal(response, &st)
+	err = s.unmarshal(response, &st)
 	return
 }
 
@@ -1737,7 +2151,7 @@ func (s *Session) ChannelMessagesPinned(channelID string) (st []*Message, err er
 		return
 	}
 
-	err = unmarshal(body, &st)
+	err = s.unmarshal(body, &st)
 	return
 }
 
@@ -1768,31 +2182,56 @@ func (s *Session) ChannelInvites(channelID string) (st []*Invite, err error) {
 		return
 	}
 
-	err = unmarshal(body, &st)
+	err = s.unmarshal(body, &st)
 	return
 }
 
 // ChannelInviteCreate creates a new invite for the given channel.
 // channelID   : The ID of a Channel
 // i           : An Invite struct with the values MaxAge, MaxUses and Temporary defined.
+// Invites to voice channels can also target a user streaming or an activity
+// with TargetType and TargetUser or TargetApplication.
 func (s *Session) ChannelInviteCreate(channelID string, i Invite) (st *Invite, err error) {
 
 	data := struct {
-		MaxAge    int  `json:"max_age"`
-		MaxUses   int  `json:"max_uses"`
-		Temporary bool `json:"temporary"`
-		Unique    bool `json:"unique"`
-	}{i.MaxAge, i.MaxUses, i.Temporary, i.Unique}
+		MaxAge              int              `json:"max_age"`
+		MaxUses             int              `json:"max_uses"`
+		Temporary           bool             `json:"temporary"`
+		Unique              bool             `json:"unique"`
+		TargetType          InviteTargetType `json:"target_type,omitempty"`
+		TargetUserID        string           `json:"target_user_id,omitempty"`
+		TargetApplicationID string           `json:"target_application_id,omitempty"`
+	}{MaxAge: i.MaxAge, MaxUses: i.MaxUses, Temporary: i.Temporary, Unique: i.Unique, TargetType: i.TargetType}
+
+	if i.TargetUser != nil {
+		data.TargetUserID = i.TargetUser.ID
+	}
+	if i.TargetApplication != nil {
+		data.TargetApplicationID = i.TargetApplication.ID
+	}
 
 	body, err := s.RequestWithBucketID("POST", EndpointChannelInvites(channelID), data, EndpointChannelInvites(channelID))
 	if err != nil {
 		return
 	}
 
-	err = unmarshal(body, &st)
+	err = s.unmarshal(body, &st)
 	return
 }
 
+// ChannelActivityInviteCreate creates an invite which launches an activity
+// in the given voice channel for everyone who joins it.
+// channelID     : The ID of a voice Channel
+// applicationID : The ID of the embedded application of the activity
+// maxAge        : The duration of the invite in seconds, 0 for never
+func (s *Session) ChannelActivityInviteCreate(channelID, applicationID string, maxAge int) (st *Invite, err error) {
+	return s.ChannelInviteCreate(channelID, Invite{
+		MaxAge:            maxAge,
+		TargetType:        InviteTargetEmbeddedApplication,
+		TargetApplication: &Application{ID: applicationID},
+	})
+}
+
 // ChannelPermissionSet creates a Permission Override for the given channel.
 // NOTE: This func name may changed.  Using Set instead of Create because
 // you can both create a new override or update an override with this function.
@@ -1830,7 +2269,7 @@ func (s *Session) ChannelMessageCrosspost(channelID, messageID string) (st *Mess
 		return
 	}
 
-	err = unmarshal(body, &st)
+	err = s.unmarshal(body, &st)
 	return
 }
 
@@ -1850,10 +2289,24 @@ func (s *Session) ChannelNewsFollow(channelID, targetID string) (st *ChannelFoll
 		return
 	}
 
-	err = unmarshal(body, &st)
+	err = s.unmarshal(body, &st)
 	return
 }
 
+// ThreadJoin adds the current user to a thread.
+// threadID : The ID of a thread.
+func (s *Session) ThreadJoin(threadID string) error {
+	_, err := s.RequestWithBucketID("PUT", EndpointThreadMember(threadID, "@me"), nil, EndpointThreadMember(threadID, ""))
+	return err
+}
+
+// ThreadLeave removes the current user from a thread.
+// threadID : The ID of a thread.
+func (s *Session) ThreadLeave(threadID string) error {
+	_, err := s.RequestWithBucketID("DELETE", EndpointThreadMember(threadID, "@me"), nil, EndpointThreadMember(threadID, ""))
+	return err
+}
+
 // ------------------------------------------------------------------------------------------------
 // Functions specific to Discord Invites
 // ------------------------------------------------------------------------------------------------
@@ -1867,7 +2320,7 @@ func (s *Session) Invite(inviteID string) (st *Invite, err error) {
 		return
 	}
 
-	err = unmarshal(body, &st)
+	err = s.unmarshal(body, &st)
 	return
 }
 
@@ -1880,7 +2333,7 @@ func (s *Session) InviteWithCounts(inviteID string) (st *Invite, err error) {
 		return
 	}
 
-	err = unmarshal(body, &st)
+	err = s.unmarshal(body, &st)
 	return
 }
 
@@ -1893,7 +2346,7 @@ func (s *Session) InviteDelete(inviteID string) (st *Invite, err error) {
 		return
 	}
 
-	err = unmarshal(body, &st)
+	err = s.unmarshal(body, &st)
 	return
 }
 
@@ -1906,7 +2359,7 @@ func (s *Session) InviteAccept(inviteID string) (st *Invite, err error) {
 		return
 	}
 
-	err = unmarshal(body, &st)
+	err = s.unmarshal(body, &st)
 	return
 }
 
@@ -1922,7 +2375,7 @@ func (s *Session) VoiceRegions() (st []*VoiceRegion, err error) {
 		return
 	}
 
-	err = unmarshal(body, &st)
+	err = s.unmarshal(body, &st)
 	return
 }
 
@@ -1934,7 +2387,7 @@ func (s *Session) VoiceICE() (st *VoiceICE, err error) {
 		return
 	}
 
-	err = unmarshal(body, &st)
+	err = s.unmarshal(body, &st)
 	return
 }
 
@@ -1954,7 +2407,7 @@ func (s *Session) Gateway() (gateway string, err error) {
 		URL string `json:"url"`
 	}{}
 
-	err = unmarshal(response, &temp)
+	err = s.unmarshal(response, &temp)
 	if err != nil {
 		return
 	}
@@ -1978,7 +2431,7 @@ func (s *Session) GatewayBot() (st *GatewayBotResponse, err error) {
 		return
 	}
 
-	err = unmarshal(response, &st)
+	err = s.unmarshal(response, &st)
 	if err != nil {
 		return
 	}
@@ -2010,7 +2463,7 @@ func (s *Session) WebhookCreate(channelID, name, avatar string) (st *Webhook, er
 		return
 	}
 
-	err = unmarshal(body, &st)
+	err = s.unmarshal(body, &st)
 
 	return
 }
@@ -2024,7 +2477,7 @@ func (s *Session) ChannelWebhooks(channelID string) (st []*Webhook, err error) {
 		return
 	}
 
-	err = unmarshal(body, &st)
+	err = s.unmarshal(body, &st)
 
 	return
 }
@@ -2038,7 +2491,7 @@ func (s *Session) GuildWebhooks(guildID string) (st []*Webhook, err error) {
 		return
 	}
 
-	err = unmarshal(body, &st)
+	err = s.unmarshal(body, &st)
 
 	return
 }
@@ -2052,7 +2505,7 @@ func (s *Session) Webhook(webhookID string) (st *Webhook, err error) {
 		return
 	}
 
-	err = unmarshal(body, &st)
+	err = s.unmarshal(body, &st)
 
 	return
 }
@@ -2067,7 +2520,7 @@ func (s *Session) WebhookWithToken(webhookID, token string) (st *Webhook, err er
 		return
 	}
 
-	err = unmarshal(body, &st)
+	err = s.unmarshal(body, &st)
 
 	return
 }
@@ -2089,7 +2542,7 @@ func (s *Session) WebhookEdit(webhookID, name, avatar, channelID string) (st *Ro
 		return
 	}
 
-	err = unmarshal(body, &st)
+	err = s.unmarshal(body, &st)
 
 	return
 }
@@ -2111,7 +2564,7 @@ func (s *Session) WebhookEditWithToken(webhookID, token, name, avatar string) (s
 		return
 	}
 
-	err = unmarshal(body, &st)
+	err = s.unmarshal(body, &st)
 
 	return
 }
@@ -2135,7 +2588,7 @@ func (s *Session) WebhookDeleteWithToken(webhookID, token string) (st *Webhook,
 		return
 	}
 
-	err = unmarshal(body, &st)
+	err = s.unmarshal(body, &st)
 
 	return
 }
@@ -2156,20 +2609,321 @@ func (s *Session) WebhookExecute(webhookID, token string, wait bool, data *Webho
 		return
 	}
 
-	err = unmarshal(response, &st)
+	err = s.unmarshal(response, &st)
+
+	return
+}
+
+// applicationCommandsEndpoint returns the endpoint of the global commands
+// of an application, or of its commands in a guild.
+func applicationCommandsEndpoint(appID, guildID string) string {
+	if guildID == "" {
+		return EndpointApplicationGlobalCommands(appID)
+	}
+	return EndpointApplicationGuildCommands(appID, guildID)
+}
+
+// ApplicationCommandCreate creates a command of an application, or replaces
+// the command with the same name and type.
+// appID   : The ID of an Application
+// guildID : The ID of a Guild for a guild command, empty for a global command
+// cmd     : The command to create
+func (s *Session) ApplicationCommandCreate(appID, guildID string, cmd *ApplicationCommand) (st *ApplicationCommand, err error) {
+	endpoint := applicationCommandsEndpoint(appID, guildID)
+
+	body, err := s.RequestWithBucketID("POST", endpoint, cmd, endpoint)
+	if err != nil {
+		return
+	}
 
+	err = s.unmarshal(body, &st)
 	return
 }
 
+// ApplicationCommandEdit edits a command of an application.
+// appID   : The ID of an Application
+// guildID : The ID of a Guild for a guild command, empty for a global command
+// cmdID   : The ID of the command
+// cmd     : The new command
+func (s *Session) ApplicationCommandEdit(appID, guildID, cmdID string, cmd *ApplicationCommand) (st *ApplicationCommand, err error) {
+	endpoint := applicationCommandsEndpoint(appID, guildID)
+
+	body, err := s.RequestWithBucketID("PATCH", endpoint+"/"+cmdID, cmd, endpoint)
+	if err != nil {
+		return
+	}
+
+	err = s.unmarshal(body, &st)
+	return
+}
+
+// ApplicationCommandDelete deletes a command of an application.
+// appID   : The ID of an Application
+// guildID : The ID of a Guild for a guild command, empty for a global command
+// cmdID   : The ID of the command
+func (s *Session) ApplicationCommandDelete(appID, guildID, cmdID string) (err error) {
+	endpoint := applicationCommandsEndpoint(appID, guildID)
+
+	_, err = s.RequestWithBucketID("DELETE", endpoint+"/"+cmdID, nil, endpoint)
+	return
+}
+
+// ApplicationCommand returns a command of an application.
+// appID   : The ID of an Application
+// guildID : The ID of a Guild for a guild command, empty for a global command
+// cmdID   : The ID of the command
+func (s *Session) ApplicationCommand(appID, guildID, cmdID string) (st *ApplicationCommand, err error) {
+	endpoint := applicationCommandsEndpoint(appID, guildID)
+
+	body, err := s.RequestWithBucketID("GET", endpoint+"/"+cmdID, nil, endpoint)
+	if err != nil {
+		return
+	}
+
+	err = s.unmarshal(body, &st)
+	return
+}
+
+// ApplicationCommands returns the global commands of an application, or its
+// commands in a guild.
+// appID   : The ID of an Application
+// guildID : The ID of a Guild for guild commands, empty for global commands
+func (s *Session) ApplicationCommands(appID, guildID string) (st []*ApplicationCommand, err error) {
+	endpoint := applicationCommandsEndpoint(appID, guildID)
+
+	body, err := s.RequestWithBucketID("GET", endpoint, nil, endpoint)
+	if err != nil {
+		return
+	}
+
+	err = s.unmarshal(body, &st)
+	return
+}
+
+// ApplicationCommandBulkOverwrite replaces all global commands of an
+// application, or all its commands in a guild, with the given commands.
+// appID    : The ID of an Application
+// guildID  : The ID of a Guild for guild commands, empty for global commands
+// commands : The new commands
+func (s *Session) ApplicationCommandBulkOverwrite(appID, guildID string, commands []*ApplicationCommand) (st []*ApplicationCommand, err error) {
+	endpoint := applicationCommandsEndpoint(appID, guildID)
+
+	body, err := s.RequestWithBucketID("PUT", endpoint, commands, endpoint)
+	if err != nil {
+		return
+	}
+
+	err = s.unmarshal(body, &st)
+	return
+}
+
+// GuildApplicationCommandsPermissions returns the permissions of the
+// commands of an application in a guild, the commands without
+// permissions are left out.
+// appID   : The ID of an Application
+// guildID : The ID of a Guild
+func (s *Session) GuildApplicationCommandsPermissions(appID, guildID string) (st []*GuildApplicationCommandPermissions, err error) {
+	endpoint := EndpointApplicationGuildCommandsPermissions(appID, guildID)
+
+	body, err := s.RequestWithBucketID("GET", endpoint, nil, EndpointApplicationGuildCommandsPermissions(appID, ""))
+	if err != nil {
+		return
+	}
+
+	err = s.unmarshal(body, &st)
+	return
+}
+
+// ApplicationCommandPermissions returns the permissions of a command in a guild.
+// appID   : The ID of an Application
+// guildID : The ID of a Guild
+// cmdID   : The ID of the command, global or of the guild
+func (s *Session) ApplicationCommandPermissions(appID, guildID, cmdID string) (st *GuildApplicationCommandPermissions, err error) {
+	endpoint := EndpointApplicationCommandPermissions(appID, guildID, cmdID)
+
+	body, err := s.RequestWithBucketID("GET", endpoint, nil, EndpointApplicationGuildCommandsPermissions(appID, ""))
+	if err != nil {
+		return
+	}
+
+	err = s.unmarshal(body, &st)
+	return
+}
+
+// ApplicationCommandPermissionsEdit replaces the permissions of a command in
+// a guild. Discord only allows it with the Bearer token of a user who can
+// manage the guild, granted the applications.commands.permissions.update
+// scope, otherwise ErrBearerTokenRequired is returned.
+// appID   : The ID of an Application
+// guildID : The ID of a Guild
+// cmdID   : The ID of the command, global or of the guild
+// data    : The new permissions
+func (s *Session) ApplicationCommandPermissionsEdit(appID, guildID, cmdID string, data *ApplicationCommandPermissionsList) (st *GuildApplicationCommandPermissions, err error) {
+	if err = s.bearerTokenOnly(); err != nil {
+		return
+	}
+
+	endpoint := EndpointApplicationCommandPermissions(appID, guildID, cmdID)
+
+	body, err := s.RequestWithBucketID("PUT", endpoint, data, EndpointApplicationGuildCommandsPermissions(appID, ""))
+	if err != nil {
+		return
+	}
+
+	err = s.unmarshal(body, &st)
+	return
+}
+
+// WebhookMessageEdit edits a message sent by a webhook.
+// webhookID : The ID of a webhook.
+// token     : The auth token for the webhook
+// messageID : The ID of a message sent by the webhook
+// data      : The fields of the message to change
+func (s *Session) WebhookMessageEdit(webhookID, token, messageID string, data *WebhookEdit) (st *Message, err error) {
+	uri := EndpointWebhookMessage(webhookID, token, messageID)
+
+	response, err := s.RequestWithBucketID("PATCH", uri, data, EndpointWebhookToken("", ""))
+	if err != nil {
+		return
+	}
+
+	err = s.unmarshal(response, &st)
+	return
+}
+
+// WebhookMessageDelete deletes a message sent by a webhook.
+// webhookID : The ID of a webhook.
+// token     : The auth token for the webhook
+// messageID : The ID of a message sent by the webhook
+func (s *Session) WebhookMessageDelete(webhookID, token, messageID string) (err error) {
+	uri := EndpointWebhookMessage(webhookID, token, messageID)
+
+	_, err = s.RequestWithBucketID("DELETE", uri, nil, EndpointWebhookToken("", ""))
+	return
+}
+
+// InteractionRespond responds to an interaction, within 3 seconds after
+// it was created.
+// interaction : The interaction to respond to.
+// resp        : The response data.
+func (s *Session) InteractionRespond(interaction *Interaction, resp *InteractionResponse) (err error) {
+	endpoint := EndpointInteractionResponse(interaction.ID, interaction.Token)
+
+	// A bucket per interaction would never be used again.
+	_, err = s.RequestWithBucketID("POST", endpoint, resp, EndpointInteractionResponse("", ""))
+	return
+}
+
+// InteractionRespondWithResponse responds to an interaction like
+// InteractionRespond, and returns the created response, eg. to get the ID
+// of the message of the response without fetching it.
+// interaction : The interaction to respond to.
+// resp        : The response data.
+func (s *Session) InteractionRespondWithResponse(interaction *Interaction, resp *InteractionResponse) (st *InteractionCallbackResponse, err error) {
+	endpoint := EndpointInteractionResponse(interaction.ID, interaction.Token)
+
+	body, err := s.RequestWithBucketID("POST", endpoint+"?with_response=true", resp, EndpointInteractionResponse("", ""))
+	if err != nil {
+		return
+	}
+
+	err = s.unmarshal(body, &st)
+	return
+}
+
+// InteractionResponseEdit edits the response to an interaction, or the
+// message of the component of a deferred component interaction.
+// interaction : The interaction of the response.
+// data        : The fields of the response to change.
+func (s *Session) InteractionResponseEdit(interaction *Interaction, data *WebhookEdit) (st *Message, err error) {
+	return s.WebhookMessageEdit(interaction.ApplicationID, interaction.Token, "@original", data)
+}
+
+// InteractionResponseDelete deletes the response to an interaction.
+// interaction : The interaction of the response.
+func (s *Session) InteractionResponseDelete(interaction *Interaction) (err error) {
+	return s.WebhookMessageDelete(interaction.ApplicationID, interaction.Token, "@original")
+}
+
+// FollowupMessageCreate sends a followup message to an interaction. The ID
+// of the message is tracked on the interaction, see Interaction.Followups.
+// interaction : The interaction to send the message to.
+// data        : The message to send.
+func (s *Session) FollowupMessageCreate(interaction *Interaction, data *WebhookParams) (st *Message, err error) {
+	st, err = s.WebhookExecute(interaction.ApplicationID, interaction.Token, true, data)
+	if err != nil {
+		return
+	}
+
+	interaction.setFollowups(func(ids []string) []string {
+		return append(ids, st.ID)
+	})
+	return
+}
+
+// FollowupMessageEdit edits a followup message of an interaction.
+// interaction : The interaction of the message.
+// messageID   : The ID of the followup message.
+// data        : The fields of the message to change.
+func (s *Session) FollowupMessageEdit(interaction *Interaction, messageID string, data *WebhookEdit) (*Message, error) {
+	return s.WebhookMessageEdit(interaction.ApplicationID, interaction.Token, messageID, data)
+}
+
+// FollowupMessageDelete deletes a followup message of an interaction.
+// interaction : The interaction of the message.
+// messageID   : The ID of the followup message.
+func (s *Session) FollowupMessageDelete(interaction *Interaction, messageID string) (err error) {
+	if err = s.WebhookMessageDelete(interaction.ApplicationID, interaction.Token, messageID); err != nil {
+		return
+	}
+
+	interaction.setFollowups(func(ids []string) []string {
+		for i, id := range ids {
+			if id == messageID {
+				return append(ids[:i], ids[i+1:]...)
+			}
+		}
+		return ids
+	})
+	return
+}
+
+// FollowupMessagesEdit edits all followup messages of an interaction created
+// with FollowupMessageCreate. All messages are attempted, if any fail an
+// IDErrors is returned.
+// interaction : The interaction of the messages.
+// data        : The fields of the messages to change.
+func (s *Session) FollowupMessagesEdit(interaction *Interaction, data *WebhookEdit) error {
+	errs := IDErrors{}
+	for _, id := range interaction.Followups() {
+		if _, err := s.FollowupMessageEdit(interaction, id, data); err != nil {
+			errs[id] = err
+		}
+	}
+	return errs.errorOrNil()
+}
+
+// FollowupMessagesDelete deletes all followup messages of an interaction
+// created with FollowupMessageCreate, eg. to clean up after a multi message
+// response. All messages are attempted, if any fail an IDErrors is returned.
+// interaction : The interaction of the messages.
+func (s *Session) FollowupMessagesDelete(interaction *Interaction) error {
+	errs := IDErrors{}
+	for _, id := range interaction.Followups() {
+		if err := s.FollowupMessageDelete(interaction, id); err != nil {
+			errs[id] = err
+		}
+	}
+	return errs.errorOrNil()
+}
+
 // MessageReactionAdd creates an emoji reaction to a message.
 // channelID : The channel ID.
 // messageID : The message ID.
-// emojiID   : Either the unicode emoji for the reaction, or a guild emoji identifier.
-func (s *Session) MessageReactionAdd(channelID, messageID, emojiID string) error {
+// emojiID   : The emoji of the reaction, see EmojiIdentifier.
+func (s *Session) MessageReactionAdd(channelID, messageID string, emojiID EmojiIdentifier) error {
 
-	// emoji such as  #⃣ need to have # escaped
-	emojiID = strings.Replace(emojiID, "#", "%23", -1)
-	_, err := s.RequestWithBucketID("PUT", EndpointMessageReaction(channelID, messageID, emojiID, "@me"), nil, EndpointMessageReaction(channelID, "", "", ""))
+	_, err := s.RequestWithBucketID("PUT", EndpointMessageReaction(channelID, messageID, emojiID.escaped(), "@me"), nil, EndpointMessageReaction(channelID, "", "", ""))
 
 	return err
 }
@@ -2177,13 +2931,11 @@ func (s *Session) MessageReactionAdd(channelID, messageID, emojiID string) error
 // MessageReactionRemove deletes an emoji reaction to a message.
 // channelID : The channel ID.
 // messageID : The message ID.
-// emojiID   : Either the unicode emoji for the reaction, or a guild emoji identifier.
+// emojiID   : The emoji of the reaction, see EmojiIdentifier.
 // userID	 : @me or ID of the user to delete the reaction for.
-func (s *Session) MessageReactionRemove(channelID, messageID, emojiID, userID string) error {
+func (s *Session) MessageReactionRemove(channelID, messageID string, emojiID EmojiIdentifier, userID string) error {
 
-	// emoji such as  #⃣ need to have # escaped
-	emojiID = strings.Replace(emojiID, "#", "%23", -1)
-	_, err := s.RequestWithBucketID("DELETE", EndpointMessageReaction(channelID, messageID, emojiID, userID), nil, EndpointMessageReaction(channelID, "", "", ""))
+	_, err := s.RequestWithBucketID("DELETE", EndpointMessageReaction(channelID, messageID, emojiID.escaped(), userID), nil, EndpointMessageReaction(channelID, "", "", ""))
 
 	return err
 }
@@ -2198,20 +2950,46 @@ func (s *Session) MessageReactionsRemoveAll(channelID, messageID string) error {
 	return err
 }
 
+// MessageReactionsRemoveEmoji deletes all reactions of an emoji from a message.
+// channelID : The channel ID.
+// messageID : The message ID.
+// emojiID   : The emoji of the reactions, see EmojiIdentifier.
+func (s *Session) MessageReactionsRemoveEmoji(channelID, messageID string, emojiID EmojiIdentifier) error {
+
+	_, err := s.RequestWithBucketID("DELETE", EndpointMessageReactions(channelID, messageID, emojiID.escaped()), nil, EndpointMessageReaction(channelID, "", "", ""))
+
+	return err
+}
+
 // MessageReactions gets all the users reactions for a specific emoji.
 // channelID : The channel ID.
 // messageID : The message ID.
-// emojiID   : Either the unicode emoji for the reaction, or a guild emoji identifier.
+// emojiID   : The emoji of the reaction, see EmojiIdentifier.
 // limit    : max number of users to return (max 100)
 // beforeID  : If provided all reactions returned will be before given ID.
 // afterID   : If provided all reactions returned will be after given ID.
-func (s *Session) MessageReactions(channelID, messageID, emojiID string, limit int, beforeID, afterID string) (st []*User, err error) {
-	// emoji such as  #⃣ need to have # escaped
-	emojiID = strings.Replace(emojiID, "#", "%23", -1)
-	uri := EndpointMessageReactions(channelID, messageID, emojiID)
+func (s *Session) MessageReactions(channelID, messageID string, emojiID EmojiIdentifier, limit int, beforeID, afterID string) (st []*User, err error) {
+	return s.MessageReactionsByType(channelID, messageID, emojiID, ReactionTypeNormal, limit, beforeID, afterID)
+}
+
+// MessageReactionsByType gets the users who reacted with a specific emoji
+// and type of reaction, use ReactionTypeBurst for the super reactions.
+// channelID    : The channel ID.
+// messageID    : The message ID.
+// emojiID      : The emoji of the reaction, see EmojiIdentifier.
+// reactionType : The type of the reactions.
+// limit        : max number of users to return (max 100)
+// beforeID     : If provided all reactions returned will be before given ID.
+// afterID      : If provided all reactions returned will be after given ID.
+func (s *Session) MessageReactionsByType(channelID, messageID string, emojiID EmojiIdentifier, reactionType ReactionType, limit int, beforeID, afterID string) (st []*User, err error) {
+	uri := EndpointMessageReactions(channelID, messageID, emojiID.escaped())
 
 	v := url.Values{}
 
+	if reactionType != ReactionTypeNormal {
+		v.Set("type", strconv.Itoa(int(reactionType)))
+	}
+
 	if limit > 0 {
 		v.Set("limit", strconv.Itoa(limit))
 	}
@@ -2232,7 +3010,7 @@ func (s *Session) MessageReactions(channelID, messageID, emojiID string, limit i
 		return
 	}
 
-	err = unmarshal(body, &st)
+	err = s.unmarshal(body, &st)
 	return
 }
 
@@ -2241,7 +3019,13 @@ func (s *Session) MessageReactions(channelID, messageID, emojiID string, limit i
 // ------------------------------------------------------------------------------------------------
 
 // UserNoteSet sets the note for a specific user.
+//
+// Deprecated: only user accounts have notes, for bots and OAuth2 tokens
+// ErrUserAccountsUnsupported is returned.
 func (s *Session) UserNoteSet(userID string, message string) (err error) {
+	if err = s.userAccountOnly(); err != nil {
+		return
+	}
 	data := struct {
 		Note string `json:"note"`
 	}{message}
@@ -2252,22 +3036,34 @@ func (s *Session) UserNoteSet(userID string, message string) (err error) {
 
 // ------------------------------------------------------------------------------------------------
 // Functions specific to Discord Relationships (Friends list)
+//
+// Only user accounts have relationships, for bots and OAuth2 tokens the
+// functions return ErrUserAccountsUnsupported.
 // ------------------------------------------------------------------------------------------------
 
 // RelationshipsGet returns an array of all the relationships of the user.
+//
+// Deprecated: only user accounts have relationships.
 func (s *Session) RelationshipsGet() (r []*Relationship, err error) {
+	if err = s.userAccountOnly(); err != nil {
+		return
+	}
 	body, err := s.RequestWithBucketID("GET", EndpointRelationships(), nil, EndpointRelationships())
 	if err != nil {
 		return
 	}
 
-	err = unmarshal(body, &r)
+	err = s.unmarshal(body, &r)
 	return
 }
 
 // relationshipCreate creates a new relationship. (I.e. send or accept a friend request, block a user.)
 // relationshipType : 1 = friend, 2 = blocked, 3 = incoming friend req, 4 = sent friend req
 func (s *Session) relationshipCreate(userID string, relationshipType int) (err error) {
+	if err = s.userAccountOnly(); err != nil {
+		return
+	}
+
 	data := struct {
 		Type int `json:"type"`
 	}{relationshipType}
@@ -2278,6 +3074,8 @@ func (s *Session) relationshipCreate(userID string, relationshipType int) (err e
 
 // RelationshipFriendRequestSend sends a friend request to a user.
 // userID: ID of the user.
+//
+// Deprecated: only user accounts have relationships.
 func (s *Session) RelationshipFriendRequestSend(userID string) (err error) {
 	err = s.relationshipCreate(userID, 4)
 	return
@@ -2285,6 +3083,8 @@ func (s *Session) RelationshipFriendRequestSend(userID string) (err error) {
 
 // RelationshipFriendRequestAccept accepts a friend request from a user.
 // userID: ID of the user.
+//
+// Deprecated: only user accounts have relationships.
 func (s *Session) RelationshipFriendRequestAccept(userID string) (err error) {
 	err = s.relationshipCreate(userID, 1)
 	return
@@ -2292,6 +3092,8 @@ func (s *Session) RelationshipFriendRequestAccept(userID string) (err error) {
 
 // RelationshipUserBlock blocks a user.
 // userID: ID of the user.
+//
+// Deprecated: only user accounts have relationships.
 func (s *Session) RelationshipUserBlock(userID string) (err error) {
 	err = s.relationshipCreate(userID, 2)
 	return
@@ -2299,19 +3101,29 @@ func (s *Session) RelationshipUserBlock(userID string) (err error) {
 
 // RelationshipDelete removes the relationship with a user.
 // userID: ID of the user.
+//
+// Deprecated: only user accounts have relationships.
 func (s *Session) RelationshipDelete(userID string) (err error) {
+	if err = s.userAccountOnly(); err != nil {
+		return
+	}
 	_, err = s.RequestWithBucketID("DELETE", EndpointRelationship(userID), nil, EndpointRelationships())
 	return
 }
 
 // RelationshipsMutualGet returns an array of all the users both @me and the given user is friends with.
 // userID: ID of the user.
+//
+// Deprecated: only user accounts have relationships.
 func (s *Session) RelationshipsMutualGet(userID string) (mf []*User, err error) {
+	if err = s.userAccountOnly(); err != nil {
+		return
+	}
 	body, err := s.RequestWithBucketID("GET", EndpointRelationshipsMutual(userID), nil, EndpointRelationshipsMutual(userID))
 	if err != nil {
 		return
 	}
 
-	err = unmarshal(body, &mf)
+	err = s.unmarshal(body, &mf)
 	return
 }
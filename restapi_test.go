@@ -1,7 +1,12 @@
 package discordgo
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
 	"testing"
+	"time"
 )
 
 //////////////////////////////////////////////////////////////////////////////
@@ -142,6 +147,320 @@ func TestUserUpdateStatus(t *testing.T) {
 	}
 }
 
+func TestUserAccountOnly(t *testing.T) {
+	for _, token := range []string{"Bot token", "Bearer token"} {
+		s := &Session{Token: token}
+		if err := s.UserNoteSet("1", "note"); err != ErrUserAccountsUnsupported {
+			t.Errorf("%s: expected ErrUserAccountsUnsupported, got %v", token, err)
+		}
+		if _, err := s.RelationshipsGet(); err != ErrUserAccountsUnsupported {
+			t.Errorf("%s: expected ErrUserAccountsUnsupported, got %v", token, err)
+		}
+	}
+
+	if err := (&Session{Token: "token"}).userAccountOnly(); err != nil {
+		t.Errorf("expected user tokens to be allowed, got %v", err)
+	}
+}
+
+func TestGuildMemberAddComplex(t *testing.T) {
+	tr := &routeTransport{handle: answer(`{"user": {"id": "2"}, "nick": "nick", "roles": ["3"]}`)}
+	s := newTestSession(tr)
+	m, err := s.GuildMemberAddComplex("1", "2", &GuildMemberAddParams{AccessToken: "token", Nick: "nick", Roles: []string{"3"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := tr.last(); r != "PUT "+EndpointGuildMember("1", "2") {
+		t.Errorf("unexpected request %s", r)
+	}
+	if m == nil || m.User.ID != "2" || m.Nick != "nick" {
+		t.Errorf("unexpected member %+v", m)
+	}
+
+	// Users who are already members are answered with no content.
+	tr.handle = answer(``)
+	if m, err = s.GuildMemberAddComplex("1", "2", &GuildMemberAddParams{AccessToken: "token"}); err != nil || m != nil {
+		t.Errorf("expected no member and no error, got %+v, %v", m, err)
+	}
+}
+
+func TestGuildBan(t *testing.T) {
+	tr := &routeTransport{
+		routes: map[string]string{
+			"GET " + EndpointGuildBan("1", "2"): `{"reason": "spam", "user": {"id": "2"}}`,
+			"GET " + EndpointGuildBan("1", "3"): `{"code": 10026, "message": "Unknown Ban"}`,
+			"PUT " + EndpointGuildBan("1", "4"): ``,
+		},
+		statuses: map[string]int{"GET " + EndpointGuildBan("1", "3"): http.StatusNotFound},
+	}
+	s := newTestSession(tr)
+
+	if ban, err := s.GuildBan("1", "2"); err != nil || ban.Reason != "spam" {
+		t.Errorf("unexpected ban %+v, %v", ban, err)
+	}
+	if _, err := s.GuildBan("1", "3"); err != ErrNotBanned {
+		t.Errorf("expected ErrNotBanned, got %v", err)
+	}
+
+	if err := s.GuildBanCreateComplex("1", "4", &BanCreateData{DeleteMessageSeconds: 604801}); err != ErrBanDeleteSecondsBounds {
+		t.Errorf("expected ErrBanDeleteSecondsBounds, got %v", err)
+	}
+	if err := s.GuildBanCreateComplex("1", "4", &BanCreateData{DeleteMessageSeconds: 3600}); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestMessageReactionsRemoveEmoji(t *testing.T) {
+	tr := &routeTransport{handle: answer(``)}
+	s := newTestSession(tr)
+	if err := s.MessageReactionsRemoveEmoji("1", "2", CustomEmoji("blob", "3")); err != nil {
+		t.Fatal(err)
+	}
+	if r := tr.last(); r != "DELETE "+EndpointMessageReactions("1", "2", "blob:3") {
+		t.Errorf("unexpected request %s", r)
+	}
+
+	e, ok := registeredInterfaceProviders[messageReactionRemoveEmojiEventType].New().(*MessageReactionRemoveEmoji)
+	if !ok || json.Unmarshal([]byte(`{"channel_id": "1", "message_id": "2", "emoji": {"name": "blob", "id": "3"}}`), e) != nil || e.Emoji.ID != "3" {
+		t.Errorf("unexpected event %+v", e)
+	}
+}
+
+func TestMessageReactionsByType(t *testing.T) {
+	tr := &routeTransport{handle: answer(`[]`)}
+	s := newTestSession(tr)
+	if _, err := s.MessageReactionsByType("1", "2", "👍", ReactionTypeBurst, 10, "", ""); err != nil {
+		t.Fatal(err)
+	}
+	if r := tr.last(); r != "GET "+EndpointMessageReactions("1", "2", "%F0%9F%91%8D")+"?limit=10&type=1" {
+		t.Errorf("unexpected request %s", r)
+	}
+
+	var r MessageReactions
+	if err := json.Unmarshal([]byte(`{"count": 3, "count_details": {"burst": 1, "normal": 2}, "burst_colors": ["#ffffff"]}`), &r); err != nil {
+		t.Fatal(err)
+	}
+	if r.CountDetails == nil || r.CountDetails.Burst != 1 || r.CountDetails.Normal != 2 || len(r.BurstColors) != 1 {
+		t.Errorf("unexpected reactions %+v", r)
+	}
+}
+
+func TestDo(t *testing.T) {
+	tr := &routeTransport{handle: answer(`{"id": "1"}`)}
+	s := newTestSession(tr)
+	response, err := s.Do(context.Background(), "PUT", "/guilds/1/onboarding", map[string]bool{"enabled": true}, WithBucketID("onboarding"), WithPriority(RequestPriorityLow))
+	if err != nil || string(response) != `{"id": "1"}` {
+		t.Fatalf("unexpected response %s, %v", response, err)
+	}
+	if r := tr.last(); r != "PUT "+EndpointGuildOnboarding("1") {
+		t.Errorf("unexpected request %s", r)
+	}
+
+	s.Ratelimiter.Lock()
+	_, ok := s.Ratelimiter.buckets["onboarding"]
+	s.Ratelimiter.Unlock()
+	if !ok {
+		t.Error("expected the request in the bucket onboarding")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	n := tr.count()
+	if _, err = s.Do(ctx, "GET", EndpointGuild("1"), nil); err != context.Canceled || tr.count() != n {
+		t.Errorf("expected a cancelled request not to be sent, got %v", err)
+	}
+
+	// A request cancelled while it waits for its bucket isn't sent.
+	held := s.Ratelimiter.LockBucket("onboarding")
+	ctx, cancel = context.WithCancel(context.Background())
+	errs := make(chan error, 1)
+	go func() {
+		_, err := s.Do(ctx, "PUT", "/guilds/1/onboarding", nil, WithBucketID("onboarding"))
+		errs <- err
+	}()
+	for waiting := 0; waiting == 0; time.Sleep(time.Millisecond) {
+		held.waitMu.Lock()
+		waiting = len(held.waiters)
+		held.waitMu.Unlock()
+	}
+	cancel()
+	if err = <-errs; err != context.Canceled {
+		t.Errorf("expected the request to be cancelled, got %v", err)
+	}
+	remaining := held.Remaining
+	held.Release(nil)
+	b := s.Ratelimiter.LockBucket("onboarding")
+	if tr.count() != n || b.Remaining != remaining-1 {
+		t.Errorf("expected the cancelled request not to be sent, got %d requests and %d remaining", tr.count()-n, b.Remaining)
+	}
+	b.Release(nil)
+
+	errTransport := &routeTransport{}
+	s.Client = &http.Client{Transport: errTransport}
+	if _, err = s.Do(context.Background(), "GET", "guilds/1/unknown", nil); err == nil {
+		t.Error("expected an error")
+	} else if _, ok := err.(*RESTError); !ok {
+		t.Errorf("expected a RESTError, got %T", err)
+	}
+}
+
+func TestChannelEditComplex(t *testing.T) {
+	tr := &routeTransport{handle: answer(`{"id": "1"}`)}
+	s := newTestSession(tr)
+
+	empty := ""
+	if _, err := s.ChannelEditComplex("1", &ChannelEdit{Name: "general", Topic: &empty, ParentID: &empty}); err != nil {
+		t.Fatal(err)
+	}
+	if body := tr.bodies[0]; body != `{"name":"general","topic":"","parent_id":""}` {
+		t.Errorf("expected the topic and category to be removed, got %s", body)
+	}
+
+	if _, err := s.ChannelEdit("1", "general"); err != nil {
+		t.Fatal(err)
+	}
+	if body := tr.bodies[1]; body != `{"name":"general"}` {
+		t.Errorf("expected only the name to change, got %s", body)
+	}
+}
+
+func TestUserGuildMember(t *testing.T) {
+	tr := &routeTransport{routes: map[string]string{
+		"GET " + EndpointUserGuildMember("@me", "1"): `{"user": {"id": "2"}, "nick": "nick", "roles": ["3"]}`,
+	}}
+	s := newTestSession(tr)
+
+	m, err := s.UserGuildMember("1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.GuildID != "1" || m.User.ID != "2" || m.Nick != "nick" || len(m.Roles) != 1 {
+		t.Errorf("unexpected member %+v", m)
+	}
+}
+
+func TestUserConnections(t *testing.T) {
+	tr := &routeTransport{routes: map[string]string{
+		"GET " + EndpointUserConnections("@me"): `[{"id": "1", "name": "user", "type": "github", "verified": true, "friend_sync": true, "show_activity": false, "visibility": 1}]`,
+	}}
+	s := newTestSession(tr)
+
+	connections, err := s.UserConnections()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c := connections[0]; !c.Verified || !c.FriendSync || c.ShowActivity || c.Visibility != ConnectionVisibilityEveryone {
+		t.Errorf("unexpected connection %+v", c)
+	}
+}
+
+func TestUserGuildsIterator(t *testing.T) {
+	tr := &routeTransport{handle: func(req *http.Request, body []byte) (int, string) {
+		// The first page is full, the second one isn't.
+		first, n := 1, 200
+		if after := req.URL.Query().Get("after"); after != "" {
+			first, _ = strconv.Atoi(after)
+			first, n = first+1, 2
+		}
+
+		guilds := make([]*UserGuild, n)
+		for i := range guilds {
+			guilds[i] = &UserGuild{ID: strconv.Itoa(first + i)}
+		}
+		b, _ := json.Marshal(guilds)
+		return http.StatusOK, string(b)
+	}}
+	s := newTestSession(tr)
+
+	var ids []string
+	it := s.UserGuildsIterator(true)
+	for it.Next() {
+		ids = append(ids, it.Guild().ID)
+	}
+	if it.Err() != nil || len(ids) != 202 || ids[200] != "201" || it.Guild() != nil {
+		t.Fatalf("expected 202 guilds, got %d, %v", len(ids), it.Err())
+	}
+	if len(tr.requests) != 2 || tr.requests[0] != "GET "+EndpointUserGuilds("@me")+"?limit=200&with_counts=true" ||
+		tr.requests[1] != "GET "+EndpointUserGuilds("@me")+"?after=200&limit=200&with_counts=true" {
+		t.Errorf("unexpected requests %v", tr.requests)
+	}
+
+	tr.handle = nil
+	it = s.UserGuildsIterator(false)
+	if it.Next() || it.Err() == nil {
+		t.Error("expected the iterator to stop with the error of the request")
+	}
+}
+
+func TestGuildChannelCreateComplexForum(t *testing.T) {
+	tr := &routeTransport{routes: map[string]string{
+		"POST " + EndpointGuildChannels("1"): `{
+			"id": "2", "type": 15, "default_auto_archive_duration": 1440, "default_sort_order": 1, "default_forum_layout": 2,
+			"available_tags": [{"id": "3", "name": "bug", "moderated": true, "emoji_name": "🐛"}],
+			"default_reaction_emoji": {"emoji_id": "4"}
+		}`,
+	}}
+	s := newTestSession(tr)
+
+	order := ForumSortOrderCreationDate
+	c, err := s.GuildChannelCreateComplex("1", GuildChannelCreateData{
+		Name:                       "bugs",
+		Type:                       ChannelTypeGuildForum,
+		DefaultAutoArchiveDuration: 1440,
+		AvailableTags:              []*ForumTag{{Name: "bug", Moderated: true, EmojiName: "🐛"}},
+		DefaultReactionEmoji:       &ForumDefaultReaction{EmojiID: "4"},
+		DefaultSortOrder:           &order,
+		DefaultForumLayout:         ForumLayoutGalleryView,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var sent map[string]interface{}
+	json.Unmarshal([]byte(tr.bodies[0]), &sent)
+	if sent["type"] != float64(15) || sent["default_sort_order"] != float64(1) || sent["default_forum_layout"] != float64(2) || sent["rtc_region"] != nil {
+		t.Errorf("unexpected request %s", tr.bodies[0])
+	}
+
+	if c.Type != ChannelTypeGuildForum || c.DefaultAutoArchiveDuration != 1440 || c.DefaultSortOrder == nil || *c.DefaultSortOrder != order ||
+		c.DefaultForumLayout != ForumLayoutGalleryView || len(c.AvailableTags) != 1 || !c.AvailableTags[0].Moderated || c.DefaultReactionEmoji.EmojiID != "4" {
+		t.Errorf("unexpected channel %+v", c)
+	}
+
+	if _, err = s.GuildChannelCreateComplex("1", GuildChannelCreateData{Name: "voice", Type: ChannelTypeGuildStageVoice, RTCRegion: "rotterdam", VideoQualityMode: VideoQualityModeFull}); err != nil {
+		t.Fatal(err)
+	}
+	if body := tr.bodies[1]; body != `{"name":"voice","type":13,"rtc_region":"rotterdam","video_quality_mode":2}` {
+		t.Errorf("unexpected request %s", body)
+	}
+}
+
+func TestChannelActivityInviteCreate(t *testing.T) {
+	tr := &routeTransport{routes: map[string]string{
+		"POST " + EndpointChannelInvites("1"): `{"code": "abc", "target_type": 2, "target_application": {"id": "2", "name": "Chess"}}`,
+	}}
+	s := newTestSession(tr)
+
+	i, err := s.ChannelActivityInviteCreate("1", "2", 3600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if body := tr.bodies[0]; body != `{"max_age":3600,"max_uses":0,"temporary":false,"unique":false,"target_type":2,"target_application_id":"2"}` {
+		t.Errorf("unexpected request %s", body)
+	}
+	if i.TargetType != InviteTargetEmbeddedApplication || i.TargetApplication == nil || i.TargetApplication.Name != "Chess" {
+		t.Errorf("unexpected invite %+v", i)
+	}
+
+	// Invites without a target leave the target fields out.
+	if _, err = s.ChannelInviteCreate("1", Invite{MaxUses: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if body := tr.bodies[1]; body != `{"max_age":0,"max_uses":1,"temporary":false,"unique":false}` {
+		t.Errorf("unexpected request %s", body)
+	}
+}
+
 // TestLogout tests the Logout() function. This should not return an error.
 func TestLogout(t *testing.T) {
 
@@ -0,0 +1,104 @@
+// Code generated by \"restbindings\"; DO NOT EDIT
+// See restbindings.json
+
+package discordgo
+
+// GuildPreview returns the preview of a guild, which must be discoverable
+// unless the session user is a member of it.
+// guildID : The ID of a Guild.
+func (s *Session) GuildPreview(guildID string) (st *GuildPreview, err error) {
+	body, err := s.RequestWithBucketID("GET", EndpointGuildPreview(guildID), nil, EndpointGuildPreview(guildID))
+	if err != nil {
+		return
+	}
+
+	err = s.unmarshal(body, &st)
+	return
+}
+
+// GuildVanityURL returns the vanity invite of a guild, which requires the
+// manage guild permission.
+// guildID : The ID of a Guild.
+func (s *Session) GuildVanityURL(guildID string) (st *GuildVanityURL, err error) {
+	body, err := s.RequestWithBucketID("GET", EndpointGuildVanityURL(guildID), nil, EndpointGuildVanityURL(guildID))
+	if err != nil {
+		return
+	}
+
+	err = s.unmarshal(body, &st)
+	return
+}
+
+// GuildWelcomeScreen returns the welcome screen of a community guild.
+// guildID : The ID of a Guild.
+func (s *Session) GuildWelcomeScreen(guildID string) (st *GuildWelcomeScreen, err error) {
+	body, err := s.RequestWithBucketID("GET", EndpointGuildWelcomeScreen(guildID), nil, EndpointGuildWelcomeScreen(guildID))
+	if err != nil {
+		return
+	}
+
+	err = s.unmarshal(body, &st)
+	return
+}
+
+// GuildWelcomeScreenEdit edits the welcome screen of a community guild.
+// guildID : The ID of a Guild.
+// data    : The changes to the welcome screen.
+func (s *Session) GuildWelcomeScreenEdit(guildID string, data *GuildWelcomeScreenParams) (st *GuildWelcomeScreen, err error) {
+	body, err := s.RequestWithBucketID("PATCH", EndpointGuildWelcomeScreen(guildID), data, EndpointGuildWelcomeScreen(guildID))
+	if err != nil {
+		return
+	}
+
+	err = s.unmarshal(body, &st)
+	return
+}
+
+// GuildOnboarding returns the onboarding of a community guild.
+// guildID : The ID of a Guild.
+func (s *Session) GuildOnboarding(guildID string) (st *GuildOnboarding, err error) {
+	body, err := s.RequestWithBucketID("GET", EndpointGuildOnboarding(guildID), nil, EndpointGuildOnboarding(guildID))
+	if err != nil {
+		return
+	}
+
+	err = s.unmarshal(body, &st)
+	return
+}
+
+// GuildOnboardingEdit edits the onboarding of a community guild, which
+// requires the manage guild and manage roles permissions.
+// guildID : The ID of a Guild.
+// data    : The changes to the onboarding.
+func (s *Session) GuildOnboardingEdit(guildID string, data *GuildOnboardingParams) (st *GuildOnboarding, err error) {
+	body, err := s.RequestWithBucketID("PUT", EndpointGuildOnboarding(guildID), data, EndpointGuildOnboarding(guildID))
+	if err != nil {
+		return
+	}
+
+	err = s.unmarshal(body, &st)
+	return
+}
+
+// ThreadStartComplex starts a thread in a channel without a message, eg. a
+// private thread which only its members and moderators can see.
+// channelID : The ID of a Channel.
+// data      : The name, type and settings of the thread.
+func (s *Session) ThreadStartComplex(channelID string, data *ThreadStart) (st *Channel, err error) {
+	body, err := s.RequestWithBucketID("POST", EndpointChannelThreads(channelID), data, EndpointChannelThreads(channelID))
+	if err != nil {
+		return
+	}
+
+	err = s.unmarshal(body, &st)
+	return
+}
+
+// ThreadMemberAdd adds a member to a thread, which requires the ability to
+// send messages in it.
+// threadID : The ID of a thread.
+// userID   : The ID of a User.
+func (s *Session) ThreadMemberAdd(threadID, userID string) (err error) {
+	_, err = s.RequestWithBucketID("PUT", EndpointThreadMember(threadID, userID), nil, EndpointThreadMember(threadID, ""))
+	return
+}
@@ -0,0 +1,104 @@
+// Code generated by \"restbindings\"; DO NOT EDIT
+// See restbindings.json
+
+package discordgo
+
+import (
+	"testing"
+)
+
+func TestGuildPreview(t *testing.T) {
+	tr := &routeTransport{handle: answer(`{}`)}
+	s := newTestSession(tr)
+	_, err := s.GuildPreview("1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := tr.last(); r != "GET "+EndpointAPI+"guilds/1/preview" {
+		t.Errorf("unexpected request %s", r)
+	}
+}
+
+func TestGuildVanityURL(t *testing.T) {
+	tr := &routeTransport{handle: answer(`{}`)}
+	s := newTestSession(tr)
+	_, err := s.GuildVanityURL("1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := tr.last(); r != "GET "+EndpointAPI+"guilds/1/vanity-url" {
+		t.Errorf("unexpected request %s", r)
+	}
+}
+
+func TestGuildWelcomeScreen(t *testing.T) {
+	tr := &routeTransport{handle: answer(`{}`)}
+	s := newTestSession(tr)
+	_, err := s.GuildWelcomeScreen("1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := tr.last(); r != "GET "+EndpointAPI+"guilds/1/welcome-screen" {
+		t.Errorf("unexpected request %s", r)
+	}
+}
+
+func TestGuildWelcomeScreenEdit(t *testing.T) {
+	tr := &routeTransport{handle: answer(`{}`)}
+	s := newTestSession(tr)
+	_, err := s.GuildWelcomeScreenEdit("1", &GuildWelcomeScreenParams{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := tr.last(); r != "PATCH "+EndpointAPI+"guilds/1/welcome-screen" {
+		t.Errorf("unexpected request %s", r)
+	}
+}
+
+func TestGuildOnboarding(t *testing.T) {
+	tr := &routeTransport{handle: answer(`{}`)}
+	s := newTestSession(tr)
+	_, err := s.GuildOnboarding("1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := tr.last(); r != "GET "+EndpointAPI+"guilds/1/onboarding" {
+		t.Errorf("unexpected request %s", r)
+	}
+}
+
+func TestGuildOnboardingEdit(t *testing.T) {
+	tr := &routeTransport{handle: answer(`{}`)}
+	s := newTestSession(tr)
+	_, err := s.GuildOnboardingEdit("1", &GuildOnboardingParams{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := tr.last(); r != "PUT "+EndpointAPI+"guilds/1/onboarding" {
+		t.Errorf("unexpected request %s", r)
+	}
+}
+
+func TestThreadStartComplex(t *testing.T) {
+	tr := &routeTransport{handle: answer(`{}`)}
+	s := newTestSession(tr)
+	_, err := s.ThreadStartComplex("1", &ThreadStart{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := tr.last(); r != "POST "+EndpointAPI+"channels/1/threads" {
+		t.Errorf("unexpected request %s", r)
+	}
+}
+
+func TestThreadMemberAdd(t *testing.T) {
+	tr := &routeTransport{handle: answer(`{}`)}
+	s := newTestSession(tr)
+	err := s.ThreadMemberAdd("1", "2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := tr.last(); r != "PUT "+EndpointAPI+"channels/1/thread-members/2" {
+		t.Errorf("unexpected request %s", r)
+	}
+}
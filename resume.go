@@ -0,0 +1,69 @@
+package discordgo
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+// ErrNoResumeState is returned by ResumeOnly when the session has no
+// gateway session to resume.
+var ErrNoResumeState = errors.New("no gateway session to resume")
+
+// ErrResumeInvalid is returned by ResumeOnly when the gateway session can't
+// be resumed anymore, a new one must be started with Open.
+var ErrResumeInvalid = errors.New("gateway session can't be resumed")
+
+// ResumeState holds what is needed to resume a gateway session, eg. in
+// another process during a deployment.
+type ResumeState struct {
+	SessionID        string `json:"session_id"`
+	Sequence         int64  `json:"sequence"`
+	ResumeGatewayURL string `json:"resume_gateway_url"`
+}
+
+// ResumeState returns the state of the gateway session. To hand the session
+// off to another process, close the connection with a code which keeps it
+// resumable, eg. CloseWithCode(websocket.CloseServiceRestart), as Close
+// ends the session, and pass the state to SetResumeState of the new process.
+func (s *Session) ResumeState() *ResumeState {
+	s.RLock()
+	defer s.RUnlock()
+	s.readyMu.RLock()
+	defer s.readyMu.RUnlock()
+
+	st := &ResumeState{SessionID: s.sessionID, ResumeGatewayURL: s.resumeGatewayURL}
+	if s.sequence != nil {
+		st.Sequence = atomic.LoadInt64(s.sequence)
+	}
+	return st
+}
+
+// SetResumeState sets the state of the gateway session, which is resumed by
+// the next Open or ResumeOnly. It must be called before the session is opened.
+func (s *Session) SetResumeState(st *ResumeState) {
+	s.Lock()
+	defer s.Unlock()
+	s.readyMu.Lock()
+	defer s.readyMu.Unlock()
+
+	s.sessionID = st.SessionID
+	s.resumeGatewayURL = st.ResumeGatewayURL
+	if s.sequence == nil {
+		s.sequence = new(int64)
+	}
+	atomic.StoreInt64(s.sequence, st.Sequence)
+}
+
+// ResumeOnly opens the session like Open, but only by resuming the gateway
+// session set with SetResumeState. If the session can't be resumed,
+// ErrResumeInvalid is returned instead of identifying with a new session.
+func (s *Session) ResumeOnly() error {
+	if s.ResumeState().SessionID == "" {
+		return ErrNoResumeState
+	}
+
+	atomic.StoreInt32(&s.resumeOnly, 1)
+	defer atomic.StoreInt32(&s.resumeOnly, 0)
+
+	return s.Open()
+}
@@ -0,0 +1,39 @@
+package discordgo
+
+import "testing"
+
+func TestResumeState(t *testing.T) {
+	s := &Session{}
+	if err := s.ResumeOnly(); err != ErrNoResumeState {
+		t.Errorf("expected ErrNoResumeState, got %v", err)
+	}
+
+	want := ResumeState{SessionID: "session", Sequence: 42, ResumeGatewayURL: "wss://resume.discord.gg"}
+	s.SetResumeState(&want)
+	if st := s.ResumeState(); *st != want {
+		t.Errorf("expected %+v, got %+v", want, *st)
+	}
+}
+
+func TestResumeStateReady(t *testing.T) {
+	s := &Session{}
+	s.SetResumeState(&ResumeState{Sequence: 1})
+
+	// The first Ready event is handled by Open, which holds the lock.
+	s.Lock()
+	s.onInterface(&Ready{SessionID: "session", ResumeGatewayURL: "wss://resume.discord.gg", Application: &Application{ID: "1"}})
+	s.Unlock()
+
+	// Later ones are handled by listen, concurrently with the getters.
+	done := make(chan struct{})
+	go func() {
+		s.onInterface(&Ready{SessionID: "other", ResumeGatewayURL: "wss://resume.discord.gg"})
+		close(done)
+	}()
+	s.ResumeState()
+	<-done
+
+	if st := s.ResumeState(); st.SessionID != "other" || st.Sequence != 1 {
+		t.Errorf("expected the session of the last Ready, got %+v", st)
+	}
+}
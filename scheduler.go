@@ -0,0 +1,276 @@
+package discordgo
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrScheduledActionNotFound is returned when cancelling an action which
+// is not scheduled, eg. because it already ran.
+var ErrScheduledActionNotFound = errors.New("scheduled action not found")
+
+// ErrScheduledActionKind is returned when scheduling an action of a kind
+// no handler was added for.
+var ErrScheduledActionKind = errors.New("no handler for scheduled action kind")
+
+// ErrNoScheduler is returned when scheduling an action, or creating a
+// feature which schedules its actions, for a session without a Scheduler.
+var ErrNoScheduler = errors.New("session has no scheduler")
+
+// ErrScheduledMessageFiles is returned when scheduling a message with files,
+// which can't be persisted.
+var ErrScheduledMessageFiles = errors.New("scheduled messages can not contain files")
+
+// ErrScheduledMessageEmpty is returned when scheduling a nil message.
+var ErrScheduledMessageEmpty = errors.New("scheduled message is nil")
+
+// A ScheduledAction is an action which a Scheduler runs at a given time.
+type ScheduledAction struct {
+	ID string `json:"id"`
+
+	// The kind of the action, which selects its handler.
+	Kind string `json:"kind"`
+
+	At time.Time `json:"at"`
+
+	// The JSON encoded data passed to the handler.
+	Data json.RawMessage `json:"data"`
+}
+
+// A ScheduleStore persists the actions of a Scheduler, so they survive a
+// restart of the bot.
+type ScheduleStore interface {
+	// ScheduleSave saves a newly scheduled action.
+	ScheduleSave(a *ScheduledAction) error
+
+	// ScheduleDelete deletes an action which ran or was cancelled.
+	ScheduleDelete(id string) error
+
+	// ScheduleLoad returns all saved actions.
+	ScheduleLoad() ([]*ScheduledAction, error)
+}
+
+// ScheduledActionHandler runs a scheduled action.
+type ScheduledActionHandler func(s *Session, a *ScheduledAction) error
+
+// Scheduled action kinds handled by every Scheduler.
+const (
+	ScheduledActionChannelMessageSend = "channel_message_send"
+)
+
+// A Scheduler runs actions at a given time. Actions are kept in memory and
+// saved to the Store if one is set, call Load after creating the Scheduler
+// to schedule the saved actions again. Actions whose time has passed when
+// they are loaded run immediately.
+type Scheduler struct {
+	sync.Mutex
+
+	// Store persists the actions, it is optional.
+	Store ScheduleStore
+
+	session  *Session
+	handlers map[string]ScheduledActionHandler
+	actions  map[string]*scheduledAction
+	lastID   int64
+}
+
+// scheduledAction is a ScheduledAction waiting to run.
+type scheduledAction struct {
+	*ScheduledAction
+	timer *time.Timer
+}
+
+// NewScheduler returns a new Scheduler which runs actions for s.
+func NewScheduler(s *Session) *Scheduler {
+	sc := &Scheduler{
+		session:  s,
+		handlers: map[string]ScheduledActionHandler{},
+		actions:  map[string]*scheduledAction{},
+	}
+
+	sc.AddHandler(ScheduledActionChannelMessageSend, scheduledChannelMessageSend)
+	return sc
+}
+
+// AddHandler adds the handler for actions of the given kind, replacing
+// any previous handler of the kind.
+func (sc *Scheduler) AddHandler(kind string, handler ScheduledActionHandler) {
+	sc.Lock()
+	defer sc.Unlock()
+
+	sc.handlers[kind] = handler
+}
+
+// Schedule schedules an action of the given kind, data is JSON encoded and
+// passed to the handler of the kind at the given time. The returned ID can
+// be used to cancel the action.
+func (sc *Scheduler) Schedule(kind string, at time.Time, data interface{}) (id string, err error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return
+	}
+
+	sc.Lock()
+	if _, ok := sc.handlers[kind]; !ok {
+		sc.Unlock()
+		return "", ErrScheduledActionKind
+	}
+
+	// IDs only need to be unique, the time keeps them unique across restarts.
+	sc.lastID++
+	id = strconv.FormatInt(time.Now().UnixNano(), 36) + "-" + strconv.FormatInt(sc.lastID, 36)
+	sc.Unlock()
+
+	a := &ScheduledAction{ID: id, Kind: kind, At: at, Data: raw}
+	if sc.Store != nil {
+		if err = sc.Store.ScheduleSave(a); err != nil {
+			return "", err
+		}
+	}
+
+	sc.add(a)
+	return
+}
+
+// add starts the timer of an action.
+func (sc *Scheduler) add(a *ScheduledAction) {
+	sc.Lock()
+	defer sc.Unlock()
+
+	sa := &scheduledAction{ScheduledAction: a}
+	sa.timer = time.AfterFunc(time.Until(a.At), func() {
+		sc.run(sa)
+	})
+	sc.actions[a.ID] = sa
+}
+
+// run runs an action and removes it.
+func (sc *Scheduler) run(sa *scheduledAction) {
+	sc.Lock()
+	if sc.actions[sa.ID] != sa {
+		// Cancelled after the timer fired.
+		sc.Unlock()
+		return
+	}
+	delete(sc.actions, sa.ID)
+	handler := sc.handlers[sa.Kind]
+	sc.Unlock()
+
+	if handler == nil {
+		sc.session.log(LogError, "no handler for scheduled action %s of kind %s", sa.ID, sa.Kind)
+	} else if err := handler(sc.session, sa.ScheduledAction); err != nil {
+		sc.session.log(LogError, "error running scheduled action %s, %s", sa.ID, err)
+	}
+
+	// Failed actions are deleted too, otherwise they would run again after every restart.
+	if sc.Store != nil {
+		if err := sc.Store.ScheduleDelete(sa.ID); err != nil {
+			sc.session.log(LogError, "error deleting scheduled action %s, %s", sa.ID, err)
+		}
+	}
+}
+
+// Cancel cancels the action with the given ID.
+func (sc *Scheduler) Cancel(id string) error {
+	sc.Lock()
+	sa, ok := sc.actions[id]
+	if ok {
+		sa.timer.Stop()
+		delete(sc.actions, id)
+	}
+	sc.Unlock()
+
+	if !ok {
+		return ErrScheduledActionNotFound
+	}
+
+	if sc.Store != nil {
+		return sc.Store.ScheduleDelete(id)
+	}
+	return nil
+}
+
+// Actions returns all scheduled actions.
+func (sc *Scheduler) Actions() (actions []*ScheduledAction) {
+	sc.Lock()
+	defer sc.Unlock()
+
+	for _, sa := range sc.actions {
+		actions = append(actions, sa.ScheduledAction)
+	}
+	return
+}
+
+// Load schedules the actions saved in the Store.
+func (sc *Scheduler) Load() error {
+	if sc.Store == nil {
+		return nil
+	}
+
+	actions, err := sc.Store.ScheduleLoad()
+	if err != nil {
+		return err
+	}
+
+	for _, a := range actions {
+		sc.Lock()
+		_, ok := sc.actions[a.ID]
+		sc.Unlock()
+
+		if !ok {
+			sc.add(a)
+		}
+	}
+	return nil
+}
+
+// Stop stops all scheduled actions without deleting them from the Store,
+// eg. before shutting down.
+func (sc *Scheduler) Stop() {
+	sc.Lock()
+	defer sc.Unlock()
+
+	for id, sa := range sc.actions {
+		sa.timer.Stop()
+		delete(sc.actions, id)
+	}
+}
+
+// scheduledMessage is the data of a ScheduledActionChannelMessageSend action.
+type scheduledMessage struct {
+	ChannelID string       `json:"channel_id"`
+	Message   *MessageSend `json:"message"`
+}
+
+func scheduledChannelMessageSend(s *Session, a *ScheduledAction) error {
+	var m scheduledMessage
+	if err := json.Unmarshal(a.Data, &m); err != nil {
+		return err
+	}
+
+	_, err := s.ChannelMessageSendComplex(m.ChannelID, m.Message)
+	return err
+}
+
+// ChannelMessageSendAt schedules a message to be sent to the given channel
+// with the Scheduler of the session. The returned ID can be passed to
+// Scheduler.Cancel to cancel the message. Messages with files can't be scheduled.
+// channelID : The ID of a Channel.
+// data      : The message struct to send.
+// at        : The time to send the message at.
+func (s *Session) ChannelMessageSendAt(channelID string, data *MessageSend, at time.Time) (id string, err error) {
+	if s.Scheduler == nil {
+		return "", ErrNoScheduler
+	}
+	if data == nil {
+		return "", ErrScheduledMessageEmpty
+	}
+	if len(data.Files) > 0 || data.File != nil {
+		return "", ErrScheduledMessageFiles
+	}
+
+	return s.Scheduler.Schedule(ScheduledActionChannelMessageSend, at, &scheduledMessage{channelID, data})
+}
@@ -0,0 +1,75 @@
+package discordgo
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScheduler(t *testing.T) {
+	sc := NewScheduler(&Session{})
+	defer sc.Stop()
+
+	ran := make(chan string, 2)
+	sc.AddHandler("test", func(s *Session, a *ScheduledAction) error {
+		ran <- string(a.Data)
+		return nil
+	})
+
+	if _, err := sc.Schedule("unknown", time.Now(), nil); err != ErrScheduledActionKind {
+		t.Fatalf("expected ErrScheduledActionKind, got %v", err)
+	}
+
+	cancelled, err := sc.Schedule("test", time.Now().Add(50*time.Millisecond), "cancelled")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = sc.Schedule("test", time.Now().Add(100*time.Millisecond), "ran"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = sc.Cancel(cancelled); err != nil {
+		t.Fatal(err)
+	}
+	if err = sc.Cancel(cancelled); err != ErrScheduledActionNotFound {
+		t.Fatalf("expected ErrScheduledActionNotFound, got %v", err)
+	}
+
+	select {
+	case data := <-ran:
+		if data != `"ran"` {
+			t.Fatalf("expected the second action to run, got %s", data)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the scheduled action")
+	}
+
+	if len(sc.Actions()) != 0 {
+		t.Fatalf("expected no scheduled actions left, got %d", len(sc.Actions()))
+	}
+}
+
+func TestChannelMessageSendAt(t *testing.T) {
+	s := &Session{}
+	at := time.Now().Add(time.Hour)
+	if _, err := s.ChannelMessageSendAt("1", &MessageSend{Content: "hi"}, at); err != ErrNoScheduler {
+		t.Errorf("expected ErrNoScheduler, got %v", err)
+	}
+
+	s.Scheduler = NewScheduler(s)
+	defer s.Scheduler.Stop()
+
+	if _, err := s.ChannelMessageSendAt("1", nil, at); err != ErrScheduledMessageEmpty {
+		t.Errorf("expected ErrScheduledMessageEmpty, got %v", err)
+	}
+	if _, err := s.ChannelMessageSendAt("1", &MessageSend{Files: []*File{{Name: "a.txt"}}}, at); err != ErrScheduledMessageFiles {
+		t.Errorf("expected ErrScheduledMessageFiles, got %v", err)
+	}
+
+	id, err := s.ChannelMessageSendAt("1", &MessageSend{Content: "hi"}, at)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actions := s.Scheduler.Actions(); len(actions) != 1 || actions[0].ID != id || actions[0].Kind != ScheduledActionChannelMessageSend {
+		t.Errorf("expected the scheduled message, got %+v", actions)
+	}
+}
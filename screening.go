@@ -0,0 +1,25 @@
+package discordgo
+
+import "errors"
+
+// ErrMemberPending is returned for members who have not passed the
+// membership screening of the guild yet, whose roles can't be changed.
+var ErrMemberPending = errors.New("member has not passed membership screening")
+
+// PassedScreening returns whether the member passed the membership
+// screening of the guild with this update. It requires the member to have
+// been cached in the state, see State.TrackMembers.
+func (m *GuildMemberUpdate) PassedScreening() bool {
+	return m.Member != nil && m.BeforeUpdate != nil && m.BeforeUpdate.Pending && !m.Pending
+}
+
+// memberPending returns whether the member is cached in the state and
+// has not passed the membership screening of the guild yet.
+func memberPending(s *Session, guildID, userID string) bool {
+	if s.State == nil {
+		return false
+	}
+
+	m, err := s.State.Member(guildID, userID)
+	return err == nil && m.Pending
+}
@@ -0,0 +1,33 @@
+package discordgo
+
+import "testing"
+
+func TestPassedScreening(t *testing.T) {
+	se := &Session{StateEnabled: true, State: NewState()}
+	se.State.OnInterface(se, &GuildCreate{&Guild{ID: "1"}})
+	se.State.OnInterface(se, &GuildMemberAdd{&Member{GuildID: "1", User: &User{ID: "2"}, Pending: true}})
+
+	if !memberPending(se, "1", "2") || memberPending(se, "1", "3") {
+		t.Error("expected only member 2 to be pending")
+	}
+
+	update := &GuildMemberUpdate{Member: &Member{GuildID: "1", User: &User{ID: "2"}, Pending: true}}
+	se.State.OnInterface(se, update)
+	if update.PassedScreening() {
+		t.Error("expected a pending member not to pass screening")
+	}
+
+	update = &GuildMemberUpdate{Member: &Member{GuildID: "1", User: &User{ID: "2"}}}
+	se.State.OnInterface(se, update)
+	if !update.PassedScreening() {
+		t.Error("expected the member to pass screening")
+	}
+	if memberPending(se, "1", "2") {
+		t.Error("expected the member not to be pending anymore")
+	}
+
+	// Members who weren't cached can't be diffed.
+	if (&GuildMemberUpdate{Member: &Member{GuildID: "1", User: &User{ID: "3"}}}).PassedScreening() {
+		t.Error("expected an uncached member not to pass screening")
+	}
+}
@@ -0,0 +1,90 @@
+package discordgo
+
+import "fmt"
+
+// ErrMissingPermission is returned by TrySendMessage and ChannelVoiceJoin
+// when the permissions cached in the state show that a message can't be sent
+// to a channel or a voice channel can't be joined.
+type ErrMissingPermission struct {
+	// The missing permission, eg. PermissionSendMessages.
+	Perm      int
+	ChannelID string
+}
+
+// Error returns the name of the missing permission.
+func (e *ErrMissingPermission) Error() string {
+	name, ok := permissionNames[e.Perm]
+	if !ok {
+		name = fmt.Sprintf("%#x", e.Perm)
+	}
+	return "missing permission " + name + " in channel " + e.ChannelID
+}
+
+// sendPermissions returns the permissions needed to send the message, in
+// the order they should be reported.
+func sendPermissions(data *MessageSend) []int {
+	perms := []int{PermissionViewChannel, PermissionSendMessages}
+	if data.Embed != nil {
+		perms = append(perms, PermissionEmbedLinks)
+	}
+	if len(data.Files) > 0 || data.File != nil {
+		perms = append(perms, PermissionAttachFiles)
+	}
+	if data.Reference != nil {
+		perms = append(perms, PermissionReadMessageHistory)
+	}
+	if data.TTS {
+		perms = append(perms, PermissionSendTTSMessages)
+	}
+	return perms
+}
+
+// missingSendPermission returns the first permission the session user is
+// missing to send the message to the channel. It returns 0 if nothing is
+// missing or the permissions are not known from the state.
+func (s *Session) missingSendPermission(channelID string, data *MessageSend) int {
+	if s.State == nil || s.State.User == nil {
+		return 0
+	}
+
+	perms, err := s.State.UserChannelPermissions(s.State.User.ID, channelID)
+	if err != nil {
+		return 0
+	}
+
+	for _, p := range sendPermissions(data) {
+		if perms&p == 0 {
+			return p
+		}
+	}
+	return 0
+}
+
+// TrySendMessage sends a message to a channel after checking the permissions
+// cached in the state. If a permission is missing the message is sent to the
+// user as a DM instead, if dmUserID is set, or an *ErrMissingPermission is
+// returned otherwise. Check the ChannelID of the returned message to
+// find out whether the message was sent as a DM.
+// channelID : The ID of a Channel.
+// data      : The message struct to send.
+// dmUserID  : The ID of the User to DM if the channel can't be used, optional.
+func (s *Session) TrySendMessage(channelID string, data *MessageSend, dmUserID string) (st *Message, err error) {
+	perm := s.missingSendPermission(channelID, data)
+	if perm == 0 {
+		return s.ChannelMessageSendComplex(channelID, data)
+	}
+
+	if dmUserID == "" {
+		return nil, &ErrMissingPermission{Perm: perm, ChannelID: channelID}
+	}
+
+	c, err := s.UserChannelCreate(dmUserID)
+	if err != nil {
+		return
+	}
+
+	// Replies can't reference messages of other channels.
+	dm := *data
+	dm.Reference = nil
+	return s.ChannelMessageSendComplex(c.ID, &dm)
+}
@@ -0,0 +1,23 @@
+package discordgo
+
+import "testing"
+
+func TestTrySendMessageMissingPermission(t *testing.T) {
+	s := &Session{State: NewState()}
+	s.State.User = &User{ID: "bot"}
+
+	err := s.State.GuildAdd(&Guild{
+		ID:       "guild",
+		Roles:    []*Role{{ID: "guild", Permissions: PermissionViewChannel | PermissionSendMessages}},
+		Channels: []*Channel{{ID: "channel", GuildID: "guild"}},
+		Members:  []*Member{{GuildID: "guild", User: &User{ID: "bot"}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = s.TrySendMessage("channel", &MessageSend{Embed: &MessageEmbed{Title: "embed"}}, "")
+	if perr, ok := err.(*ErrMissingPermission); !ok || perr.Perm != PermissionEmbedLinks {
+		t.Errorf("expected a missing embed links permission error, got %v", err)
+	}
+}
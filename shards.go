@@ -0,0 +1,120 @@
+package discordgo
+
+import (
+	"strconv"
+	"time"
+)
+
+// DefaultShardIdentifyInterval is the default IdentifyInterval of a
+// ShardManager, the rate limit of identifies of most bots.
+const DefaultShardIdentifyInterval = 5 * time.Second
+
+// GuildShardID returns the ID of the shard which receives the events of a
+// guild. The events of DMs, and invalid guild IDs, go to shard 0.
+// guildID    : The ID of a Guild.
+// shardCount : The number of shards.
+func GuildShardID(guildID string, shardCount int) int {
+	id, err := strconv.ParseUint(guildID, 10, 64)
+	if err != nil || shardCount < 1 {
+		return 0
+	}
+	return int((id >> 22) % uint64(shardCount))
+}
+
+// A ShardManager runs the shards of a bot, one Session per shard. The
+// sessions share their rate limiter, as the REST rate limits are per bot.
+type ShardManager struct {
+	// The sessions of the shards, by shard ID. Configure them, eg. their
+	// Identify, with Broadcast before calling Open.
+	Shards []*Session
+
+	// The time to wait between opening two shards, 0 for
+	// DefaultShardIdentifyInterval.
+	IdentifyInterval time.Duration
+}
+
+// NewShardManager creates the sessions of the shards of a bot. Use the
+// Shards of GatewayBot for the recommended number of shards.
+// token      : The token of the bot, prefixed with "Bot ".
+// shardCount : The number of shards.
+func NewShardManager(token string, shardCount int) (*ShardManager, error) {
+	if shardCount < 1 {
+		return nil, ErrWSShardBounds
+	}
+
+	m := &ShardManager{Shards: make([]*Session, shardCount)}
+	for i := range m.Shards {
+		s, err := New(token)
+		if err != nil {
+			return nil, err
+		}
+		s.ShardID, s.ShardCount = i, shardCount
+		if i > 0 {
+			s.Ratelimiter = m.Shards[0].Ratelimiter
+		}
+		m.Shards[i] = s
+	}
+	return m, nil
+}
+
+// Open opens the shards one after the other, waiting IdentifyInterval
+// between them. If a shard fails the shards opened before it are closed.
+func (m *ShardManager) Open() error {
+	interval := m.IdentifyInterval
+	if interval == 0 {
+		interval = DefaultShardIdentifyInterval
+	}
+
+	for i, s := range m.Shards {
+		if i > 0 {
+			time.Sleep(interval)
+		}
+
+		if err := s.Open(); err != nil {
+			for _, opened := range m.Shards[:i] {
+				opened.Close()
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// Close closes all shards, and returns the first error.
+func (m *ShardManager) Close() (err error) {
+	for _, s := range m.Shards {
+		if closeErr := s.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	return
+}
+
+// AddHandler adds an event handler to all shards, see Session.AddHandler.
+// The returned function removes the handler from all shards.
+func (m *ShardManager) AddHandler(handler interface{}) func() {
+	removes := make([]func(), len(m.Shards))
+	for i, s := range m.Shards {
+		removes[i] = s.AddHandler(handler)
+	}
+
+	return func() {
+		for _, remove := range removes {
+			remove()
+		}
+	}
+}
+
+// ForGuild returns the session of the shard which receives the events of a
+// guild, which has to be used for its voice connections and member requests.
+// guildID : The ID of a Guild, or "" for DMs.
+func (m *ShardManager) ForGuild(guildID string) *Session {
+	return m.Shards[GuildShardID(guildID, len(m.Shards))]
+}
+
+// Broadcast calls f with the session of every shard, one after the other.
+func (m *ShardManager) Broadcast(f func(s *Session)) {
+	for _, s := range m.Shards {
+		f(s)
+	}
+}
@@ -0,0 +1,39 @@
+package discordgo
+
+import "testing"
+
+func TestGuildShardID(t *testing.T) {
+	// 81384788765712384 >> 22 is 19403645698.
+	if id := GuildShardID("81384788765712384", 16); id != 2 {
+		t.Errorf("unexpected shard %d", id)
+	}
+	if id := GuildShardID("", 16); id != 0 {
+		t.Errorf("expected shard 0 for DMs, got %d", id)
+	}
+}
+
+func TestShardManager(t *testing.T) {
+	if _, err := NewShardManager("Bot token", 0); err != ErrWSShardBounds {
+		t.Errorf("expected ErrWSShardBounds, got %v", err)
+	}
+
+	m, err := NewShardManager("Bot token", 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	count := 0
+	m.Broadcast(func(s *Session) {
+		if s.ShardID != count || s.ShardCount != 4 || s.Ratelimiter != m.Shards[0].Ratelimiter {
+			t.Errorf("unexpected shard %d of %d", s.ShardID, s.ShardCount)
+		}
+		count++
+	})
+	if count != 4 {
+		t.Errorf("expected 4 shards, got %d", count)
+	}
+
+	if s := m.ForGuild("81384788765712384"); s.ShardID != GuildShardID("81384788765712384", 4) {
+		t.Errorf("unexpected shard %d", s.ShardID)
+	}
+}
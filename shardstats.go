@@ -0,0 +1,110 @@
+package discordgo
+
+import (
+	"sync"
+	"time"
+)
+
+// ShardStats holds the statistics of the shards of a bot, from their state.
+type ShardStats struct {
+	Guilds int
+
+	// The sum of the member counts of the guilds, approximate as the
+	// members of multiple guilds are counted once per guild.
+	Members int
+
+	Channels int
+
+	// The statistics of every shard, by shard ID.
+	Shards []*ShardStat
+}
+
+// ShardStat holds the statistics of a shard.
+type ShardStat struct {
+	ID      int
+	Guilds  int
+	Latency time.Duration
+
+	// The statistics of the gateway messages of the shard.
+	Gateway *GatewayStats
+}
+
+// Stats returns the guild, member and channel counts of the shards from
+// their state, and the heartbeat latency and gateway statistics of each
+// shard.
+func (m *ShardManager) Stats() *ShardStats {
+	stats := &ShardStats{Shards: make([]*ShardStat, len(m.Shards))}
+	for i, s := range m.Shards {
+		stat := &ShardStat{ID: s.ShardID, Latency: s.HeartbeatLatency(), Gateway: s.GatewayStats()}
+		stats.Shards[i] = stat
+
+		if s.State == nil {
+			continue
+		}
+
+		s.State.RLock()
+		stat.Guilds = len(s.State.Guilds)
+		for _, g := range s.State.Guilds {
+			stats.Members += g.MemberCount
+			stats.Channels += len(g.Channels)
+		}
+		s.State.RUnlock()
+
+		stats.Guilds += stat.Guilds
+	}
+	return stats
+}
+
+// StatsEvery calls f with the Stats of the shards every interval, eg. to
+// post them to bot lists, until the returned function is called.
+// interval : The time between two calls of f.
+// f        : The function called with the stats.
+func (m *ShardManager) StatsEvery(interval time.Duration, f func(stats *ShardStats)) (stop func()) {
+	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
+
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				f(m.Stats())
+			case <-done:
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { close(done) })
+	}
+}
+
+// A StatsPoster posts the statistics of a bot, eg. to a bot list. Use
+// ShardStats.Guilds for the server count and Shards for the counts of the
+// shards.
+type StatsPoster interface {
+	PostStats(stats *ShardStats) error
+}
+
+// StatsPosterFunc is a function used as a StatsPoster.
+type StatsPosterFunc func(stats *ShardStats) error
+
+// PostStats calls f.
+func (f StatsPosterFunc) PostStats(stats *ShardStats) error {
+	return f(stats)
+}
+
+// PostStatsEvery posts the Stats of the shards with p every interval,
+// until the returned function is called. Errors are logged with the
+// session of shard 0, the next post is attempted anyway.
+// p        : The poster, eg. of a bot list.
+// interval : The time between two posts.
+func (m *ShardManager) PostStatsEvery(p StatsPoster, interval time.Duration) (stop func()) {
+	return m.StatsEvery(interval, func(stats *ShardStats) {
+		if err := p.PostStats(stats); err != nil {
+			m.Shards[0].log(LogWarning, "error posting stats, %s", err)
+		}
+	})
+}
@@ -0,0 +1,64 @@
+package discordgo
+
+import (
+	"testing"
+	"time"
+)
+
+func TestShardManagerStats(t *testing.T) {
+	m, err := NewShardManager("Bot token", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.Shards[0].State.GuildAdd(&Guild{ID: "1", MemberCount: 10, Channels: []*Channel{{ID: "3"}, {ID: "4"}}})
+	m.Shards[1].State.GuildAdd(&Guild{ID: "2", MemberCount: 5, Channels: []*Channel{{ID: "5"}}})
+
+	stats := m.Stats()
+	if stats.Guilds != 2 || stats.Members != 15 || stats.Channels != 3 || len(stats.Shards) != 2 || stats.Shards[1].Guilds != 1 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+
+	received := make(chan *ShardStats, 1)
+	stop := m.StatsEvery(time.Millisecond, func(stats *ShardStats) {
+		select {
+		case received <- stats:
+		default:
+		}
+	})
+	defer stop()
+
+	select {
+	case stats = <-received:
+		if stats.Guilds != 2 {
+			t.Errorf("unexpected stats %+v", stats)
+		}
+	case <-time.After(time.Second):
+		t.Error("stats were not reported")
+	}
+}
+
+func TestShardManagerPostStatsEvery(t *testing.T) {
+	m, err := NewShardManager("Bot token", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	posted := make(chan int, 1)
+	stop := m.PostStatsEvery(StatsPosterFunc(func(stats *ShardStats) error {
+		select {
+		case posted <- len(stats.Shards):
+		default:
+		}
+		return nil
+	}), time.Millisecond)
+	defer stop()
+
+	select {
+	case n := <-posted:
+		if n != 1 {
+			t.Errorf("expected 1 shard, got %d", n)
+		}
+	case <-time.After(time.Second):
+		t.Error("stats were not posted")
+	}
+}
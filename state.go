@@ -46,9 +46,19 @@ type State struct {
 	TrackVoice      bool
 	TrackPresences  bool
 
+	// The fraction of the guilds which must be unavailable for a
+	// GuildOutage event, eg. 0.1 for 10%. Zero disables the events.
+	OutageThreshold float64
+
 	guildMap   map[string]*Guild
 	channelMap map[string]*Channel
 	memberMap  map[string]map[string]*Member
+
+	// The number of cached members with a role, by guild and role ID.
+	roleCounts map[string]map[string]int
+
+	unavailableGuilds map[string]*Guild
+	outage            bool
 }
 
 // NewState creates an empty state.
@@ -58,24 +68,51 @@ func NewState() *State {
 			PrivateChannels: []*Channel{},
 			Guilds:          []*Guild{},
 		},
-		TrackChannels:  true,
-		TrackEmojis:    true,
-		TrackMembers:   true,
-		TrackRoles:     true,
-		TrackVoice:     true,
-		TrackPresences: true,
-		guildMap:       make(map[string]*Guild),
-		channelMap:     make(map[string]*Channel),
-		memberMap:      make(map[string]map[string]*Member),
+		TrackChannels:     true,
+		TrackEmojis:       true,
+		TrackMembers:      true,
+		TrackRoles:        true,
+		TrackVoice:        true,
+		TrackPresences:    true,
+		OutageThreshold:   DefaultOutageThreshold,
+		guildMap:          make(map[string]*Guild),
+		channelMap:        make(map[string]*Channel),
+		memberMap:         make(map[string]map[string]*Member),
+		roleCounts:        make(map[string]map[string]int),
+		unavailableGuilds: make(map[string]*Guild),
 	}
 }
 
 func (s *State) createMemberMap(guild *Guild) {
 	members := make(map[string]*Member)
+	counts := make(map[string]int)
 	for _, m := range guild.Members {
 		members[m.User.ID] = m
+		for _, r := range m.Roles {
+			counts[r]++
+		}
 	}
 	s.memberMap[guild.ID] = members
+	s.roleCounts[guild.ID] = counts
+}
+
+// updateRoleCounts updates the role counts of a guild for a member whose
+// roles changed from before to after. The state must be locked.
+func (s *State) updateRoleCounts(guildID string, before, after []string) {
+	counts, ok := s.roleCounts[guildID]
+	if !ok {
+		counts = make(map[string]int)
+		s.roleCounts[guildID] = counts
+	}
+
+	for _, r := range before {
+		if counts[r]--; counts[r] <= 0 {
+			delete(counts, r)
+		}
+	}
+	for _, r := range after {
+		counts[r]++
+	}
 }
 
 // GuildAdd adds a guild to the current world state, or
@@ -113,6 +150,9 @@ func (s *State) GuildAdd(guild *Guild) error {
 		if guild.Emojis == nil {
 			guild.Emojis = g.Emojis
 		}
+		if guild.Stickers == nil {
+			guild.Stickers = g.Stickers
+		}
 		if guild.Members == nil {
 			guild.Members = g.Members
 		}
@@ -150,6 +190,8 @@ func (s *State) GuildRemove(guild *Guild) error {
 	defer s.Unlock()
 
 	delete(s.guildMap, guild.ID)
+	delete(s.memberMap, guild.ID)
+	delete(s.roleCounts, guild.ID)
 
 	for i, g := range s.Guilds {
 		if g.ID == guild.ID {
@@ -208,6 +250,7 @@ func (s *State) PresenceAdd(guildID string, presence *Presence) error {
 			if presence.Nick != "" {
 				guild.Presences[i].Nick = presence.Nick
 			}
+			guild.Presences[i].ClientStatus = presence.ClientStatus
 
 			//Update the optionally sent user information
 			//ID Is a mandatory field so you should not need to check if it is empty
@@ -281,6 +324,55 @@ func (s *State) Presence(guildID, userID string) (*Presence, error) {
 	return nil, ErrStateNotFound
 }
 
+// MemberStatus returns the status of a member in a guild and their status
+// on each client from the cached presences. Members without a cached
+// presence are offline.
+// guildID : The ID of the guild.
+// userID  : The ID of the user.
+func (s *State) MemberStatus(guildID, userID string) (Status, ClientStatus) {
+	p, err := s.Presence(guildID, userID)
+	if err != nil || p.Status == "" {
+		return StatusOffline, ClientStatus{}
+	}
+	return p.Status, p.ClientStatus
+}
+
+// OnlineMembersWithRole returns the members of a guild with a role who
+// aren't offline, from the cached presences. Members who aren't cached are
+// built from their presence.
+// guildID : The ID of the guild.
+// roleID  : The ID of the role.
+func (s *State) OnlineMembersWithRole(guildID, roleID string) ([]*Member, error) {
+	if s == nil {
+		return nil, ErrNilState
+	}
+
+	guild, err := s.Guild(guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.RLock()
+	defer s.RUnlock()
+
+	var members []*Member
+	for _, p := range guild.Presences {
+		if p.User == nil || p.Status == "" || p.Status == StatusOffline {
+			continue
+		}
+
+		m, ok := s.memberMap[guildID][p.User.ID]
+		if !ok {
+			m = &Member{GuildID: guildID, User: p.User, Nick: p.Nick, Roles: p.Roles}
+		}
+		if memberHasRole(m, roleID) {
+			members = append(members, m)
+		}
+	}
+
+	return members, nil
+}
+
 // TODO: Consider moving Guild state update methods onto *Guild.
 
 // MemberAdd adds a member to the current world state, or
@@ -307,12 +399,14 @@ func (s *State) MemberAdd(member *Member) error {
 	if !ok {
 		members[member.User.ID] = member
 		guild.Members = append(guild.Members, member)
+		s.updateRoleCounts(member.GuildID, nil, member.Roles)
 	} else {
 		// We are about to replace `m` in the state with `member`, but first we need to
 		// make sure we preserve any fields that the `member` doesn't contain from `m`.
 		if member.JoinedAt == "" {
 			member.JoinedAt = m.JoinedAt
 		}
+		s.updateRoleCounts(member.GuildID, m.Roles, member.Roles)
 		*m = *member
 	}
 
@@ -338,11 +432,12 @@ func (s *State) MemberRemove(member *Member) error {
 		return ErrStateNotFound
 	}
 
-	_, ok = members[member.User.ID]
+	m, ok := members[member.User.ID]
 	if !ok {
 		return ErrStateNotFound
 	}
 	delete(members, member.User.ID)
+	s.updateRoleCounts(member.GuildID, m.Roles, nil)
 
 	for i, m := range guild.Members {
 		if m.User.ID == member.User.ID {
@@ -376,6 +471,30 @@ func (s *State) Member(guildID, userID string) (*Member, error) {
 	return nil, ErrStateNotFound
 }
 
+// RoleMemberCount returns the number of cached members of a guild with a
+// role, without iterating over the members. The count of the @everyone
+// role, whose ID is the ID of the guild, is the number of cached members.
+// guildID : The ID of the guild.
+// roleID  : The ID of the role.
+func (s *State) RoleMemberCount(guildID, roleID string) (int, error) {
+	if s == nil {
+		return 0, ErrNilState
+	}
+
+	s.RLock()
+	defer s.RUnlock()
+
+	members, ok := s.memberMap[guildID]
+	if !ok {
+		return 0, ErrStateNotFound
+	}
+	if roleID == guildID {
+		return len(members), nil
+	}
+
+	return s.roleCounts[guildID][roleID], nil
+}
+
 // RoleAdd adds a role to the current world state, or
 // updates it if it already exists.
 func (s *State) RoleAdd(guildID string, role *Role) error {
@@ -788,9 +907,12 @@ func (s *State) onReady(se *Session, r *Ready) (err error) {
 	// if state is disabled, store the bare essentials.
 	if !se.StateEnabled {
 		ready := Ready{
-			Version:   r.Version,
-			SessionID: r.SessionID,
-			User:      r.User,
+			Version:     r.Version,
+			SessionID:   r.SessionID,
+			SessionType: r.SessionType,
+			User:        r.User,
+			Shard:       r.Shard,
+			Application: r.Application,
 		}
 
 		s.Ready = ready
@@ -833,10 +955,21 @@ func (s *State) OnInterface(se *Session, i interface{}) (err error) {
 
 	switch t := i.(type) {
 	case *GuildCreate:
+		s.guildAvailable(t.ID)
 		err = s.GuildAdd(t.Guild)
 	case *GuildUpdate:
+		if old, err := s.Guild(t.ID); err == nil {
+			oldCopy := *old
+			t.BeforeUpdate = &oldCopy
+		}
+
 		err = s.GuildAdd(t.Guild)
 	case *GuildDelete:
+		if t.Unavailable {
+			s.guildUnavailable(t.Guild)
+		} else {
+			s.guildAvailable(t.ID)
+		}
 		err = s.GuildRemove(t.Guild)
 	case *GuildMemberAdd:
 		// Updates the MemberCount of the guild.
@@ -852,6 +985,11 @@ func (s *State) OnInterface(se *Session, i interface{}) (err error) {
 		}
 	case *GuildMemberUpdate:
 		if s.TrackMembers {
+			if old, err := s.Member(t.GuildID, t.User.ID); err == nil {
+				oldCopy := *old
+				t.BeforeUpdate = &oldCopy
+			}
+
 			err = s.MemberAdd(t.Member)
 		}
 	case *GuildMemberRemove:
@@ -885,6 +1023,11 @@ func (s *State) OnInterface(se *Session, i interface{}) (err error) {
 		}
 	case *GuildRoleUpdate:
 		if s.TrackRoles {
+			if old, err := s.Role(t.GuildID, t.Role.ID); err == nil {
+				oldCopy := *old
+				t.BeforeUpdate = &oldCopy
+			}
+
 			err = s.RoleAdd(t.GuildID, t.Role)
 		}
 	case *GuildRoleDelete:
@@ -901,12 +1044,31 @@ func (s *State) OnInterface(se *Session, i interface{}) (err error) {
 		}
 	case *ChannelUpdate:
 		if s.TrackChannels {
+			if old, err := s.Channel(t.ID); err == nil {
+				oldCopy := *old
+				t.BeforeUpdate = &oldCopy
+			}
+
 			err = s.ChannelAdd(t.Channel)
+
+			// The channel is diffed after the merge, which keeps the
+			// overwrites of the cached channel if the update has none.
+			if t.BeforeUpdate != nil {
+				t.Changes = DiffChannels(t.BeforeUpdate, t.Channel)
+			}
 		}
 	case *ChannelDelete:
 		if s.TrackChannels {
 			err = s.ChannelRemove(t.Channel)
 		}
+	case *ChannelPinsUpdate:
+		if s.TrackChannels {
+			s.Lock()
+			if c, ok := s.channelMap[t.ChannelID]; ok {
+				c.LastPinTimestamp = t.LastPinTimestamp
+			}
+			s.Unlock()
+		}
 	case *MessageCreate:
 		if s.MaxMessageCount != 0 {
 			err = s.MessageAdd(t.Message)
@@ -972,12 +1134,20 @@ func (s *State) OnInterface(se *Session, i interface{}) (err error) {
 				}
 
 			} else {
+				// Update a copy, MemberAdd diffs the roles of the cached
+				// member with the new ones to update the role counts.
+				updated := *m
+				if m.User != nil {
+					user := *m.User
+					updated.User = &user
+				}
+				m = &updated
 
 				if t.Nick != "" {
 					m.Nick = t.Nick
 				}
 
-				if t.User.Username != "" {
+				if t.User.Username != "" && m.User != nil {
 					m.User.Username = t.User.Username
 				}
 
@@ -1072,6 +1242,29 @@ func (s *State) UserColor(userID, channelID string) int {
 	return firstRoleColorColor(guild, member.Roles)
 }
 
+// MemberColor returns the color of a member's name in a guild, the color
+// of their highest colored role.
+// 0 is returned in cases of error, which is the color of @everyone.
+// guildID : The ID of the guild.
+// userID  : The ID of the user to calculate the color for.
+func (s *State) MemberColor(guildID, userID string) int {
+	if s == nil {
+		return 0
+	}
+
+	guild, err := s.Guild(guildID)
+	if err != nil {
+		return 0
+	}
+
+	member, err := s.Member(guildID, userID)
+	if err != nil {
+		return 0
+	}
+
+	return firstRoleColorColor(guild, member.Roles)
+}
+
 // MessageColor returns the color of the author's name as displayed
 // in the client associated with this message.
 func (s *State) MessageColor(message *Message) int {
@@ -0,0 +1,234 @@
+package discordgo
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestStateReady(t *testing.T) {
+	var r Ready
+	err := json.Unmarshal([]byte(`{"v":8,"session_id":"s","session_type":"normal","shard":[1,4],"application":{"id":"42","flags":4096},"geo_ordered_rtc_regions":["rotterdam","london"]}`), &r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	se := &Session{State: NewState()}
+	if err = se.State.OnInterface(se, &r); err != nil {
+		t.Fatal(err)
+	}
+	se.onReady(&r)
+
+	if se.State.Application == nil || se.State.Application.ID != "42" || se.State.Application.Flags != ApplicationFlagGatewayPresence {
+		t.Errorf("unexpected state application %+v", se.State.Application)
+	}
+	if a := se.CurrentApplication(); a == nil || a.ID != "42" {
+		t.Errorf("unexpected session application %+v", a)
+	}
+	if se.State.Shard == nil || *se.State.Shard != [2]int{1, 4} || se.State.SessionType != "normal" {
+		t.Errorf("unexpected shard %v or session type %q", se.State.Shard, se.State.SessionType)
+	}
+	if len(r.GeoOrderedRTCRegions) != 2 || r.GeoOrderedRTCRegions[0] != "rotterdam" {
+		t.Errorf("unexpected regions %v", r.GeoOrderedRTCRegions)
+	}
+}
+
+func TestStateMemberColor(t *testing.T) {
+	s := NewState()
+	err := s.GuildAdd(&Guild{ID: "1", Roles: []*Role{
+		{ID: "1", Position: 0},
+		{ID: "2", Position: 1, Color: ColorRed},
+		{ID: "3", Position: 2, Color: ColorBlurple},
+		{ID: "4", Position: 3},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = s.MemberAdd(&Member{GuildID: "1", User: &User{ID: "2"}, Roles: []string{"2", "3", "4"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if c := s.MemberColor("1", "2"); c != ColorBlurple {
+		t.Errorf("expected %x, got %x", ColorBlurple, c)
+	}
+	if c := s.MemberColor("1", "3"); c != 0 {
+		t.Errorf("expected no color for an unknown member, got %x", c)
+	}
+}
+
+func TestStateMemberStatus(t *testing.T) {
+	s := NewState()
+	err := s.GuildAdd(&Guild{ID: "1", Presences: []*Presence{
+		{User: &User{ID: "2"}, Status: StatusIdle, ClientStatus: ClientStatus{Mobile: StatusIdle}, Roles: []string{"3"}},
+		{User: &User{ID: "3"}, Status: StatusOffline, Roles: []string{"3"}},
+		{User: &User{ID: "4"}, Status: StatusOnline},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = s.MemberAdd(&Member{GuildID: "1", User: &User{ID: "4"}, Roles: []string{"3"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if status, clients := s.MemberStatus("1", "2"); status != StatusIdle || clients.Mobile != StatusIdle || clients.Desktop != "" {
+		t.Errorf("unexpected status %s %+v", status, clients)
+	}
+	if status, _ := s.MemberStatus("1", "5"); status != StatusOffline {
+		t.Errorf("expected an uncached member to be offline, got %s", status)
+	}
+
+	members, err := s.OnlineMembersWithRole("1", "3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(members) != 2 || members[0].User.ID != "2" || members[1].User.ID != "4" {
+		t.Errorf("unexpected members %v", members)
+	}
+}
+
+func TestStateOnlineMembersWithRolePartialPresence(t *testing.T) {
+	s := NewState()
+	err := s.GuildAdd(&Guild{ID: "1", Presences: []*Presence{
+		{Status: StatusOnline, Roles: []string{"3"}},
+		{User: &User{ID: "2"}, Status: StatusOnline, Roles: []string{"3"}},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	members, err := s.OnlineMembersWithRole("1", "3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(members) != 1 || members[0].User.ID != "2" {
+		t.Errorf("expected the presence without a user to be skipped, got %v", members)
+	}
+}
+
+func TestStateRoleMemberCount(t *testing.T) {
+	s := NewState()
+	err := s.GuildAdd(&Guild{ID: "1", Members: []*Member{
+		{GuildID: "1", User: &User{ID: "2"}, Roles: []string{"10", "11"}},
+		{GuildID: "1", User: &User{ID: "3"}, Roles: []string{"10"}},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	count := func(roleID string) int {
+		n, err := s.RoleMemberCount("1", roleID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return n
+	}
+
+	if n := count("10"); n != 2 {
+		t.Errorf("expected 2 members with role 10, got %d", n)
+	}
+
+	if err = s.MemberAdd(&Member{GuildID: "1", User: &User{ID: "4"}, Roles: []string{"11"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err = s.MemberAdd(&Member{GuildID: "1", User: &User{ID: "2"}, Roles: []string{"11"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err = s.MemberRemove(&Member{GuildID: "1", User: &User{ID: "3"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := count("10"); n != 0 {
+		t.Errorf("expected 0 members with role 10, got %d", n)
+	}
+	if n := count("11"); n != 2 {
+		t.Errorf("expected 2 members with role 11, got %d", n)
+	}
+	if n := count("1"); n != 2 {
+		t.Errorf("expected 2 members with @everyone, got %d", n)
+	}
+
+	if _, err = s.RoleMemberCount("5", "10"); err != ErrStateNotFound {
+		t.Errorf("expected ErrStateNotFound, got %v", err)
+	}
+
+	s.TrackPresences = false
+	for i := 0; i < 2; i++ {
+		err = s.OnInterface(&Session{StateEnabled: true}, &PresenceUpdate{
+			Presence: Presence{User: &User{ID: "2", Username: "renamed"}, Status: StatusOnline},
+			GuildID:  "1",
+			Roles:    []string{"10"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := count("10"); n != 1 {
+		t.Errorf("expected 1 member with role 10 after the presence updates, got %d", n)
+	}
+	if n := count("11"); n != 1 {
+		t.Errorf("expected 1 member with role 11 after the presence updates, got %d", n)
+	}
+	if m, _ := s.Member("1", "2"); m.User.Username != "renamed" {
+		t.Errorf("expected the username of the presence, got %s", m.User.Username)
+	}
+
+	if err = s.GuildRemove(&Guild{ID: "1"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := s.roleCounts["1"]; ok {
+		t.Error("expected the role counts of the removed guild to be dropped")
+	}
+	if _, ok := s.memberMap["1"]; ok {
+		t.Error("expected the members of the removed guild to be dropped")
+	}
+}
+
+func TestStateChannelPinsUpdate(t *testing.T) {
+	s, _ := New("")
+	s.SyncEvents = true
+	s.State.GuildAdd(&Guild{ID: "1", Channels: []*Channel{{ID: "2", GuildID: "1"}}})
+
+	pins := func(data string) {
+		if _, err := s.onEvent(websocket.TextMessage, []byte(`{"op": 0, "s": 1, "t": "CHANNEL_PINS_UPDATE", "d": `+data+`}`)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	pins(`{"guild_id": "1", "channel_id": "2", "last_pin_timestamp": "2021-01-02T03:04:05+00:00"}`)
+	if c, _ := s.State.Channel("2"); c.LastPinTimestamp != "2021-01-02T03:04:05+00:00" {
+		t.Errorf("expected the last pin timestamp, got %q", c.LastPinTimestamp)
+	}
+
+	// Unpinning the last pinned message clears the timestamp.
+	pins(`{"guild_id": "1", "channel_id": "2", "last_pin_timestamp": null}`)
+	if c, _ := s.State.Channel("2"); c.LastPinTimestamp != "" {
+		t.Errorf("expected no last pin timestamp, got %q", c.LastPinTimestamp)
+	}
+}
+
+func TestIntegrationEvents(t *testing.T) {
+	s, _ := New("")
+	s.SyncEvents = true
+
+	var created *IntegrationCreate
+	var deleted *IntegrationDelete
+	s.AddHandler(func(s *Session, e *IntegrationCreate) { created = e })
+	s.AddHandler(func(s *Session, e *IntegrationDelete) { deleted = e })
+
+	for _, event := range []string{
+		`{"op": 0, "s": 1, "t": "INTEGRATION_CREATE", "d": {"id": "1", "guild_id": "2", "type": "discord", "subscriber_count": 3, "application": {"id": "4", "bot": {"id": "5"}}}}`,
+		`{"op": 0, "s": 2, "t": "INTEGRATION_DELETE", "d": {"id": "1", "guild_id": "2", "application_id": "4"}}`,
+	} {
+		if _, err := s.onEvent(websocket.TextMessage, []byte(event)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if created == nil || created.GuildID != "2" || created.SubscriberCount != 3 || created.Application == nil || created.Application.Bot.ID != "5" {
+		t.Errorf("unexpected integration create %+v", created)
+	}
+	if deleted == nil || deleted.ID != "1" || deleted.ApplicationID != "4" {
+		t.Errorf("unexpected integration delete %+v", deleted)
+	}
+}
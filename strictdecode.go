@@ -0,0 +1,181 @@
+package discordgo
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// StrictDecodeMode is the mode of the strict decoding of the payloads of
+// Discord, see Session.StrictDecode.
+type StrictDecodeMode int
+
+// Block contains the valid known StrictDecodeMode values
+const (
+	// Payloads are decoded as they are, fields the structs don't have are
+	// dropped silently.
+	StrictDecodeOff StrictDecodeMode = iota
+
+	// Unknown fields and decode errors are logged with their path.
+	StrictDecodeLog
+
+	// REST functions return an *ErrStrictDecode for unknown fields or
+	// decode errors. Gateway events can't fail, they are logged as errors
+	// and dispatched as usual.
+	StrictDecodeError
+)
+
+// An ErrStrictDecode describes a payload which contained fields its struct
+// doesn't have, or failed to decode.
+type ErrStrictDecode struct {
+	// The Go type the payload was decoded into.
+	Type string
+
+	// The paths of the unknown fields, eg. guilds[0].new_field.
+	UnknownFields []string
+
+	// The decode error, if the payload failed to decode.
+	Err error
+}
+
+// Error returns a description of the unknown fields and decode error.
+func (e *ErrStrictDecode) Error() string {
+	msg := "strict decode of " + e.Type
+	if len(e.UnknownFields) > 0 {
+		msg += ", unknown fields: " + strings.Join(e.UnknownFields, ", ")
+	}
+	if e.Err != nil {
+		msg += ", " + e.Err.Error()
+	}
+	return msg
+}
+
+// unmarshal is unmarshal with the strict decoding of the session.
+func (s *Session) unmarshal(data []byte, v interface{}) error {
+	err := json.Unmarshal(data, v)
+
+	if s.StrictDecode != StrictDecodeOff {
+		if serr := strictDecodeCheck(data, v, err); serr != nil {
+			if s.StrictDecode == StrictDecodeError {
+				return serr
+			}
+			s.log(LogWarning, "%s", serr)
+		}
+	}
+
+	if err != nil {
+		return ErrJSONUnmarshal
+	}
+	return nil
+}
+
+// strictDecodeCheck returns an *ErrStrictDecode if the payload decoded
+// into v has unknown fields or failed to decode with err, otherwise nil.
+func strictDecodeCheck(data []byte, v interface{}, err error) *ErrStrictDecode {
+	unknown := unknownFields(data, reflect.TypeOf(v))
+	if err == nil && len(unknown) == 0 {
+		return nil
+	}
+	return &ErrStrictDecode{Type: reflect.TypeOf(v).String(), UnknownFields: unknown, Err: err}
+}
+
+var jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// unknownFields returns the sorted paths of the fields of the payload the
+// type doesn't have.
+func unknownFields(data []byte, t reflect.Type) []string {
+	var raw interface{}
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	if d.Decode(&raw) != nil {
+		return nil
+	}
+
+	var paths []string
+	walkUnknownFields(raw, t, "", &paths)
+	sort.Strings(paths)
+	return paths
+}
+
+// walkUnknownFields adds the paths of the fields of v the type doesn't have.
+// Types with their own decoding are not checked.
+func walkUnknownFields(v interface{}, t reflect.Type, path string, paths *[]string) {
+	for {
+		if t.Implements(jsonUnmarshalerType) || reflect.PtrTo(t).Implements(jsonUnmarshalerType) {
+			return
+		}
+		if t.Kind() != reflect.Ptr {
+			break
+		}
+		t = t.Elem()
+	}
+
+	switch v := v.(type) {
+	case map[string]interface{}:
+		switch t.Kind() {
+		case reflect.Struct:
+			fields := map[string]reflect.Type{}
+			jsonFields(t, fields)
+			for k, fv := range v {
+				// encoding/json matches field names case insensitively.
+				ft, ok := fields[strings.ToLower(k)]
+				if !ok {
+					*paths = append(*paths, joinFieldPath(path, k))
+					continue
+				}
+				walkUnknownFields(fv, ft, joinFieldPath(path, k), paths)
+			}
+		case reflect.Map:
+			for k, fv := range v {
+				walkUnknownFields(fv, t.Elem(), joinFieldPath(path, k), paths)
+			}
+		}
+	case []interface{}:
+		if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+			for i, e := range v {
+				walkUnknownFields(e, t.Elem(), path+"["+strconv.Itoa(i)+"]", paths)
+			}
+		}
+	}
+}
+
+// jsonFields adds the types of the fields of a struct by their lower case
+// JSON names, including the fields of embedded structs.
+func jsonFields(t reflect.Type, fields map[string]reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+
+		ft := f.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if f.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			jsonFields(ft, fields)
+			continue
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+		fields[strings.ToLower(name)] = f.Type
+	}
+}
+
+// joinFieldPath joins a field path and the name of a field.
+func joinFieldPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,37 @@
+package discordgo
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUnknownFields(t *testing.T) {
+	data := []byte(`{"id":"1","Name":"guild","new_field":1,"roles":[{"id":"2","color":1},{"id":"3","new_role_field":true}],"channels":[{"id":"4","permission_overwrites":[{"id":"5","new":1}]}],"unavailable":false}`)
+
+	got := unknownFields(data, reflect.TypeOf(&GuildCreate{}))
+	want := []string{"channels[0].permission_overwrites[0].new", "new_field", "roles[1].new_role_field"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestSessionUnmarshalStrict(t *testing.T) {
+	s := &Session{}
+	data := []byte(`{"id":"1","new_field":1}`)
+
+	var u *User
+	if err := s.unmarshal(data, &u); err != nil {
+		t.Errorf("expected no error when not strict, got %v", err)
+	}
+
+	s.StrictDecode = StrictDecodeError
+	err := s.unmarshal(data, &u)
+	serr, ok := err.(*ErrStrictDecode)
+	if !ok || len(serr.UnknownFields) != 1 || serr.UnknownFields[0] != "new_field" {
+		t.Errorf("expected an unknown field error, got %v", err)
+	}
+
+	if err = s.unmarshal([]byte(`{"id":1}`), &u); err == nil {
+		t.Error("expected a decode error")
+	}
+}
@@ -8,13 +8,20 @@
 // This file contains all structures for the discordgo package.  These
 // may be moved about later into separate files but I find it easier to have
 // them all located together.
+//
+// The structs can be checked for fields missing from Discord's OpenAPI
+// document by cloning https://github.com/discord/discord-api-spec into
+// the package directory and running go generate, see tools/cmd/structcoverage.
+//go:generate go run tools/cmd/structcoverage/main.go -schema discord-api-spec/specs/openapi.json
 
 package discordgo
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
 	"sync"
 	"time"
@@ -32,12 +39,21 @@ type Session struct {
 	// TODO: Remove Below, Deprecated, Use Identify struct
 	Token string
 
+	// TokenProvider, if set, provides the token instead of Token, so it can
+	// be rotated without a restart. Token is used if the provider fails.
+	TokenProvider TokenProvider
+
 	MFA bool
 
 	// Debug for printing JSON request/responses
 	Debug    bool // Deprecated, will be removed.
 	LogLevel int
 
+	// StrictDecode reports the fields of gateway events and REST responses
+	// the structs don't have, to discover changes of the API during
+	// development. Off by default.
+	StrictDecode StrictDecodeMode
+
 	// Should the session reconnect the websocket on errors.
 	ShouldReconnectOnError bool
 
@@ -62,6 +78,14 @@ type Session struct {
 	// e.g false = launch event handlers in their own goroutines.
 	SyncEvents bool
 
+	// Tracer, if set, is used to start a trace span for every event
+	// dispatched to context aware handlers.
+	Tracer EventTracer
+
+	// Scheduler runs scheduled actions, such as messages sent with
+	// ChannelMessageSendAt.
+	Scheduler *Scheduler
+
 	// Exposed but should not be modified by User.
 
 	// Whether the Data Websocket is ready
@@ -87,9 +111,32 @@ type Session struct {
 	// StateEnabled is true.
 	State *State
 
+	// Whether the Resolve functions add what they fetch from the API to
+	// the State, so the next calls find it there.
+	ResolveWriteBack bool
+
+	// Decides which messages of MessageCreate and MessageUpdate events
+	// received without content get it with Message.FetchContent before the
+	// event is handled, when the session identifies without
+	// IntentsMessageContent. Nil fetches none. The events wait for the
+	// request, up to ContentFallbackTimeout, and so do the events after them.
+	ContentFallback func(m *Message) bool
+
 	// The http client used for REST requests
 	Client *http.Client
 
+	// The size limit of decompressed REST responses in bytes, 0 for
+	// DefaultMaxResponseSize and negative for no limit.
+	MaxResponseSize int64
+
+	// The number of invalid requests in an InvalidRequestWindow after which
+	// requests are paused, 0 for DefaultInvalidRequestThreshold.
+	InvalidRequestThreshold int
+
+	// Returns the priority of a request in its rate limit bucket, nil for
+	// DefaultRequestPriority. See RequestWithPriority to set it per request.
+	RequestPriorityFunc func(method, urlStr string) RequestPriority
+
 	// The user agent used for REST APIs
 	UserAgent string
 
@@ -103,9 +150,19 @@ type Session struct {
 	Ratelimiter *RateLimiter
 
 	// Event handlers
-	handlersMu   sync.RWMutex
-	handlers     map[string][]*eventHandlerInstance
-	onceHandlers map[string][]*eventHandlerInstance
+	handlersMu            sync.RWMutex
+	handlers              map[string][]*eventHandlerInstance
+	onceHandlers          map[string][]*eventHandlerInstance
+	lastHandlerID         HandlerID
+	disabledHandlerGroups map[string]bool
+
+	// Modules added to the session
+	modulesMu sync.RWMutex
+	modules   map[string]*moduleEntry
+
+	// Hooks receiving the command events
+	commandHooksMu sync.RWMutex
+	commandHooks   []*commandHookEntry
 
 	// The websocket connection.
 	wsConn *websocket.Conn
@@ -119,22 +176,73 @@ type Session struct {
 	// stores sessions current Discord Gateway
 	gateway string
 
+	// guards sessionID, resumeGatewayURL and application, which the Ready
+	// event sets while Open may hold the lock of the session
+	readyMu sync.RWMutex
+
 	// stores session ID of current Gateway connection
 	sessionID string
 
+	// the gateway URL to resume the session with, from the Ready event
+	resumeGatewayURL string
+
+	// the application of the bot, from the Ready event
+	application *Application
+
+	// set to 1 while ResumeOnly opens the session
+	resumeOnly int32
+
 	// used to make sure gateway websocket writes do not happen concurrently
 	wsMutex sync.Mutex
+
+	// the last status sent with UpdateStatusComplex, sent again when
+	// identifying after reconnects. Guarded by wsMutex.
+	presence *UpdateStatusData
+
+	// counts the gateway sends to stay below the gateway rate limit
+	gatewaySends gatewaySendLimiter
+
+	// counts the messages received from the gateway
+	gatewayStats gatewayStatsCounter
+
+	// counts the invalid REST requests to stay below the Cloudflare ban
+	invalidRequests invalidRequestLimiter
+
+	// the API requests of the Resolve functions in flight
+	resolves resolveGroup
+
+	// ctx is the context of the current gateway connection, it is canceled
+	// when the connection is closed.
+	ctxMu     sync.RWMutex
+	ctx       context.Context
+	cancelCtx context.CancelFunc
 }
 
 // UserConnection is a Connection returned from the UserConnections endpoint
 type UserConnection struct {
-	ID           string         `json:"id"`
-	Name         string         `json:"name"`
-	Type         string         `json:"type"`
-	Revoked      bool           `json:"revoked"`
-	Integrations []*Integration `json:"integrations"`
+	ID           string               `json:"id"`
+	Name         string               `json:"name"`
+	Type         string               `json:"type"`
+	Revoked      bool                 `json:"revoked"`
+	Integrations []*Integration       `json:"integrations"`
+	Verified     bool                 `json:"verified"`
+	FriendSync   bool                 `json:"friend_sync"`
+	ShowActivity bool                 `json:"show_activity"`
+	Visibility   ConnectionVisibility `json:"visibility"`
 }
 
+// ConnectionVisibility is the visibility of a UserConnection
+// https://discord.com/developers/docs/resources/user#connection-object-visibility-types
+type ConnectionVisibility int
+
+// Block of valid ConnectionVisibility values
+const (
+	// Only the user can see the connection.
+	ConnectionVisibilityNone ConnectionVisibility = iota
+	// Everyone can see the connection.
+	ConnectionVisibilityEveryone
+)
+
 // Integration stores integration information
 type Integration struct {
 	ID                string             `json:"id"`
@@ -149,6 +257,22 @@ type Integration struct {
 	User              *User              `json:"user"`
 	Account           IntegrationAccount `json:"account"`
 	SyncedAt          Timestamp          `json:"synced_at"`
+
+	// The ID of the guild of the integration, only set in integration events.
+	GuildID string `json:"guild_id,omitempty"`
+
+	SubscriberCount int                     `json:"subscriber_count"`
+	Revoked         bool                    `json:"revoked"`
+	Application     *IntegrationApplication `json:"application"`
+}
+
+// IntegrationApplication is the bot or OAuth2 application of an Integration.
+type IntegrationApplication struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Icon        string `json:"icon"`
+	Description string `json:"description"`
+	Bot         *User  `json:"bot"`
 }
 
 //ExpireBehavior of Integration
@@ -205,6 +329,11 @@ type Invite struct {
 	TargetUser     *User          `json:"target_user"`
 	TargetUserType TargetUserType `json:"target_user_type"`
 
+	// The target of a voice channel invite, the user to watch for streams
+	// or the activity to launch for embedded applications.
+	TargetType        InviteTargetType `json:"target_type"`
+	TargetApplication *Application     `json:"target_application"`
+
 	// will only be filled when using InviteWithCounts
 	ApproximatePresenceCount int `json:"approximate_presence_count"`
 	ApproximateMemberCount   int `json:"approximate_member_count"`
@@ -219,6 +348,16 @@ const (
 	TargetUserTypeStream TargetUserType = iota
 )
 
+// InviteTargetType is the type of the target of a voice channel invite
+// https://discord.com/developers/docs/resources/invite#invite-object-invite-target-types
+type InviteTargetType int
+
+// Block contains known InviteTargetType values
+const (
+	InviteTargetStream              InviteTargetType = 1
+	InviteTargetEmbeddedApplication InviteTargetType = 2
+)
+
 // ChannelType is the type of a Channel
 type ChannelType int
 
@@ -233,6 +372,78 @@ const (
 	ChannelTypeGuildStore
 )
 
+// Channel types which don't follow the sequence above.
+const (
+	ChannelTypeGuildNewsThread    ChannelType = 10
+	ChannelTypeGuildPublicThread  ChannelType = 11
+	ChannelTypeGuildPrivateThread ChannelType = 12
+	ChannelTypeGuildStageVoice    ChannelType = 13
+	ChannelTypeGuildForum         ChannelType = 15
+)
+
+// ChannelFlags are the flags of a channel.
+type ChannelFlags int
+
+// Block contains known ChannelFlags values
+const (
+	// The thread is pinned to the top of its forum channel.
+	ChannelFlagPinned ChannelFlags = 1 << 1
+
+	// A tag is required to post in the forum channel.
+	ChannelFlagRequireTag ChannelFlags = 1 << 4
+)
+
+// VideoQualityMode is the camera video quality of a voice channel.
+type VideoQualityMode int
+
+// Block contains known VideoQualityMode values
+const (
+	// Discord chooses the quality for optimal performance.
+	VideoQualityModeAuto VideoQualityMode = 1
+	VideoQualityModeFull VideoQualityMode = 2
+)
+
+// ForumSortOrder is the order in which the posts of a forum channel are sorted.
+type ForumSortOrder int
+
+// Block contains known ForumSortOrder values
+const (
+	ForumSortOrderLatestActivity ForumSortOrder = iota
+	ForumSortOrderCreationDate
+)
+
+// ForumLayout is the default layout in which the posts of a forum channel are shown.
+type ForumLayout int
+
+// Block contains known ForumLayout values
+const (
+	ForumLayoutNotSet ForumLayout = iota
+	ForumLayoutListView
+	ForumLayoutGalleryView
+)
+
+// A ForumTag is a tag which can be applied to the posts of a forum channel.
+type ForumTag struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name"`
+
+	// Whether the tag can only be applied by members with the manage threads permission.
+	Moderated bool `json:"moderated"`
+
+	// The emoji of the tag, either the ID of a custom emoji or the unicode
+	// character of a standard emoji.
+	EmojiID   string `json:"emoji_id,omitempty"`
+	EmojiName string `json:"emoji_name,omitempty"`
+}
+
+// A ForumDefaultReaction is the emoji shown in the add reaction button of
+// the posts of a forum channel, either the ID of a custom emoji or the
+// unicode character of a standard emoji.
+type ForumDefaultReaction struct {
+	EmojiID   string `json:"emoji_id,omitempty"`
+	EmojiName string `json:"emoji_name,omitempty"`
+}
+
 // A Channel holds all data related to an individual Discord channel.
 type Channel struct {
 	// The ID of the channel.
@@ -296,6 +507,34 @@ type Channel struct {
 
 	// ApplicationID of the DM creator Zeroed if guild channel or not a bot user
 	ApplicationID string `json:"application_id"`
+
+	// The flags of the channel.
+	Flags ChannelFlags `json:"flags"`
+
+	// The IDs of the forum tags applied to the forum post.
+	AppliedTags []string `json:"applied_tags"`
+
+	// The voice region of the voice channel, empty for automatic.
+	RTCRegion string `json:"rtc_region"`
+
+	// The camera video quality of the voice channel.
+	VideoQualityMode VideoQualityMode `json:"video_quality_mode"`
+
+	// The default auto archive duration in minutes of new threads in the channel.
+	DefaultAutoArchiveDuration int `json:"default_auto_archive_duration"`
+
+	// The tags which can be applied to the posts of the forum channel.
+	AvailableTags []*ForumTag `json:"available_tags"`
+
+	// The emoji shown in the add reaction button of the posts of the forum channel.
+	DefaultReactionEmoji *ForumDefaultReaction `json:"default_reaction_emoji"`
+
+	// The slowmode of new threads in the channel.
+	DefaultThreadRateLimitPerUser int `json:"default_thread_rate_limit_per_user"`
+
+	// The default sort order and layout of the posts of the forum channel.
+	DefaultSortOrder   *ForumSortOrder `json:"default_sort_order"`
+	DefaultForumLayout ForumLayout     `json:"default_forum_layout"`
 }
 
 // Mention returns a string which mentions the channel
@@ -308,16 +547,61 @@ func (c *Channel) Send(text string, session *Session) (*Message, error) {
 }
 
 // A ChannelEdit holds Channel Field data for a channel edit.
+// Empty and nil fields are not changed, pointer fields can be set to the
+// zero value, eg. to disable slowmode, remove the topic or move the channel
+// out of its category.
 type ChannelEdit struct {
 	Name                 string                 `json:"name,omitempty"`
-	Topic                string                 `json:"topic,omitempty"`
-	NSFW                 bool                   `json:"nsfw,omitempty"`
-	Position             int                    `json:"position"`
+	Topic                *string                `json:"topic,omitempty"`
+	NSFW                 *bool                  `json:"nsfw,omitempty"`
+	Position             *int                   `json:"position,omitempty"`
 	Bitrate              int                    `json:"bitrate,omitempty"`
-	UserLimit            int                    `json:"user_limit,omitempty"`
+	UserLimit            *int                   `json:"user_limit,omitempty"`
 	PermissionOverwrites []*PermissionOverwrite `json:"permission_overwrites,omitempty"`
-	ParentID             string                 `json:"parent_id,omitempty"`
-	RateLimitPerUser     int                    `json:"rate_limit_per_user,omitempty"`
+	ParentID             *string                `json:"parent_id,omitempty"`
+	RateLimitPerUser     *int                   `json:"rate_limit_per_user,omitempty"`
+	Flags                *ChannelFlags          `json:"flags,omitempty"`
+
+	// Group DM channels only, a base64 encoded data URI of the new icon.
+	Icon string `json:"icon,omitempty"`
+
+	// Voice channels only, an empty RTCRegion selects the region
+	// automatically.
+	RTCRegion        *string          `json:"rtc_region,omitempty"`
+	VideoQualityMode VideoQualityMode `json:"video_quality_mode,omitempty"`
+
+	// Forum channels only.
+	AvailableTags                 []*ForumTag           `json:"available_tags,omitempty"`
+	DefaultReactionEmoji          *ForumDefaultReaction `json:"default_reaction_emoji,omitempty"`
+	DefaultThreadRateLimitPerUser *int                  `json:"default_thread_rate_limit_per_user,omitempty"`
+	DefaultAutoArchiveDuration    int                   `json:"default_auto_archive_duration,omitempty"`
+	DefaultSortOrder              *ForumSortOrder       `json:"default_sort_order,omitempty"`
+	DefaultForumLayout            ForumLayout           `json:"default_forum_layout,omitempty"`
+
+	// Threads only, AppliedTags are the IDs of the forum tags of a forum post.
+	Archived            *bool     `json:"archived,omitempty"`
+	Locked              *bool     `json:"locked,omitempty"`
+	Invitable           *bool     `json:"invitable,omitempty"`
+	AutoArchiveDuration int       `json:"auto_archive_duration,omitempty"`
+	AppliedTags         *[]string `json:"applied_tags,omitempty"`
+}
+
+// A ThreadStart holds the data of a thread started without a message.
+type ThreadStart struct {
+	Name string `json:"name"`
+
+	// The minutes of inactivity after which the thread is archived, one
+	// of 60, 1440, 4320 or 10080.
+	AutoArchiveDuration int `json:"auto_archive_duration,omitempty"`
+
+	// ChannelTypeGuildPrivateThread for a private thread, public otherwise.
+	Type ChannelType `json:"type,omitempty"`
+
+	// Private threads only, whether members who aren't moderators can
+	// add other members.
+	Invitable *bool `json:"invitable,omitempty"`
+
+	RateLimitPerUser int `json:"rate_limit_per_user,omitempty"`
 }
 
 // A ChannelFollow holds data returned after following a news channel
@@ -346,6 +630,29 @@ type Emoji struct {
 	Available     bool     `json:"available"`
 }
 
+// StickerFormatType is the file format of a Sticker.
+type StickerFormatType int
+
+// Valid StickerFormatType values
+const (
+	StickerFormatTypePNG    StickerFormatType = 1
+	StickerFormatTypeAPNG   StickerFormatType = 2
+	StickerFormatTypeLottie StickerFormatType = 3
+	StickerFormatTypeGIF    StickerFormatType = 4
+)
+
+// A Sticker is a custom sticker of a guild.
+type Sticker struct {
+	ID          string            `json:"id"`
+	GuildID     string            `json:"guild_id"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Tags        string            `json:"tags"`
+	FormatType  StickerFormatType `json:"format_type"`
+	Available   bool              `json:"available"`
+	User        *User             `json:"user"`
+}
+
 // MessageFormat returns a correctly formatted Emoji for use in Message content and embeds
 func (e *Emoji) MessageFormat() string {
 	if e.ID != "" && e.Name != "" {
@@ -370,6 +677,46 @@ func (e *Emoji) APIName() string {
 	return e.ID
 }
 
+// Identifier returns the EmojiIdentifier of the emoji for the reaction endpoints.
+func (e *Emoji) Identifier() EmojiIdentifier {
+	return EmojiIdentifier(e.APIName())
+}
+
+// An EmojiIdentifier identifies the emoji of a reaction, either a unicode
+// emoji or a custom emoji in the name:id format. Untyped string constants
+// convert to it, eg. MessageReactionAdd(channelID, messageID, "👍").
+type EmojiIdentifier string
+
+// UnicodeEmoji returns the EmojiIdentifier of a unicode emoji.
+func UnicodeEmoji(emoji string) EmojiIdentifier {
+	return EmojiIdentifier(emoji)
+}
+
+// CustomEmoji returns the EmojiIdentifier of a custom emoji.
+// name : The name of the emoji.
+// id   : The ID of the emoji.
+func CustomEmoji(name, id string) EmojiIdentifier {
+	return EmojiIdentifier(name + ":" + id)
+}
+
+// ParseEmojiIdentifier returns the EmojiIdentifier of an emoji in any of the
+// formats users write them in: a unicode emoji, the <:name:id> or
+// <a:name:id> message format, or name:id.
+func ParseEmojiIdentifier(emoji string) EmojiIdentifier {
+	emoji = strings.TrimSpace(emoji)
+	if strings.HasPrefix(emoji, "<") && strings.HasSuffix(emoji, ">") {
+		emoji = strings.TrimPrefix(emoji[1:len(emoji)-1], "a:")
+		emoji = strings.TrimPrefix(emoji, ":")
+	}
+	return EmojiIdentifier(emoji)
+}
+
+// escaped returns the identifier escaped for a URL path, emoji like #⃣
+// would be cut off at the # otherwise.
+func (e EmojiIdentifier) escaped() string {
+	return url.PathEscape(string(e))
+}
+
 // VerificationLevel type definition
 type VerificationLevel int
 
@@ -392,6 +739,17 @@ const (
 	ExplicitContentFilterAllMembers
 )
 
+// GuildNSFWLevel is the age-restriction level of a guild.
+type GuildNSFWLevel int
+
+// Constants for GuildNSFWLevel levels from 0 to 3 inclusive
+const (
+	GuildNSFWLevelDefault GuildNSFWLevel = iota
+	GuildNSFWLevelExplicit
+	GuildNSFWLevelSafe
+	GuildNSFWLevelAgeRestricted
+)
+
 // MfaLevel type definition
 type MfaLevel int
 
@@ -479,6 +837,9 @@ type Guild struct {
 	// A list of the custom emojis present in the guild.
 	Emojis []*Emoji `json:"emojis"`
 
+	// A list of the custom stickers present in the guild.
+	Stickers []*Sticker `json:"stickers"`
+
 	// A list of the members in the guild.
 	// This field is only present in GUILD_CREATE events and websocket
 	// update events, and thus is only present in state-cached guilds.
@@ -513,6 +874,9 @@ type Guild struct {
 	// The explicit content filter level
 	ExplicitContentFilter ExplicitContentFilterLevel `json:"explicit_content_filter"`
 
+	// The age-restriction level of the guild.
+	NSFWLevel GuildNSFWLevel `json:"nsfw_level"`
+
 	// The list of enabled guild features
 	Features []string `json:"features"`
 
@@ -558,6 +922,9 @@ type Guild struct {
 	// The id of the channel where admins and moderators of guilds with the "PUBLIC" feature receive notices from Discord
 	PublicUpdatesChannelID string `json:"public_updates_channel_id"`
 
+	// The id of the channel where admins and moderators of community guilds receive safety alerts from Discord
+	SafetyAlertsChannelID string `json:"safety_alerts_channel_id"`
+
 	// The maximum amount of users in a video channel
 	MaxVideoChannelUsers int `json:"max_video_channel_users"`
 
@@ -591,7 +958,7 @@ const (
 	SystemChannelFlagsSuppressPremium
 )
 
-// IconURL returns a URL to the guild's icon.
+// IconURL returns a URL to the guild's icon, a GIF if it is animated.
 func (g *Guild) IconURL() string {
 	if g.Icon == "" {
 		return ""
@@ -606,11 +973,133 @@ func (g *Guild) IconURL() string {
 
 // A UserGuild holds a brief version of a Guild
 type UserGuild struct {
-	ID          string `json:"id"`
-	Name        string `json:"name"`
-	Icon        string `json:"icon"`
-	Owner       bool   `json:"owner"`
-	Permissions int    `json:"permissions"`
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Icon        string   `json:"icon"`
+	Owner       bool     `json:"owner"`
+	Permissions int      `json:"permissions"`
+	Features    []string `json:"features"`
+
+	// will only be filled when using UserGuildsComplex with counts
+	ApproximateMemberCount   int `json:"approximate_member_count"`
+	ApproximatePresenceCount int `json:"approximate_presence_count"`
+}
+
+// A GuildPreview is the public preview of a discoverable guild.
+type GuildPreview struct {
+	ID              string   `json:"id"`
+	Name            string   `json:"name"`
+	Icon            string   `json:"icon"`
+	Splash          string   `json:"splash"`
+	DiscoverySplash string   `json:"discovery_splash"`
+	Emojis          []*Emoji `json:"emojis"`
+	Features        []string `json:"features"`
+	Description     string   `json:"description"`
+
+	ApproximateMemberCount   int `json:"approximate_member_count"`
+	ApproximatePresenceCount int `json:"approximate_presence_count"`
+}
+
+// A GuildVanityURL is the vanity invite of a guild.
+type GuildVanityURL struct {
+	// The code of the invite, empty if the guild has none.
+	Code string `json:"code"`
+	Uses int    `json:"uses"`
+}
+
+// A GuildWelcomeScreen is shown to new members of a community guild.
+type GuildWelcomeScreen struct {
+	Description     string                 `json:"description"`
+	WelcomeChannels []*GuildWelcomeChannel `json:"welcome_channels"`
+}
+
+// A GuildWelcomeChannel is a channel suggested by a GuildWelcomeScreen.
+type GuildWelcomeChannel struct {
+	ChannelID   string `json:"channel_id"`
+	Description string `json:"description"`
+
+	// The emoji of the channel, the ID of a guild emoji or the name of a
+	// unicode emoji.
+	EmojiID   string `json:"emoji_id,omitempty"`
+	EmojiName string `json:"emoji_name,omitempty"`
+}
+
+// GuildWelcomeScreenParams stores the changes to a GuildWelcomeScreen.
+type GuildWelcomeScreenParams struct {
+	Enabled         *bool                  `json:"enabled,omitempty"`
+	WelcomeChannels []*GuildWelcomeChannel `json:"welcome_channels,omitempty"`
+	Description     *string                `json:"description,omitempty"`
+}
+
+// GuildOnboardingMode is the criteria of the default channels of a
+// GuildOnboarding.
+type GuildOnboardingMode int
+
+// Valid GuildOnboardingMode values
+const (
+	// Only the default channels count towards the constraints.
+	GuildOnboardingModeDefault GuildOnboardingMode = 0
+	// The default channels and the questions count towards the constraints.
+	GuildOnboardingModeAdvanced GuildOnboardingMode = 1
+)
+
+// A GuildOnboarding is the onboarding of new members of a community guild,
+// the questions which give them roles and channels.
+type GuildOnboarding struct {
+	GuildID string                   `json:"guild_id"`
+	Prompts []*GuildOnboardingPrompt `json:"prompts"`
+
+	// The channels new members get without answering a question.
+	DefaultChannelIDs []string            `json:"default_channel_ids"`
+	Enabled           bool                `json:"enabled"`
+	Mode              GuildOnboardingMode `json:"mode"`
+}
+
+// GuildOnboardingPromptType is the type of a GuildOnboardingPrompt.
+type GuildOnboardingPromptType int
+
+// Valid GuildOnboardingPromptType values
+const (
+	GuildOnboardingPromptTypeMultipleChoice GuildOnboardingPromptType = 0
+	GuildOnboardingPromptTypeDropdown       GuildOnboardingPromptType = 1
+)
+
+// A GuildOnboardingPrompt is a question of a GuildOnboarding.
+type GuildOnboardingPrompt struct {
+	ID      string                         `json:"id"`
+	Type    GuildOnboardingPromptType      `json:"type"`
+	Options []*GuildOnboardingPromptOption `json:"options"`
+	Title   string                         `json:"title"`
+
+	// Whether only one option can be selected.
+	SingleSelect bool `json:"single_select"`
+	Required     bool `json:"required"`
+
+	// Whether the question is asked during the onboarding, or only later
+	// in the Channels & Roles page.
+	InOnboarding bool `json:"in_onboarding"`
+}
+
+// A GuildOnboardingPromptOption is an answer to a GuildOnboardingPrompt.
+type GuildOnboardingPromptOption struct {
+	ID string `json:"id"`
+
+	// The channels and roles the members who select the option get.
+	ChannelIDs []string `json:"channel_ids"`
+	RoleIDs    []string `json:"role_ids"`
+
+	Emoji       *Emoji `json:"emoji,omitempty"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+// GuildOnboardingParams stores the changes to a GuildOnboarding. The
+// prompts replace the prompts of the onboarding.
+type GuildOnboardingParams struct {
+	Prompts           []*GuildOnboardingPrompt `json:"prompts,omitempty"`
+	DefaultChannelIDs []string                 `json:"default_channel_ids,omitempty"`
+	Enabled           *bool                    `json:"enabled,omitempty"`
+	Mode              *GuildOnboardingMode     `json:"mode,omitempty"`
 }
 
 // A GuildParams stores all the data needed to update discord guild settings
@@ -624,6 +1113,7 @@ type GuildParams struct {
 	Icon                        string             `json:"icon,omitempty"`
 	OwnerID                     string             `json:"owner_id,omitempty"`
 	Splash                      string             `json:"splash,omitempty"`
+	DiscoverySplash             string             `json:"discovery_splash,omitempty"`
 	Banner                      string             `json:"banner,omitempty"`
 }
 
@@ -699,6 +1189,16 @@ type Presence struct {
 	Nick       string   `json:"nick"`
 	Roles      []string `json:"roles"`
 	Since      *int     `json:"since"`
+
+	ClientStatus ClientStatus `json:"client_status"`
+}
+
+// ClientStatus stores the status of a user on each of their clients, the
+// status of a client the user isn't active on is empty.
+type ClientStatus struct {
+	Desktop Status `json:"desktop,omitempty"`
+	Mobile  Status `json:"mobile,omitempty"`
+	Web     Status `json:"web,omitempty"`
 }
 
 // GameType is the type of "game" (see GameType* consts) in the Game struct
@@ -724,7 +1224,19 @@ type Game struct {
 	Assets        Assets     `json:"assets,omitempty"`
 	ApplicationID string     `json:"application_id,omitempty"`
 	Instance      int8       `json:"instance,omitempty"`
-	// TODO: Party and Secrets (unknown structure)
+	Party         *Party     `json:"party,omitempty"`
+
+	// The ID of the track of a Spotify activity.
+	SyncID string `json:"sync_id,omitempty"`
+	// TODO: Secrets (unknown structure)
+}
+
+// A Party is the party of the user in a rich presence Game
+type Party struct {
+	ID string `json:"id,omitempty"`
+
+	// The current and maximum size of the party.
+	Size []int `json:"size,omitempty"`
 }
 
 // A TimeStamps struct contains start and end times used in the rich presence "playing .." Game
@@ -782,13 +1294,63 @@ type Member struct {
 
 	// When the user used their Nitro boost on the server
 	PremiumSince Timestamp `json:"premium_since"`
+
+	// Whether the member has not yet passed the membership screening of the guild.
+	Pending bool `json:"pending"`
+
+	// The flags of the member.
+	Flags MemberFlags `json:"flags"`
+
+	// When the timeout of the member ends, empty if they are not timed out.
+	CommunicationDisabledUntil Timestamp `json:"communication_disabled_until"`
 }
 
+// MemberFlags are the flags of a guild member.
+type MemberFlags int
+
+// Block contains known MemberFlags values
+const (
+	// The member left and rejoined the guild.
+	MemberFlagDidRejoin MemberFlags = 1 << 0
+
+	// The member completed the onboarding of the guild.
+	MemberFlagCompletedOnboarding MemberFlags = 1 << 1
+
+	// The member is exempt from the verification level of the guild.
+	MemberFlagBypassesVerification MemberFlags = 1 << 2
+
+	// The member started the onboarding of the guild.
+	MemberFlagStartedOnboarding MemberFlags = 1 << 3
+)
+
 // Mention creates a member mention
 func (m *Member) Mention() string {
 	return "<@!" + m.User.ID + ">"
 }
 
+// JoinedAgo returns how long ago the member joined the guild.
+func (m *Member) JoinedAgo() (time.Duration, error) {
+	joinedAt, err := m.JoinedAt.Parse()
+	if err != nil {
+		return 0, err
+	}
+	return time.Since(joinedAt), nil
+}
+
+// GuildMemberAddParams stores the data to add a user to a guild with. The
+// bot needs the create instant invite permission, and the permissions to
+// set the other fields: manage nicknames, manage roles, mute members and
+// deafen members.
+type GuildMemberAddParams struct {
+	// An OAuth2 access token of the user granted the guilds.join scope.
+	AccessToken string `json:"access_token"`
+
+	Nick  string   `json:"nick,omitempty"`
+	Roles []string `json:"roles,omitempty"`
+	Mute  bool     `json:"mute,omitempty"`
+	Deaf  bool     `json:"deaf,omitempty"`
+}
+
 // A Settings stores data for a specific users Discord client settings.
 type Settings struct {
 	RenderEmbeds           bool               `json:"render_embeds"`
@@ -840,6 +1402,10 @@ type TooManyRequests struct {
 	Bucket     string        `json:"bucket"`
 	Message    string        `json:"message"`
 	RetryAfter time.Duration `json:"retry_after"`
+	Global     bool          `json:"global"`
+
+	// The scope of the rate limit, from the X-RateLimit-Scope header.
+	Scope RateLimitScope `json:"-"`
 }
 
 // A ReadState stores data on the read state of channels.
@@ -866,12 +1432,57 @@ type GuildBan struct {
 	User   *User  `json:"user"`
 }
 
+// BanCreateData stores the options of a ban.
+type BanCreateData struct {
+	// The number of seconds of the messages of the user to delete, at most
+	// 604800, 7 days.
+	DeleteMessageSeconds int `json:"delete_message_seconds,omitempty"`
+
+	// The reason of the ban, shown in the audit log.
+	Reason string `json:"-"`
+}
+
 // A GuildEmbed stores data for a guild embed.
 type GuildEmbed struct {
 	Enabled   bool   `json:"enabled"`
 	ChannelID string `json:"channel_id"`
 }
 
+// MembershipScreening stores the membership screening form of a guild,
+// which new members have to complete before they can talk.
+// https://discord.com/developers/docs/resources/guild#membership-screening-object
+type MembershipScreening struct {
+	// When the form was last updated, in ISO8601.
+	Version     Timestamp                   `json:"version"`
+	FormFields  []*MembershipScreeningField `json:"form_fields"`
+	Description string                      `json:"description"`
+}
+
+// MembershipScreeningFieldType is the type of a MembershipScreeningField
+type MembershipScreeningFieldType string
+
+// Block of valid MembershipScreeningFieldTypes
+const (
+	// The member has to accept the rules in Values.
+	MembershipScreeningFieldTypeTerms MembershipScreeningFieldType = "TERMS"
+)
+
+// A MembershipScreeningField is a field of a MembershipScreening form.
+type MembershipScreeningField struct {
+	FieldType MembershipScreeningFieldType `json:"field_type"`
+	Label     string                       `json:"label"`
+	Values    []string                     `json:"values,omitempty"`
+	Required  bool                         `json:"required"`
+}
+
+// MembershipScreeningEdit holds the changes of a MembershipScreening
+// made with GuildMembershipScreeningEdit, nil fields are not changed.
+type MembershipScreeningEdit struct {
+	Enabled     *bool                       `json:"enabled,omitempty"`
+	FormFields  []*MembershipScreeningField `json:"form_fields,omitempty"`
+	Description *string                     `json:"description,omitempty"`
+}
+
 // A GuildAuditLog stores data for a guild audit log.
 // https://discord.com/developers/docs/resources/audit-log#audit-log-object-audit-log-structure
 type GuildAuditLog struct {
@@ -1071,6 +1682,15 @@ type Webhook struct {
 
 	// ApplicationID is the bot/OAuth2 application that created this webhook
 	ApplicationID string `json:"application_id,omitempty"`
+
+	// The guild and channel followed by a WebhookTypeChannelFollower
+	// webhook, partial objects with their ID, name and for the guild icon.
+	SourceGuild   *Guild   `json:"source_guild,omitempty"`
+	SourceChannel *Channel `json:"source_channel,omitempty"`
+
+	// The URL to execute the webhook, only sent for WebhookTypeIncoming
+	// webhooks.
+	URL string `json:"url,omitempty"`
 }
 
 // WebhookType is the type of Webhook (see WebhookType* consts) in the Webhook struct
@@ -1079,8 +1699,9 @@ type WebhookType int
 
 // Valid WebhookType values
 const (
-	WebhookTypeIncoming WebhookType = iota
-	WebhookTypeChannelFollower
+	WebhookTypeIncoming        WebhookType = 1
+	WebhookTypeChannelFollower WebhookType = 2
+	WebhookTypeApplication     WebhookType = 3
 )
 
 // WebhookParams is a struct for webhook params, used in the WebhookExecute command.
@@ -1092,6 +1713,21 @@ type WebhookParams struct {
 	File            string                  `json:"file,omitempty"`
 	Embeds          []*MessageEmbed         `json:"embeds,omitempty"`
 	AllowedMentions *MessageAllowedMentions `json:"allowed_mentions,omitempty"`
+	Components      []*MessageComponent     `json:"components,omitempty"`
+
+	// Only MessageFlagsSupressEmbeds, and MessageFlagsEphemeral for
+	// interaction followup messages, can be set.
+	Flags MessageFlags `json:"flags,omitempty"`
+}
+
+// WebhookEdit stores the data of a webhook message edit, only the set fields are changed.
+type WebhookEdit struct {
+	Content         *string                 `json:"content,omitempty"`
+	Embeds          *[]*MessageEmbed        `json:"embeds,omitempty"`
+	AllowedMentions *MessageAllowedMentions `json:"allowed_mentions,omitempty"`
+
+	// An empty slice removes all components.
+	Components *[]*MessageComponent `json:"components,omitempty"`
 }
 
 // MessageReaction stores the data for a message reaction.
@@ -1101,8 +1737,24 @@ type MessageReaction struct {
 	Emoji     Emoji  `json:"emoji"`
 	ChannelID string `json:"channel_id"`
 	GuildID   string `json:"guild_id,omitempty"`
+
+	// Whether the reaction is a super reaction, and its colors as hex
+	// strings, only sent on MessageReactionAdd events.
+	Burst       bool     `json:"burst"`
+	BurstColors []string `json:"burst_colors,omitempty"`
+
+	Type ReactionType `json:"type"`
 }
 
+// ReactionType is the type of a reaction.
+type ReactionType int
+
+// Valid ReactionType values
+const (
+	ReactionTypeNormal ReactionType = 0
+	ReactionTypeBurst  ReactionType = 1
+)
+
 // GatewayBotResponse stores the data for the gateway/bot response
 type GatewayBotResponse struct {
 	URL    string `json:"url"`
@@ -1121,9 +1773,9 @@ type GatewayStatusUpdate struct {
 // Activity defines the Activity sent with GatewayStatusUpdate
 // https://discord.com/developers/docs/topics/gateway#activity-object
 type Activity struct {
-	Name string
-	Type ActivityType
-	URL  string
+	Name string       `json:"name"`
+	Type ActivityType `json:"type"`
+	URL  string       `json:"url,omitempty"`
 }
 
 // ActivityType is the type of Activity (see ActivityType* consts) in the Activity struct
@@ -1145,7 +1797,7 @@ type Identify struct {
 	Token              string              `json:"token"`
 	Properties         IdentifyProperties  `json:"properties"`
 	Compress           bool                `json:"compress"`
-	LargeThreshold     int                 `json:"large_threshold"`
+	LargeThreshold     int                 `json:"large_threshold"` // 50 to 250, 0 for Discord's default of 50
 	Shard              *[2]int             `json:"shard,omitempty"`
 	Presence           GatewayStatusUpdate `json:"presence,omitempty"`
 	GuildSubscriptions bool                `json:"guild_subscriptions"`
@@ -1257,6 +1909,7 @@ const (
 	ErrCodeUnknownUser        = 10013
 	ErrCodeUnknownEmoji       = 10014
 	ErrCodeUnknownWebhook     = 10015
+	ErrCodeUnknownBan         = 10026
 
 	ErrCodeBotsCannotUseEndpoint  = 20001
 	ErrCodeOnlyBotsCanUseEndpoint = 20002
@@ -1267,7 +1920,8 @@ const (
 	ErrCodeMaximumGuildRolesReached = 30005
 	ErrCodeTooManyReactions         = 30010
 
-	ErrCodeUnauthorized = 40001
+	ErrCodeUnauthorized      = 40001
+	ErrCodeOpeningDMsTooFast = 40003
 
 	ErrCodeMissingAccess                             = 50001
 	ErrCodeInvalidAccountType                        = 50002
@@ -1316,6 +1970,15 @@ const (
 	IntentsDirectMessageReactions
 	IntentsDirectMessageTyping
 
+	// IntentsMessageContent is required for the content, embeds,
+	// attachments and components of messages in events. Without it they
+	// are only sent for DMs, messages mentioning the bot and the messages
+	// of the bot. REST responses include them if the intent is enabled for
+	// the application, see Session.ContentFallback. It is privileged, but
+	// not part of IntentsAll so bots which identify with IntentsAll don't
+	// fail to connect without it.
+	IntentsMessageContent
+
 	IntentsAllWithoutPrivileged = IntentsGuilds |
 		IntentsGuildBans |
 		IntentsGuildEmojis |
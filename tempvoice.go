@@ -0,0 +1,261 @@
+package discordgo
+
+import (
+	"context"
+	"sync"
+)
+
+// tempVoiceOwnerPermissions are the permissions given to the owner of a temporary voice channel.
+const tempVoiceOwnerPermissions = PermissionViewChannel | PermissionVoiceConnect | PermissionVoiceSpeak | PermissionManageChannels | PermissionVoiceMoveMembers | PermissionVoiceMuteMembers
+
+// A TempVoiceHub is a voice channel which creates a temporary voice channel
+// for every user who joins it.
+type TempVoiceHub struct {
+	ChannelID string
+
+	// The channel whose settings, category and permission overwrites are
+	// copied to the temporary channels, the hub itself if empty.
+	TemplateChannelID string
+
+	// The name of the temporary channels, rendered with the variables of the
+	// member (see TemplateVars.AddMember). It defaults to
+	// "{{member.nick}}'s channel".
+	Name string
+}
+
+// A TempVoiceChannel is a temporary voice channel created by a TempVoiceManager.
+type TempVoiceChannel struct {
+	GuildID   string `json:"guild_id"`
+	ChannelID string `json:"channel_id"`
+	OwnerID   string `json:"owner_id"`
+}
+
+// A TempVoiceManager creates a temporary voice channel when a user joins a
+// hub channel and moves the user into it. The user owns the channel and can
+// manage it, and the channel is deleted when it becomes empty.
+// State.TrackVoice must be enabled, as empty channels are detected with
+// VoiceChannelEmpty events.
+type TempVoiceManager struct {
+	sync.RWMutex
+
+	session  *Session
+	handlers []HandlerID
+	hubs     map[string]*TempVoiceHub
+	channels map[string]*TempVoiceChannel
+	creating map[guildUserKey]bool
+}
+
+// NewTempVoiceManager returns a new TempVoiceManager which handles the voice
+// state events of s.
+func NewTempVoiceManager(s *Session, hubs ...*TempVoiceHub) *TempVoiceManager {
+	m := &TempVoiceManager{
+		session:  s,
+		hubs:     map[string]*TempVoiceHub{},
+		channels: map[string]*TempVoiceChannel{},
+		creating: map[guildUserKey]bool{},
+	}
+	m.AddHub(hubs...)
+
+	m.handlers = []HandlerID{
+		s.AddHandlerComplex(m.onVoiceStateUpdate, HandlerOptions{}),
+		s.AddHandlerComplex(func(s *Session, e *VoiceChannelEmpty) {
+			m.onEmpty(e.ChannelID)
+		}, HandlerOptions{}),
+		s.AddHandlerComplex(func(s *Session, c *ChannelDelete) {
+			m.Lock()
+			delete(m.channels, c.ID)
+			m.Unlock()
+		}, HandlerOptions{}),
+	}
+	return m
+}
+
+// Close removes the event handlers of the manager.
+func (m *TempVoiceManager) Close() {
+	for _, id := range m.handlers {
+		m.session.RemoveHandler(id)
+	}
+}
+
+// AddHub adds hub channels.
+func (m *TempVoiceManager) AddHub(hubs ...*TempVoiceHub) {
+	m.Lock()
+	defer m.Unlock()
+
+	for _, h := range hubs {
+		m.hubs[h.ChannelID] = h
+	}
+}
+
+// RemoveHub removes a hub channel, its temporary channels are still
+// deleted when they become empty.
+func (m *TempVoiceManager) RemoveHub(channelID string) {
+	m.Lock()
+	defer m.Unlock()
+
+	delete(m.hubs, channelID)
+}
+
+// Add adds temporary channels to the manager, eg. channels persisted before a restart.
+func (m *TempVoiceManager) Add(channels ...*TempVoiceChannel) {
+	m.Lock()
+	defer m.Unlock()
+
+	for _, c := range channels {
+		m.channels[c.ChannelID] = c
+	}
+}
+
+// Channel returns a copy of the temporary channel with the given ID, or nil
+// if the channel is not a temporary channel.
+func (m *TempVoiceManager) Channel(channelID string) *TempVoiceChannel {
+	m.RLock()
+	defer m.RUnlock()
+
+	c, ok := m.channels[channelID]
+	if !ok {
+		return nil
+	}
+	copied := *c
+	return &copied
+}
+
+// Channels returns copies of all temporary channels, eg. to persist them.
+func (m *TempVoiceManager) Channels() (channels []*TempVoiceChannel) {
+	m.RLock()
+	defer m.RUnlock()
+
+	for _, c := range m.channels {
+		copied := *c
+		channels = append(channels, &copied)
+	}
+	return
+}
+
+// Create creates a temporary channel of the hub for the member without
+// moving the member into it.
+func (m *TempVoiceManager) Create(hub *TempVoiceHub, member *Member) (tc *TempVoiceChannel, err error) {
+	s := m.session
+
+	templateID := hub.TemplateChannelID
+	if templateID == "" {
+		templateID = hub.ChannelID
+	}
+
+	template, err := s.resolveChannel(context.Background(), templateID)
+	if err != nil {
+		return
+	}
+
+	name := hub.Name
+	if name == "" {
+		name = "{{member.nick}}'s channel"
+	}
+
+	overwrites := []*PermissionOverwrite{{ID: member.User.ID, Type: "member", Allow: tempVoiceOwnerPermissions}}
+	for _, o := range template.PermissionOverwrites {
+		if o.ID != member.User.ID {
+			overwrites = append(overwrites, o)
+		}
+	}
+
+	c, err := s.GuildChannelCreateComplex(template.GuildID, GuildChannelCreateData{
+		Name:                 TemplateVars{}.AddMember(member).Replace(name),
+		Type:                 ChannelTypeGuildVoice,
+		Bitrate:              template.Bitrate,
+		UserLimit:            template.UserLimit,
+		ParentID:             template.ParentID,
+		PermissionOverwrites: overwrites,
+		RTCRegion:            template.RTCRegion,
+		VideoQualityMode:     template.VideoQualityMode,
+	})
+	if err != nil {
+		return
+	}
+
+	tc = &TempVoiceChannel{GuildID: c.GuildID, ChannelID: c.ID, OwnerID: member.User.ID}
+	copied := *tc
+	m.Add(&copied)
+	return
+}
+
+// Transfer makes another user the owner of a temporary channel, eg. after
+// the owner left it.
+func (m *TempVoiceManager) Transfer(channelID, userID string) error {
+	tc := m.Channel(channelID)
+	if tc == nil {
+		return ErrStateNotFound
+	}
+
+	s := m.session
+	if err := s.ChannelPermissionSet(channelID, userID, "member", tempVoiceOwnerPermissions, 0); err != nil {
+		return err
+	}
+	if err := s.ChannelPermissionDelete(channelID, tc.OwnerID); err != nil {
+		return err
+	}
+
+	m.Lock()
+	if c, ok := m.channels[channelID]; ok {
+		c.OwnerID = userID
+	}
+	m.Unlock()
+	return nil
+}
+
+func (m *TempVoiceManager) onVoiceStateUpdate(s *Session, v *VoiceStateUpdate) {
+	// Only joining the hub creates a channel, not eg. muting in it.
+	if v.BeforeUpdate != nil && v.BeforeUpdate.ChannelID == v.ChannelID {
+		return
+	}
+
+	m.Lock()
+	hub, ok := m.hubs[v.ChannelID]
+	key := guildUserKey{v.GuildID, v.UserID}
+	if !ok || m.creating[key] {
+		m.Unlock()
+		return
+	}
+	m.creating[key] = true
+	m.Unlock()
+
+	defer func() {
+		m.Lock()
+		delete(m.creating, key)
+		m.Unlock()
+	}()
+
+	member, err := s.resolveMember(context.Background(), v.GuildID, v.UserID)
+	if err != nil {
+		s.log(LogError, "error getting member %s for temporary voice channel, %s", v.UserID, err)
+		return
+	}
+
+	tc, err := m.Create(hub, member)
+	if err != nil {
+		s.log(LogError, "error creating temporary voice channel in %s, %s", v.GuildID, err)
+		return
+	}
+
+	if err = s.GuildMemberMove(v.GuildID, v.UserID, &tc.ChannelID); err != nil {
+		// The user left the hub before the channel was created.
+		s.log(LogInformational, "error moving %s to temporary voice channel, %s", v.UserID, err)
+		m.onEmpty(tc.ChannelID)
+	}
+}
+
+// onEmpty deletes a temporary channel.
+func (m *TempVoiceManager) onEmpty(channelID string) {
+	m.Lock()
+	_, ok := m.channels[channelID]
+	delete(m.channels, channelID)
+	m.Unlock()
+
+	if !ok {
+		return
+	}
+
+	if _, err := m.session.ChannelDelete(channelID); err != nil {
+		m.session.log(LogError, "error deleting temporary voice channel %s, %s", channelID, err)
+	}
+}
@@ -0,0 +1,64 @@
+package discordgo
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestTempVoiceManager(t *testing.T) {
+	tr := &routeTransport{routes: map[string]string{
+		"GET " + EndpointChannel("10"):                   `{"id": "10", "guild_id": "1", "type": 2, "bitrate": 64000, "permission_overwrites": [{"id": "1", "type": "role", "deny": 1024}]}`,
+		"GET " + EndpointGuildMember("1", "2"):           `{"user": {"id": "2", "username": "owner"}}`,
+		"POST " + EndpointGuildChannels("1"):             `{"id": "20", "guild_id": "1", "type": 2}`,
+		"PATCH " + EndpointGuildMember("1", "2"):         `{}`,
+		"PUT " + EndpointChannelPermission("20", "3"):    ``,
+		"DELETE " + EndpointChannelPermission("20", "2"): ``,
+		"DELETE " + EndpointChannel("20"):                `{"id": "20"}`,
+	}}
+	s := newTestSession(tr)
+
+	m := NewTempVoiceManager(s, &TempVoiceHub{ChannelID: "10"})
+	defer m.Close()
+
+	m.onVoiceStateUpdate(s, &VoiceStateUpdate{VoiceState: &VoiceState{GuildID: "1", UserID: "2", ChannelID: "10"}})
+	tc := m.Channel("20")
+	if tc == nil || tc.OwnerID != "2" || tr.last() != "PATCH "+EndpointGuildMember("1", "2") {
+		t.Fatalf("expected a channel of user 2, got %+v after %v", tc, tr.requests)
+	}
+	var data GuildChannelCreateData
+	json.Unmarshal([]byte(tr.bodies[2]), &data)
+	if data.Name != "owner's channel" || data.Bitrate != 64000 || len(data.PermissionOverwrites) != 2 || data.PermissionOverwrites[0].Allow != tempVoiceOwnerPermissions {
+		t.Errorf("expected a copy of the hub owned by user 2, got %+v", data)
+	}
+
+	// Changes of the voice state in the hub don't create channels.
+	n := tr.count()
+	m.onVoiceStateUpdate(s, &VoiceStateUpdate{
+		VoiceState:   &VoiceState{GuildID: "1", UserID: "2", ChannelID: "10", SelfMute: true},
+		BeforeUpdate: &VoiceState{GuildID: "1", UserID: "2", ChannelID: "10"},
+	})
+	if tr.count() != n {
+		t.Errorf("expected no requests, got %v", tr.requests[n:])
+	}
+
+	// The channels returned are copies.
+	tc.OwnerID = "4"
+	if c := m.Channels(); len(c) != 1 || c[0].OwnerID != "2" {
+		t.Errorf("expected the owner to be unchanged, got %+v", c)
+	}
+
+	if err := m.Transfer("20", "3"); err != nil {
+		t.Fatal(err)
+	}
+	if tc = m.Channel("20"); tc.OwnerID != "3" || tr.last() != "DELETE "+EndpointChannelPermission("20", "2") {
+		t.Errorf("expected the channel to be transferred, got %+v after %v", tc, tr.requests)
+	}
+	if err := m.Transfer("21", "3"); err != ErrStateNotFound {
+		t.Errorf("expected ErrStateNotFound, got %v", err)
+	}
+
+	m.onEmpty("20")
+	if m.Channel("20") != nil || tr.last() != "DELETE "+EndpointChannel("20") {
+		t.Errorf("expected the empty channel to be deleted, got %v", tr.requests)
+	}
+}
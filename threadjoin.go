@@ -0,0 +1,98 @@
+package discordgo
+
+import "sync"
+
+// A ThreadAutoJoiner joins the bot to the public threads created in its
+// channels, as bots only receive the messages of the threads they joined.
+//
+// The joins are sent one at a time in the background with a low request
+// priority, so a burst of new threads neither floods the rate limit of
+// the endpoint nor delays the other requests of the bot.
+type ThreadAutoJoiner struct {
+	sync.Mutex
+
+	session *Session
+	handler HandlerID
+
+	// The IDs of the parent channels, nil for all channels.
+	channels map[string]bool
+
+	queue   []string
+	working bool
+}
+
+// NewThreadAutoJoiner returns a new ThreadAutoJoiner which joins the
+// threads created in the given channels, or in all channels if none are
+// given.
+// s          : The session to join the threads with.
+// channelIDs : The IDs of the parent channels of the threads, eg. forums.
+func NewThreadAutoJoiner(s *Session, channelIDs ...string) *ThreadAutoJoiner {
+	j := &ThreadAutoJoiner{session: s}
+	if len(channelIDs) > 0 {
+		j.channels = make(map[string]bool, len(channelIDs))
+		for _, id := range channelIDs {
+			j.channels[id] = true
+		}
+	}
+
+	j.handler = s.AddHandlerComplex(j.onThreadCreate, HandlerOptions{})
+	return j
+}
+
+// Close removes the event handler of the joiner.
+func (j *ThreadAutoJoiner) Close() {
+	j.session.RemoveHandler(j.handler)
+}
+
+func (j *ThreadAutoJoiner) onThreadCreate(s *Session, t *ThreadCreate) {
+	if t.Channel == nil || !t.NewlyCreated {
+		return
+	}
+	if t.Type != ChannelTypeGuildPublicThread && t.Type != ChannelTypeGuildNewsThread {
+		return
+	}
+	if j.channels != nil && !j.channels[t.ParentID] {
+		return
+	}
+
+	// The creator of a thread is added to it by Discord.
+	if s.State != nil {
+		s.State.RLock()
+		own := s.State.User != nil && s.State.User.ID == t.OwnerID
+		s.State.RUnlock()
+		if own {
+			return
+		}
+	}
+
+	j.Lock()
+	j.queue = append(j.queue, t.ID)
+	start := !j.working
+	j.working = true
+	j.Unlock()
+
+	if start {
+		go j.work()
+	}
+}
+
+// work joins the queued threads until the queue is empty.
+func (j *ThreadAutoJoiner) work() {
+	s := j.session
+	for {
+		j.Lock()
+		if len(j.queue) == 0 {
+			j.working = false
+			j.Unlock()
+			return
+		}
+		threadID := j.queue[0]
+		j.queue = j.queue[1:]
+		j.Unlock()
+
+		_, err := s.RequestWithPriority("PUT", EndpointThreadMember(threadID, "@me"), nil, EndpointThreadMember(threadID, ""), RequestPriorityLow)
+		if err != nil {
+			s.log(LogError, "error joining thread %s, %s", threadID, err)
+		}
+	}
+}
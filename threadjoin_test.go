@@ -0,0 +1,51 @@
+package discordgo
+
+import (
+	"testing"
+	"time"
+)
+
+func TestThreadAutoJoiner(t *testing.T) {
+	tr := &routeTransport{routes: map[string]string{
+		"PUT " + EndpointThreadMember("10", "@me"): ``,
+		"PUT " + EndpointThreadMember("13", "@me"): ``,
+	}}
+	s := newTestSession(tr)
+	s.State.User = &User{ID: "1"}
+
+	j := NewThreadAutoJoiner(s, "2")
+	defer j.Close()
+
+	threads := []*ThreadCreate{
+		{Channel: &Channel{ID: "10", ParentID: "2", Type: ChannelTypeGuildPublicThread}, NewlyCreated: true},
+		{Channel: &Channel{ID: "11", ParentID: "3", Type: ChannelTypeGuildPublicThread}, NewlyCreated: true},
+		{Channel: &Channel{ID: "12", ParentID: "2", Type: ChannelTypeGuildPrivateThread}, NewlyCreated: true},
+		{Channel: &Channel{ID: "13", ParentID: "2", Type: ChannelTypeGuildNewsThread}, NewlyCreated: true},
+		{Channel: &Channel{ID: "14", ParentID: "2", Type: ChannelTypeGuildPublicThread, OwnerID: "1"}, NewlyCreated: true},
+		{Channel: &Channel{ID: "15", ParentID: "2", Type: ChannelTypeGuildPublicThread}},
+	}
+	for _, thread := range threads {
+		j.onThreadCreate(s, thread)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		j.Lock()
+		working := j.working
+		j.Unlock()
+		if !working || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	expected := []string{"PUT " + EndpointThreadMember("10", "@me"), "PUT " + EndpointThreadMember("13", "@me")}
+	if len(tr.requests) != len(expected) {
+		t.Fatalf("expected requests %v, got %v", expected, tr.requests)
+	}
+	for i, r := range expected {
+		if tr.requests[i] != r {
+			t.Errorf("expected request %s, got %s", r, tr.requests[i])
+		}
+	}
+}
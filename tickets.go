@@ -0,0 +1,400 @@
+package discordgo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrTicketNotFound is returned when a channel is not a ticket of a TicketManager.
+var ErrTicketNotFound = errors.New("ticket not found")
+
+// Emojis of the buttons of the control panel of a ticket.
+const (
+	TicketCloseEmoji = "🔒"
+	TicketClaimEmoji = "🙋"
+)
+
+// Custom IDs of the buttons of the control panel of a ticket.
+const (
+	TicketCloseCustomID = "ticket:close"
+	TicketClaimCustomID = "ticket:claim"
+)
+
+// ticketPermissions are the permissions given to openers and staff in a ticket channel.
+const ticketPermissions = PermissionViewChannel | PermissionSendMessages | PermissionReadMessageHistory | PermissionAttachFiles | PermissionEmbedLinks
+
+// ticketArchiveDuration is the auto archive duration of ticket threads in
+// minutes, a week.
+const ticketArchiveDuration = 10080
+
+// A TicketConfig configures the tickets of a TicketManager.
+type TicketConfig struct {
+	GuildID string
+
+	// The category ticket channels are created in, optional.
+	CategoryID string
+
+	// The text channel tickets are opened in as private threads, optional.
+	// Without it every ticket is a channel, which counts towards the limit
+	// of 500 channels of a guild. The staff roles are added to a thread by
+	// mentioning them in the panel, so they must be mentionable by the bot.
+	// Closed threads are archived and locked rather than deleted.
+	ThreadChannelID string
+
+	// The roles which can see, claim and close all tickets.
+	StaffRoleIDs []string
+
+	// Ticket channels and threads are named NamePrefix followed by the
+	// username of the opener, it defaults to "ticket-".
+	NamePrefix string
+
+	// The channel transcripts of closed tickets are posted to, optional.
+	LogChannelID string
+}
+
+// A Ticket is a private channel or thread of a member and the staff.
+type Ticket struct {
+	ChannelID      string    `json:"channel_id"`
+	Thread         bool      `json:"thread,omitempty"`
+	OpenerID       string    `json:"opener_id"`
+	ClaimerID      string    `json:"claimer_id,omitempty"`
+	PanelMessageID string    `json:"panel_message_id"`
+	OpenedAt       time.Time `json:"opened_at"`
+}
+
+// A TicketManager opens ticket channels or threads for members, and claims
+// and closes them when the opener or the staff press the buttons of the
+// control panel of the ticket. Closed tickets are archived with a
+// transcript.
+type TicketManager struct {
+	sync.RWMutex
+
+	Config TicketConfig
+
+	// OnClose is called after a ticket was closed, optional.
+	OnClose func(t *Ticket, transcript *Transcript)
+
+	session  *Session
+	handlers []HandlerID
+	tickets  map[string]*Ticket
+}
+
+// NewTicketManager returns a new TicketManager which handles the
+// interactions of s.
+func NewTicketManager(s *Session, config TicketConfig) *TicketManager {
+	if config.NamePrefix == "" {
+		config.NamePrefix = "ticket-"
+	}
+
+	m := &TicketManager{
+		Config:  config,
+		session: s,
+		tickets: map[string]*Ticket{},
+	}
+
+	m.handlers = []HandlerID{
+		s.AddHandlerComplex(m.onInteraction, HandlerOptions{}),
+	}
+	return m
+}
+
+// Close removes the event handlers of the manager.
+func (m *TicketManager) Close() {
+	for _, id := range m.handlers {
+		m.session.RemoveHandler(id)
+	}
+}
+
+// Add adds tickets to the manager, eg. tickets persisted before a restart.
+func (m *TicketManager) Add(tickets ...*Ticket) {
+	m.Lock()
+	defer m.Unlock()
+
+	for _, t := range tickets {
+		copied := *t
+		m.tickets[t.ChannelID] = &copied
+	}
+}
+
+// Ticket returns a copy of the ticket of a channel.
+func (m *TicketManager) Ticket(channelID string) (*Ticket, error) {
+	m.RLock()
+	defer m.RUnlock()
+
+	t, ok := m.tickets[channelID]
+	if !ok {
+		return nil, ErrTicketNotFound
+	}
+	copied := *t
+	return &copied, nil
+}
+
+// Tickets returns copies of all open tickets of the manager, eg. to
+// persist them.
+func (m *TicketManager) Tickets() (tickets []*Ticket) {
+	m.RLock()
+	defer m.RUnlock()
+
+	for _, t := range m.tickets {
+		copied := *t
+		tickets = append(tickets, &copied)
+	}
+	return
+}
+
+// Open creates a ticket channel for the user, or a private thread if the
+// config has a ThreadChannelID, which only the user and the staff roles can
+// see, and posts the control panel to it.
+// userID : The ID of the user opening the ticket.
+// reason : The reason of the ticket shown in the panel, optional.
+func (m *TicketManager) Open(userID, reason string) (t *Ticket, err error) {
+	s := m.session
+
+	member, err := s.resolveMember(context.Background(), m.Config.GuildID, userID)
+	if err != nil {
+		return
+	}
+
+	var c *Channel
+	if m.Config.ThreadChannelID != "" {
+		c, err = m.openThread(member)
+	} else {
+		c, err = m.openChannel(member, reason)
+	}
+	if err != nil {
+		return
+	}
+
+	embed := &MessageEmbed{
+		Title:       "Ticket",
+		Description: "Press " + TicketClaimEmoji + " to claim this ticket, or " + TicketCloseEmoji + " to close it.",
+	}
+	if reason != "" {
+		embed.AddField("Reason", reason, false)
+	}
+
+	// In a thread the mentions add the staff to it.
+	content := member.User.Mention()
+	mentions := &MessageAllowedMentions{Users: []string{userID}}
+	if m.Config.ThreadChannelID != "" {
+		for _, id := range m.Config.StaffRoleIDs {
+			content += " <@&" + id + ">"
+		}
+		mentions.Roles = m.Config.StaffRoleIDs
+	}
+
+	panel, err := s.ChannelMessageSendComplex(c.ID, &MessageSend{
+		Content:         content,
+		Embed:           embed,
+		Components:      ticketComponents(),
+		AllowedMentions: mentions,
+	})
+	if err != nil {
+		return
+	}
+
+	t = &Ticket{
+		ChannelID:      c.ID,
+		Thread:         m.Config.ThreadChannelID != "",
+		OpenerID:       userID,
+		PanelMessageID: panel.ID,
+		OpenedAt:       time.Now().UTC(),
+	}
+	m.Add(t)
+	return
+}
+
+// openChannel creates the channel of a ticket.
+func (m *TicketManager) openChannel(member *Member, reason string) (*Channel, error) {
+	s := m.session
+	guildID := m.Config.GuildID
+
+	overwrites := []*PermissionOverwrite{
+		// The ID of the @everyone role is the guild ID.
+		{ID: guildID, Type: "role", Deny: PermissionViewChannel},
+		{ID: member.User.ID, Type: "member", Allow: ticketPermissions},
+	}
+	for _, id := range m.Config.StaffRoleIDs {
+		overwrites = append(overwrites, &PermissionOverwrite{ID: id, Type: "role", Allow: ticketPermissions})
+	}
+	if s.State != nil && s.State.User != nil {
+		overwrites = append(overwrites, &PermissionOverwrite{ID: s.State.User.ID, Type: "member", Allow: ticketPermissions | PermissionManageChannels})
+	}
+
+	return s.GuildChannelCreateComplex(guildID, GuildChannelCreateData{
+		Name:                 m.Config.NamePrefix + member.User.Username,
+		Type:                 ChannelTypeGuildText,
+		Topic:                reason,
+		ParentID:             m.Config.CategoryID,
+		PermissionOverwrites: overwrites,
+	})
+}
+
+// openThread starts the private thread of a ticket and adds the opener.
+func (m *TicketManager) openThread(member *Member) (*Channel, error) {
+	s := m.session
+
+	invitable := false
+	c, err := s.ThreadStartComplex(m.Config.ThreadChannelID, &ThreadStart{
+		Name:                m.Config.NamePrefix + member.User.Username,
+		Type:                ChannelTypeGuildPrivateThread,
+		AutoArchiveDuration: ticketArchiveDuration,
+		Invitable:           &invitable,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.ThreadMemberAdd(c.ID, member.User.ID); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// ticketComponents returns the buttons of the control panel of a ticket.
+func ticketComponents() []*MessageComponent {
+	return []*MessageComponent{{
+		Type: ComponentTypeActionsRow,
+		Components: []*MessageComponent{
+			{
+				Type:     ComponentTypeButton,
+				Style:    ButtonStyleSecondary,
+				Label:    "Claim",
+				Emoji:    &ComponentEmoji{Name: TicketClaimEmoji},
+				CustomID: TicketClaimCustomID,
+			},
+			{
+				Type:     ComponentTypeButton,
+				Style:    ButtonStyleDanger,
+				Label:    "Close",
+				Emoji:    &ComponentEmoji{Name: TicketCloseEmoji},
+				CustomID: TicketCloseCustomID,
+			},
+		},
+	}}
+}
+
+// Claim marks the ticket of the channel as claimed by a staff member.
+func (m *TicketManager) Claim(channelID, staffID string) error {
+	m.Lock()
+	t, ok := m.tickets[channelID]
+	if ok {
+		t.ClaimerID = staffID
+	}
+	m.Unlock()
+
+	if !ok {
+		return ErrTicketNotFound
+	}
+
+	_, err := m.session.ChannelMessageSend(channelID, "This ticket was claimed by <@"+staffID+">.")
+	return err
+}
+
+// CloseTicket archives the ticket of the channel with a transcript, posts
+// the transcript to the log channel and deletes the ticket channel, or
+// archives and locks the ticket thread.
+func (m *TicketManager) CloseTicket(channelID, closerID string) (transcript *Transcript, err error) {
+	// The copy of the ticket isn't changed by claims meanwhile.
+	t, err := m.Ticket(channelID)
+	if err != nil {
+		return
+	}
+
+	s := m.session
+	transcript, err = s.ChannelTranscript(channelID, 0)
+	if err != nil {
+		return
+	}
+
+	if m.Config.LogChannelID != "" {
+		var html bytes.Buffer
+		if err = transcript.WriteHTML(&html); err != nil {
+			return
+		}
+
+		_, err = s.ChannelMessageSendComplex(m.Config.LogChannelID, &MessageSend{
+			Content: "Ticket #" + transcript.ChannelName + " of <@" + t.OpenerID + "> closed by <@" + closerID + ">.",
+			Files: []*File{{
+				Name:        transcript.ChannelName + ".html",
+				ContentType: "text/html",
+				Reader:      &html,
+			}},
+			AllowedMentions: &MessageAllowedMentions{},
+		})
+		if err != nil {
+			return
+		}
+	}
+
+	if t.Thread {
+		archived := true
+		_, err = s.ChannelEditComplex(channelID, &ChannelEdit{Archived: &archived, Locked: &archived})
+	} else {
+		_, err = s.ChannelDelete(channelID)
+	}
+	if err != nil {
+		return
+	}
+
+	m.Lock()
+	delete(m.tickets, channelID)
+	m.Unlock()
+
+	if m.OnClose != nil {
+		m.OnClose(t, transcript)
+	}
+	return
+}
+
+// isStaff returns whether the member has one of the staff roles.
+func (m *TicketManager) isStaff(member *Member) bool {
+	return member != nil && memberHasRole(member, m.Config.StaffRoleIDs...)
+}
+
+func (m *TicketManager) onInteraction(s *Session, i *InteractionCreate) {
+	if i.Type != InteractionMessageComponent || i.Message == nil || i.Member == nil || i.Member.User == nil {
+		return
+	}
+	data, err := i.MessageComponentData()
+	if err != nil || data.CustomID != TicketClaimCustomID && data.CustomID != TicketCloseCustomID {
+		return
+	}
+	t, err := m.Ticket(i.ChannelID)
+	if err != nil || t.PanelMessageID != i.Message.ID {
+		return
+	}
+
+	userID := i.Member.User.ID
+	allowed := m.isStaff(i.Member)
+	if data.CustomID == TicketCloseCustomID {
+		allowed = allowed || userID == t.OpenerID
+	}
+	if !allowed {
+		err = s.InteractionRespond(i.Interaction, &InteractionResponse{
+			Type: InteractionResponseChannelMessageWithSource,
+			Data: &InteractionResponseData{Content: "Only the staff can do this.", Flags: MessageFlagsEphemeral},
+		})
+		if err != nil {
+			s.log(LogWarning, "error responding to ticket button of %s, %s", userID, err)
+		}
+		return
+	}
+
+	// Closing takes longer than Discord waits for a response.
+	if err = i.DeferUpdate(s); err != nil {
+		s.log(LogWarning, "error responding to ticket button of %s, %s", userID, err)
+	}
+
+	if data.CustomID == TicketClaimCustomID {
+		err = m.Claim(i.ChannelID, userID)
+	} else {
+		_, err = m.CloseTicket(i.ChannelID, userID)
+	}
+	if err != nil {
+		s.log(LogError, "error handling ticket button in %s, %s", i.ChannelID, err)
+	}
+}
@@ -0,0 +1,115 @@
+package discordgo
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestTicketManagerThread(t *testing.T) {
+	tr := &routeTransport{
+		routes: map[string]string{
+			"GET " + EndpointGuildMember("1", "2"):               `{"user": {"id": "2", "username": "opener"}}`,
+			"POST " + EndpointChannelThreads("3"):                `{"id": "4", "name": "ticket-opener", "type": 12}`,
+			"PUT " + EndpointThreadMember("4", "2"):              ``,
+			"POST " + EndpointChannelMessages("4"):               `{"id": "5", "channel_id": "4"}`,
+			"GET " + EndpointChannel("4"):                        `{"id": "4", "name": "ticket-opener", "guild_id": "1"}`,
+			"PATCH " + EndpointChannel("4"):                      `{"id": "4"}`,
+			"POST " + EndpointInteractionResponse("20", "token"): `{}`,
+		},
+		handle: func(req *http.Request, body []byte) (int, string) {
+			if req.Method == "GET" && strings.HasPrefix(req.URL.String(), EndpointChannelMessages("4")) {
+				return http.StatusOK, `[]`
+			}
+			return http.StatusNotFound, `{"code": 10003, "message": "Unknown Channel"}`
+		},
+	}
+	s := newTestSession(tr)
+	s.SyncEvents = true
+
+	m := NewTicketManager(s, TicketConfig{GuildID: "1", ThreadChannelID: "3", StaffRoleIDs: []string{"9"}})
+	defer m.Close()
+
+	ticket, err := m.Open("2", "help")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ticket.Thread || ticket.ChannelID != "4" || ticket.PanelMessageID != "5" {
+		t.Fatalf("unexpected ticket %+v", ticket)
+	}
+
+	var start ThreadStart
+	json.Unmarshal([]byte(tr.bodies[1]), &start)
+	if start.Type != ChannelTypeGuildPrivateThread || start.Invitable == nil || *start.Invitable || start.Name != "ticket-opener" {
+		t.Errorf("expected a private thread, got %+v", start)
+	}
+	var panel MessageSend
+	json.Unmarshal([]byte(tr.bodies[3]), &panel)
+	if panel.Content != "<@2> <@&9>" || len(panel.AllowedMentions.Roles) != 1 || len(panel.Components) != 1 {
+		t.Errorf("expected a panel mentioning the staff with buttons, got %+v", panel)
+	}
+
+	press := func(customID string, member *Member) {
+		s.handleEvent(interactionCreateEventType, &InteractionCreate{&Interaction{
+			ID:        "20",
+			Token:     "token",
+			Type:      InteractionMessageComponent,
+			ChannelID: "4",
+			Data:      []byte(`{"custom_id": "` + customID + `", "component_type": 2}`),
+			Message:   &Message{ID: "5"},
+			Member:    member,
+		}})
+	}
+
+	n := tr.count()
+	press(TicketClaimCustomID, &Member{User: &User{ID: "2"}})
+	if tr.count() != n+1 || !strings.Contains(tr.bodies[n], `"flags":64`) {
+		t.Fatalf("expected an ephemeral refusal, got %v", tr.requests[n:])
+	}
+
+	press(TicketCloseCustomID, &Member{User: &User{ID: "2"}})
+	if r := tr.last(); r != "PATCH "+EndpointChannel("4") {
+		t.Fatalf("expected the thread to be archived, got %s", r)
+	}
+	if body := tr.bodies[len(tr.bodies)-1]; !strings.Contains(body, `"archived":true`) || !strings.Contains(body, `"locked":true`) {
+		t.Errorf("expected an archived and locked thread, got %s", body)
+	}
+	if _, err = m.Ticket("4"); err != ErrTicketNotFound {
+		t.Errorf("expected the ticket to be closed, got %v", err)
+	}
+}
+
+func TestTicketManagerCopies(t *testing.T) {
+	tr := &routeTransport{handle: answer(`{"id": "5"}`)}
+	s := newTestSession(tr)
+	m := NewTicketManager(s, TicketConfig{GuildID: "1"})
+	defer m.Close()
+
+	added := &Ticket{ChannelID: "4", OpenerID: "2"}
+	m.Add(added)
+	ticket, err := m.Ticket("4")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Claims don't change the tickets returned before, which can be read
+	// meanwhile.
+	claimed := make(chan error)
+	go func() {
+		claimed <- m.Claim("4", "3")
+	}()
+	if ticket.ClaimerID != "" || added.ClaimerID != "" {
+		t.Errorf("expected the returned tickets to be copies, got %+v", ticket)
+	}
+	if err = <-claimed; err != nil {
+		t.Fatal(err)
+	}
+
+	if tickets := m.Tickets(); len(tickets) != 1 || tickets[0].ClaimerID != "3" {
+		t.Errorf("expected the claimed ticket, got %+v", tickets)
+	}
+	if ticket.ClaimerID != "" {
+		t.Errorf("expected the copy to be unchanged, got %+v", ticket)
+	}
+}
@@ -0,0 +1,152 @@
+package discordgo
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrEmptyToken is returned by the token providers when they have no token.
+var ErrEmptyToken = errors.New("token provider returned an empty token")
+
+// A TokenProvider provides the token of a Session, so the token can be
+// rotated without a restart and doesn't have to be passed to New. The
+// token must be prefixed, eg. with "Bot ", as for New.
+type TokenProvider interface {
+	Token() (string, error)
+}
+
+// StaticToken is a TokenProvider of a fixed token.
+type StaticToken string
+
+// Token returns the token.
+func (t StaticToken) Token() (string, error) {
+	if t == "" {
+		return "", ErrEmptyToken
+	}
+	return string(t), nil
+}
+
+// EnvToken is a TokenProvider reading the token from the environment
+// variable of its name on every call.
+type EnvToken string
+
+// Token returns the value of the environment variable.
+func (e EnvToken) Token() (string, error) {
+	token := strings.TrimSpace(os.Getenv(string(e)))
+	if token == "" {
+		return "", ErrEmptyToken
+	}
+	return token, nil
+}
+
+// TokenProviderFunc is a function used as a TokenProvider, eg. to fetch
+// the token from a secret manager. Wrap it with CachedToken to avoid a
+// fetch for every request.
+type TokenProviderFunc func() (string, error)
+
+// Token calls f.
+func (f TokenProviderFunc) Token() (string, error) {
+	return f()
+}
+
+// A FileTokenProvider reads the token from a file, and reads it again when
+// the modification time of the file changes.
+type FileTokenProvider struct {
+	sync.Mutex
+
+	path    string
+	token   string
+	modTime time.Time
+}
+
+// NewFileTokenProvider returns a FileTokenProvider of the file at path.
+// Leading and trailing whitespace of the file is ignored.
+func NewFileTokenProvider(path string) *FileTokenProvider {
+	return &FileTokenProvider{path: path}
+}
+
+// Token returns the token of the file.
+func (p *FileTokenProvider) Token() (string, error) {
+	info, err := os.Stat(p.path)
+	if err != nil {
+		return "", err
+	}
+
+	p.Lock()
+	defer p.Unlock()
+
+	if p.token != "" && info.ModTime().Equal(p.modTime) {
+		return p.token, nil
+	}
+
+	b, err := ioutil.ReadFile(p.path)
+	if err != nil {
+		return "", err
+	}
+	token := strings.TrimSpace(string(b))
+	if token == "" {
+		return "", ErrEmptyToken
+	}
+
+	p.token, p.modTime = token, info.ModTime()
+	return token, nil
+}
+
+// cachedToken is the TokenProvider returned by CachedToken.
+type cachedToken struct {
+	sync.Mutex
+
+	provider TokenProvider
+	ttl      time.Duration
+	token    string
+	expires  time.Time
+}
+
+// CachedToken returns a TokenProvider which keeps the token of p for ttl.
+// If p fails after that, the previous token is kept for another ttl.
+// p   : The provider, eg. a TokenProviderFunc fetching from a secret manager.
+// ttl : The time to keep the token for.
+func CachedToken(p TokenProvider, ttl time.Duration) TokenProvider {
+	return &cachedToken{provider: p, ttl: ttl}
+}
+
+// Token returns the cached token, or the token of the provider once it expired.
+func (c *cachedToken) Token() (string, error) {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.token != "" && time.Now().Before(c.expires) {
+		return c.token, nil
+	}
+
+	token, err := c.provider.Token()
+	if err != nil {
+		if c.token != "" {
+			c.expires = time.Now().Add(c.ttl)
+			return c.token, nil
+		}
+		return "", err
+	}
+
+	c.token, c.expires = token, time.Now().Add(c.ttl)
+	return token, nil
+}
+
+// token returns the token of the session, from its TokenProvider if it has
+// one. Token is used if the provider fails.
+func (s *Session) token() string {
+	if s.TokenProvider == nil {
+		return s.Token
+	}
+
+	token, err := s.TokenProvider.Token()
+	if err != nil {
+		s.log(LogError, "error getting token from provider, %s", err)
+		return s.Token
+	}
+	return token
+}
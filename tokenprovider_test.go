@@ -0,0 +1,70 @@
+package discordgo
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileTokenProvider(t *testing.T) {
+	dir, err := ioutil.TempDir("", "discordgo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "token")
+	if err = ioutil.WriteFile(path, []byte("Bot first\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tr := &routeTransport{handle: answer(`{}`)}
+	s, _ := New("Bot unused")
+	s.Client = &http.Client{Transport: tr}
+	s.TokenProvider = NewFileTokenProvider(path)
+	if token := s.token(); token != "Bot first" {
+		t.Errorf("expected the token of the file, got %s", token)
+	}
+
+	if err = ioutil.WriteFile(path, []byte("Bot second"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Minute)
+	if err = os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = s.GuildPreview("1"); err != nil {
+		t.Fatal(err)
+	}
+	if auth := tr.headers[len(tr.headers)-1].Get("authorization"); auth != "Bot second" {
+		t.Errorf("expected the rotated token, got %s", auth)
+	}
+}
+
+func TestCachedToken(t *testing.T) {
+	calls := 0
+	var fail error
+	p := CachedToken(TokenProviderFunc(func() (string, error) {
+		calls++
+		return "Bot token", fail
+	}), time.Hour)
+
+	p.Token()
+	if token, err := p.Token(); err != nil || token != "Bot token" || calls != 1 {
+		t.Errorf("expected a cached token, got %s, %v after %d calls", token, err, calls)
+	}
+
+	if _, err := CachedToken(TokenProviderFunc(func() (string, error) {
+		return "", errors.New("unavailable")
+	}), time.Hour).Token(); err == nil {
+		t.Error("expected an error without a previous token")
+	}
+
+	if _, err := EnvToken("DISCORDGO_TEST_UNSET_TOKEN").Token(); err != ErrEmptyToken {
+		t.Errorf("expected ErrEmptyToken, got %v", err)
+	}
+}
@@ -23,6 +23,8 @@ var eventHandlerTmpl = template.Must(template.New("eventHandler").Funcs(template
 
 package discordgo
 
+import "context"
+
 // Following are all the event types.
 // Event type values are used to match the events returned by Discord.
 // EventTypes surrounded by __ are synthetic and are internal to DiscordGo.
@@ -49,13 +51,38 @@ func (eh {{privateName .}}EventHandler) Handle(s *Session, i interface{}) {
   }
 }
 
+// {{privateName .}}ContextEventHandler is an event handler for {{.}} events
+// that receives the context of the event.
+type {{privateName .}}ContextEventHandler func(context.Context, *Session, *{{.}})
+
+// Type returns the event type for {{.}} events.
+func (eh {{privateName .}}ContextEventHandler) Type() string {
+  return {{privateName .}}EventType
+}
+
+// Handle is the handler for {{.}} events.
+func (eh {{privateName .}}ContextEventHandler) Handle(s *Session, i interface{}) {
+  eh.HandleContext(context.Background(), s, i)
+}
+
+// HandleContext is the context aware handler for {{.}} events.
+func (eh {{privateName .}}ContextEventHandler) HandleContext(ctx context.Context, s *Session, i interface{}) {
+  if t, ok := i.(*{{.}}); ok {
+    eh(ctx, s, t)
+  }
+}
+
 {{end}}
 func handlerForInterface(handler interface{}) EventHandler {
   switch v := handler.(type) {
   case func(*Session, interface{}):
-    return interfaceEventHandler(v){{range .}}
+    return interfaceEventHandler(v)
+  case func(context.Context, *Session, interface{}):
+    return interfaceContextEventHandler(v){{range .}}
   case func(*Session, *{{.}}):
-    return {{privateName .}}EventHandler(v){{end}}
+    return {{privateName .}}EventHandler(v)
+  case func(context.Context, *Session, *{{.}}):
+    return {{privateName .}}ContextEventHandler(v){{end}}
   }
 
   return nil
@@ -104,7 +131,7 @@ func constCase(name string) string {
 
 func isDiscordEvent(name string) bool {
 	switch {
-	case name == "Connect", name == "Disconnect", name == "Event", name == "RateLimit", name == "Interface":
+	case name == "BatchJobComplete", name == "Connect", name == "Disconnect", name == "Event", name == "GuildOutage", name == "InvalidRequestLimit", name == "RateLimit", name == "SpamDetected", name == "VoiceChannelFull", name == "VoiceChannelEmpty", name == "Interface":
 		return false
 	default:
 		return true
@@ -0,0 +1,228 @@
+// restbindings generates the REST functions of the endpoints declared in
+// restbindings.json, in restbindings.go, and a test of each function in
+// restbindings_test.go.
+//
+// An endpoint is declared with:
+//
+//	name      : The name of the function.
+//	doc       : The doc comment of the function.
+//	method    : The HTTP method.
+//	endpoint  : The Endpoint function of the URL, called with the args.
+//	route     : The route relative to EndpointAPI, with the args in braces,
+//	            which the test checks the URL with.
+//	args      : The arguments of the function and the endpoint, with docs.
+//	bucket    : The names of the args kept in the rate limit bucket, the
+//	            others are left empty. By default the first arg is kept.
+//	params    : The type of the JSON body, optional.
+//	paramsDoc : The doc of the params.
+//	response  : The type the response is decoded into, optional.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+type arg struct {
+	Name string `json:"name"`
+	Doc  string `json:"doc"`
+}
+
+type binding struct {
+	Name      string   `json:"name"`
+	Doc       string   `json:"doc"`
+	Method    string   `json:"method"`
+	Endpoint  string   `json:"endpoint"`
+	Route     string   `json:"route"`
+	Args      []arg    `json:"args"`
+	Bucket    []string `json:"bucket"`
+	Params    string   `json:"params"`
+	ParamsDoc string   `json:"paramsDoc"`
+	Response  string   `json:"response"`
+}
+
+// Comments returns the doc comment of the function, with a line per arg.
+func (b *binding) Comments() string {
+	lines := strings.Split(b.Doc, "\n")
+
+	docs := append([]arg(nil), b.Args...)
+	if b.Params != "" {
+		docs = append(docs, arg{"data", b.ParamsDoc})
+	}
+	width := 0
+	for _, a := range docs {
+		if len(a.Name) > width {
+			width = len(a.Name)
+		}
+	}
+	for _, a := range docs {
+		lines = append(lines, a.Name+strings.Repeat(" ", width-len(a.Name))+" : "+a.Doc)
+	}
+
+	return "// " + strings.Join(lines, "\n// ")
+}
+
+// Signature returns the parameters and results of the function.
+func (b *binding) Signature() string {
+	var names []string
+	for _, a := range b.Args {
+		names = append(names, a.Name)
+	}
+	params := ""
+	if len(names) > 0 {
+		params = strings.Join(names, ", ") + " string"
+	}
+	if b.Params != "" {
+		if params != "" {
+			params += ", "
+		}
+		params += "data " + b.Params
+	}
+
+	if b.Response == "" {
+		return "(" + params + ") (err error)"
+	}
+	return "(" + params + ") (st " + b.Response + ", err error)"
+}
+
+// Request returns the arguments of RequestWithBucketID.
+func (b *binding) Request() string {
+	var args, bucket []string
+	for i, a := range b.Args {
+		args = append(args, a.Name)
+
+		kept := i == 0 && b.Bucket == nil
+		for _, k := range b.Bucket {
+			kept = kept || k == a.Name
+		}
+		if kept {
+			bucket = append(bucket, a.Name)
+		} else {
+			bucket = append(bucket, `""`)
+		}
+	}
+
+	data := "nil"
+	if b.Params != "" {
+		data = "data"
+	}
+	return strconv.Quote(b.Method) + ", " + b.Endpoint + "(" + strings.Join(args, ", ") + "), " + data + ", " + b.Endpoint + "(" + strings.Join(bucket, ", ") + ")"
+}
+
+// TestCall returns the call of the function in its test.
+func (b *binding) TestCall() string {
+	var args []string
+	for i := range b.Args {
+		args = append(args, strconv.Quote(strconv.Itoa(i+1)))
+	}
+	if b.Params != "" {
+		args = append(args, "&"+strings.TrimPrefix(b.Params, "*")+"{}")
+	}
+
+	call := "s." + b.Name + "(" + strings.Join(args, ", ") + ")"
+	if b.Response == "" {
+		return "err := " + call
+	}
+	return "_, err := " + call
+}
+
+// TestURL returns the URL the test expects.
+func (b *binding) TestURL() string {
+	route := b.Route
+	for i, a := range b.Args {
+		route = strings.Replace(route, "{"+a.Name+"}", strconv.Itoa(i+1), -1)
+	}
+	return strconv.Quote(route)
+}
+
+// TestResponse returns the response body of the test.
+func (b *binding) TestResponse() string {
+	if strings.HasPrefix(b.Response, "[]") {
+		return "`[]`"
+	}
+	return "`{}`"
+}
+
+var bindingsTmpl = template.Must(template.New("bindings").Parse(`// Code generated by \"restbindings\"; DO NOT EDIT
+// See restbindings.json
+
+package discordgo
+{{range .}}
+{{.Comments}}
+func (s *Session) {{.Name}}{{.Signature}} {
+{{- if .Response}}
+  body, err := s.RequestWithBucketID({{.Request}})
+  if err != nil {
+    return
+  }
+
+  err = s.unmarshal(body, &st)
+  return
+{{- else}}
+  _, err = s.RequestWithBucketID({{.Request}})
+  return
+{{- end}}
+}
+{{end}}
+`))
+
+var testsTmpl = template.Must(template.New("tests").Parse(`// Code generated by \"restbindings\"; DO NOT EDIT
+// See restbindings.json
+
+package discordgo
+
+import (
+  "testing"
+)
+{{range .}}
+func Test{{.Name}}(t *testing.T) {
+  tr := &routeTransport{handle: answer({{.TestResponse}})}
+  s := newTestSession(tr)
+  {{.TestCall}}
+  if err != nil {
+    t.Fatal(err)
+  }
+  if r := tr.last(); r != "{{.Method}} "+EndpointAPI+{{.TestURL}} {
+    t.Errorf("unexpected request %s", r)
+  }
+}
+{{end}}
+`))
+
+func main() {
+	data, err := ioutil.ReadFile("restbindings.json")
+	if err != nil {
+		log.Fatalf("reading restbindings.json: %s", err)
+	}
+
+	var bindings []*binding
+	if err = json.Unmarshal(data, &bindings); err != nil {
+		log.Fatalf("parsing restbindings.json: %s", err)
+	}
+
+	generate(bindingsTmpl, bindings, "restbindings.go")
+	generate(testsTmpl, bindings, "restbindings_test.go")
+}
+
+func generate(tmpl *template.Template, bindings []*binding, path string) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, bindings); err != nil {
+		log.Fatalf("generating %s: %s", path, err)
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Println("warning: internal error: invalid Go generated:", err)
+		src = buf.Bytes()
+	}
+
+	if err = ioutil.WriteFile(path, src, 0644); err != nil {
+		log.Fatalf("writing output: %s", err)
+	}
+}
@@ -0,0 +1,355 @@
+// structcoverage compares the structs of the package with the schemas of
+// Discord's OpenAPI document, https://github.com/discord/discord-api-spec,
+// and writes structcoverage_test.go, a test which fails for every field of
+// the API a struct is missing, with a stub of the field to add.
+//
+// A struct is compared with the schema of the same name, or the name with
+// a Response suffix, eg. Guild with GuildResponse. Other schemas can be
+// given with a JSON file mapping struct names to schema names.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+var (
+	schemaPath = flag.String("schema", "openapi.json", "path of Discord's OpenAPI document")
+	mapPath    = flag.String("map", "", "optional JSON file mapping struct names to schema names")
+	outputPath = flag.String("o", "structcoverage_test.go", "path of the generated test")
+)
+
+var coverageTmpl = template.Must(template.New("coverage").Funcs(template.FuncMap{
+	"quote": strconv.Quote,
+}).Parse(`// Code generated by \"structcoverage\"; DO NOT EDIT
+// See tools/cmd/structcoverage
+
+package discordgo
+
+import "testing"
+
+// TestStructCoverage fails for the fields of Discord's API the structs are missing.
+func TestStructCoverage(t *testing.T) { {{range .}}
+  t.Error({{quote .String}}){{end}}
+}
+`))
+
+// schema is the part of an OpenAPI schema the fields are compared with.
+type schema struct {
+	Ref        string             `json:"$ref"`
+	Type       interface{}        `json:"type"`
+	Format     string             `json:"format"`
+	Properties map[string]*schema `json:"properties"`
+	Items      *schema            `json:"items"`
+	OneOf      []*schema          `json:"oneOf"`
+	AnyOf      []*schema          `json:"anyOf"`
+	AllOf      []*schema          `json:"allOf"`
+}
+
+type document struct {
+	Components struct {
+		Schemas map[string]*schema `json:"schemas"`
+	} `json:"components"`
+}
+
+// missingField is a field of a schema a struct doesn't have.
+type missingField struct {
+	Struct, Schema, Field, Stub string
+}
+
+func (f missingField) String() string {
+	return f.Struct + " is missing the field " + f.Field + " of " + f.Schema + ": " + f.Stub
+}
+
+func main() {
+	flag.Parse()
+
+	data, err := ioutil.ReadFile(*schemaPath)
+	if os.IsNotExist(err) {
+		log.Printf("%s not found, download it from https://github.com/discord/discord-api-spec", *schemaPath)
+		return
+	}
+	if err != nil {
+		log.Fatalf("reading the schema: %s", err)
+	}
+
+	var doc document
+	if err = json.Unmarshal(data, &doc); err != nil {
+		log.Fatalf("parsing the schema: %s", err)
+	}
+
+	mapping := map[string]string{}
+	if *mapPath != "" {
+		data, err = ioutil.ReadFile(*mapPath)
+		if err != nil {
+			log.Fatalf("reading the mapping: %s", err)
+		}
+		if err = json.Unmarshal(data, &mapping); err != nil {
+			log.Fatalf("parsing the mapping: %s", err)
+		}
+	}
+
+	structs, err := parseStructs(".")
+	if err != nil {
+		log.Fatalf("parsing the package: %s", err)
+	}
+
+	var missing []missingField
+	for _, name := range sortedKeys(structs) {
+		schemaName, s := findSchema(doc.Components.Schemas, mapping, name)
+		if s == nil {
+			continue
+		}
+
+		fields := map[string]bool{}
+		structFields(structs, name, fields)
+
+		properties := map[string]*schema{}
+		schemaProperties(doc.Components.Schemas, s, properties)
+		for _, p := range sortedKeys(properties) {
+			if fields[strings.ToLower(p)] {
+				continue
+			}
+			stub := goName(p) + " " + goType(doc.Components.Schemas, properties[p]) + " `json:\"" + p + "\"`"
+			missing = append(missing, missingField{name, schemaName, p, stub})
+		}
+	}
+
+	var buf bytes.Buffer
+	if err = coverageTmpl.Execute(&buf, missing); err != nil {
+		log.Fatalf("generating the test: %s", err)
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Println("warning: internal error: invalid Go generated:", err)
+		src = buf.Bytes()
+	}
+
+	if err = ioutil.WriteFile(*outputPath, src, 0644); err != nil {
+		log.Fatalf("writing output: %s", err)
+	}
+	log.Printf("%d missing fields", len(missing))
+}
+
+// parseStructs returns the struct types of the package in the directory.
+func parseStructs(dir string) (map[string]*ast.StructType, error) {
+	fs := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fs, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	structs := map[string]*ast.StructType{}
+	for _, pkg := range pkgs {
+		for _, f := range pkg.Files {
+			ast.Inspect(f, func(n ast.Node) bool {
+				if ts, ok := n.(*ast.TypeSpec); ok {
+					if st, ok := ts.Type.(*ast.StructType); ok && ts.Name.IsExported() {
+						structs[ts.Name.Name] = st
+					}
+				}
+				return true
+			})
+		}
+	}
+	return structs, nil
+}
+
+// structFields adds the lower case JSON names of the fields of a struct,
+// including the fields of embedded structs.
+func structFields(structs map[string]*ast.StructType, name string, fields map[string]bool) {
+	st, ok := structs[name]
+	if !ok {
+		return
+	}
+
+	for _, f := range st.Fields.List {
+		tag := ""
+		if f.Tag != nil {
+			tag, _ = strconv.Unquote(f.Tag.Value)
+			tag = reflect.StructTag(tag).Get("json")
+		}
+		if tag == "-" {
+			continue
+		}
+		jsonName := strings.Split(tag, ",")[0]
+
+		if len(f.Names) == 0 {
+			typ := f.Type
+			if star, ok := typ.(*ast.StarExpr); ok {
+				typ = star.X
+			}
+			if ident, ok := typ.(*ast.Ident); ok && jsonName == "" {
+				structFields(structs, ident.Name, fields)
+			}
+			continue
+		}
+
+		for _, n := range f.Names {
+			if jsonName != "" {
+				fields[strings.ToLower(jsonName)] = true
+			} else {
+				fields[strings.ToLower(n.Name)] = true
+			}
+		}
+	}
+}
+
+// findSchema returns the schema of a struct.
+func findSchema(schemas map[string]*schema, mapping map[string]string, name string) (string, *schema) {
+	candidates := []string{name, name + "Response"}
+	if m, ok := mapping[name]; ok {
+		candidates = []string{m}
+	}
+
+	for _, c := range candidates {
+		if s, ok := schemas[c]; ok && (len(s.Properties) > 0 || len(s.AllOf) > 0) {
+			return c, s
+		}
+	}
+	return "", nil
+}
+
+// resolve returns the schema a reference points to.
+func resolve(schemas map[string]*schema, s *schema) *schema {
+	for s.Ref != "" {
+		r, ok := schemas[refName(s.Ref)]
+		if !ok {
+			break
+		}
+		s = r
+	}
+	return s
+}
+
+func refName(ref string) string {
+	return ref[strings.LastIndex(ref, "/")+1:]
+}
+
+// schemaProperties adds the properties of a schema, including the schemas
+// it is composed of.
+func schemaProperties(schemas map[string]*schema, s *schema, properties map[string]*schema) {
+	s = resolve(schemas, s)
+	for name, p := range s.Properties {
+		properties[name] = p
+	}
+	for _, a := range s.AllOf {
+		schemaProperties(schemas, a, properties)
+	}
+}
+
+// types returns the types of a schema other than null.
+func (s *schema) types() (types []string) {
+	switch t := s.Type.(type) {
+	case string:
+		types = append(types, t)
+	case []interface{}:
+		for _, v := range t {
+			if v, ok := v.(string); ok {
+				types = append(types, v)
+			}
+		}
+	}
+
+	for i := 0; i < len(types); i++ {
+		if types[i] == "null" {
+			types = append(types[:i], types[i+1:]...)
+			i--
+		}
+	}
+	return
+}
+
+// goType returns the Go type of a property for the stub.
+func goType(schemas map[string]*schema, s *schema) string {
+	if s.Ref != "" {
+		r := resolve(schemas, s)
+		if len(r.Properties) > 0 || len(r.AllOf) > 0 {
+			return "*" + strings.TrimSuffix(refName(s.Ref), "Response")
+		}
+		return goType(schemas, r)
+	}
+
+	var variants []*schema
+	for _, v := range append(append(s.OneOf, s.AnyOf...), s.AllOf...) {
+		if types := v.types(); v.Ref != "" || len(types) > 0 {
+			variants = append(variants, v)
+		}
+	}
+	if len(variants) == 1 {
+		return goType(schemas, variants[0])
+	}
+	if len(variants) > 1 {
+		return "interface{}"
+	}
+
+	types := s.types()
+	if len(types) != 1 {
+		return "interface{}"
+	}
+
+	switch types[0] {
+	case "string":
+		if s.Format == "date-time" {
+			return "Timestamp"
+		}
+		return "string"
+	case "integer":
+		if s.Format == "int64" {
+			return "int64"
+		}
+		return "int"
+	case "number":
+		return "float64"
+	case "boolean":
+		return "bool"
+	case "array":
+		if s.Items == nil {
+			return "[]interface{}"
+		}
+		return "[]" + goType(schemas, s.Items)
+	}
+	return "map[string]interface{}"
+}
+
+// initialisms are the words written in capitals in Go names.
+var initialisms = map[string]string{"id": "ID", "ids": "IDs", "url": "URL", "api": "API", "nsfw": "NSFW", "mfa": "MFA"}
+
+// goName returns the Go name of a snake case JSON name.
+func goName(name string) string {
+	words := strings.Split(name, "_")
+	for i, w := range words {
+		if v, ok := initialisms[w]; ok {
+			words[i] = v
+		} else if w != "" {
+			words[i] = strings.ToUpper(w[:1]) + w[1:]
+		}
+	}
+	return strings.Join(words, "")
+}
+
+// sortedKeys returns the sorted keys of a map with string keys.
+func sortedKeys(m interface{}) []string {
+	var keys []string
+	for _, k := range reflect.ValueOf(m).MapKeys() {
+		keys = append(keys, k.String())
+	}
+	sort.Strings(keys)
+	return keys
+}
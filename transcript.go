@@ -0,0 +1,128 @@
+package discordgo
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"time"
+)
+
+// A Transcript is an archive of the messages of a channel, eg. of a
+// support ticket before it is closed.
+type Transcript struct {
+	ChannelID   string    `json:"channel_id"`
+	ChannelName string    `json:"channel_name"`
+	GuildID     string    `json:"guild_id,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+
+	// The messages in chronological order.
+	Messages []*Message `json:"messages"`
+}
+
+// ChannelTranscript pages through the messages of a channel and returns
+// them as a Transcript.
+// channelID : The ID of a Channel.
+// limit     : The maximum number of messages, the most recent are kept. 0 for all messages.
+func (s *Session) ChannelTranscript(channelID string, limit int) (t *Transcript, err error) {
+	c, err := s.Channel(channelID)
+	if err != nil {
+		return
+	}
+
+	t = &Transcript{
+		ChannelID:   c.ID,
+		ChannelName: c.Name,
+		GuildID:     c.GuildID,
+		CreatedAt:   time.Now().UTC(),
+	}
+
+	var messages []*Message
+	beforeID := ""
+	for limit <= 0 || len(messages) < limit {
+		n := 100
+		if limit > 0 && limit-len(messages) < n {
+			n = limit - len(messages)
+		}
+
+		var page []*Message
+		page, err = s.ChannelMessages(channelID, n, beforeID, "", "")
+		if err != nil {
+			return nil, err
+		}
+
+		// Pages are returned newest first.
+		messages = append(messages, page...)
+		if len(page) < n {
+			break
+		}
+		beforeID = page[len(page)-1].ID
+	}
+
+	t.Messages = make([]*Message, len(messages))
+	for i, m := range messages {
+		t.Messages[len(messages)-1-i] = m
+	}
+	return
+}
+
+// WriteJSON writes the transcript as JSON to w.
+func (t *Transcript) WriteJSON(w io.Writer) error {
+	return json.NewEncoder(w).Encode(t)
+}
+
+// WriteHTML writes the transcript as a standalone HTML page to w.
+func (t *Transcript) WriteHTML(w io.Writer) error {
+	return transcriptTemplate.Execute(w, t)
+}
+
+var transcriptTemplate = template.Must(template.New("transcript").Funcs(template.FuncMap{
+	"time": func(ts Timestamp) string {
+		t, err := ts.Parse()
+		if err != nil {
+			return string(ts)
+		}
+		return t.UTC().Format("2006-01-02 15:04:05 MST")
+	},
+	"content": func(m *Message) string {
+		return m.ContentWithMentionsReplaced()
+	},
+	"color": func(color int) template.CSS {
+		return template.CSS(fmt.Sprintf("#%06x", color))
+	},
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>#{{.ChannelName}}</title>
+<style>
+body { font-family: sans-serif; background: #36393f; color: #dcddde; }
+.message { margin: 8px 0; }
+.author { font-weight: bold; color: #fff; }
+.time { font-size: 0.75em; color: #72767d; margin-left: 6px; }
+.content { white-space: pre-wrap; }
+.embed { border-left: 4px solid #202225; background: #2f3136; padding: 6px 10px; margin: 4px 0; max-width: 520px; }
+.embed-title { font-weight: bold; }
+.field-name { font-weight: bold; margin-top: 4px; }
+a { color: #00b0f4; }
+</style>
+</head>
+<body>
+<h1>#{{.ChannelName}}</h1>
+<p>{{len .Messages}} messages, archived {{.CreatedAt.Format "2006-01-02 15:04:05 MST"}}</p>
+{{range .Messages}}<div class="message" id="{{.ID}}">
+<div>{{with .Author}}<span class="author" title="{{.ID}}">{{.String}}</span>{{end}}<span class="time">{{time .Timestamp}}{{if .EditedTimestamp}} (edited){{end}}</span></div>
+{{with content .}}<div class="content">{{.}}</div>{{end}}
+{{range .Embeds}}<div class="embed"{{if .Color}} style="border-color: {{color .Color}}"{{end}}>
+{{with .Author}}<div>{{.Name}}</div>{{end}}
+{{if .Title}}<div class="embed-title">{{if .URL}}<a href="{{.URL}}">{{.Title}}</a>{{else}}{{.Title}}{{end}}</div>{{end}}
+{{with .Description}}<div class="content">{{.}}</div>{{end}}
+{{range .Fields}}<div class="field-name">{{.Name}}</div><div class="content">{{.Value}}</div>{{end}}
+{{with .Image}}<div><a href="{{.URL}}">{{.URL}}</a></div>{{end}}
+{{with .Footer}}<div class="time">{{.Text}}</div>{{end}}
+</div>{{end}}
+{{range .Attachments}}<div><a href="{{.URL}}">{{.Filename}}</a> ({{.Size}} bytes)</div>{{end}}
+</div>
+{{end}}</body>
+</html>
+`))
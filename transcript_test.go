@@ -0,0 +1,32 @@
+package discordgo
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTranscriptHTML(t *testing.T) {
+	tr := &Transcript{
+		ChannelName: "ticket",
+		Messages: []*Message{{
+			ID:          "1",
+			Author:      &User{ID: "2", Username: "user", Discriminator: "0001"},
+			Timestamp:   "2021-01-02T03:04:05+00:00",
+			Content:     "<b>hi</b>",
+			Embeds:      []*MessageEmbed{{Title: "Embed", Color: 0xff0000}},
+			Attachments: []*MessageAttachment{{Filename: "log.txt", URL: "https://cdn.example/log.txt"}},
+		}},
+	}
+
+	var b strings.Builder
+	if err := tr.WriteHTML(&b); err != nil {
+		t.Fatal(err)
+	}
+
+	html := b.String()
+	for _, want := range []string{"user#0001", "2021-01-02 03:04:05 UTC", "&lt;b&gt;hi&lt;/b&gt;", "#ff0000", `href="https://cdn.example/log.txt"`} {
+		if !strings.Contains(html, want) {
+			t.Errorf("expected transcript to contain %q", want)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package discordgo
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"testing"
+)
+
+// routeTransport is the fake HTTP transport of the tests. It records the
+// requests and answers them with the body of their method and URL, and the
+// status of the route if it has one. Requests without a route are answered
+// by handle, or with a 404 Unknown application command error.
+type routeTransport struct {
+	sync.Mutex
+
+	routes   map[string]string
+	statuses map[string]int
+
+	// handle answers the requests without a route, eg. for stateful fakes.
+	// It is called with the lock held.
+	handle func(req *http.Request, body []byte) (status int, response string)
+
+	// release blocks the requests until it is closed, if set.
+	release chan struct{}
+
+	// The method and URL, the headers and the bodies of the requests.
+	requests []string
+	headers  []http.Header
+	bodies   []string
+}
+
+func (t *routeTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		body, _ = ioutil.ReadAll(req.Body)
+	}
+
+	route := req.Method + " " + req.URL.String()
+	t.Lock()
+	t.requests = append(t.requests, route)
+	t.headers = append(t.headers, req.Header)
+	t.bodies = append(t.bodies, string(body))
+	t.Unlock()
+
+	if t.release != nil {
+		<-t.release
+	}
+
+	t.Lock()
+	defer t.Unlock()
+
+	response, ok := t.routes[route]
+	status := http.StatusOK
+	if !ok {
+		if t.handle != nil {
+			status, response = t.handle(req, body)
+		} else {
+			status, response = http.StatusNotFound, `{"code": 10063, "message": "Unknown application command"}`
+		}
+	}
+	if s, ok := t.statuses[route]; ok {
+		status = s
+	}
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{},
+		Body:       ioutil.NopCloser(bytes.NewBufferString(response)),
+		Request:    req,
+	}, nil
+}
+
+// last returns the method and URL of the last request, empty if there was
+// none.
+func (t *routeTransport) last() string {
+	t.Lock()
+	defer t.Unlock()
+
+	if len(t.requests) == 0 {
+		return ""
+	}
+	return t.requests[len(t.requests)-1]
+}
+
+// count returns the number of requests.
+func (t *routeTransport) count() int {
+	t.Lock()
+	defer t.Unlock()
+	return len(t.requests)
+}
+
+// answer returns a handle func which answers all requests with the body.
+func answer(response string) func(*http.Request, []byte) (int, string) {
+	return func(*http.Request, []byte) (int, string) {
+		return http.StatusOK, response
+	}
+}
+
+// newTestSession returns a session whose requests go to the transport.
+func newTestSession(tr *routeTransport) *Session {
+	s, _ := New("Bot token")
+	s.Client = &http.Client{Transport: tr}
+	return s
+}
+
+func TestRouteTransport(t *testing.T) {
+	tr := &routeTransport{
+		routes:   map[string]string{"GET " + EndpointGuild("1"): `{"id": "1"}`},
+		statuses: map[string]int{"GET " + EndpointChannel("2"): http.StatusForbidden},
+	}
+	s := newTestSession(tr)
+
+	if g, err := s.Guild("1"); err != nil || g.ID != "1" {
+		t.Errorf("expected guild 1, got %+v, %v", g, err)
+	}
+	if _, err := s.Channel("2"); restErrorCode(err) != 10063 {
+		t.Errorf("expected an unknown route error, got %v", err)
+	} else if err.(*RESTError).Response.StatusCode != http.StatusForbidden {
+		t.Errorf("expected the status of the route, got %d", err.(*RESTError).Response.StatusCode)
+	}
+
+	tr.handle = answer(`{"id": "3"}`)
+	if c, err := s.Channel("3"); err != nil || c.ID != "3" {
+		t.Errorf("expected channel 3, got %+v, %v", c, err)
+	}
+	if r := tr.last(); r != "GET "+EndpointChannel("3") || tr.count() != 3 {
+		t.Errorf("unexpected requests %v", tr.requests)
+	}
+	if auth := tr.headers[0].Get("authorization"); auth != "Bot token" {
+		t.Errorf("expected the token of the session, got %s", auth)
+	}
+}
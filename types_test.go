@@ -1,6 +1,7 @@
 package discordgo
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
 )
@@ -22,3 +23,59 @@ func TestTimestampParse(t *testing.T) {
 		t.Error("Incorrect timezone")
 	}
 }
+
+func TestGameSpotify(t *testing.T) {
+	g := &Game{
+		Name:       "Spotify",
+		Type:       GameTypeListening,
+		Details:    "Song",
+		State:      "Artist A; Artist B",
+		SyncID:     "track",
+		Assets:     Assets{LargeImageID: "spotify:ab67616d", LargeText: "Album"},
+		TimeStamps: TimeStamps{StartTimestamp: 1000, EndTimestamp: 181000},
+	}
+
+	a, ok := g.Spotify()
+	if !ok {
+		t.Fatal("expected a Spotify activity")
+	}
+	if len(a.Artists) != 2 || a.AlbumArtURL != "https://i.scdn.co/image/ab67616d" || a.Duration() != 3*time.Minute {
+		t.Errorf("unexpected Spotify activity %+v", a)
+	}
+
+	if url := (&Game{ApplicationID: "1"}).AssetURL("2"); url != EndpointCDNAppAssets+"1/2.png" {
+		t.Errorf("unexpected application asset URL %s", url)
+	}
+}
+
+func TestEmojiIdentifier(t *testing.T) {
+	tests := []struct {
+		id   EmojiIdentifier
+		want string
+	}{
+		{UnicodeEmoji("👍"), "%F0%9F%91%8D"},
+		{UnicodeEmoji("#⃣"), "%23%E2%83%A3"},
+		{CustomEmoji("blob", "1"), "blob:1"},
+		{(&Emoji{Name: "blob", ID: "1"}).Identifier(), "blob:1"},
+		{ParseEmojiIdentifier("<:blob:1>"), "blob:1"},
+		{ParseEmojiIdentifier("<a:blob:1>"), "blob:1"},
+		{ParseEmojiIdentifier(" blob:1 "), "blob:1"},
+	}
+
+	for _, test := range tests {
+		if got := test.id.escaped(); got != test.want {
+			t.Errorf("%s: expected %s, got %s", test.id, test.want, got)
+		}
+	}
+}
+
+func TestWebhookChannelFollower(t *testing.T) {
+	var w Webhook
+	err := json.Unmarshal([]byte(`{"id": "1", "type": 2, "source_guild": {"id": "2", "name": "news"}, "source_channel": {"id": "3", "name": "announcements"}}`), &w)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.Type != WebhookTypeChannelFollower || w.SourceGuild == nil || w.SourceGuild.ID != "2" || w.SourceChannel == nil || w.SourceChannel.ID != "3" {
+		t.Errorf("unexpected webhook %+v", w)
+	}
+}
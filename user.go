@@ -1,6 +1,9 @@
 package discordgo
 
-import "strings"
+import (
+	"strings"
+	"time"
+)
 
 // UserFlags is the flags of "user" (see UserFlags* consts)
 // https://discord.com/developers/docs/resources/user#user-object-user-flags
@@ -85,6 +88,12 @@ func (u *User) Mention() string {
 	return "<@" + u.ID + ">"
 }
 
+// CreatedAt returns the time the account of the user was created at, which
+// is encoded in its ID.
+func (u *User) CreatedAt() (time.Time, error) {
+	return SnowflakeTimestamp(u.ID)
+}
+
 // AvatarURL returns a URL to the user's avatar.
 //    size:    The size of the user's avatar as a power of two
 //             if size is an empty string, no size parameter will
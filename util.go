@@ -15,3 +15,21 @@ func SnowflakeTimestamp(ID string) (t time.Time, err error) {
 	t = time.Unix(0, timestamp*1000000)
 	return
 }
+
+// guildUserKey is the key of a user in a guild.
+type guildUserKey struct {
+	guildID string
+	userID  string
+}
+
+// memberHasRole returns whether the member has any of the roles.
+func memberHasRole(m *Member, roleIDs ...string) bool {
+	for _, id := range m.Roles {
+		for _, roleID := range roleIDs {
+			if id == roleID {
+				return true
+			}
+		}
+	}
+	return false
+}
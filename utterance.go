@@ -0,0 +1,224 @@
+package discordgo
+
+import (
+	"bytes"
+	"hash/crc32"
+	"sync"
+	"time"
+)
+
+// Default durations of an UtteranceDetector.
+const (
+	DefaultUtteranceSilence     = 500 * time.Millisecond
+	DefaultUtteranceMinDuration = 200 * time.Millisecond
+	DefaultUtteranceMaxDuration = 30 * time.Second
+)
+
+// An Utterance is a contiguous piece of speech of a user, eg. to transcribe
+// it with a speech to text service.
+type Utterance struct {
+	UserID string
+	SSRC   uint32
+
+	// When the utterance started and ended, from the arrival time of the
+	// first packet and the RTP timestamps.
+	Start time.Time
+	End   time.Time
+
+	// The 20ms opus frames of the utterance.
+	Frames [][]byte
+
+	firstTS  uint32
+	lastTS   uint32
+	received time.Time
+}
+
+// Duration returns the duration of the utterance.
+func (u *Utterance) Duration() time.Duration {
+	return u.End.Sub(u.Start)
+}
+
+// Ogg returns the utterance as an Ogg Opus file, which speech to text
+// services usually accept as is.
+func (u *Utterance) Ogg() ([]byte, error) {
+	var b bytes.Buffer
+	w, err := NewOggOpusWriter(&b, pcmChannels, crc32.ChecksumIEEE([]byte(u.UserID)))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range u.Frames {
+		if err = w.WriteFrame(f); err != nil {
+			return nil, err
+		}
+	}
+	if err = w.Close(); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// An UtteranceDetector splits the audio received by a voice connection into
+// the utterances of its users. An utterance ends when the user is silent,
+// Discord stops sending audio or sends silence frames when users stop
+// speaking, or when it reaches the MaxDuration.
+type UtteranceDetector struct {
+	sync.Mutex
+
+	// How long a user must be silent to end an utterance.
+	Silence time.Duration
+
+	// Shorter utterances, eg. coughs and clicks, are dropped.
+	MinDuration time.Duration
+
+	// Longer utterances are split.
+	MaxDuration time.Duration
+
+	handler func(u *Utterance)
+
+	users  map[uint32]string
+	active map[uint32]*Utterance
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewUtteranceDetector starts detecting the utterances of the users of the
+// connection, with the default durations. The detector reads all packets of
+// OpusRecv until Stop is called.
+// v       : The voice connection to listen to.
+// handler : Called in its own goroutine with each utterance.
+func NewUtteranceDetector(v *VoiceConnection, handler func(u *Utterance)) (*UtteranceDetector, error) {
+	v.RLock()
+	recv := v.OpusRecv
+	v.RUnlock()
+	if recv == nil {
+		return nil, ErrVoiceNotReady
+	}
+
+	d := &UtteranceDetector{
+		Silence:     DefaultUtteranceSilence,
+		MinDuration: DefaultUtteranceMinDuration,
+		MaxDuration: DefaultUtteranceMaxDuration,
+		handler:     handler,
+		users:       map[uint32]string{},
+		active:      map[uint32]*Utterance{},
+		stop:        make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+
+	v.AddHandler(func(vc *VoiceConnection, vs *VoiceSpeakingUpdate) {
+		d.Lock()
+		d.users[uint32(vs.SSRC)] = vs.UserID
+		if u, ok := d.active[uint32(vs.SSRC)]; ok {
+			u.UserID = vs.UserID
+		}
+		d.Unlock()
+	})
+
+	go d.run(recv)
+	return d, nil
+}
+
+// run reads the received packets until the detector is stopped.
+func (d *UtteranceDetector) run(recv <-chan *Packet) {
+	defer close(d.done)
+
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-d.stop:
+			d.flush(time.Time{})
+			return
+		case now := <-ticker.C:
+			d.flush(now)
+		case p, ok := <-recv:
+			if !ok {
+				d.flush(time.Time{})
+				return
+			}
+			d.receive(p, time.Now())
+		}
+	}
+}
+
+// receive adds a packet to the utterance of its SSRC.
+func (d *UtteranceDetector) receive(p *Packet, now time.Time) {
+	if len(p.Opus) == 0 {
+		return
+	}
+
+	d.Lock()
+	defer d.Unlock()
+
+	u, ok := d.active[p.SSRC]
+
+	// Silence frames end the utterance.
+	if bytes.Equal(p.Opus, opusSilenceFrame) {
+		if ok {
+			d.end(u)
+		}
+		return
+	}
+
+	if ok && int32(p.Timestamp-u.lastTS) <= 0 {
+		// A late or repeated packet.
+		return
+	}
+
+	if !ok {
+		u = &Utterance{UserID: d.users[p.SSRC], SSRC: p.SSRC, Start: now, firstTS: p.Timestamp}
+		d.active[p.SSRC] = u
+	}
+	u.Frames = append(u.Frames, p.Opus)
+	u.lastTS, u.received = p.Timestamp, now
+	u.End = u.Start.Add(utteranceDuration(u.firstTS, u.lastTS))
+
+	if d.MaxDuration > 0 && u.Duration() >= d.MaxDuration {
+		d.end(u)
+	}
+}
+
+// utteranceDuration returns the duration from the first to the end of the
+// last frame of an utterance.
+func utteranceDuration(first, last uint32) time.Duration {
+	return time.Duration(last-first+960) * time.Second / pcmSampleRate
+}
+
+// flush ends the utterances of users who were silent since before now, or
+// all utterances if now is zero.
+func (d *UtteranceDetector) flush(now time.Time) {
+	d.Lock()
+	defer d.Unlock()
+
+	for _, u := range d.active {
+		if now.IsZero() || now.Sub(u.received) >= d.Silence {
+			d.end(u)
+		}
+	}
+}
+
+// end ends an utterance, the lock must be held.
+func (d *UtteranceDetector) end(u *Utterance) {
+	delete(d.active, u.SSRC)
+
+	if u.Duration() < d.MinDuration {
+		return
+	}
+	go d.handler(u)
+}
+
+// Stop stops the detector, ending the current utterances.
+func (d *UtteranceDetector) Stop() {
+	d.Lock()
+	select {
+	case <-d.stop:
+	default:
+		close(d.stop)
+	}
+	d.Unlock()
+
+	<-d.done
+}
@@ -0,0 +1,54 @@
+package discordgo
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUtteranceDetector(t *testing.T) {
+	recv := make(chan *Packet)
+	v := &VoiceConnection{OpusRecv: recv}
+
+	utterances := make(chan *Utterance, 2)
+	d, err := NewUtteranceDetector(v, func(u *Utterance) { utterances <- u })
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.Lock()
+	d.Silence = 100 * time.Millisecond
+	d.Unlock()
+
+	for _, h := range v.voiceSpeakingUpdateHandlers {
+		h.handler(v, &VoiceSpeakingUpdate{UserID: "user", SSRC: 1, Speaking: true})
+	}
+
+	// The first utterance ends with silence frames, the second one when
+	// the user stays silent.
+	for i := uint32(0); i < 20; i++ {
+		recv <- &Packet{SSRC: 1, Timestamp: 960 * i, Opus: []byte{0xf8, byte(i)}}
+	}
+	recv <- &Packet{SSRC: 1, Timestamp: 960 * 20, Opus: opusSilenceFrame}
+	for i := uint32(100); i < 115; i++ {
+		recv <- &Packet{SSRC: 1, Timestamp: 960 * i, Opus: []byte{0xf8, byte(i)}}
+	}
+
+	for i, frames := range []int{20, 15} {
+		select {
+		case u := <-utterances:
+			if u.UserID != "user" || len(u.Frames) != frames || u.Duration() != time.Duration(frames)*20*time.Millisecond {
+				t.Errorf("utterance %d: unexpected utterance of %s with %d frames lasting %s", i, u.UserID, len(u.Frames), u.Duration())
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("utterance %d wasn't detected", i)
+		}
+	}
+
+	// Utterances shorter than the MinDuration are dropped.
+	recv <- &Packet{SSRC: 1, Timestamp: 960 * 200, Opus: []byte{0xf8, 0}}
+	d.Stop()
+	select {
+	case u := <-utterances:
+		t.Errorf("unexpected utterance with %d frames", len(u.Frames))
+	case <-time.After(50 * time.Millisecond):
+	}
+}
@@ -10,6 +10,7 @@
 package discordgo
 
 import (
+	"context"
 	"encoding/binary"
 	"encoding/json"
 	"fmt"
@@ -42,6 +43,12 @@ type VoiceConnection struct {
 	speaking     bool
 	reconnecting bool // If true, voice connection is trying to reconnect
 
+	// The speaking flags sent when audio is sent, SpeakingMicrophone if 0.
+	// Set SpeakingPriority to use priority speaker mode, which lowers the
+	// volume of other users while the bot speaks. It requires the
+	// PermissionVoicePrioritySpeaker permission.
+	SpeakingFlags SpeakingFlag
+
 	OpusSend chan []byte  // Chan for sending opus audio
 	OpusRecv chan *Packet // Chan for receiving opus audio
 
@@ -66,24 +73,79 @@ type VoiceConnection struct {
 	op4 voiceOP4
 	op2 voiceOP2
 
-	voiceSpeakingUpdateHandlers []VoiceSpeakingUpdateHandler
+	voiceSpeakingUpdateHandlers []*voiceSpeakingUpdateHandlerInstance
 }
 
 // VoiceSpeakingUpdateHandler type provides a function definition for the
 // VoiceSpeakingUpdate event
 type VoiceSpeakingUpdateHandler func(vc *VoiceConnection, vs *VoiceSpeakingUpdate)
 
+// SpeakingFlag is a flag of the speaking state of a voice connection.
+// https://discord.com/developers/docs/topics/voice-connections#speaking
+type SpeakingFlag int
+
+// Block contains the valid known SpeakingFlag values
+const (
+	// Normal transmission of voice audio.
+	SpeakingMicrophone SpeakingFlag = 1 << iota
+
+	// Transmission of context audio for video, no speaking indicator.
+	SpeakingSoundshare
+
+	// Priority speaker, lowering audio of other speakers.
+	SpeakingPriority
+)
+
 // Speaking sends a speaking notification to Discord over the voice websocket.
 // This must be sent as true prior to sending audio and should be set to false
-// once finished sending audio.
+// once finished sending audio. The flags sent when speaking are SpeakingFlags.
 //  b  : Send true if speaking, false if not.
 func (v *VoiceConnection) Speaking(b bool) (err error) {
 
 	v.log(LogDebug, "called (%t)", b)
 
+	var flags SpeakingFlag
+	if b {
+		v.RLock()
+		flags = v.SpeakingFlags
+		v.RUnlock()
+
+		if flags == 0 {
+			flags = SpeakingMicrophone
+		}
+	}
+
+	return v.speakingFlags(flags)
+}
+
+// SetPrioritySpeaker enables or disables priority speaker mode, see
+// SpeakingFlags. If the bot is speaking the new flags are sent immediately.
+func (v *VoiceConnection) SetPrioritySpeaker(priority bool) (err error) {
+	v.Lock()
+	if v.SpeakingFlags == 0 {
+		v.SpeakingFlags = SpeakingMicrophone
+	}
+	if priority {
+		v.SpeakingFlags |= SpeakingPriority
+	} else {
+		v.SpeakingFlags &^= SpeakingPriority
+	}
+	speaking := v.speaking
+	v.Unlock()
+
+	if speaking {
+		err = v.Speaking(true)
+	}
+	return
+}
+
+// speakingFlags sends the speaking flags, 0 when not speaking.
+func (v *VoiceConnection) speakingFlags(flags SpeakingFlag) (err error) {
+
 	type voiceSpeakingData struct {
-		Speaking bool `json:"speaking"`
-		Delay    int  `json:"delay"`
+		Speaking SpeakingFlag `json:"speaking"`
+		Delay    int          `json:"delay"`
+		SSRC     uint32       `json:"ssrc"`
 	}
 
 	type voiceSpeakingOp struct {
@@ -95,7 +157,11 @@ func (v *VoiceConnection) Speaking(b bool) (err error) {
 		return fmt.Errorf("no VoiceConnection websocket")
 	}
 
-	data := voiceSpeakingOp{5, voiceSpeakingData{b, 0}}
+	v.RLock()
+	ssrc := v.op2.SSRC
+	v.RUnlock()
+
+	data := voiceSpeakingOp{5, voiceSpeakingData{flags, 0, ssrc}}
 	v.wsMutex.Lock()
 	err = v.wsConn.WriteJSON(data)
 	v.wsMutex.Unlock()
@@ -108,7 +174,7 @@ func (v *VoiceConnection) Speaking(b bool) (err error) {
 		return
 	}
 
-	v.speaking = b
+	v.speaking = flags != 0
 
 	return
 }
@@ -212,19 +278,72 @@ func (v *VoiceConnection) Close() {
 	}
 }
 
+// voiceSpeakingUpdateHandlerInstance is an added VoiceSpeakingUpdateHandler,
+// so the handler can be found again to remove it.
+type voiceSpeakingUpdateHandlerInstance struct {
+	handler VoiceSpeakingUpdateHandler
+}
+
 // AddHandler adds a Handler for VoiceSpeakingUpdate events.
-func (v *VoiceConnection) AddHandler(h VoiceSpeakingUpdateHandler) {
+//
+// The return value of this method is a function, that when called will remove the
+// handler.
+func (v *VoiceConnection) AddHandler(h VoiceSpeakingUpdateHandler) func() {
 	v.Lock()
 	defer v.Unlock()
 
-	v.voiceSpeakingUpdateHandlers = append(v.voiceSpeakingUpdateHandlers, h)
+	vhi := &voiceSpeakingUpdateHandlerInstance{h}
+	v.voiceSpeakingUpdateHandlers = append(v.voiceSpeakingUpdateHandlers, vhi)
+
+	return func() {
+		v.Lock()
+		defer v.Unlock()
+
+		// The handlers are copied, so handlers being called keep their slice.
+		handlers := make([]*voiceSpeakingUpdateHandlerInstance, 0, len(v.voiceSpeakingUpdateHandlers))
+		for _, h := range v.voiceSpeakingUpdateHandlers {
+			if h != vhi {
+				handlers = append(handlers, h)
+			}
+		}
+		v.voiceSpeakingUpdateHandlers = handlers
+	}
 }
 
 // VoiceSpeakingUpdate is a struct for a VoiceSpeakingUpdate event.
 type VoiceSpeakingUpdate struct {
 	UserID   string `json:"user_id"`
 	SSRC     int    `json:"ssrc"`
-	Speaking bool   `json:"speaking"`
+	Speaking bool   `json:"-"`
+
+	// The speaking flags of the user, 0 when the user stopped speaking.
+	Flags SpeakingFlag `json:"-"`
+}
+
+// UnmarshalJSON is a helper function to unmarshal VoiceSpeakingUpdate, whose
+// speaking field is either a bool or speaking flags.
+func (vs *VoiceSpeakingUpdate) UnmarshalJSON(data []byte) error {
+	type voiceSpeakingUpdate VoiceSpeakingUpdate
+	v := struct {
+		voiceSpeakingUpdate
+		Speaking json.RawMessage `json:"speaking"`
+	}{}
+
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*vs = VoiceSpeakingUpdate(v.voiceSpeakingUpdate)
+
+	var speaking bool
+	if err := json.Unmarshal(v.Speaking, &speaking); err == nil {
+		if speaking {
+			vs.Flags = SpeakingMicrophone
+		}
+	} else if err := json.Unmarshal(v.Speaking, &vs.Flags); err != nil {
+		return err
+	}
+	vs.Speaking = vs.Flags != 0
+	return nil
 }
 
 // ------------------------------------------------------------------------------------------------
@@ -250,11 +369,13 @@ type voiceOP2 struct {
 
 // WaitUntilConnected waits for the Voice Connection to
 // become ready, if it does not become ready it returns an err
-func (v *VoiceConnection) waitUntilConnected() error {
+func (v *VoiceConnection) waitUntilConnected(ctx context.Context) error {
 
 	v.log(LogInformational, "called")
 
-	i := 0
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+
 	for {
 		v.RLock()
 		ready := v.Ready
@@ -263,12 +384,11 @@ func (v *VoiceConnection) waitUntilConnected() error {
 			return nil
 		}
 
-		if i > 10 {
-			return fmt.Errorf("timeout waiting for voice")
+		select {
+		case <-ctx.Done():
+			return ErrVoiceJoinTimeout
+		case <-ticker.C:
 		}
-
-		time.Sleep(1 * time.Second)
-		i++
 	}
 }
 
@@ -348,10 +468,10 @@ func (v *VoiceConnection) wsListen(wsConn *websocket.Conn, close <-chan struct{}
 	for {
 		_, message, err := v.wsConn.ReadMessage()
 		if err != nil {
-			// 4014 indicates a manual disconnection by someone in the guild;
-			// we shouldn't reconnect.
-			if websocket.IsCloseError(err, 4014) {
-				v.log(LogInformational, "received 4014 manual disconnection")
+			// Fatal close codes, eg. a manual disconnection by someone in the
+			// guild, mean we shouldn't reconnect.
+			if ce, ok := err.(*websocket.CloseError); ok && VoiceCloseCode(ce.Code).IsFatal() {
+				v.log(LogInformational, "voice websocket closed with %d (%s), not reconnecting", ce.Code, VoiceCloseCode(ce.Code))
 
 				// Abandon the voice WS connection
 				v.Lock()
@@ -459,7 +579,10 @@ func (v *VoiceConnection) onEvent(message []byte) {
 		return
 
 	case 5:
-		if len(v.voiceSpeakingUpdateHandlers) == 0 {
+		v.RLock()
+		handlers := v.voiceSpeakingUpdateHandlers
+		v.RUnlock()
+		if len(handlers) == 0 {
 			return
 		}
 
@@ -469,8 +592,8 @@ func (v *VoiceConnection) onEvent(message []byte) {
 			return
 		}
 
-		for _, h := range v.voiceSpeakingUpdateHandlers {
-			h(v, voiceSpeakingUpdate)
+		for _, h := range handlers {
+			h.handler(v, voiceSpeakingUpdate)
 		}
 
 	default:
@@ -883,6 +1006,19 @@ func (v *VoiceConnection) reconnect() {
 			continue
 		}
 
+		// A failed join removes the connection, add it back unless another
+		// connection to the guild was made in the meantime.
+		v.session.Lock()
+		current, ok := v.session.VoiceConnections[v.GuildID]
+		if !ok {
+			v.session.VoiceConnections[v.GuildID] = v
+		}
+		v.session.Unlock()
+		if ok && current != v {
+			v.log(LogInformational, "voice connection to guild %s was replaced, exiting", v.GuildID)
+			return
+		}
+
 		v.log(LogInformational, "trying to reconnect to channel %s", v.ChannelID)
 
 		_, err := v.session.ChannelVoiceJoin(v.GuildID, v.ChannelID, v.mute, v.deaf)
@@ -0,0 +1,103 @@
+package discordgo
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestVoiceSpeakingUpdateUnmarshal(t *testing.T) {
+	tests := []struct {
+		data     string
+		speaking bool
+		flags    SpeakingFlag
+	}{
+		{`{"user_id":"1","ssrc":2,"speaking":true}`, true, SpeakingMicrophone},
+		{`{"user_id":"1","ssrc":2,"speaking":false}`, false, 0},
+		{`{"user_id":"1","ssrc":2,"speaking":5}`, true, SpeakingMicrophone | SpeakingPriority},
+		{`{"user_id":"1","ssrc":2,"speaking":0}`, false, 0},
+	}
+
+	for _, test := range tests {
+		var vs VoiceSpeakingUpdate
+		if err := json.Unmarshal([]byte(test.data), &vs); err != nil {
+			t.Fatalf("%s: %s", test.data, err)
+		}
+		if vs.UserID != "1" || vs.SSRC != 2 {
+			t.Errorf("%s: got user %q and ssrc %d", test.data, vs.UserID, vs.SSRC)
+		}
+		if vs.Speaking != test.speaking || vs.Flags != test.flags {
+			t.Errorf("%s: got speaking %t and flags %d, want %t and %d", test.data, vs.Speaking, vs.Flags, test.speaking, test.flags)
+		}
+	}
+}
+
+func TestCheckVoiceJoin(t *testing.T) {
+	s := &Session{State: NewState()}
+	s.State.User = &User{ID: "bot"}
+
+	err := s.State.GuildAdd(&Guild{
+		ID:    "guild",
+		Roles: []*Role{{ID: "guild", Permissions: PermissionViewChannel | PermissionVoiceConnect}},
+		Channels: []*Channel{
+			{ID: "full", GuildID: "guild", Type: ChannelTypeGuildVoice, UserLimit: 1},
+			{ID: "locked", GuildID: "guild", Type: ChannelTypeGuildVoice, PermissionOverwrites: []*PermissionOverwrite{
+				{ID: "guild", Type: "role", Deny: PermissionVoiceConnect},
+			}},
+		},
+		Members:     []*Member{{GuildID: "guild", User: &User{ID: "bot"}}},
+		VoiceStates: []*VoiceState{{GuildID: "guild", ChannelID: "full", UserID: "user"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = s.checkVoiceJoin("full"); err != ErrVoiceChannelFull {
+		t.Errorf("expected ErrVoiceChannelFull, got %v", err)
+	}
+	if err, ok := s.checkVoiceJoin("locked").(*ErrMissingPermission); !ok || err.Perm != PermissionVoiceConnect {
+		t.Errorf("expected a missing connect permission error, got %v", err)
+	}
+	if err = s.checkVoiceJoin("unknown"); err != nil {
+		t.Errorf("expected no error for a channel missing from the state, got %v", err)
+	}
+}
+
+func TestVoiceServerUpdateNullEndpoint(t *testing.T) {
+	s := &Session{VoiceConnections: map[string]*VoiceConnection{}}
+	v := &VoiceConnection{GuildID: "guild", endpoint: "old.discord.media:80", session: s}
+	s.VoiceConnections["guild"] = v
+
+	// Opening a connection without a session ID would fail after a second.
+	s.onVoiceServerUpdate(&VoiceServerUpdate{GuildID: "guild", Token: "token"})
+
+	if v.endpoint != "" || v.token != "token" {
+		t.Errorf("expected the endpoint to be cleared, got %q", v.endpoint)
+	}
+	if s.VoiceConnections["guild"] != v {
+		t.Error("expected the voice connection to be kept")
+	}
+}
+
+func TestChannelVoiceJoinFailure(t *testing.T) {
+	s, _ := New("Bot token")
+	existing := &VoiceConnection{GuildID: "guild", ChannelID: "old", Ready: true}
+	s.VoiceConnections = map[string]*VoiceConnection{"guild": existing}
+
+	// Without a gateway connection the join fails, and a failed move keeps
+	// the connection to the previous channel.
+	if v, err := s.ChannelVoiceJoinContext(context.Background(), "guild", "new", true, false); err == nil || v != nil {
+		t.Fatalf("expected the join to fail, got %v, %v", v, err)
+	}
+	if s.VoiceConnections["guild"] != existing || existing.ChannelID != "old" || existing.mute {
+		t.Errorf("expected the existing connection to be kept, got %+v", s.VoiceConnections["guild"])
+	}
+
+	// A failed join removes the connection it created.
+	if _, err := s.ChannelVoiceJoinContext(context.Background(), "other", "new", false, false); err == nil {
+		t.Fatal("expected the join to fail")
+	}
+	if _, ok := s.VoiceConnections["other"]; ok {
+		t.Error("expected the new connection to be removed")
+	}
+}
@@ -0,0 +1,70 @@
+package discordgo
+
+// VoiceChannelOccupancy is the number of users in a voice or stage channel.
+type VoiceChannelOccupancy struct {
+	GuildID   string
+	ChannelID string
+	Count     int
+
+	// The user limit of the channel, 0 if it is unlimited.
+	Limit int
+}
+
+// Full returns whether no more users can join the channel, ignoring users
+// who can bypass the limit with the move members permission.
+func (o *VoiceChannelOccupancy) Full() bool {
+	return o.Limit > 0 && o.Count >= o.Limit
+}
+
+// VoiceChannelOccupancy returns the number of users in a voice or stage
+// channel, from the voice states in the state. State.TrackVoice must be enabled.
+// channelID : The ID of a Channel.
+func (s *State) VoiceChannelOccupancy(channelID string) (*VoiceChannelOccupancy, error) {
+	if s == nil {
+		return nil, ErrNilState
+	}
+
+	c, err := s.Channel(channelID)
+	if err != nil {
+		return nil, err
+	}
+
+	g, err := s.Guild(c.GuildID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.RLock()
+	defer s.RUnlock()
+
+	o := &VoiceChannelOccupancy{GuildID: g.ID, ChannelID: c.ID, Limit: c.UserLimit}
+	for _, vs := range g.VoiceStates {
+		if vs.ChannelID == channelID {
+			o.Count++
+		}
+	}
+	return o, nil
+}
+
+// voiceOccupancyEvents fires VoiceChannelFull and VoiceChannelEmpty events
+// for the channels a user left or joined, after the state was updated.
+func (s *Session) voiceOccupancyEvents(v *VoiceStateUpdate) {
+	left := ""
+	if v.BeforeUpdate != nil {
+		left = v.BeforeUpdate.ChannelID
+	}
+	if left == v.ChannelID {
+		return
+	}
+
+	if left != "" {
+		if o, err := s.State.VoiceChannelOccupancy(left); err == nil && o.Count == 0 {
+			go s.handleEvent(voiceChannelEmptyEventType, &VoiceChannelEmpty{o})
+		}
+	}
+	if v.ChannelID != "" {
+		if o, err := s.State.VoiceChannelOccupancy(v.ChannelID); err == nil && o.Limit > 0 && o.Count == o.Limit {
+			go s.handleEvent(voiceChannelFullEventType, &VoiceChannelFull{o})
+		}
+	}
+}
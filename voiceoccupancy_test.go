@@ -0,0 +1,60 @@
+package discordgo
+
+import (
+	"testing"
+	"time"
+)
+
+func TestVoiceChannelOccupancyEvents(t *testing.T) {
+	s := &Session{SyncEvents: true, StateEnabled: true, State: NewState()}
+	err := s.State.GuildAdd(&Guild{ID: "1", Channels: []*Channel{
+		{ID: "2", GuildID: "1", Type: ChannelTypeGuildVoice, UserLimit: 2},
+		{ID: "3", GuildID: "1", Type: ChannelTypeGuildVoice},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	full := make(chan *VoiceChannelFull, 10)
+	empty := make(chan *VoiceChannelEmpty, 10)
+	s.AddHandler(func(s *Session, e *VoiceChannelFull) { full <- e })
+	s.AddHandler(func(s *Session, e *VoiceChannelEmpty) { empty <- e })
+
+	move := func(userID, channelID string) {
+		s.handleEvent(voiceStateUpdateEventType, &VoiceStateUpdate{VoiceState: &VoiceState{GuildID: "1", UserID: userID, ChannelID: channelID}})
+	}
+	expect := func(want string) {
+		var got string
+		select {
+		case e := <-full:
+			got = "full " + e.ChannelID
+		case e := <-empty:
+			got = "empty " + e.ChannelID
+		case <-time.After(50 * time.Millisecond):
+		}
+		if got != want {
+			t.Fatalf("expected event %q, got %q", want, got)
+		}
+	}
+
+	move("4", "2")
+	expect("")
+	move("5", "2")
+	expect("full 2")
+
+	if o, err := s.State.VoiceChannelOccupancy("2"); err != nil || o.Count != 2 || !o.Full() {
+		t.Errorf("expected a full channel, got %+v, %v", o, err)
+	}
+	if o, err := s.State.VoiceChannelOccupancy("3"); err != nil || o.Count != 0 || o.Full() {
+		t.Errorf("expected an empty unlimited channel, got %+v, %v", o, err)
+	}
+
+	// Staying in a channel, eg. when muting, fires no events.
+	move("5", "2")
+	expect("")
+
+	move("5", "3")
+	expect("")
+	move("4", "")
+	expect("empty 2")
+}
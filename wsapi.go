@@ -13,6 +13,7 @@ package discordgo
 import (
 	"bytes"
 	"compress/zlib"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -36,6 +37,30 @@ var ErrWSNotFound = errors.New("no websocket connection exists")
 // less than the total shard count
 var ErrWSShardBounds = errors.New("ShardID must be less than ShardCount")
 
+// ErrWSLargeThreshold is returned by Open when the LargeThreshold of the
+// Identify struct isn't between 50 and 250.
+var ErrWSLargeThreshold = errors.New("LargeThreshold must be between 50 and 250")
+
+// ErrWSInvalidStatus is returned by Open when the status of the presence of
+// the Identify struct isn't a known Status.
+var ErrWSInvalidStatus = errors.New("invalid presence status")
+
+// ErrVoiceJoinTimeout is returned by ChannelVoiceJoin when the voice
+// connection wasn't ready before the timeout.
+var ErrVoiceJoinTimeout = errors.New("timeout waiting for voice connection")
+
+// ErrVoiceChannelFull is returned by ChannelVoiceJoin when the user limit of
+// the channel is reached and the session user can't bypass it.
+var ErrVoiceChannelFull = errors.New("voice channel is full")
+
+// ErrVoiceNotReady is returned when audio is played on a voice connection
+// which isn't connected.
+var ErrVoiceNotReady = errors.New("voice connection is not ready")
+
+// VoiceJoinTimeout is the time ChannelVoiceJoin waits for the voice
+// connection to be ready.
+var VoiceJoinTimeout = 10 * time.Second
+
 type resumePacket struct {
 	Op   int `json:"op"`
 	Data struct {
@@ -62,6 +87,15 @@ func (s *Session) Open() error {
 		return ErrWSAlreadyOpen
 	}
 
+	s.readyMu.RLock()
+	sessionID, resumeGatewayURL := s.sessionID, s.resumeGatewayURL
+	s.readyMu.RUnlock()
+
+	// Sessions are resumed with the gateway URL given in the Ready event.
+	if sessionID != "" && resumeGatewayURL != "" {
+		s.gateway = resumeGatewayURL + "?v=" + APIVersion + "&encoding=json"
+	}
+
 	// Get the gateway to use for the Websocket connection
 	if s.gateway == "" {
 		s.gateway, err = s.Gateway()
@@ -89,6 +123,12 @@ func (s *Session) Open() error {
 		return nil
 	})
 
+	// Create the context handed to context aware event handlers, it lives
+	// as long as this connection does.
+	s.ctxMu.Lock()
+	s.ctx, s.cancelCtx = context.WithCancel(context.Background())
+	s.ctxMu.Unlock()
+
 	defer func() {
 		// because of this, all code below must set err to the error
 		// when exiting with an error :)  Maybe someone has a better
@@ -96,6 +136,7 @@ func (s *Session) Open() error {
 		if err != nil {
 			s.wsConn.Close()
 			s.wsConn = nil
+			s.cancelEventContext()
 		}
 	}()
 
@@ -103,6 +144,9 @@ func (s *Session) Open() error {
 	// When processed by onEvent the heartbeat goroutine will be started.
 	mt, m, err := s.wsConn.ReadMessage()
 	if err != nil {
+		if cerr := gatewayCloseError(err); cerr != nil {
+			err = cerr
+		}
 		return err
 	}
 	e, err := s.onEvent(mt, m)
@@ -124,7 +168,7 @@ func (s *Session) Open() error {
 	// Now we send either an Op 2 Identity if this is a brand new
 	// connection or Op 6 Resume if we are resuming an existing connection.
 	sequence := atomic.LoadInt64(s.sequence)
-	if s.sessionID == "" && sequence == 0 {
+	if sessionID == "" && sequence == 0 {
 
 		// Send Op 2 Identity Packet
 		err = s.identify()
@@ -138,11 +182,12 @@ func (s *Session) Open() error {
 		// Send Op 6 Resume Packet
 		p := resumePacket{}
 		p.Op = 6
-		p.Data.Token = s.Token
-		p.Data.SessionID = s.sessionID
+		p.Data.Token = s.token()
+		p.Data.SessionID = sessionID
 		p.Data.Sequence = sequence
 
 		s.log(LogInformational, "sending resume packet to gateway")
+		s.gatewaySends.wait(true)
 		s.wsMutex.Lock()
 		err = s.wsConn.WriteJSON(p)
 		s.wsMutex.Unlock()
@@ -170,6 +215,9 @@ func (s *Session) Open() error {
 	// Now Discord should send us a READY or RESUMED packet.
 	mt, m, err = s.wsConn.ReadMessage()
 	if err != nil {
+		if cerr := gatewayCloseError(err); cerr != nil {
+			err = cerr
+		}
 		return err
 	}
 	e, err = s.onEvent(mt, m)
@@ -229,11 +277,17 @@ func (s *Session) listen(wsConn *websocket.Conn, listening <-chan interface{}) {
 				s.log(LogWarning, "error reading from gateway %s websocket, %s", s.gateway, err)
 				// There has been an error reading, close the websocket so that
 				// OnDisconnect event is emitted.
-				err := s.Close()
+				cerr := gatewayCloseError(err)
+				err := s.closeWithCode(websocket.CloseNormalClosure, cerr)
 				if err != nil {
 					s.log(LogWarning, "error closing session connection, %s", err)
 				}
 
+				if cerr != nil && cerr.IsFatal() {
+					s.log(LogError, "not reconnecting, %s", cerr)
+					return
+				}
+
 				s.log(LogInformational, "calling reconnect() now")
 				s.reconnect()
 			}
@@ -293,6 +347,7 @@ func (s *Session) heartbeat(wsConn *websocket.Conn, listening <-chan interface{}
 		s.RUnlock()
 		sequence := atomic.LoadInt64(s.sequence)
 		s.log(LogDebug, "sending gateway websocket heartbeat seq %d", sequence)
+		s.gatewaySends.wait(true)
 		s.wsMutex.Lock()
 		s.LastHeartbeatSent = time.Now().UTC()
 		err = wsConn.WriteJSON(heartbeatOp{1, sequence})
@@ -382,18 +437,14 @@ func (s *Session) UpdateListeningStatus(game string) (err error) {
 }
 
 // UpdateStatusComplex allows for sending the raw status update data untouched by discordgo.
+// The status is kept and sent again when identifying after reconnects.
 func (s *Session) UpdateStatusComplex(usd UpdateStatusData) (err error) {
-
-	s.RLock()
-	defer s.RUnlock()
-	if s.wsConn == nil {
-		return ErrWSNotFound
+	err = s.gatewayWrite(updateStatusOp{3, usd})
+	if err == nil {
+		s.wsMutex.Lock()
+		s.presence = &usd
+		s.wsMutex.Unlock()
 	}
-
-	s.wsMutex.Lock()
-	err = s.wsConn.WriteJSON(updateStatusOp{3, usd})
-	s.wsMutex.Unlock()
-
 	return
 }
 
@@ -446,17 +497,7 @@ func (s *Session) RequestGuildMembersBatch(guildIDs []string, query string, limi
 func (s *Session) requestGuildMembers(data requestGuildMembersData) (err error) {
 	s.log(LogInformational, "called")
 
-	s.RLock()
-	defer s.RUnlock()
-	if s.wsConn == nil {
-		return ErrWSNotFound
-	}
-
-	s.wsMutex.Lock()
-	err = s.wsConn.WriteJSON(requestGuildMembersOp{8, data})
-	s.wsMutex.Unlock()
-
-	return
+	return s.gatewayWrite(requestGuildMembersOp{8, data})
 }
 
 // onEvent is the "event handler" for all messages received on the
@@ -494,18 +535,26 @@ func (s *Session) onEvent(messageType int, message []byte) (*Event, error) {
 
 	// Decode the event into an Event struct.
 	var e *Event
-	decoder := json.NewDecoder(reader)
+	counter := &countingReader{r: reader}
+	decoder := json.NewDecoder(counter)
 	if err = decoder.Decode(&e); err != nil {
 		s.log(LogError, "error decoding websocket message, %s", err)
 		return e, err
 	}
 
+	eventType := ""
+	if e.Operation == 0 {
+		eventType = e.Type
+	}
+	s.gatewayStats.record(eventType, int64(len(message)), counter.n)
+
 	s.log(LogDebug, "Op: %d, Seq: %d, Type: %s, Data: %s\n\n", e.Operation, e.Sequence, e.Type, string(e.RawData))
 
 	// Ping request.
 	// Must respond with a heartbeat packet within 5 seconds
 	if e.Operation == 1 {
 		s.log(LogInformational, "sending heartbeat in response to Op1")
+		s.gatewaySends.wait(true)
 		s.wsMutex.Lock()
 		err = s.wsConn.WriteJSON(heartbeatOp{1, atomic.LoadInt64(s.sequence)})
 		s.wsMutex.Unlock()
@@ -530,6 +579,12 @@ func (s *Session) onEvent(messageType int, message []byte) (*Event, error) {
 	// Must respond with a Identify packet.
 	if e.Operation == 9 {
 
+		// ResumeOnly leaves starting a new session to the caller.
+		if atomic.LoadInt32(&s.resumeOnly) == 1 {
+			s.log(LogInformational, "session can't be resumed, not identifying in response to Op9")
+			return e, ErrResumeInvalid
+		}
+
 		s.log(LogInformational, "sending identify packet to gateway in response to Op9")
 
 		err = s.identify()
@@ -574,6 +629,16 @@ func (s *Session) onEvent(messageType int, message []byte) (*Event, error) {
 			s.log(LogError, "error unmarshalling %s event, %s", e.Type, err)
 		}
 
+		if s.StrictDecode != StrictDecodeOff {
+			if serr := strictDecodeCheck(e.RawData, e.Struct, err); serr != nil {
+				level := LogWarning
+				if s.StrictDecode == StrictDecodeError {
+					level = LogError
+				}
+				s.log(level, "%s event, %s", e.Type, serr)
+			}
+		}
+
 		// Send event to any registered event handlers for it's type.
 		// Because the above doesn't cancel this, in case of an error
 		// the struct could be partially populated or at default values.
@@ -581,6 +646,7 @@ func (s *Session) onEvent(messageType int, message []byte) (*Event, error) {
 		// it's better to pass along what we received than nothing at all.
 		// TODO: Think about that decision :)
 		// Either way, READY events must fire, even with errors.
+		s.fetchEventContent(e.Struct)
 		s.handleEvent(e.Type, e.Struct)
 	} else {
 		s.log(LogWarning, "unknown event: Op: %d, Seq: %d, Type: %s, Data: %s", e.Operation, e.Sequence, e.Type, string(e.RawData))
@@ -608,26 +674,75 @@ type voiceChannelJoinOp struct {
 	Data voiceChannelJoinData `json:"d"`
 }
 
-// ChannelVoiceJoin joins the session user to a voice channel.
+// ChannelVoiceJoin joins the session user to a voice channel, waiting up to
+// VoiceJoinTimeout for the connection, see ChannelVoiceJoinContext.
 //
 //    gID     : Guild ID of the channel to join.
 //    cID     : Channel ID of the channel to join.
 //    mute    : If true, you will be set to muted upon joining.
 //    deaf    : If true, you will be set to deafened upon joining.
 func (s *Session) ChannelVoiceJoin(gID, cID string, mute, deaf bool) (voice *VoiceConnection, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), VoiceJoinTimeout)
+	defer cancel()
+
+	return s.ChannelVoiceJoinContext(ctx, gID, cID, mute, deaf)
+}
+
+// ChannelVoiceJoinContext joins the session user to a voice channel, waiting
+// for the connection until the context is done. If the state shows that the
+// channel can't be joined an *ErrMissingPermission or ErrVoiceChannelFull is
+// returned, ErrVoiceJoinTimeout if the connection wasn't ready in time. On
+// failure a new connection is closed and removed from VoiceConnections,
+// while an existing connection stays in its previous channel.
+//
+//    ctx     : The context of the join, eg. with a timeout.
+//    gID     : Guild ID of the channel to join.
+//    cID     : Channel ID of the channel to join.
+//    mute    : If true, you will be set to muted upon joining.
+//    deaf    : If true, you will be set to deafened upon joining.
+func (s *Session) ChannelVoiceJoinContext(ctx context.Context, gID, cID string, mute, deaf bool) (voice *VoiceConnection, err error) {
 
 	s.log(LogInformational, "called")
 
-	s.RLock()
-	voice, _ = s.VoiceConnections[gID]
-	s.RUnlock()
+	if err = s.checkVoiceJoin(cID); err != nil {
+		return nil, err
+	}
 
-	if voice == nil {
+	s.Lock()
+	voice, _ = s.VoiceConnections[gID]
+	created := voice == nil
+	if created {
 		voice = &VoiceConnection{}
-		s.Lock()
 		s.VoiceConnections[gID] = voice
-		s.Unlock()
 	}
+	s.Unlock()
+
+	voice.Lock()
+	channelID, wasDeaf, wasMute := voice.ChannelID, voice.deaf, voice.mute
+	voice.Unlock()
+
+	defer func() {
+		if err == nil {
+			return
+		}
+
+		// A failed move keeps the connection to the previous channel.
+		if !created {
+			voice.Lock()
+			voice.ChannelID, voice.deaf, voice.mute = channelID, wasDeaf, wasMute
+			voice.Unlock()
+			voice = nil
+			return
+		}
+
+		voice.Close()
+		s.Lock()
+		if s.VoiceConnections[gID] == voice {
+			delete(s.VoiceConnections, gID)
+		}
+		s.Unlock()
+		voice = nil
+	}()
 
 	voice.Lock()
 	voice.GuildID = gID
@@ -642,17 +757,49 @@ func (s *Session) ChannelVoiceJoin(gID, cID string, mute, deaf bool) (voice *Voi
 		return
 	}
 
-	// doesn't exactly work perfect yet.. TODO
-	err = voice.waitUntilConnected()
+	err = voice.waitUntilConnected(ctx)
 	if err != nil {
 		s.log(LogWarning, "error waiting for voice to connect, %s", err)
-		voice.Close()
 		return
 	}
 
 	return
 }
 
+// checkVoiceJoin returns an error if the state shows that the session user
+// can't join the voice channel. Channels missing from the state are not checked.
+func (s *Session) checkVoiceJoin(channelID string) error {
+	if s.State == nil || s.State.User == nil {
+		return nil
+	}
+
+	perms, err := s.State.UserChannelPermissions(s.State.User.ID, channelID)
+	if err != nil {
+		return nil
+	}
+
+	for _, p := range []int{PermissionViewChannel, PermissionVoiceConnect} {
+		if perms&p == 0 {
+			return &ErrMissingPermission{Perm: p, ChannelID: channelID}
+		}
+	}
+
+	if perms&PermissionVoiceMoveMembers != 0 {
+		return nil
+	}
+
+	o, err := s.State.VoiceChannelOccupancy(channelID)
+	if err != nil || !o.Full() {
+		return nil
+	}
+
+	// Moving within the channel never exceeds the limit.
+	if vs, err := s.State.VoiceState(o.GuildID, s.State.User.ID); err == nil && vs.ChannelID == channelID {
+		return nil
+	}
+	return ErrVoiceChannelFull
+}
+
 // ChannelVoiceJoinManual initiates a voice session to a voice channel, but does not complete it.
 //
 // This should only be used when the VoiceServerUpdate will be intercepted and used elsewhere.
@@ -674,10 +821,7 @@ func (s *Session) ChannelVoiceJoinManual(gID, cID string, mute, deaf bool) (err
 
 	// Send the request to Discord that we want to join the voice channel
 	data := voiceChannelJoinOp{4, voiceChannelJoinData{&gID, channelID, mute, deaf}}
-	s.wsMutex.Lock()
-	err = s.wsConn.WriteJSON(data)
-	s.wsMutex.Unlock()
-	return
+	return s.gatewayWrite(data)
 }
 
 // onVoiceStateUpdate handles Voice State Update events on the data websocket.
@@ -738,6 +882,15 @@ func (s *Session) onVoiceServerUpdate(st *VoiceServerUpdate) {
 	voice.GuildID = st.GuildID
 	voice.Unlock()
 
+	// A null endpoint means the voice server is being reallocated, eg.
+	// after the region was changed. Sending pauses until another update
+	// with the new endpoint opens the connection again, as OpusSend is
+	// kept and read by the sender of the new connection.
+	if st.Endpoint == "" {
+		s.log(LogInformational, "voice server of guild %s is being reallocated, waiting for a new endpoint", st.GuildID)
+		return
+	}
+
 	// Open a connection to the voice server
 	err := voice.open()
 	if err != nil {
@@ -762,7 +915,9 @@ func (s *Session) identify() error {
 
 	// TODO: This is a temporary block of code to help
 	// maintain backwards compatability
-	if s.Token != "" && s.Identify.Token == "" {
+	if s.TokenProvider != nil {
+		s.Identify.Token = s.token()
+	} else if s.Token != "" && s.Identify.Token == "" {
 		s.Identify.Token = s.Token
 	}
 
@@ -778,16 +933,58 @@ func (s *Session) identify() error {
 		s.Identify.Shard = &[2]int{s.ShardID, s.ShardCount}
 	}
 
-	// Send Identify packet to Discord
+	if err := validateIdentify(&s.Identify); err != nil {
+		return err
+	}
+
+	s.gatewaySends.wait(true)
+	s.wsMutex.Lock()
+
+	// Keep the status set since the session was opened.
 	op := identifyOp{2, s.Identify}
+	if s.presence != nil {
+		op.Data.Presence = s.presence.gatewayStatusUpdate()
+	}
+
+	// Send Identify packet to Discord
 	s.log(LogDebug, "Identify Packet: \n%#v", op)
-	s.wsMutex.Lock()
 	err := s.wsConn.WriteJSON(op)
 	s.wsMutex.Unlock()
 
 	return err
 }
 
+// validateIdentify checks the values of an Identify struct Discord would
+// close the connection for.
+func validateIdentify(id *Identify) error {
+	if id.LargeThreshold != 0 && (id.LargeThreshold < 50 || id.LargeThreshold > 250) {
+		return ErrWSLargeThreshold
+	}
+
+	if id.Shard != nil && (id.Shard[0] < 0 || id.Shard[0] >= id.Shard[1]) {
+		return ErrWSShardBounds
+	}
+
+	switch Status(id.Presence.Status) {
+	case "", StatusOnline, StatusIdle, StatusDoNotDisturb, StatusInvisible, StatusOffline:
+	default:
+		return ErrWSInvalidStatus
+	}
+	return nil
+}
+
+// gatewayStatusUpdate converts the status to the presence of an Identify struct.
+func (usd *UpdateStatusData) gatewayStatusUpdate() GatewayStatusUpdate {
+	p := GatewayStatusUpdate{Status: usd.Status, AFK: usd.AFK}
+	if usd.IdleSince != nil {
+		p.Since = *usd.IdleSince
+	}
+	if usd.Game != nil {
+		p.Game = Activity{Name: usd.Game.Name, Type: ActivityType(usd.Game.Type), URL: usd.Game.URL}
+	}
+	return p
+}
+
 func (s *Session) reconnect() {
 
 	s.log(LogInformational, "called")
@@ -832,6 +1029,11 @@ func (s *Session) reconnect() {
 				return
 			}
 
+			if cerr, ok := err.(*GatewayCloseError); ok && cerr.IsFatal() {
+				s.log(LogError, "not reconnecting, %s", cerr)
+				return
+			}
+
 			s.log(LogError, "error reconnecting to gateway, %s", err)
 
 			<-time.After(wait * time.Second)
@@ -843,6 +1045,17 @@ func (s *Session) reconnect() {
 	}
 }
 
+// cancelEventContext cancels the context handed to context aware event
+// handlers of the current connection.
+func (s *Session) cancelEventContext() {
+	s.ctxMu.Lock()
+	if s.cancelCtx != nil {
+		s.cancelCtx()
+		s.cancelCtx = nil
+	}
+	s.ctxMu.Unlock()
+}
+
 // Close closes a websocket and stops all listening/heartbeat goroutines.
 // TODO: Add support for Voice WS/UDP
 func (s *Session) Close() error {
@@ -853,6 +1066,12 @@ func (s *Session) Close() error {
 // listening/heartbeat goroutines.
 // TODO: Add support for Voice WS/UDP connections
 func (s *Session) CloseWithCode(closeCode int) (err error) {
+	return s.closeWithCode(closeCode, nil)
+}
+
+// closeWithCode closes the websocket like CloseWithCode, the cause is given
+// to the Disconnect event if Discord closed the websocket.
+func (s *Session) closeWithCode(closeCode int, cause *GatewayCloseError) (err error) {
 
 	s.log(LogInformational, "called")
 	s.Lock()
@@ -894,8 +1113,10 @@ func (s *Session) CloseWithCode(closeCode int) (err error) {
 
 	s.Unlock()
 
+	s.cancelEventContext()
+
 	s.log(LogInformational, "emit disconnect event")
-	s.handleEvent(disconnectEventType, &Disconnect{})
+	s.handleEvent(disconnectEventType, &Disconnect{Err: cause})
 
 	return
 }